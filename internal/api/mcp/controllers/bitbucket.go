@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -423,6 +424,12 @@ func (bc *BitbucketController) newMergePRServerTool() server.ServerTool {
 		mcp.WithString("commit_message",
 			mcp.Description("Custom commit message for the merge (optional)"),
 		),
+		mcp.WithBoolean("compose_message",
+			mcp.Description(
+				"Generate the commit message from the pull request title, commit subjects, "+
+					"Jira keys and co-authors using the repository template (optional, can not be used with commit_message)",
+			),
+		),
 		mcp.WithString("close_source_branch",
 			mcp.Description("Whether to close the source branch after merge (true/false)"),
 		),
@@ -454,6 +461,7 @@ func (bc *BitbucketController) newMergePRServerTool() server.ServerTool {
 		mergeStrategy := request.GetString("merge_strategy", "")
 		commitMessage := request.GetString("commit_message", "")
 		closeSourceBranch := request.GetBool("close_source_branch", false)
+		composeMessage := request.GetBool("compose_message", false)
 		account := request.GetString("account", "")
 
 		// Create parameters for the service layer
@@ -464,12 +472,23 @@ func (bc *BitbucketController) newMergePRServerTool() server.ServerTool {
 			MergeStrategy:     mergeStrategy,
 			Message:           commitMessage,
 			CloseSourceBranch: closeSourceBranch,
+			ComposeMessage:    composeMessage,
 			AccountName:       account,
 		}
 
 		// Call the service to merge the pull request
 		pr, err := bc.bitbucketService.MergePR(ctx, params)
 		if err != nil {
+			// Merge refusals are expected outcomes the caller can act on, so report them as tool errors
+			var mergeErr *bitbucket.MergeError
+			if errors.As(err, &mergeErr) {
+				text := fmt.Sprintf("Pull request #%d could not be merged (%s): %s",
+					prID, mergeErr.Reason, mergeErr.Message)
+				if len(mergeErr.BlockedBy) > 0 {
+					text += "\nBlocked by branch restrictions:\n- " + strings.Join(mergeErr.BlockedBy, "\n- ")
+				}
+				return mcp.NewToolResultError(text), nil
+			}
 			return nil, fmt.Errorf("failed to merge pull request: %w", err)
 		}
 
@@ -484,8 +503,13 @@ func (bc *BitbucketController) newMergePRServerTool() server.ServerTool {
 			closeBranchText = " and source branch was closed"
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Pull request #%d successfully merged%s%s",
-			pr.ID, strategyText, closeBranchText)), nil
+		var mergeCommitText string
+		if pr.MergeCommit != nil && pr.MergeCommit.Hash != "" {
+			mergeCommitText = fmt.Sprintf(". Merge commit: %s", pr.MergeCommit.Hash)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Pull request #%d successfully merged%s%s%s",
+			pr.ID, strategyText, closeBranchText, mergeCommitText)), nil
 	}
 
 	return server.ServerTool{
@@ -860,6 +884,48 @@ func (bc *BitbucketController) NewTools() []server.ServerTool {
 		bc.newRequestPRChangesServerTool(),
 		bc.newListPRCommentsServerTool(),
 		bc.newResolvePRCommentServerTool(),
+		bc.newCheckPRMergeableServerTool(),
+		bc.newListBuildStatusesServerTool(),
+		bc.newSetBuildStatusServerTool(),
+		bc.newListPipelinesServerTool(),
+		bc.newGetPipelineServerTool(),
+		bc.newTriggerPipelineServerTool(),
+		bc.newStopPipelineServerTool(),
+		bc.newValidatePipelinesConfigServerTool(),
+		bc.newGetPipelineFailureServerTool(),
+		bc.newWaitForCIServerTool(),
+		bc.newListRepositoryVariablesServerTool(),
+		bc.newCreateRepositoryVariableServerTool(),
+		bc.newUpdateRepositoryVariableServerTool(),
+		bc.newDeleteRepositoryVariableServerTool(),
+		bc.newListEnvironmentsServerTool(),
+		bc.newListDeploymentsServerTool(),
+		bc.newDeploymentsOverviewServerTool(),
+		bc.newListBranchesServerTool(),
+		bc.newGetBranchServerTool(),
+		bc.newCreateBranchServerTool(),
+		bc.newDeleteBranchServerTool(),
+		bc.newCompareBranchesServerTool(),
+		bc.newListTagsServerTool(),
+		bc.newCreateTagServerTool(),
+		bc.newDeleteTagServerTool(),
+		bc.newListTagChangesServerTool(),
+		bc.newGetBranchRestrictionsServerTool(),
+		bc.newListDirectoryServerTool(),
+		bc.newListFileHistoryServerTool(),
+		bc.newBlameFileLinesServerTool(),
+		bc.newCommitFilesServerTool(),
+		bc.newOpenChangeServerTool(),
+		bc.newSearchCodeServerTool(),
+		bc.newListWorkspacesServerTool(),
+		bc.newListRepositoriesServerTool(),
+		bc.newGetRepositoryServerTool(),
+		bc.newWhoAmIServerTool(),
+		bc.newCommitPullRequestsServerTool(),
+		bc.newFindIssuePullRequestsServerTool(),
+		bc.newMyReviewQueueServerTool(),
+		bc.newStalePRsServerTool(),
+		bc.newGetPRReviewBundleServerTool(),
 	}
 }
 
@@ -949,7 +1015,9 @@ func (bc *BitbucketController) newGetPRDiffstatServerTool() server.ServerTool {
 func (bc *BitbucketController) newGetPRDiffServerTool() server.ServerTool {
 	tool := mcp.NewTool(
 		"bitbucket_get_pr_diff",
-		mcp.WithDescription("Get the diff for a pull request in Bitbucket"),
+		mcp.WithDescription("Get the diff for a pull request in Bitbucket. Large diffs can be read in chunks "+
+			"split at file boundaries using max_bytes and the returned cursor, generated and vendored files "+
+			"can be left out with skip_generated or skip_patterns."),
 		mcp.WithNumber("pr_id",
 			mcp.Description("Pull request ID"),
 			mcp.Required(),
@@ -972,6 +1040,9 @@ func (bc *BitbucketController) newGetPRDiffServerTool() server.ServerTool {
 			mcp.Description("Number of context lines to include in the diff (optional)"),
 		),
 	)
+	for _, option := range prDiffChunkToolOptions() {
+		option(&tool)
+	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		bc.logger.Debug("Received bitbucket_get_pr_diff request", "params", request.Params)
@@ -1018,6 +1089,10 @@ func (bc *BitbucketController) newGetPRDiffServerTool() server.ServerTool {
 			ContextLines:  contextLines,
 		}
 
+		if chunkParams, chunked := prDiffChunkParams(request, params); chunked {
+			return bc.getPRDiffChunk(ctx, chunkParams)
+		}
+
 		// Call the service
 		diff, err := bc.bitbucketService.GetPRDiff(ctx, params)
 		if err != nil {
@@ -1065,6 +1140,18 @@ func (bc *BitbucketController) newGetFileContentServerTool() server.ServerTool {
 			mcp.Description("The SHA hash to fetch file content from. Only commit hashes are supported."),
 			mcp.Required(),
 		),
+		mcp.WithNumber("from_line",
+			mcp.Description("First line to return, 1-based (optional, defaults to the first line)"),
+		),
+		mcp.WithNumber("to_line",
+			mcp.Description("Last line to return, inclusive (optional, defaults to the last line)"),
+		),
+		mcp.WithNumber("max_bytes",
+			mcp.Description("Maximum size of returned text in bytes (optional, defaults to 100KB, max 1MB)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
 	)
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		bc.logger.Debug("Received bitbucket_get_file_content request", "params", request.Params)
@@ -1098,6 +1185,9 @@ func (bc *BitbucketController) newGetFileContentServerTool() server.ServerTool {
 			RepoName:    repoName,
 			Commit:      commitHash,
 			Path:        filePath,
+			FromLine:    request.GetInt("from_line", 0),
+			ToLine:      request.GetInt("to_line", 0),
+			MaxBytes:    request.GetInt("max_bytes", 0),
 		}
 
 		result, err := bc.bitbucketService.GetFileContent(ctx, params)
@@ -1105,22 +1195,61 @@ func (bc *BitbucketController) newGetFileContentServerTool() server.ServerTool {
 			return nil, fmt.Errorf("failed to get file content: %w", err)
 		}
 
-		// Compose summary
-		summaryText := fmt.Sprintf("File content for %s at %s/%s", filePath, repoOwner, repoName)
+		return newFileContentResult(
+			fmt.Sprintf("File content for %s at %s/%s", filePath, repoOwner, repoName),
+			result,
+		), nil
+	}
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
 
+// newFileContentResult returns text content with a summary of the returned lines. Images are
+// returned as image content, content of other binary files is omitted.
+func newFileContentResult(summary string, result *bitbucket.FileContentResult) *mcp.CallToolResult {
+	meta := result.Meta
+	switch {
+	case meta.Binary && result.Content != "":
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				mcp.TextContent{
-					Type: "text",
-					Text: summaryText,
-				},
-				mcp.NewTextContent(result.Content),
+				mcp.NewTextContent(fmt.Sprintf("%s (%s image, %d bytes)", summary, meta.MimeType, meta.Size)),
+				mcp.NewImageContent(result.Content, meta.MimeType),
 			},
-		}, nil
+		}
+	case meta.Binary:
+		reason := "binary file"
+		if meta.Truncated {
+			reason = "image is too large"
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("%s: content omitted, %s (%s, %d bytes)",
+					summary, reason, lo.CoalesceOrEmpty(meta.MimeType, "unknown type"), meta.Size)),
+			},
+		}
 	}
-	return server.ServerTool{
-		Tool:    tool,
-		Handler: handler,
+
+	if meta.ToLine > 0 {
+		total := ""
+		if meta.TotalLines > 0 {
+			total = fmt.Sprintf(" of %d", meta.TotalLines)
+		}
+		summary += fmt.Sprintf(" (lines %d-%d%s", meta.FromLine, meta.ToLine, total)
+		if meta.Truncated {
+			summary += ", truncated"
+		}
+		summary += ")"
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: summary,
+			},
+			mcp.NewTextContent(result.Content),
+		},
 	}
 }
 
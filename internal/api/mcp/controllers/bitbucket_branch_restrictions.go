@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newGetBranchRestrictionsServerTool returns a server tool for getting branch restrictions of a branch.
+func (bc *BitbucketController) newGetBranchRestrictionsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branch_restrictions_get",
+		mcp.WithDescription(
+			"Get branch restrictions that apply to a branch of a Bitbucket repository: required approvals, "+
+				"default reviewer approvals, passing builds, resolved tasks and who can push or merge. "+
+				"Requires admin access to the repository.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Branch name or pattern, e.g. main or release/*"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branch_restrictions_get request", "params", request.Params)
+
+		params, errResult := parseBranchParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		policy, err := bc.bitbucketService.GetBranchPolicy(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch restrictions: %w", err)
+		}
+
+		policyJSON, err := json.MarshalIndent(policy, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal branch restrictions to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatBranchPolicySummary(policy),
+				},
+				mcp.NewTextContent(string(policyJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatBranchPolicySummary renders a branch policy as human readable text.
+func formatBranchPolicySummary(policy *app.BranchPolicy) string {
+	if len(policy.Restrictions) == 0 {
+		return "No branch restrictions apply to " + policy.Branch
+	}
+
+	var rules []string
+	if policy.RequiredApprovals > 0 {
+		rules = append(rules, fmt.Sprintf("%d approvals required to merge", policy.RequiredApprovals))
+	}
+	if policy.RequiredDefaultReviewerApprovals > 0 {
+		rules = append(rules, fmt.Sprintf("%d approvals of default reviewers required to merge",
+			policy.RequiredDefaultReviewerApprovals))
+	}
+	if policy.RequiredPassingBuilds > 0 {
+		rules = append(rules, fmt.Sprintf("%d passing builds required to merge", policy.RequiredPassingBuilds))
+	}
+	if policy.RequireTasksCompleted {
+		rules = append(rules, "all tasks must be resolved to merge")
+	}
+	if policy.RequireNoChangesRequested {
+		rules = append(rules, "no changes may be requested to merge")
+	}
+	if policy.MergeAllowedTo != nil {
+		rules = append(rules, "only "+policy.MergeAllowedTo.String()+" can merge")
+	}
+	if policy.PushAllowedTo != nil {
+		rules = append(rules, "only "+policy.PushAllowedTo.String()+" can push")
+	}
+	if policy.ForcePushPrevented {
+		rules = append(rules, "force pushes are prevented")
+	}
+	if policy.DeletionPrevented {
+		rules = append(rules, "deletion is prevented")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Branch restrictions of %s:", policy.Branch)
+	for _, rule := range rules {
+		sb.WriteString("\n- " + rule)
+	}
+	return sb.String()
+}
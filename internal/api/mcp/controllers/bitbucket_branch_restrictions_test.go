@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_BranchRestrictions(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeBranchParams := func(name string) app.BitbucketBranchParams {
+		return app.BitbucketBranchParams{
+			BitbucketRepositoryParams: app.BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "workspace-" + faker.Username(),
+				RepoName:    "repo-" + faker.Word(),
+			},
+			Name: name,
+		}
+	}
+
+	branchArguments := func(params app.BitbucketBranchParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+			"branch":     params.Name,
+		}
+	}
+
+	t.Run("bitbucket_branch_restrictions_get", func(t *testing.T) {
+		t.Run("should get branch restrictions", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeBranchParams("main")
+			policy := &app.BranchPolicy{
+				Branch:                           params.Name,
+				RequiredApprovals:                2,
+				RequiredDefaultReviewerApprovals: 1,
+				RequiredPassingBuilds:            1,
+				RequireTasksCompleted:            true,
+				RequireNoChangesRequested:        true,
+				MergeAllowedTo:                   &app.BranchAccessList{Users: []string{"Lead"}, Groups: []string{}},
+				PushAllowedTo:                    &app.BranchAccessList{Users: []string{}, Groups: []string{"admins"}},
+				ForcePushPrevented:               true,
+				DeletionPrevented:                true,
+				Restrictions: []bitbucket.BranchRestriction{
+					{ID: 1, Kind: bitbucket.BranchRestrictionForce, Pattern: "*"},
+				},
+			}
+			mockService.EXPECT().GetBranchPolicy(ctx, params).Return(policy, nil)
+
+			result, err := controller.newGetBranchRestrictionsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branch_restrictions_get", Arguments: branchArguments(params)},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Branch restrictions of main:"+
+				"\n- 2 approvals required to merge"+
+				"\n- 1 approvals of default reviewers required to merge"+
+				"\n- 1 passing builds required to merge"+
+				"\n- all tasks must be resolved to merge"+
+				"\n- no changes may be requested to merge"+
+				"\n- only Lead can merge"+
+				"\n- only group admins can push"+
+				"\n- force pushes are prevented"+
+				"\n- deletion is prevented",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.BranchPolicy
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *policy, parsed)
+		})
+
+		t.Run("should report branch without restrictions", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeBranchParams("feature/" + faker.Word())
+			mockService.EXPECT().GetBranchPolicy(ctx, params).Return(&app.BranchPolicy{
+				Branch:       params.Name,
+				Restrictions: []bitbucket.BranchRestriction{},
+			}, nil)
+
+			result, err := controller.newGetBranchRestrictionsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branch_restrictions_get", Arguments: branchArguments(params)},
+			})
+
+			require.NoError(t, err)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "No branch restrictions apply to "+params.Name, summary.Text)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeBranchParams("main")
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().GetBranchPolicy(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newGetBranchRestrictionsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branch_restrictions_get", Arguments: branchArguments(params)},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "branch"} {
+				args := branchArguments(makeBranchParams(faker.Word()))
+				delete(args, missing)
+
+				result, err := controller.newGetBranchRestrictionsServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_branch_restrictions_get", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+}
@@ -0,0 +1,406 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListBranchesServerTool returns a server tool for listing branches of a repository.
+func (bc *BitbucketController) newListBranchesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branches_list",
+		mcp.WithDescription("List open branches of a Bitbucket repository, optionally filtered by name"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("filter",
+			mcp.Description("Only list branches whose name contains this text (optional)"),
+		),
+		mcp.WithString("sort",
+			mcp.Description(
+				"Sort field, prefix with - for descending order, e.g. name or -target.date for recently updated first "+
+					"(optional)",
+			),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of branches to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branches_list request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		branches, err := bc.bitbucketService.ListBranches(ctx, app.BitbucketListBranchesParams{
+			BitbucketRepositoryParams: repoParams,
+			Filter:                    request.GetString("filter", ""),
+			Sort:                      request.GetString("sort", ""),
+			Limit:                     request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		branchesJSON, err := json.MarshalIndent(branches, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal branches to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatBranchesSummary(branches),
+				},
+				mcp.NewTextContent(string(branchesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newGetBranchServerTool returns a server tool for getting a branch, e.g. to check that it exists.
+func (bc *BitbucketController) newGetBranchServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branches_get",
+		mcp.WithDescription(
+			"Get a branch of a Bitbucket repository with its head commit. "+
+				"Fails when the branch does not exist, use it to check a branch before creating a pull request.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Branch name"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branches_get request", "params", request.Params)
+
+		params, errResult := parseBranchParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		branch, err := bc.bitbucketService.GetBranch(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch: %w", err)
+		}
+
+		return newBranchResult("Branch "+formatBranchLine(*branch), branch)
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newCreateBranchServerTool returns a server tool for creating a branch.
+func (bc *BitbucketController) newCreateBranchServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branches_create",
+		mcp.WithDescription("Create a branch in a Bitbucket repository from another branch or a commit"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Name of the new branch"),
+			mcp.Required(),
+		),
+		mcp.WithString("from",
+			mcp.Description("Branch name or commit hash the new branch starts from"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branches_create request", "params", request.Params)
+
+		params, errResult := parseBranchParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		from, err := request.RequireString("from")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid from parameter", err), nil
+		}
+
+		branch, err := bc.bitbucketService.CreateBranch(ctx, app.BitbucketCreateBranchParams{
+			BitbucketRepositoryParams: params.BitbucketRepositoryParams,
+			Name:                      params.Name,
+			From:                      from,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create branch: %w", err)
+		}
+
+		return newBranchResult("Branch created: "+formatBranchLine(*branch), branch)
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newDeleteBranchServerTool returns a server tool for deleting a branch.
+func (bc *BitbucketController) newDeleteBranchServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branches_delete",
+		mcp.WithDescription("Delete a branch of a Bitbucket repository"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Name of the branch to delete"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branches_delete request", "params", request.Params)
+
+		params, errResult := parseBranchParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if err := bc.bitbucketService.DeleteBranch(ctx, params); err != nil {
+			return nil, fmt.Errorf("failed to delete branch: %w", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Branch %s deleted", params.Name)), nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newCompareBranchesServerTool returns a server tool for comparing two branches.
+func (bc *BitbucketController) newCompareBranchesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_branches_compare",
+		mcp.WithDescription(
+			"Compare two branches of a Bitbucket repository: count commits the source branch is ahead of "+
+				"and behind the destination branch, and list the most recent commits ahead",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("source",
+			mcp.Description("Branch that is compared, e.g. a feature branch"),
+			mcp.Required(),
+		),
+		mcp.WithString("destination",
+			mcp.Description("Branch the source is compared against, e.g. main"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_branches_compare request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		source, err := request.RequireString("source")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid source parameter", err), nil
+		}
+
+		destination, err := request.RequireString("destination")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid destination parameter", err), nil
+		}
+
+		comparison, err := bc.bitbucketService.CompareBranches(ctx, app.BitbucketCompareBranchesParams{
+			BitbucketRepositoryParams: repoParams,
+			Source:                    source,
+			Destination:               destination,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare branches: %w", err)
+		}
+
+		comparisonJSON, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal branch comparison to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatBranchComparisonSummary(comparison),
+				},
+				mcp.NewTextContent(string(comparisonJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// parseBranchParams extracts parameters identifying a branch.
+// A tool error result is returned when a required parameter is missing.
+func parseBranchParams(request mcp.CallToolRequest) (app.BitbucketBranchParams, *mcp.CallToolResult) {
+	repoParams, errResult := parseRepositoryParams(request)
+	if errResult != nil {
+		return app.BitbucketBranchParams{}, errResult
+	}
+
+	name, err := request.RequireString("branch")
+	if err != nil {
+		return app.BitbucketBranchParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid branch parameter", err)
+	}
+
+	return app.BitbucketBranchParams{
+		BitbucketRepositoryParams: repoParams,
+		Name:                      name,
+	}, nil
+}
+
+// newBranchResult renders a branch as a tool result with the given summary.
+func newBranchResult(summary string, branch *bitbucket.Branch) (*mcp.CallToolResult, error) {
+	branchJSON, err := json.MarshalIndent(branch, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal branch to JSON: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: summary,
+			},
+			mcp.NewTextContent(string(branchJSON)),
+		},
+	}, nil
+}
+
+// formatCommitLine renders a one line description of a commit as HASH SUMMARY.
+func formatCommitLine(commit bitbucket.Commit) string {
+	line := commit.Hash
+	if summary, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n"); summary != "" {
+		line += " " + summary
+	}
+	return line
+}
+
+// formatBranchLine renders a one line description of a branch.
+func formatBranchLine(branch bitbucket.Branch) string {
+	line := branch.Name
+	if target := branch.Target; target != nil {
+		line += " at " + target.Hash
+		if target.Date != "" {
+			line += " (" + target.Date + ")"
+		}
+	}
+	return line
+}
+
+// formatBranchesSummary renders branches as human readable text.
+func formatBranchesSummary(branches []bitbucket.Branch) string {
+	if len(branches) == 0 {
+		return "No branches found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d branches:", len(branches))
+	for _, branch := range branches {
+		sb.WriteString("\n- " + formatBranchLine(branch))
+	}
+	return sb.String()
+}
+
+// formatCommitCount renders a number of commits, truncated counts are lower bounds.
+func formatCommitCount(count int, truncated bool) string {
+	if truncated {
+		return fmt.Sprintf("at least %d commits", count)
+	}
+	return fmt.Sprintf("%d commits", count)
+}
+
+// formatBranchComparisonSummary renders a branch comparison as human readable text.
+func formatBranchComparisonSummary(comparison *app.BranchComparison) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s is %s ahead of and %s behind %s",
+		comparison.Source.Name,
+		formatCommitCount(comparison.Ahead, comparison.AheadTruncated),
+		formatCommitCount(comparison.Behind, comparison.BehindTruncated),
+		comparison.Destination.Name,
+	)
+	if len(comparison.AheadCommits) > 0 {
+		sb.WriteString("\nCommits ahead:")
+		for _, commit := range comparison.AheadCommits {
+			sb.WriteString("\n- " + formatCommitLine(commit))
+		}
+	}
+	return sb.String()
+}
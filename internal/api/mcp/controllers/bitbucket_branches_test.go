@@ -0,0 +1,315 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Branches(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	repoArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+		}
+	}
+
+	newRandomBranch := func(name string) *bitbucket.Branch {
+		return &bitbucket.Branch{
+			Type:   "branch",
+			Name:   name,
+			Target: &bitbucket.Commit{Hash: faker.UUIDDigit(), Date: "2025-01-02T03:04:05+00:00"},
+		}
+	}
+
+	t.Run("bitbucket_branches_list", func(t *testing.T) {
+		t.Run("should list branches", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Filter:                    "feature/",
+				Sort:                      "-target.date",
+				Limit:                     1 + rand.IntN(100),
+			}
+			branch := newRandomBranch("feature/" + faker.Word())
+			mockService.EXPECT().ListBranches(ctx, params).Return([]bitbucket.Branch{*branch}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["filter"] = params.Filter
+			args["sort"] = params.Sort
+			args["limit"] = params.Limit
+			result, err := controller.newListBranchesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_list", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"Found 1 branches:\n- "+branch.Name+" at "+branch.Target.Hash+" ("+branch.Target.Date+")",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.Branch
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, []bitbucket.Branch{*branch}, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := app.BitbucketListBranchesParams{BitbucketRepositoryParams: makeRepoParams()}
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListBranches(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newListBranchesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_branches_list",
+					Arguments: repoArguments(params.BitbucketRepositoryParams),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_branches_get", func(t *testing.T) {
+		t.Run("should get branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "feature/" + faker.Word(),
+			}
+			branch := newRandomBranch(params.Name)
+			mockService.EXPECT().GetBranch(ctx, params).Return(branch, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["branch"] = params.Name
+			result, err := controller.newGetBranchServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_get", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Branch "+params.Name+" at "+branch.Target.Hash)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "branch"} {
+				args := repoArguments(makeRepoParams())
+				args["branch"] = faker.Word()
+				delete(args, missing)
+
+				result, err := controller.newGetBranchServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_branches_get", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+
+	t.Run("bitbucket_branches_create", func(t *testing.T) {
+		t.Run("should create branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketCreateBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "feature/" + faker.Word(),
+				From:                      faker.UUIDDigit(),
+			}
+			branch := newRandomBranch(params.Name)
+			mockService.EXPECT().CreateBranch(ctx, params).Return(branch, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["branch"] = params.Name
+			args["from"] = params.From
+			result, err := controller.newCreateBranchServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_create", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Branch created: "+params.Name)
+		})
+
+		t.Run("should require from parameter", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			args := repoArguments(makeRepoParams())
+			args["branch"] = faker.Word()
+			result, err := controller.newCreateBranchServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_create", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	})
+
+	t.Run("bitbucket_branches_delete", func(t *testing.T) {
+		t.Run("should delete branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "feature/" + faker.Word(),
+			}
+			mockService.EXPECT().DeleteBranch(ctx, params).Return(nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["branch"] = params.Name
+			result, err := controller.newDeleteBranchServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_delete", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Branch "+params.Name+" deleted", summary.Text)
+		})
+	})
+
+	t.Run("bitbucket_branches_compare", func(t *testing.T) {
+		t.Run("should compare branches", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "feature/" + faker.Word(),
+				Destination:               "main",
+			}
+			commit := bitbucket.Commit{Hash: faker.UUIDDigit(), Message: "Add feature\n\nDetails"}
+			mockService.EXPECT().CompareBranches(ctx, params).Return(&app.BranchComparison{
+				Source:       newRandomBranch(params.Source),
+				Destination:  newRandomBranch(params.Destination),
+				Ahead:        1,
+				Behind:       3,
+				AheadCommits: []bitbucket.Commit{commit},
+			}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["source"] = params.Source
+			args["destination"] = params.Destination
+			result, err := controller.newCompareBranchesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_compare", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				params.Source+" is 1 commits ahead of and 3 commits behind main"+
+					"\nCommits ahead:\n- "+commit.Hash+" Add feature",
+				summary.Text,
+			)
+		})
+
+		t.Run("should report lower bounds of truncated comparison", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "release",
+				Destination:               "main",
+			}
+			mockService.EXPECT().CompareBranches(ctx, params).Return(&app.BranchComparison{
+				Source:          newRandomBranch(params.Source),
+				Destination:     newRandomBranch(params.Destination),
+				Behind:          500,
+				BehindTruncated: true,
+			}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["source"] = params.Source
+			args["destination"] = params.Destination
+			result, err := controller.newCompareBranchesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_branches_compare", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "release is 0 commits ahead of and at least 500 commits behind main", summary.Text)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"source", "destination"} {
+				args := repoArguments(makeRepoParams())
+				args["source"] = faker.Word()
+				args["destination"] = faker.Word()
+				delete(args, missing)
+
+				result, err := controller.newCompareBranchesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_branches_compare", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListBuildStatusesServerTool returns a server tool for listing build statuses of a pull request or a commit.
+func (bc *BitbucketController) newListBuildStatusesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_list_build_statuses",
+		mcp.WithDescription(
+			"List CI build statuses reported for a pull request or a commit in Bitbucket. "+
+				"Provide either pr_id or commit.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("pr_id",
+			mcp.Description("Pull request ID to list statuses of all its commits (optional)"),
+		),
+		mcp.WithString("commit",
+			mcp.Description("Commit hash to list statuses of (optional)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_list_build_statuses request", "params", request.Params)
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		prID := request.GetInt("pr_id", 0)
+		commit := request.GetString("commit", "")
+		if (prID > 0) == (commit != "") {
+			return mcp.NewToolResultError("Either pr_id or commit parameter must be provided"), nil
+		}
+
+		statuses, err := bc.bitbucketService.ListBuildStatuses(ctx, app.BitbucketListBuildStatusesParams{
+			AccountName:   request.GetString("account", ""),
+			RepoOwner:     repoOwner,
+			RepoName:      repoName,
+			PullRequestID: prID,
+			Commit:        commit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list build statuses: %w", err)
+		}
+
+		statusesJSON, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal build statuses to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatBuildStatusesSummary(statuses),
+				},
+				mcp.NewTextContent(string(statusesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newSetBuildStatusServerTool returns a server tool for reporting a build status of a commit.
+func (bc *BitbucketController) newSetBuildStatusServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_set_build_status",
+		mcp.WithDescription(
+			"Create or update a CI build status of a commit in Bitbucket. "+
+				"A status is created when url is provided, otherwise the existing status with the same key is updated.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("commit",
+			mcp.Description("Commit hash to report the status for"),
+			mcp.Required(),
+		),
+		mcp.WithString("key",
+			mcp.Description("Key identifying the build, unique per commit"),
+			mcp.Required(),
+		),
+		mcp.WithString("state",
+			mcp.Description("Build state"),
+			mcp.Enum(
+				bitbucket.CommitStatusStateSuccessful,
+				bitbucket.CommitStatusStateFailed,
+				bitbucket.CommitStatusStateInProgress,
+				bitbucket.CommitStatusStateStopped,
+			),
+			mcp.Required(),
+		),
+		mcp.WithString("url",
+			mcp.Description("URL of the build results (required to create a status, omit to update an existing one)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Name of the build (optional)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Description of the build results (optional)"),
+		),
+		mcp.WithString("refname",
+			mcp.Description("Name of the branch or tag the build was run for (optional)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_set_build_status request", "params", request.Params)
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		commit, err := request.RequireString("commit")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid commit parameter", err), nil
+		}
+
+		key, err := request.RequireString("key")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid key parameter", err), nil
+		}
+
+		state, err := request.RequireString("state")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid state parameter", err), nil
+		}
+
+		params := app.BitbucketSetBuildStatusParams{
+			AccountName: request.GetString("account", ""),
+			RepoOwner:   repoOwner,
+			RepoName:    repoName,
+			Commit:      commit,
+			Key:         key,
+			State:       state,
+			URL:         request.GetString("url", ""),
+			Name:        request.GetString("name", ""),
+			Description: request.GetString("description", ""),
+			RefName:     request.GetString("refname", ""),
+		}
+
+		status, err := bc.bitbucketService.SetBuildStatus(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set build status: %w", err)
+		}
+
+		statusJSON, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal build status to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Build status %s of commit %s is %s", status.Key, params.Commit, status.State),
+				},
+				mcp.NewTextContent(string(statusJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatBuildStatusesSummary renders build statuses as human readable text.
+func formatBuildStatusesSummary(statuses []bitbucket.CommitStatus) string {
+	if len(statuses) == 0 {
+		return "No build statuses found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d build statuses:", len(statuses))
+	for _, status := range statuses {
+		fmt.Fprintf(&sb, "\n- [%s] %s", status.State, status.Key)
+		if status.Name != "" && status.Name != status.Key {
+			fmt.Fprintf(&sb, " (%s)", status.Name)
+		}
+		if status.Commit != nil && status.Commit.Hash != "" {
+			fmt.Fprintf(&sb, " on %s", status.Commit.Hash)
+		}
+		if status.URL != "" {
+			fmt.Fprintf(&sb, ": %s", status.URL)
+		}
+	}
+	return sb.String()
+}
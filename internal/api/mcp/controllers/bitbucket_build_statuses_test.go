@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_BuildStatuses(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("bitbucket_list_build_statuses", func(t *testing.T) {
+		t.Run("should list statuses of a pull request", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListBuildStatusesParams{
+				AccountName:   "account-" + faker.Username(),
+				RepoOwner:     "workspace-" + faker.Username(),
+				RepoName:      "repo-" + faker.Word(),
+				PullRequestID: 1 + rand.IntN(1000),
+			}
+			statuses := []bitbucket.CommitStatus{
+				{
+					Key:    "build-" + faker.Word(),
+					Name:   faker.Word(),
+					State:  bitbucket.CommitStatusStateFailed,
+					URL:    faker.URL(),
+					Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()},
+				},
+			}
+			mockService.EXPECT().ListBuildStatuses(ctx, params).Return(statuses, nil)
+
+			result, err := controller.newListBuildStatusesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_list_build_statuses",
+					Arguments: map[string]interface{}{
+						"repo_owner": params.RepoOwner,
+						"repo_name":  params.RepoName,
+						"pr_id":      params.PullRequestID,
+						"account":    params.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Found 1 build statuses")
+			assert.Contains(t, summary.Text, "[FAILED] "+statuses[0].Key+" ("+statuses[0].Name+") on "+
+				statuses[0].Commit.Hash+": "+statuses[0].URL)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.CommitStatus
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, statuses, parsed)
+		})
+
+		t.Run("should list statuses of a commit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListBuildStatusesParams{
+				RepoOwner: "workspace-" + faker.Username(),
+				RepoName:  "repo-" + faker.Word(),
+				Commit:    faker.UUIDDigit(),
+			}
+			mockService.EXPECT().ListBuildStatuses(ctx, params).Return(nil, nil)
+
+			result, err := controller.newListBuildStatusesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_list_build_statuses",
+					Arguments: map[string]interface{}{
+						"repo_owner": params.RepoOwner,
+						"repo_name":  params.RepoName,
+						"commit":     params.Commit,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "No build statuses found", summary.Text)
+		})
+
+		t.Run("should require either pr_id or commit", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newListBuildStatusesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_list_build_statuses",
+					Arguments: map[string]interface{}{
+						"repo_owner": "owner",
+						"repo_name":  "repo",
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListBuildStatuses(ctx, app.BitbucketListBuildStatusesParams{
+				RepoOwner:     "owner",
+				RepoName:      "repo",
+				PullRequestID: 1,
+			}).Return(nil, expectedErr)
+
+			result, err := controller.newListBuildStatusesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_list_build_statuses",
+					Arguments: map[string]interface{}{
+						"repo_owner": "owner",
+						"repo_name":  "repo",
+						"pr_id":      1,
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_set_build_status", func(t *testing.T) {
+		t.Run("should set build status", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketSetBuildStatusParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "workspace-" + faker.Username(),
+				RepoName:    "repo-" + faker.Word(),
+				Commit:      faker.UUIDDigit(),
+				Key:         "build-" + faker.Word(),
+				State:       bitbucket.CommitStatusStateSuccessful,
+				URL:         faker.URL(),
+				Name:        faker.Word(),
+				Description: faker.Sentence(),
+				RefName:     "main",
+			}
+			status := &bitbucket.CommitStatus{Key: params.Key, State: params.State, URL: params.URL}
+			mockService.EXPECT().SetBuildStatus(ctx, params).Return(status, nil)
+
+			result, err := controller.newSetBuildStatusServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_set_build_status",
+					Arguments: map[string]interface{}{
+						"repo_owner":  params.RepoOwner,
+						"repo_name":   params.RepoName,
+						"commit":      params.Commit,
+						"key":         params.Key,
+						"state":       params.State,
+						"url":         params.URL,
+						"name":        params.Name,
+						"description": params.Description,
+						"refname":     params.RefName,
+						"account":     params.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Build status "+params.Key+" of commit "+params.Commit+" is SUCCESSFUL", summary.Text)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "commit", "key", "state"} {
+				args := map[string]interface{}{
+					"repo_owner": "owner",
+					"repo_name":  "repo",
+					"commit":     "abc",
+					"key":        "build",
+					"state":      "FAILED",
+				}
+				delete(args, missing)
+
+				result, err := controller.newSetBuildStatusServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_set_build_status", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().SetBuildStatus(ctx, app.BitbucketSetBuildStatusParams{
+				RepoOwner: "owner",
+				RepoName:  "repo",
+				Commit:    "abc",
+				Key:       "build",
+				State:     "FAILED",
+			}).Return(nil, expectedErr)
+
+			result, err := controller.newSetBuildStatusServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_set_build_status",
+					Arguments: map[string]interface{}{
+						"repo_owner": "owner",
+						"repo_name":  "repo",
+						"commit":     "abc",
+						"key":        "build",
+						"state":      "FAILED",
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/samber/lo"
+)
+
+// newCommitFilesServerTool returns a server tool for committing file changes to a branch.
+func (bc *BitbucketController) newCommitFilesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_commit_files",
+		mcp.WithDescription("Commit added, modified and deleted files to a branch of a Bitbucket repository "+
+			"without a local clone, e.g. to apply a review suggestion to the source branch of a pull request."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Branch to commit to"),
+			mcp.Required(),
+		),
+		mcp.WithString("message",
+			mcp.Description("Commit message"),
+			mcp.Required(),
+		),
+		mcp.WithObject("files",
+			mcp.Description("Added or modified files as a map of path to the complete new content (optional)"),
+		),
+		mcp.WithArray("delete",
+			mcp.Description("Paths of files to delete (optional)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("author",
+			mcp.Description("Commit author in \"Name <email>\" format (optional, defaults to the authenticated user)"),
+		),
+		mcp.WithString("expected_head",
+			mcp.Description("Commit hash the branch must point at (optional). "+
+				"The commit is rejected if the branch moved, so that newer changes are not overwritten."),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_commit_files request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		branch, err := request.RequireString("branch")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid branch parameter", err), nil
+		}
+		message, err := request.RequireString("message")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid message parameter", err), nil
+		}
+		files, err := parseFileChanges(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Invalid files parameter", err), nil
+		}
+
+		committed, err := bc.bitbucketService.CommitFiles(ctx, app.BitbucketCommitFilesParams{
+			BitbucketRepositoryParams: repoParams,
+			Branch:                    branch,
+			Message:                   message,
+			Author:                    request.GetString("author", ""),
+			ExpectedHead:              request.GetString("expected_head", ""),
+			Files:                     files,
+			Delete:                    request.GetStringSlice("delete", nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to commit files: %w", err)
+		}
+
+		committedJSON, err := json.MarshalIndent(committed, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal commit to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatCommittedFilesSummary(committed),
+				},
+				mcp.NewTextContent(string(committedJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// parseFileChanges reads the files argument, a map of path to the new content of the file.
+func parseFileChanges(request mcp.CallToolRequest) ([]bitbucket.FileChange, error) {
+	raw, ok := request.GetArguments()["files"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("files must be an object")
+	}
+	paths := lo.Keys(values)
+	slices.Sort(paths)
+	files := make([]bitbucket.FileChange, 0, len(paths))
+	for _, path := range paths {
+		content, isString := values[path].(string)
+		if !isString {
+			return nil, fmt.Errorf("content of %s must be a string", path)
+		}
+		files = append(files, bitbucket.FileChange{Path: path, Content: content})
+	}
+	return files, nil
+}
+
+// formatCommittedFilesSummary renders a commit created from file changes as human readable text.
+func formatCommittedFilesSummary(committed *app.CommittedFiles) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Committed %s to %s", committed.Commit, committed.Branch)
+	if len(committed.Updated) > 0 {
+		sb.WriteString("\nUpdated:")
+		for _, path := range committed.Updated {
+			sb.WriteString("\n- " + path)
+		}
+	}
+	if len(committed.Deleted) > 0 {
+		sb.WriteString("\nDeleted:")
+		for _, path := range committed.Deleted {
+			sb.WriteString("\n- " + path)
+		}
+	}
+	return sb.String()
+}
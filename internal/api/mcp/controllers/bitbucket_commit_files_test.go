@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_CommitFiles(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	commitArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+			"branch":     "feature/typo",
+			"message":    "Fix typo",
+		}
+	}
+
+	t.Run("should commit files", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		repoParams := makeRepoParams()
+		args := commitArguments(repoParams)
+		args["files"] = map[string]any{"docs/b.md": "B\n", "a.md": "A\n"}
+		args["delete"] = []any{"old.md"}
+		args["author"] = "Jane <jane@example.com>"
+		args["expected_head"] = "abc123"
+		committed := &app.CommittedFiles{
+			Branch:  "feature/typo",
+			Commit:  "def456",
+			Updated: []string{"a.md", "docs/b.md"},
+			Deleted: []string{"old.md"},
+		}
+		mockService.EXPECT().CommitFiles(ctx, app.BitbucketCommitFilesParams{
+			BitbucketRepositoryParams: repoParams,
+			Branch:                    "feature/typo",
+			Message:                   "Fix typo",
+			Author:                    "Jane <jane@example.com>",
+			ExpectedHead:              "abc123",
+			Files: []bitbucket.FileChange{
+				{Path: "a.md", Content: "A\n"},
+				{Path: "docs/b.md", Content: "B\n"},
+			},
+			Delete: []string{"old.md"},
+		}).Return(committed, nil)
+
+		result, err := controller.newCommitFilesServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_commit_files", Arguments: args},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Committed def456 to feature/typo"+
+			"\nUpdated:\n- a.md\n- docs/b.md"+
+			"\nDeleted:\n- old.md",
+			summary.Text,
+		)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.CommittedFiles
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *committed, parsed)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		repoParams := makeRepoParams()
+		args := commitArguments(repoParams)
+		args["delete"] = []any{"old.md"}
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().CommitFiles(ctx, app.BitbucketCommitFilesParams{
+			BitbucketRepositoryParams: repoParams,
+			Branch:                    "feature/typo",
+			Message:                   "Fix typo",
+			Delete:                    []string{"old.md"},
+		}).Return(nil, expectedErr)
+
+		result, err := controller.newCommitFilesServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_commit_files", Arguments: args},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should reject invalid files", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		for _, files := range []any{"a.md", map[string]any{"a.md": 1}} {
+			args := commitArguments(makeRepoParams())
+			args["files"] = files
+
+			result, err := controller.newCommitFilesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_commit_files", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError)
+		}
+	})
+
+	t.Run("should handle missing required parameters", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		for _, missing := range []string{"repo_owner", "repo_name", "branch", "message"} {
+			args := commitArguments(makeRepoParams())
+			delete(args, missing)
+
+			result, err := controller.newCommitFilesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_commit_files", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError, "missing %s should produce error result", missing)
+		}
+	})
+}
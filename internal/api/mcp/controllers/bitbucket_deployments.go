@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListEnvironmentsServerTool returns a server tool for listing deployment environments of a repository.
+func (bc *BitbucketController) newListEnvironmentsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_deployment_environments_list",
+		mcp.WithDescription("List Bitbucket deployment environments of a repository ordered by rank"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_deployment_environments_list request", "params", request.Params)
+
+		params, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		environments, err := bc.bitbucketService.ListEnvironments(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list environments: %w", err)
+		}
+
+		environmentsJSON, err := json.MarshalIndent(environments, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal environments to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatEnvironmentsSummary(environments),
+				},
+				mcp.NewTextContent(string(environmentsJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newListDeploymentsServerTool returns a server tool for listing deployments of an environment.
+func (bc *BitbucketController) newListDeploymentsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_deployments_list",
+		mcp.WithDescription("List the most recent Bitbucket deployments to an environment, newest first"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("environment",
+			mcp.Description("Environment UUID, name or slug, e.g. Production"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of deployments to return (optional, defaults to 10, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_deployments_list request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		environment, err := request.RequireString("environment")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid environment parameter", err), nil
+		}
+
+		deployments, err := bc.bitbucketService.ListDeployments(ctx, app.BitbucketListDeploymentsParams{
+			BitbucketRepositoryParams: repoParams,
+			Environment:               environment,
+			Limit:                     request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", err)
+		}
+
+		deploymentsJSON, err := json.MarshalIndent(deployments, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal deployments to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatDeploymentsSummary(environment, deployments),
+				},
+				mcp.NewTextContent(string(deploymentsJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newDeploymentsOverviewServerTool returns a server tool reporting what is deployed to each environment.
+func (bc *BitbucketController) newDeploymentsOverviewServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_deployments_overview",
+		mcp.WithDescription(
+			"Show what is currently deployed to each Bitbucket deployment environment of a repository, "+
+				"including the latest deployment when it is in progress or has failed",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_deployments_overview request", "params", request.Params)
+
+		params, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		overview, err := bc.bitbucketService.GetDeploymentsOverview(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployments overview: %w", err)
+		}
+
+		overviewJSON, err := json.MarshalIndent(overview, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal deployments overview to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatDeploymentsOverviewSummary(overview),
+				},
+				mcp.NewTextContent(string(overviewJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatDeploymentState renders a deployment state as STATE or STATE/STATUS.
+func formatDeploymentState(state *bitbucket.DeploymentState) string {
+	if state == nil {
+		return "UNKNOWN"
+	}
+	if state.Status != nil && state.Status.Name != "" {
+		return state.Name + "/" + state.Status.Name
+	}
+	return state.Name
+}
+
+// formatDeploymentLine renders a one line description of a deployment.
+func formatDeploymentLine(deployment bitbucket.Deployment) string {
+	line := "[" + formatDeploymentState(deployment.State) + "]"
+	if release := deployment.Release; release != nil {
+		if release.Name != "" {
+			line += " " + release.Name
+		}
+		if release.Commit != nil && release.Commit.Hash != "" {
+			line += " at " + release.Commit.Hash
+		}
+	}
+	if state := deployment.State; state != nil && state.StartDate != nil {
+		line += " started " + state.StartDate.Format("2006-01-02 15:04:05")
+	}
+	return line + " " + deployment.UUID
+}
+
+// formatEnvironmentsSummary renders deployment environments as human readable text.
+func formatEnvironmentsSummary(environments []bitbucket.DeploymentEnvironment) string {
+	if len(environments) == 0 {
+		return "No deployment environments found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d deployment environments:", len(environments))
+	for _, environment := range environments {
+		fmt.Fprintf(&sb, "\n- %s", environment.Name)
+		if environment.EnvironmentType != nil {
+			fmt.Fprintf(&sb, " (%s)", environment.EnvironmentType.Name)
+		}
+		sb.WriteString(" " + environment.UUID)
+	}
+	return sb.String()
+}
+
+// formatDeploymentsSummary renders deployments of an environment as human readable text.
+func formatDeploymentsSummary(environment string, deployments []bitbucket.Deployment) string {
+	if len(deployments) == 0 {
+		return "No deployments found in " + environment
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d deployments in %s:", len(deployments), environment)
+	for _, deployment := range deployments {
+		sb.WriteString("\n- " + formatDeploymentLine(deployment))
+	}
+	return sb.String()
+}
+
+// formatDeploymentsOverviewSummary renders what is deployed to each environment as human readable text.
+func formatDeploymentsOverviewSummary(overview []app.EnvironmentDeployments) string {
+	if len(overview) == 0 {
+		return "No deployment environments found"
+	}
+	var sb strings.Builder
+	sb.WriteString("Deployments overview:")
+	for _, item := range overview {
+		sb.WriteString("\n- " + item.Environment.Name + ": ")
+		if item.Deployed != nil {
+			sb.WriteString(formatDeploymentLine(*item.Deployed))
+		} else {
+			sb.WriteString("nothing deployed")
+		}
+		if item.Latest != nil && (item.Deployed == nil || item.Latest.UUID != item.Deployed.UUID) {
+			sb.WriteString("\n  latest: " + formatDeploymentLine(*item.Latest))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Deployments(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	repoArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+		}
+	}
+
+	newRandomEnvironment := func(name string) bitbucket.DeploymentEnvironment {
+		return bitbucket.DeploymentEnvironment{
+			UUID: "{" + faker.UUIDHyphenated() + "}",
+			Name: name,
+			Slug: "slug-" + faker.Word(),
+			Rank: rand.IntN(10),
+		}
+	}
+
+	newRandomDeployment := func(status string) bitbucket.Deployment {
+		startedAt := time.Now().UTC().Truncate(time.Second)
+		return bitbucket.Deployment{
+			UUID: "{" + faker.UUIDHyphenated() + "}",
+			State: &bitbucket.DeploymentState{
+				Name:      bitbucket.DeploymentStateCompleted,
+				Status:    &bitbucket.DeploymentStateStatus{Name: status},
+				StartDate: &startedAt,
+			},
+			Release: &bitbucket.DeploymentRelease{
+				Name:   "release-" + faker.Word(),
+				Commit: &bitbucket.PipelineCommit{Hash: faker.UUIDDigit()},
+			},
+		}
+	}
+
+	t.Run("bitbucket_deployment_environments_list", func(t *testing.T) {
+		t.Run("should list environments", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeRepoParams()
+			environment := newRandomEnvironment("Staging")
+			environment.EnvironmentType = &bitbucket.DeploymentEnvironmentType{Name: "Staging"}
+			mockService.EXPECT().ListEnvironments(ctx, params).
+				Return([]bitbucket.DeploymentEnvironment{environment}, nil)
+
+			result, err := controller.newListEnvironmentsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_deployment_environments_list",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Found 1 deployment environments")
+			assert.Contains(t, summary.Text, "- Staging (Staging) "+environment.UUID)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.DeploymentEnvironment
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, []bitbucket.DeploymentEnvironment{environment}, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListEnvironments(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newListEnvironmentsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_deployment_environments_list",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_deployments_list", func(t *testing.T) {
+		t.Run("should list deployments", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListDeploymentsParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Environment:               "Production",
+				Limit:                     1 + rand.IntN(100),
+			}
+			deployment := newRandomDeployment(bitbucket.DeploymentStatusSuccessful)
+			mockService.EXPECT().ListDeployments(ctx, params).Return([]bitbucket.Deployment{deployment}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["environment"] = params.Environment
+			args["limit"] = params.Limit
+			result, err := controller.newListDeploymentsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_deployments_list", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Found 1 deployments in Production")
+			assert.Contains(t, summary.Text,
+				"[COMPLETED/SUCCESSFUL] "+deployment.Release.Name+" at "+deployment.Release.Commit.Hash)
+		})
+
+		t.Run("should handle missing environment", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newListDeploymentsServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_deployments_list",
+					Arguments: repoArguments(makeRepoParams()),
+				},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	})
+
+	t.Run("bitbucket_deployments_overview", func(t *testing.T) {
+		t.Run("should report what is deployed where", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeRepoParams()
+			deployed := newRandomDeployment(bitbucket.DeploymentStatusSuccessful)
+			failed := newRandomDeployment(bitbucket.DeploymentStatusFailed)
+			overview := []app.EnvironmentDeployments{
+				{Environment: newRandomEnvironment("Test")},
+				{Environment: newRandomEnvironment("Staging"), Deployed: &deployed, Latest: &deployed},
+				{Environment: newRandomEnvironment("Production"), Deployed: &deployed, Latest: &failed},
+			}
+			mockService.EXPECT().GetDeploymentsOverview(ctx, params).Return(overview, nil)
+
+			result, err := controller.newDeploymentsOverviewServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_deployments_overview",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			deployedLine := formatDeploymentLine(deployed)
+			assert.Equal(t,
+				"Deployments overview:"+
+					"\n- Test: nothing deployed"+
+					"\n- Staging: "+deployedLine+
+					"\n- Production: "+deployedLine+
+					"\n  latest: "+formatDeploymentLine(failed),
+				summary.Text,
+			)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().GetDeploymentsOverview(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newDeploymentsOverviewServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_deployments_overview",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListFileHistoryServerTool returns a server tool for listing commits that modified a file.
+func (bc *BitbucketController) newListFileHistoryServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_file_history",
+		mcp.WithDescription("List commits that modified a file in a Bitbucket repository, newest first. "+
+			"Renames of the file are followed."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("ref",
+			mcp.Description("Commit hash or branch name to list the history from"),
+			mcp.Required(),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path of the file"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of commits to return (optional, defaults to 20, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_file_history request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		ref, err := request.RequireString("ref")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid ref parameter", err), nil
+		}
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid path parameter", err), nil
+		}
+
+		history, err := bc.bitbucketService.ListFileHistory(ctx, app.BitbucketFileHistoryParams{
+			BitbucketRepositoryParams: repoParams,
+			Ref:                       ref,
+			Path:                      path,
+			Limit:                     request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list file history: %w", err)
+		}
+
+		historyJSON, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal file history to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatFileHistorySummary(history),
+				},
+				mcp.NewTextContent(string(historyJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newBlameFileLinesServerTool returns a server tool for finding commits that last changed lines of a file.
+func (bc *BitbucketController) newBlameFileLinesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_blame",
+		mcp.WithDescription("Find commits, authors and pull requests that last changed a range of lines "+
+			"of a file in a Bitbucket repository, e.g. to judge the risk of changing them."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("ref",
+			mcp.Description("Commit hash or branch name to blame the file at"),
+			mcp.Required(),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path of the file"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("from_line",
+			mcp.Description("First line of the range, 1-based"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("to_line",
+			mcp.Description("Last line of the range, inclusive (optional, defaults to from_line, at most 500 lines)"),
+		),
+		mcp.WithNumber("max_commits",
+			mcp.Description("Maximum number of file versions to compare (optional, defaults to 20, max 50)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_blame request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		ref, err := request.RequireString("ref")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid ref parameter", err), nil
+		}
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid path parameter", err), nil
+		}
+		fromLine, err := request.RequireInt("from_line")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid from_line parameter", err), nil
+		}
+
+		blame, err := bc.bitbucketService.BlameFileLines(ctx, app.BitbucketBlameParams{
+			BitbucketRepositoryParams: repoParams,
+			Ref:                       ref,
+			Path:                      path,
+			FromLine:                  fromLine,
+			ToLine:                    request.GetInt("to_line", 0),
+			MaxCommits:                request.GetInt("max_commits", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to blame file lines: %w", err)
+		}
+
+		blameJSON, err := json.MarshalIndent(blame, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal file blame to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatFileBlameSummary(blame),
+				},
+				mcp.NewTextContent(string(blameJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatAuthoredCommitLine renders a one line description of a commit with its author and date.
+func formatAuthoredCommitLine(commit bitbucket.Commit) string {
+	line := formatCommitLine(commit)
+	if author := commit.Author.Name(); author != "" {
+		line += " by " + author
+	}
+	if commit.Date != "" {
+		line += " on " + commit.Date
+	}
+	return line
+}
+
+// formatFileHistorySummary renders the history of a file as human readable text.
+func formatFileHistorySummary(history *app.FileHistory) string {
+	if len(history.Entries) == 0 {
+		return fmt.Sprintf("No history of %s found at %s", history.Path, history.Ref)
+	}
+	var sb strings.Builder
+	count := fmt.Sprintf("%d", len(history.Entries))
+	if history.Truncated {
+		count = "latest " + count
+	}
+	fmt.Fprintf(&sb, "History of %s at %s (%s commits):", history.Path, history.Ref, count)
+	for _, entry := range history.Entries {
+		sb.WriteString("\n- " + formatAuthoredCommitLine(*entry.Commit))
+		if entry.Path != history.Path {
+			sb.WriteString(" (as " + entry.Path + ")")
+		}
+	}
+	return sb.String()
+}
+
+// formatFileBlameSummary renders commits that last changed lines of a file as human readable text.
+func formatFileBlameSummary(blame *app.FileBlame) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Lines %d-%d of %s at %s were last changed by %d commits:",
+		blame.FromLine, blame.ToLine, blame.Path, blame.Ref, len(blame.Commits))
+	for _, commit := range blame.Commits {
+		var lines []string
+		for _, lineRange := range blame.Ranges {
+			if lineRange.Commit != commit.Commit.Hash {
+				continue
+			}
+			if lineRange.FromLine == lineRange.ToLine {
+				lines = append(lines, fmt.Sprintf("%d", lineRange.FromLine))
+			} else {
+				lines = append(lines, fmt.Sprintf("%d-%d", lineRange.FromLine, lineRange.ToLine))
+			}
+		}
+		sb.WriteString("\n- " + formatAuthoredCommitLine(commit.Commit))
+		sb.WriteString("\n  lines " + strings.Join(lines, ", "))
+		if commit.Path != blame.Path {
+			sb.WriteString(" (as " + commit.Path + ")")
+		}
+		for _, pr := range commit.PullRequests {
+			fmt.Fprintf(&sb, "\n  pull request #%d %s", pr.ID, pr.Title)
+		}
+	}
+	if blame.Incomplete {
+		sb.WriteString("\nThe history was compared up to the oldest listed commit, " +
+			"lines attributed to it may have been changed earlier")
+	}
+	return sb.String()
+}
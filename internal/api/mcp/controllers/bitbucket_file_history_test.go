@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_FileHistory(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	fileArguments := func(params app.BitbucketRepositoryParams, ref, path string) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+			"ref":        ref,
+			"path":       path,
+		}
+	}
+
+	t.Run("bitbucket_file_history", func(t *testing.T) {
+		t.Run("should list file history", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			repoParams := makeRepoParams()
+			history := &app.FileHistory{
+				Ref:  "main",
+				Path: "app/main.go",
+				Entries: []bitbucket.TreeEntry{
+					{Path: "app/main.go", Commit: &bitbucket.Commit{
+						Hash:    "c2",
+						Message: "Move main\n\nDetails",
+						Date:    "2025-01-02T10:00:00+00:00",
+						Author:  &bitbucket.CommitAuthor{Raw: "Jane <jane@example.com>"},
+					}},
+					{Path: "main.go", Commit: &bitbucket.Commit{Hash: "c1", Message: "Init"}},
+				},
+				Truncated: true,
+			}
+			args := fileArguments(repoParams, "main", "app/main.go")
+			args["limit"] = float64(2)
+			mockService.EXPECT().ListFileHistory(ctx, app.BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "app/main.go",
+				Limit:                     2,
+			}).Return(history, nil)
+
+			result, err := controller.newListFileHistoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_file_history", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "History of app/main.go at main (latest 2 commits):"+
+				"\n- c2 Move main by Jane <jane@example.com> on 2025-01-02T10:00:00+00:00"+
+				"\n- c1 Init (as main.go)",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.FileHistory
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *history, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			repoParams := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListFileHistory(ctx, app.BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+			}).Return(nil, expectedErr)
+
+			result, err := controller.newListFileHistoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_file_history",
+					Arguments: fileArguments(repoParams, "main", "main.go"),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "ref", "path"} {
+				args := fileArguments(makeRepoParams(), "main", "main.go")
+				delete(args, missing)
+
+				result, err := controller.newListFileHistoryServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_file_history", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+
+	t.Run("bitbucket_blame", func(t *testing.T) {
+		t.Run("should blame file lines", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			repoParams := makeRepoParams()
+			blame := &app.FileBlame{
+				Ref:      "main",
+				Path:     "app/main.go",
+				FromLine: 10,
+				ToLine:   14,
+				Ranges: []app.BlameRange{
+					{FromLine: 10, ToLine: 11, Commit: "c2"},
+					{FromLine: 12, ToLine: 12, Commit: "c1"},
+					{FromLine: 13, ToLine: 14, Commit: "c2"},
+				},
+				Commits: []app.BlameCommit{
+					{
+						Commit: bitbucket.Commit{
+							Hash:    "c2",
+							Message: "Fix parsing",
+							Author:  &bitbucket.CommitAuthor{User: &bitbucket.Account{DisplayName: "Jane"}},
+						},
+						Author:       "Jane",
+						Path:         "app/main.go",
+						Lines:        4,
+						PullRequests: []bitbucket.PullRequest{{ID: 12, Title: "Parser fixes"}},
+					},
+					{
+						Commit:       bitbucket.Commit{Hash: "c1", Message: "Init"},
+						Path:         "main.go",
+						Lines:        1,
+						PullRequests: []bitbucket.PullRequest{},
+					},
+				},
+				Incomplete: true,
+			}
+			args := fileArguments(repoParams, "main", "app/main.go")
+			args["from_line"] = float64(10)
+			args["to_line"] = float64(14)
+			args["max_commits"] = float64(5)
+			mockService.EXPECT().BlameFileLines(ctx, app.BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "app/main.go",
+				FromLine:                  10,
+				ToLine:                    14,
+				MaxCommits:                5,
+			}).Return(blame, nil)
+
+			result, err := controller.newBlameFileLinesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_blame", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Lines 10-14 of app/main.go at main were last changed by 2 commits:"+
+				"\n- c2 Fix parsing by Jane"+
+				"\n  lines 10-11, 13-14"+
+				"\n  pull request #12 Parser fixes"+
+				"\n- c1 Init"+
+				"\n  lines 12 (as main.go)"+
+				"\nThe history was compared up to the oldest listed commit, "+
+				"lines attributed to it may have been changed earlier",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.FileBlame
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *blame, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			repoParams := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+			args := fileArguments(repoParams, "main", "main.go")
+			args["from_line"] = float64(1)
+			mockService.EXPECT().BlameFileLines(ctx, app.BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+				FromLine:                  1,
+			}).Return(nil, expectedErr)
+
+			result, err := controller.newBlameFileLinesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_blame", Arguments: args},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "ref", "path", "from_line"} {
+				args := fileArguments(makeRepoParams(), "main", "main.go")
+				args["from_line"] = float64(1)
+				delete(args, missing)
+
+				result, err := controller.newBlameFileLinesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_blame", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+}
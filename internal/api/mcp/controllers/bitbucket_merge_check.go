@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newCheckPRMergeableServerTool returns a server tool for checking if a pull request can be merged.
+func (bc *BitbucketController) newCheckPRMergeableServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_check_pr_mergeable",
+		mcp.WithDescription(
+			"Check if a pull request in Bitbucket is ready to be merged. "+
+				"Returns a pass/fail checklist covering state, draft, approvals, requested changes, "+
+				"unresolved tasks, build statuses of the head commit, conflicts and branch restrictions.",
+		),
+		mcp.WithNumber("pr_id",
+			mcp.Description("Pull request ID"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_check_pr_mergeable request", "params", request.Params)
+
+		prID, err := request.RequireInt("pr_id")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid pr_id parameter", err), nil
+		}
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		account := request.GetString("account", "")
+
+		result, err := bc.bitbucketService.CheckPRMergeable(ctx, app.BitbucketCheckPRMergeableParams{
+			PullRequestID: prID,
+			RepoOwner:     repoOwner,
+			RepoName:      repoName,
+			AccountName:   account,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if pull request is mergeable: %w", err)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal merge check result to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatMergeCheckSummary(result),
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatMergeCheckSummary renders the merge checklist as human readable text.
+func formatMergeCheckSummary(result *app.PRMergeCheckResult) string {
+	var sb strings.Builder
+	if result.Mergeable {
+		fmt.Fprintf(&sb, "Pull request #%d is ready to be merged", result.PullRequestID)
+	} else {
+		fmt.Fprintf(&sb, "Pull request #%d is not ready to be merged", result.PullRequestID)
+	}
+	for _, check := range result.Checks {
+		status := "PASS"
+		if !check.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&sb, "\n[%s] %s: %s", status, check.Name, check.Details)
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(&sb, "\nWarning: %s", warning)
+	}
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_CheckPRMergeable(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should define CheckPRMergeable tool correctly", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		serverTool := controller.newCheckPRMergeableServerTool()
+
+		assert.Equal(t, "bitbucket_check_pr_mergeable", serverTool.Tool.Name)
+		assert.NotEmpty(t, serverTool.Tool.Description)
+		assert.NotNil(t, serverTool.Tool.InputSchema)
+		assert.NotNil(t, serverTool.Handler)
+	})
+
+	t.Run("should return merge checklist", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		params := app.BitbucketCheckPRMergeableParams{
+			PullRequestID: 1 + rand.IntN(1000),
+			RepoOwner:     "workspace-" + faker.Username(),
+			RepoName:      "repo-" + faker.Word(),
+			AccountName:   "account-" + faker.Username(),
+		}
+		checkResult := &app.PRMergeCheckResult{
+			PullRequestID:     params.PullRequestID,
+			DestinationBranch: "main",
+			Mergeable:         false,
+			Checks: []app.PRMergeCheck{
+				{Name: app.MergeCheckOpen, Passed: true, Details: faker.Sentence()},
+				{Name: app.MergeCheckApprovals, Passed: false, Details: faker.Sentence()},
+			},
+			Warnings: []string{faker.Sentence()},
+		}
+		mockService.EXPECT().CheckPRMergeable(ctx, params).Return(checkResult, nil)
+
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_check_pr_mergeable",
+				Arguments: map[string]interface{}{
+					"pr_id":      params.PullRequestID,
+					"repo_owner": params.RepoOwner,
+					"repo_name":  params.RepoName,
+					"account":    params.AccountName,
+				},
+			},
+		}
+
+		result, err := controller.newCheckPRMergeableServerTool().Handler(ctx, request)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text,
+			fmt.Sprintf("Pull request #%d is not ready to be merged", params.PullRequestID))
+		assert.Contains(t, summary.Text, "[PASS] open: "+checkResult.Checks[0].Details)
+		assert.Contains(t, summary.Text, "[FAIL] approvals: "+checkResult.Checks[1].Details)
+		assert.Contains(t, summary.Text, "Warning: "+checkResult.Warnings[0])
+
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.PRMergeCheckResult
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *checkResult, parsed)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().CheckPRMergeable(ctx, app.BitbucketCheckPRMergeableParams{
+			PullRequestID: 1,
+			RepoOwner:     "owner",
+			RepoName:      "repo",
+		}).Return(nil, expectedErr)
+
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_check_pr_mergeable",
+				Arguments: map[string]interface{}{
+					"pr_id":      1,
+					"repo_owner": "owner",
+					"repo_name":  "repo",
+				},
+			},
+		}
+
+		result, err := controller.newCheckPRMergeableServerTool().Handler(ctx, request)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should handle missing required parameters", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+		ctx := t.Context()
+
+		for _, missing := range []string{"pr_id", "repo_owner", "repo_name"} {
+			args := map[string]interface{}{
+				"pr_id":      1,
+				"repo_owner": "owner",
+				"repo_name":  "repo",
+			}
+			delete(args, missing)
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_check_pr_mergeable", Arguments: args},
+			}
+
+			result, err := controller.newCheckPRMergeableServerTool().Handler(ctx, request)
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError, "missing %s should produce error result", missing)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/samber/lo"
+)
+
+// newOpenChangeServerTool returns a server tool for proposing file changes as a pull request.
+func (bc *BitbucketController) newOpenChangeServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_open_change",
+		mcp.WithDescription("Propose file changes as a pull request in one step: create a branch from the base "+
+			"branch, commit the files to it and open a pull request into the base branch. "+
+			"The branch is deleted if committing or opening the pull request fails."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Name of the new branch"),
+			mcp.Required(),
+		),
+		mcp.WithString("base",
+			mcp.Description("Branch to start from and to open the pull request into"),
+			mcp.Required(),
+		),
+		mcp.WithString("message",
+			mcp.Description("Commit message"),
+			mcp.Required(),
+		),
+		mcp.WithObject("files",
+			mcp.Description("Added or modified files as a map of path to the complete new content (optional)"),
+		),
+		mcp.WithArray("delete",
+			mcp.Description("Paths of files to delete (optional)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("title",
+			mcp.Description("Pull request title (optional, defaults to the first line of the commit message)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Pull request description (optional)"),
+		),
+		mcp.WithArray("reviewers",
+			mcp.Description("Reviewer usernames (optional)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithBoolean("draft",
+			mcp.Description("Create as draft pull request (optional, defaults to false)"),
+		),
+		mcp.WithBoolean("close_source_branch",
+			mcp.Description("Close the branch after merging (optional, defaults to false)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Commit author in \"Name <email>\" format (optional, defaults to the authenticated user)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_open_change request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		branch, err := request.RequireString("branch")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid branch parameter", err), nil
+		}
+		base, err := request.RequireString("base")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid base parameter", err), nil
+		}
+		message, err := request.RequireString("message")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid message parameter", err), nil
+		}
+		files, err := parseFileChanges(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Invalid files parameter", err), nil
+		}
+
+		change, err := bc.bitbucketService.OpenChange(ctx, app.BitbucketOpenChangeParams{
+			BitbucketRepositoryParams: repoParams,
+			Branch:                    branch,
+			Base:                      base,
+			Message:                   message,
+			Author:                    request.GetString("author", ""),
+			Files:                     files,
+			Delete:                    request.GetStringSlice("delete", nil),
+			Title:                     request.GetString("title", ""),
+			Description:               request.GetString("description", ""),
+			Reviewers:                 request.GetStringSlice("reviewers", nil),
+			CloseSourceBranch:         request.GetBool("close_source_branch", false),
+			Draft:                     lo.ToPtr(request.GetBool("draft", false)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open change: %w", err)
+		}
+
+		changeJSON, err := json.MarshalIndent(change, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal change to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Opened pull request #%d: %s\n%s",
+						change.PullRequest.ID, change.PullRequest.Title, formatCommittedFilesSummary(change.Commit)),
+				},
+				mcp.NewTextContent(string(changeJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_OpenChange(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	openChangeArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+			"branch":     "fix/typo",
+			"base":       "main",
+			"message":    "Fix typo",
+			"files":      map[string]any{"README.md": "Hello\n"},
+		}
+	}
+
+	t.Run("should open change", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		repoParams := makeRepoParams()
+		args := openChangeArguments(repoParams)
+		args["delete"] = []any{"old.md"}
+		args["title"] = "Fix readme typo"
+		args["description"] = "Spotted in review"
+		args["reviewers"] = []any{"alice"}
+		args["close_source_branch"] = true
+		args["draft"] = true
+		args["author"] = "Jane <jane@example.com>"
+		change := &app.OpenedChange{
+			Branch: &bitbucket.Branch{Name: "fix/typo", Target: &bitbucket.Commit{Hash: "abc123"}},
+			Commit: &app.CommittedFiles{
+				Branch:  "fix/typo",
+				Commit:  "def456",
+				Updated: []string{"README.md"},
+				Deleted: []string{"old.md"},
+			},
+			PullRequest: &bitbucket.PullRequest{ID: 42, Title: "Fix readme typo"},
+		}
+		mockService.EXPECT().OpenChange(ctx, app.BitbucketOpenChangeParams{
+			BitbucketRepositoryParams: repoParams,
+			Branch:                    "fix/typo",
+			Base:                      "main",
+			Message:                   "Fix typo",
+			Author:                    "Jane <jane@example.com>",
+			Files:                     []bitbucket.FileChange{{Path: "README.md", Content: "Hello\n"}},
+			Delete:                    []string{"old.md"},
+			Title:                     "Fix readme typo",
+			Description:               "Spotted in review",
+			Reviewers:                 []string{"alice"},
+			CloseSourceBranch:         true,
+			Draft:                     lo.ToPtr(true),
+		}).Return(change, nil)
+
+		result, err := controller.newOpenChangeServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_open_change", Arguments: args},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Opened pull request #42: Fix readme typo"+
+			"\nCommitted def456 to fix/typo"+
+			"\nUpdated:\n- README.md"+
+			"\nDeleted:\n- old.md",
+			summary.Text,
+		)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.OpenedChange
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, change.Commit, parsed.Commit)
+		assert.Equal(t, change.PullRequest.ID, parsed.PullRequest.ID)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().OpenChange(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newOpenChangeServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_open_change", Arguments: openChangeArguments(makeRepoParams())},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should handle missing required parameters", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		for _, missing := range []string{"repo_owner", "repo_name", "branch", "base", "message"} {
+			args := openChangeArguments(makeRepoParams())
+			delete(args, missing)
+
+			result, err := controller.newOpenChangeServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_open_change", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError, "missing %s should produce error result", missing)
+		}
+	})
+}
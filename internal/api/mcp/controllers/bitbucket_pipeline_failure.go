@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newGetPipelineFailureServerTool returns a server tool for summarizing why a pipeline run failed.
+func (bc *BitbucketController) newGetPipelineFailureServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_get_pipeline_failure",
+		mcp.WithDescription(
+			"Get a compact failure summary of a Bitbucket Pipelines run. "+
+				"For each failed step returns test failure and compiler error lines found in the log and the log tail.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("pipeline_uuid",
+			mcp.Description("Pipeline UUID"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_get_pipeline_failure request", "params", request.Params)
+
+		params, errResult := parsePipelineParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		failure, err := bc.bitbucketService.GetPipelineFailure(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline failure: %w", err)
+		}
+
+		failureJSON, err := json.MarshalIndent(failure, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pipeline failure to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatPipelineFailureSummary(failure),
+				},
+				mcp.NewTextContent(string(failureJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatPipelineFailureSummary renders failed steps of a pipeline as human readable text.
+func formatPipelineFailureSummary(failure *app.PipelineFailure) string {
+	var sb strings.Builder
+	sb.WriteString("Pipeline " + formatPipelineLine(*failure.Pipeline))
+	if len(failure.FailedSteps) == 0 {
+		sb.WriteString("\nNo failed steps")
+		return sb.String()
+	}
+	for _, step := range failure.FailedSteps {
+		fmt.Fprintf(&sb, "\n\nStep %s [%s]", step.StepName, step.Result)
+		if step.LogError != "" {
+			sb.WriteString("\nLog is not available: " + step.LogError)
+			continue
+		}
+		if len(step.ErrorLines) > 0 {
+			sb.WriteString("\nErrors:\n" + strings.Join(step.ErrorLines, "\n"))
+		}
+		if len(step.Tail) > 0 {
+			sb.WriteString("\nLog tail:\n" + strings.Join(step.Tail, "\n"))
+		}
+	}
+	return sb.String()
+}
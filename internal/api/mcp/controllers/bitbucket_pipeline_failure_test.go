@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_GetPipelineFailure(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeParams := func() app.BitbucketPipelineParams {
+		return app.BitbucketPipelineParams{
+			AccountName:  "account-" + faker.Username(),
+			RepoOwner:    "workspace-" + faker.Username(),
+			RepoName:     "repo-" + faker.Word(),
+			PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+		}
+	}
+
+	makeRequest := func(params app.BitbucketPipelineParams) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pipeline_failure",
+				Arguments: map[string]interface{}{
+					"repo_owner":    params.RepoOwner,
+					"repo_name":     params.RepoName,
+					"pipeline_uuid": params.PipelineUUID,
+					"account":       params.AccountName,
+				},
+			},
+		}
+	}
+
+	t.Run("should return failure summary", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makeParams()
+
+		failure := &app.PipelineFailure{
+			Pipeline: &bitbucket.Pipeline{UUID: params.PipelineUUID, BuildNumber: 7},
+			FailedSteps: []app.PipelineStepFailure{
+				{
+					StepUUID: "{" + faker.UUIDHyphenated() + "}",
+					StepName: "test",
+					Result:   bitbucket.PipelineResultFailed,
+					PipelineLogExcerpt: app.PipelineLogExcerpt{
+						ErrorLines: []string{"--- FAIL: TestX (0.00s)"},
+						Tail:       []string{"FAIL"},
+					},
+				},
+				{
+					StepUUID: "{" + faker.UUIDHyphenated() + "}",
+					StepName: "lint",
+					Result:   bitbucket.PipelineResultError,
+					LogError: "log expired",
+				},
+			},
+		}
+		mockService.EXPECT().GetPipelineFailure(ctx, params).Return(failure, nil)
+
+		result, err := controller.newGetPipelineFailureServerTool().Handler(ctx, makeRequest(params))
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text, "Pipeline #7")
+		assert.Contains(t, summary.Text, "Step test [FAILED]\nErrors:\n--- FAIL: TestX (0.00s)\nLog tail:\nFAIL")
+		assert.Contains(t, summary.Text, "Step lint [ERROR]\nLog is not available: log expired")
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.PipelineFailure
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *failure, parsed)
+	})
+
+	t.Run("should report pipeline without failed steps", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makeParams()
+		mockService.EXPECT().GetPipelineFailure(ctx, params).Return(&app.PipelineFailure{
+			Pipeline:    &bitbucket.Pipeline{UUID: params.PipelineUUID},
+			FailedSteps: []app.PipelineStepFailure{},
+		}, nil)
+
+		result, err := controller.newGetPipelineFailureServerTool().Handler(ctx, makeRequest(params))
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text, "No failed steps")
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makeParams()
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().GetPipelineFailure(ctx, params).Return(nil, expectedErr)
+
+		result, err := controller.newGetPipelineFailureServerTool().Handler(ctx, makeRequest(params))
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
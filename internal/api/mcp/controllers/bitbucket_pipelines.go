@@ -0,0 +1,417 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/samber/lo"
+)
+
+// newListPipelinesServerTool returns a server tool for listing pipeline runs of a repository.
+func (bc *BitbucketController) newListPipelinesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_pipelines_list",
+		mcp.WithDescription("List the most recent Bitbucket Pipelines runs of a repository, newest first"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Only list pipelines of this branch (optional)"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Only list pipelines with this status, e.g. PENDING, BUILDING, PASSED, FAILED (optional)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pipelines to return (optional, defaults to 20, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_pipelines_list request", "params", request.Params)
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		pipelines, err := bc.bitbucketService.ListPipelines(ctx, app.BitbucketListPipelinesParams{
+			AccountName: request.GetString("account", ""),
+			RepoOwner:   repoOwner,
+			RepoName:    repoName,
+			Branch:      request.GetString("branch", ""),
+			Status:      request.GetString("status", ""),
+			Limit:       request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		}
+
+		pipelinesJSON, err := json.MarshalIndent(pipelines, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pipelines to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatPipelinesSummary(pipelines),
+				},
+				mcp.NewTextContent(string(pipelinesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newGetPipelineServerTool returns a server tool for inspecting a pipeline run and its steps.
+func (bc *BitbucketController) newGetPipelineServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_pipelines_get",
+		mcp.WithDescription("Get a Bitbucket Pipelines run together with the state of its steps"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("pipeline_uuid",
+			mcp.Description("Pipeline UUID"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_pipelines_get request", "params", request.Params)
+
+		params, errResult := parsePipelineParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		run, err := bc.bitbucketService.GetPipeline(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline: %w", err)
+		}
+
+		runJSON, err := json.MarshalIndent(run, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pipeline to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatPipelineRunSummary(run),
+				},
+				mcp.NewTextContent(string(runJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newTriggerPipelineServerTool returns a server tool for triggering a pipeline run.
+func (bc *BitbucketController) newTriggerPipelineServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_pipelines_trigger",
+		mcp.WithDescription(
+			"Trigger a Bitbucket Pipelines run on a branch or a commit. "+
+				"Provide branch, commit or both to run on a specific commit of a branch.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("branch",
+			mcp.Description("Branch to run the pipeline on (optional)"),
+		),
+		mcp.WithString("commit",
+			mcp.Description("Commit hash to run the pipeline on (optional)"),
+		),
+		mcp.WithString("custom_pipeline",
+			mcp.Description("Name of a custom pipeline defined in bitbucket-pipelines.yml (optional, "+
+				"the default pipeline runs when only commit is given)"),
+		),
+		mcp.WithObject("variables",
+			mcp.Description("Pipeline variables as a map of name to string value (optional)"),
+		),
+		mcp.WithObject("secured_variables",
+			mcp.Description("Secured pipeline variables as a map of name to string value (optional)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_pipelines_trigger request",
+			"arguments", maskSecuredVariablesArgument(request.GetArguments()))
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		branch := request.GetString("branch", "")
+		commit := request.GetString("commit", "")
+		if branch == "" && commit == "" {
+			return mcp.NewToolResultError("Either branch or commit parameter must be provided"), nil
+		}
+
+		variables, err := parsePipelineVariables(request, "variables", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Invalid variables parameter", err), nil
+		}
+		securedVariables, err := parsePipelineVariables(request, "secured_variables", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Invalid secured_variables parameter", err), nil
+		}
+
+		pipeline, err := bc.bitbucketService.TriggerPipeline(ctx, app.BitbucketTriggerPipelineParams{
+			AccountName:    request.GetString("account", ""),
+			RepoOwner:      repoOwner,
+			RepoName:       repoName,
+			Branch:         branch,
+			Commit:         commit,
+			CustomPipeline: request.GetString("custom_pipeline", ""),
+			Variables:      append(variables, securedVariables...),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to trigger pipeline: %w", err)
+		}
+
+		pipelineJSON, err := json.MarshalIndent(pipeline, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pipeline to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Pipeline #%d triggered (%s)", pipeline.BuildNumber, pipeline.UUID),
+				},
+				mcp.NewTextContent(string(pipelineJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newStopPipelineServerTool returns a server tool for stopping a running pipeline.
+func (bc *BitbucketController) newStopPipelineServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_pipelines_stop",
+		mcp.WithDescription("Stop a running Bitbucket Pipelines run"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("pipeline_uuid",
+			mcp.Description("Pipeline UUID"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_pipelines_stop request", "params", request.Params)
+
+		params, errResult := parsePipelineParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if err := bc.bitbucketService.StopPipeline(ctx, params); err != nil {
+			return nil, fmt.Errorf("failed to stop pipeline: %w", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Pipeline %s is being stopped", params.PipelineUUID)), nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// parsePipelineParams extracts parameters identifying a pipeline run.
+// A tool error result is returned when a required parameter is missing.
+func parsePipelineParams(request mcp.CallToolRequest) (app.BitbucketPipelineParams, *mcp.CallToolResult) {
+	repoOwner, err := request.RequireString("repo_owner")
+	if err != nil {
+		return app.BitbucketPipelineParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err)
+	}
+
+	repoName, err := request.RequireString("repo_name")
+	if err != nil {
+		return app.BitbucketPipelineParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err)
+	}
+
+	pipelineUUID, err := request.RequireString("pipeline_uuid")
+	if err != nil {
+		return app.BitbucketPipelineParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid pipeline_uuid parameter", err)
+	}
+
+	return app.BitbucketPipelineParams{
+		AccountName:  request.GetString("account", ""),
+		RepoOwner:    repoOwner,
+		RepoName:     repoName,
+		PipelineUUID: pipelineUUID,
+	}, nil
+}
+
+// parsePipelineVariables reads a map of variable names to string values, sorted by name.
+func parsePipelineVariables(
+	request mcp.CallToolRequest,
+	name string,
+	secured bool,
+) ([]bitbucket.PipelineVariable, error) {
+	raw, ok := request.GetArguments()[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object", name)
+	}
+	keys := lo.Keys(values)
+	slices.Sort(keys)
+	variables := make([]bitbucket.PipelineVariable, 0, len(keys))
+	for _, key := range keys {
+		value, isString := values[key].(string)
+		if !isString {
+			return nil, fmt.Errorf("value of %s must be a string", key)
+		}
+		variables = append(variables, bitbucket.PipelineVariable{Key: key, Value: value, Secured: secured})
+	}
+	return variables, nil
+}
+
+// formatPipelineState renders a pipeline or step state as STATE or STATE/RESULT.
+func formatPipelineState(state *bitbucket.PipelineState) string {
+	if state == nil {
+		return "UNKNOWN"
+	}
+	if state.Result != nil {
+		return state.Name + "/" + state.Result.Name
+	}
+	if state.Stage != nil {
+		return state.Name + "/" + state.Stage.Name
+	}
+	return state.Name
+}
+
+// formatPipelineLine renders a one line description of a pipeline.
+func formatPipelineLine(pipeline bitbucket.Pipeline) string {
+	line := fmt.Sprintf("#%d [%s]", pipeline.BuildNumber, formatPipelineState(pipeline.State))
+	if target := pipeline.Target; target != nil {
+		if target.RefName != "" {
+			line += " on " + target.RefName
+		}
+		if target.Commit != nil && target.Commit.Hash != "" {
+			line += " at " + target.Commit.Hash
+		}
+		if target.Selector != nil && target.Selector.Pattern != "" {
+			line += " (" + target.Selector.Pattern + ")"
+		}
+	}
+	return line + " " + pipeline.UUID
+}
+
+// formatPipelinesSummary renders pipelines as human readable text.
+func formatPipelinesSummary(pipelines []bitbucket.Pipeline) string {
+	if len(pipelines) == 0 {
+		return "No pipelines found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d pipelines:", len(pipelines))
+	for _, pipeline := range pipelines {
+		sb.WriteString("\n- " + formatPipelineLine(pipeline))
+	}
+	return sb.String()
+}
+
+// formatPipelineRunSummary renders a pipeline and its steps as human readable text.
+func formatPipelineRunSummary(run *app.PipelineRun) string {
+	var sb strings.Builder
+	sb.WriteString("Pipeline " + formatPipelineLine(*run.Pipeline))
+	for _, step := range run.Steps {
+		fmt.Fprintf(&sb, "\n- Step %s [%s] %s", step.Name, formatPipelineState(step.State), step.UUID)
+	}
+	return sb.String()
+}
+
+// maskSecuredVariablesArgument returns a copy of tool arguments with values of secured variables masked,
+// names of the variables are kept.
+func maskSecuredVariablesArgument(arguments map[string]any) map[string]any {
+	masked := maps.Clone(arguments)
+	switch securedVariables := masked["secured_variables"].(type) {
+	case nil:
+	case map[string]any:
+		masked["secured_variables"] = lo.MapValues(securedVariables, func(any, string) any {
+			return maskedVariableValue
+		})
+	default:
+		masked["secured_variables"] = maskedVariableValue
+	}
+	return masked
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newValidatePipelinesConfigServerTool returns a server tool for validating a bitbucket-pipelines.yml file.
+func (bc *BitbucketController) newValidatePipelinesConfigServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_pipelines_validate",
+		mcp.WithDescription(
+			"Validate a bitbucket-pipelines.yml against the pipelines schema and check references to steps, "+
+				"caches, services and YAML anchors. Provide the content inline or a repository ref to read it from. "+
+				"Returns line-numbered diagnostics.",
+		),
+		mcp.WithString("content",
+			mcp.Description("Inline bitbucket-pipelines.yml content (optional)"),
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace), required when content is not provided"),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug), required when content is not provided"),
+		),
+		mcp.WithString("ref",
+			mcp.Description("Branch, tag or commit to read the configuration from, required when content is not provided"),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path of the configuration file (optional, defaults to bitbucket-pipelines.yml)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_pipelines_validate request", "params", request.Params)
+
+		params := app.BitbucketValidatePipelinesConfigParams{
+			AccountName: request.GetString("account", ""),
+			RepoOwner:   request.GetString("repo_owner", ""),
+			RepoName:    request.GetString("repo_name", ""),
+			Ref:         request.GetString("ref", ""),
+			Path:        request.GetString("path", ""),
+			Content:     request.GetString("content", ""),
+		}
+		if params.Content == "" && (params.RepoOwner == "" || params.RepoName == "" || params.Ref == "") {
+			return mcp.NewToolResultError("Either content or repo_owner, repo_name and ref parameters must be provided"), nil
+		}
+
+		result, err := bc.bitbucketService.ValidatePipelinesConfig(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate pipelines configuration: %w", err)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal validation result to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatPipelinesConfigValidationSummary(result),
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatPipelinesConfigValidationSummary renders validation diagnostics as human readable text.
+func formatPipelinesConfigValidationSummary(result *app.PipelinesConfigValidationResult) string {
+	var sb strings.Builder
+	if result.Valid {
+		sb.WriteString("Pipelines configuration " + result.Source + " is valid")
+	} else {
+		sb.WriteString("Pipelines configuration " + result.Source + " is invalid")
+	}
+	for _, diagnostic := range result.Diagnostics {
+		sb.WriteString("\n- ")
+		if diagnostic.Line > 0 {
+			fmt.Fprintf(&sb, "line %d: ", diagnostic.Line)
+		}
+		sb.WriteString(diagnostic.Severity + ": " + diagnostic.Message)
+		if diagnostic.Path != "" {
+			sb.WriteString(" (" + diagnostic.Path + ")")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_ValidatePipelinesConfig(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRequest := func(arguments map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "bitbucket_pipelines_validate",
+				Arguments: arguments,
+			},
+		}
+	}
+
+	t.Run("should validate configuration of the repository", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := app.BitbucketValidatePipelinesConfigParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+			Ref:         "branch-" + faker.Word(),
+			Path:        "ci/" + faker.Word() + ".yml",
+		}
+		validation := &app.PipelinesConfigValidationResult{
+			Source: faker.Word(),
+			Diagnostics: []app.PipelinesConfigDiagnostic{
+				{
+					Line:     12,
+					Severity: app.PipelinesConfigSeverityError,
+					Path:     "pipelines.default[0].step",
+					Message:  "missing property 'script'",
+				},
+				{
+					Line:     3,
+					Severity: app.PipelinesConfigSeverityWarning,
+					Message:  "anchor &build is never referenced",
+				},
+			},
+		}
+		mockService.EXPECT().ValidatePipelinesConfig(ctx, params).Return(validation, nil)
+
+		result, err := controller.newValidatePipelinesConfigServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"ref":        params.Ref,
+			"path":       params.Path,
+			"account":    params.AccountName,
+		}))
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t,
+			"Pipelines configuration "+validation.Source+" is invalid\n"+
+				"- line 12: error: missing property 'script' (pipelines.default[0].step)\n"+
+				"- line 3: warning: anchor &build is never referenced",
+			summary.Text,
+		)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.PipelinesConfigValidationResult
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *validation, parsed)
+	})
+
+	t.Run("should validate inline content", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := app.BitbucketValidatePipelinesConfigParams{Content: faker.Paragraph()}
+		mockService.EXPECT().ValidatePipelinesConfig(ctx, params).Return(&app.PipelinesConfigValidationResult{
+			Source:      "inline content",
+			Valid:       true,
+			Diagnostics: []app.PipelinesConfigDiagnostic{},
+		}, nil)
+
+		result, err := controller.newValidatePipelinesConfigServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"content": params.Content,
+		}))
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Pipelines configuration inline content is valid", summary.Text)
+	})
+
+	t.Run("should require content or repository ref", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		result, err := controller.newValidatePipelinesConfigServerTool().Handler(t.Context(), makeRequest(
+			map[string]interface{}{
+				"repo_owner": "workspace-" + faker.Username(),
+				"repo_name":  "repo-" + faker.Word(),
+			},
+		))
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := app.BitbucketValidatePipelinesConfigParams{Content: faker.Paragraph()}
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().ValidatePipelinesConfig(ctx, params).Return(nil, expectedErr)
+
+		result, err := controller.newValidatePipelinesConfigServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"content": params.Content,
+		}))
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,359 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Pipelines(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	newRandomPipeline := func() *bitbucket.Pipeline {
+		return &bitbucket.Pipeline{
+			UUID:        "{" + faker.UUIDHyphenated() + "}",
+			BuildNumber: 1 + rand.IntN(1000),
+			State: &bitbucket.PipelineState{
+				Name:   bitbucket.PipelineStateCompleted,
+				Result: &bitbucket.PipelineStateResult{Name: bitbucket.PipelineResultFailed},
+			},
+			Target: &bitbucket.PipelineTarget{
+				Type:    bitbucket.PipelineTargetTypeRef,
+				RefType: bitbucket.PipelineRefTypeBranch,
+				RefName: "feature/" + faker.Word(),
+			},
+		}
+	}
+
+	t.Run("bitbucket_pipelines_list", func(t *testing.T) {
+		t.Run("should list pipelines", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListPipelinesParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "workspace-" + faker.Username(),
+				RepoName:    "repo-" + faker.Word(),
+				Branch:      "feature/" + faker.Word(),
+				Status:      "FAILED",
+				Limit:       1 + rand.IntN(100),
+			}
+			pipeline := newRandomPipeline()
+			mockService.EXPECT().ListPipelines(ctx, params).Return([]bitbucket.Pipeline{*pipeline}, nil)
+
+			result, err := controller.newListPipelinesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_list",
+					Arguments: map[string]interface{}{
+						"repo_owner": params.RepoOwner,
+						"repo_name":  params.RepoName,
+						"branch":     params.Branch,
+						"status":     params.Status,
+						"limit":      params.Limit,
+						"account":    params.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Found 1 pipelines")
+			assert.Contains(t, summary.Text, "[COMPLETED/FAILED] on "+pipeline.Target.RefName)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.Pipeline
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, []bitbucket.Pipeline{*pipeline}, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListPipelines(ctx, app.BitbucketListPipelinesParams{
+				RepoOwner: "owner",
+				RepoName:  "repo",
+			}).Return(nil, expectedErr)
+
+			result, err := controller.newListPipelinesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_pipelines_list",
+					Arguments: map[string]interface{}{"repo_owner": "owner", "repo_name": "repo"},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_pipelines_get", func(t *testing.T) {
+		t.Run("should get pipeline with steps", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketPipelineParams{
+				RepoOwner:    "workspace-" + faker.Username(),
+				RepoName:     "repo-" + faker.Word(),
+				PipelineUUID: faker.UUIDHyphenated(),
+			}
+			run := &app.PipelineRun{
+				Pipeline: newRandomPipeline(),
+				Steps: []bitbucket.PipelineStep{
+					{
+						UUID: "{" + faker.UUIDHyphenated() + "}",
+						Name: "build-" + faker.Word(),
+						State: &bitbucket.PipelineState{
+							Name:   bitbucket.PipelineStateCompleted,
+							Result: &bitbucket.PipelineStateResult{Name: bitbucket.PipelineResultFailed},
+						},
+					},
+				},
+			}
+			mockService.EXPECT().GetPipeline(ctx, params).Return(run, nil)
+
+			result, err := controller.newGetPipelineServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_get",
+					Arguments: map[string]interface{}{
+						"repo_owner":    params.RepoOwner,
+						"repo_name":     params.RepoName,
+						"pipeline_uuid": params.PipelineUUID,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Pipeline #")
+			assert.Contains(t, summary.Text, "Step "+run.Steps[0].Name+" [COMPLETED/FAILED]")
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "pipeline_uuid"} {
+				args := map[string]interface{}{
+					"repo_owner":    "owner",
+					"repo_name":     "repo",
+					"pipeline_uuid": faker.UUIDHyphenated(),
+				}
+				delete(args, missing)
+
+				result, err := controller.newGetPipelineServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_pipelines_get", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+
+	t.Run("bitbucket_pipelines_trigger", func(t *testing.T) {
+		t.Run("should trigger pipeline with variables", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketTriggerPipelineParams{
+				AccountName:    "account-" + faker.Username(),
+				RepoOwner:      "workspace-" + faker.Username(),
+				RepoName:       "repo-" + faker.Word(),
+				Branch:         "feature/" + faker.Word(),
+				Commit:         faker.UUIDDigit(),
+				CustomPipeline: "deploy-" + faker.Word(),
+				Variables: []bitbucket.PipelineVariable{
+					{Key: "A_" + faker.Word(), Value: faker.Word()},
+					{Key: "B_" + faker.Word(), Value: faker.Word()},
+					{Key: "TOKEN", Value: faker.UUIDHyphenated(), Secured: true},
+				},
+			}
+			pipeline := newRandomPipeline()
+			mockService.EXPECT().TriggerPipeline(ctx, params).Return(pipeline, nil)
+
+			result, err := controller.newTriggerPipelineServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_trigger",
+					Arguments: map[string]interface{}{
+						"repo_owner":      params.RepoOwner,
+						"repo_name":       params.RepoName,
+						"branch":          params.Branch,
+						"commit":          params.Commit,
+						"custom_pipeline": params.CustomPipeline,
+						"variables": map[string]any{
+							params.Variables[1].Key: params.Variables[1].Value,
+							params.Variables[0].Key: params.Variables[0].Value,
+						},
+						"secured_variables": map[string]any{
+							params.Variables[2].Key: params.Variables[2].Value,
+						},
+						"account": params.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, pipeline.UUID)
+		})
+
+		t.Run("should not log secured variable values", func(t *testing.T) {
+			var logs bytes.Buffer
+			deps := makeMockDeps(t)
+			deps.RootLogger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			value := "secret-" + faker.Password()
+			mockService.EXPECT().TriggerPipeline(ctx, mock.Anything).Return(newRandomPipeline(), nil)
+
+			args := map[string]interface{}{
+				"repo_owner":        "owner",
+				"repo_name":         "repo",
+				"branch":            "main",
+				"variables":         map[string]any{"ENV": "staging"},
+				"secured_variables": map[string]any{"TOKEN": value},
+			}
+			_, err := controller.newTriggerPipelineServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_pipelines_trigger", Arguments: args},
+			})
+			require.NoError(t, err)
+
+			assert.Contains(t, logs.String(), "Received bitbucket_pipelines_trigger request")
+			assert.Contains(t, logs.String(), "TOKEN")
+			assert.Contains(t, logs.String(), "staging")
+			assert.NotContains(t, logs.String(), value)
+			assert.Equal(t, map[string]any{"TOKEN": value}, args["secured_variables"],
+				"arguments of the request must not be modified")
+		})
+
+		t.Run("should require branch or commit", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newTriggerPipelineServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_pipelines_trigger",
+					Arguments: map[string]interface{}{"repo_owner": "owner", "repo_name": "repo"},
+				},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+
+		t.Run("should reject non string variable values", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newTriggerPipelineServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_trigger",
+					Arguments: map[string]interface{}{
+						"repo_owner": "owner",
+						"repo_name":  "repo",
+						"branch":     "main",
+						"variables":  map[string]any{"COUNT": 1},
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	})
+
+	t.Run("bitbucket_pipelines_stop", func(t *testing.T) {
+		t.Run("should stop pipeline", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketPipelineParams{
+				AccountName:  "account-" + faker.Username(),
+				RepoOwner:    "workspace-" + faker.Username(),
+				RepoName:     "repo-" + faker.Word(),
+				PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+			}
+			mockService.EXPECT().StopPipeline(ctx, params).Return(nil)
+
+			result, err := controller.newStopPipelineServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_stop",
+					Arguments: map[string]interface{}{
+						"repo_owner":    params.RepoOwner,
+						"repo_name":     params.RepoName,
+						"pipeline_uuid": params.PipelineUUID,
+						"account":       params.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().StopPipeline(ctx, app.BitbucketPipelineParams{
+				RepoOwner:    "owner",
+				RepoName:     "repo",
+				PipelineUUID: "uuid",
+			}).Return(expectedErr)
+
+			result, err := controller.newStopPipelineServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_pipelines_stop",
+					Arguments: map[string]interface{}{
+						"repo_owner":    "owner",
+						"repo_name":     "repo",
+						"pipeline_uuid": "uuid",
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+}
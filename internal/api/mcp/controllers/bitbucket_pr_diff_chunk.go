@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// prDiffChunkToolOptions returns parameters of bitbucket_get_pr_diff for reading large diffs in chunks.
+func prDiffChunkToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithNumber("max_bytes",
+			mcp.Description("Maximum size of the returned diff in bytes (optional). The diff is split at file "+
+				"boundaries and a cursor for the next chunk is returned, a single file over the limit is cut "+
+				"and continued in the next chunk"),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor returned with the previous chunk to get the next one (optional). "+
+				"The cursor is rejected once new commits are pushed to the pull request or its destination"),
+		),
+		mcp.WithBoolean("skip_generated",
+			mcp.Description("Leave out generated and vendored files matching the configured patterns, "+
+				"e.g. lockfiles, *.pb.go and minified assets (optional, defaults to false)"),
+		),
+		mcp.WithArray("skip_patterns",
+			mcp.Description("Additional globs of files to leave out (optional). Patterns without a slash match "+
+				"file names, patterns ending with /** match directories, e.g. *.snap or testdata/**"),
+			mcp.WithStringItems(),
+		),
+	}
+}
+
+// prDiffChunkParams extracts chunking parameters of bitbucket_get_pr_diff,
+// chunked is false when none of them are given and the whole diff should be returned.
+func prDiffChunkParams(
+	request mcp.CallToolRequest,
+	params app.BitbucketGetPRDiffParams,
+) (app.BitbucketGetPRDiffChunkParams, bool) {
+	chunkParams := app.BitbucketGetPRDiffChunkParams{
+		BitbucketGetPRDiffParams: params,
+		MaxBytes:                 request.GetInt("max_bytes", 0),
+		Cursor:                   request.GetString("cursor", ""),
+		SkipGenerated:            request.GetBool("skip_generated", false),
+		SkipPatterns:             request.GetStringSlice("skip_patterns", nil),
+	}
+	chunked := chunkParams.MaxBytes != 0 || chunkParams.Cursor != "" || chunkParams.SkipGenerated ||
+		len(chunkParams.SkipPatterns) > 0
+	return chunkParams, chunked
+}
+
+// getPRDiffChunk returns a chunk of a pull request diff for bitbucket_get_pr_diff
+// when a size limit, a cursor or skip patterns are given.
+func (bc *BitbucketController) getPRDiffChunk(
+	ctx context.Context,
+	params app.BitbucketGetPRDiffChunkParams,
+) (*mcp.CallToolResult, error) {
+	chunk, err := bc.bitbucketService.GetPRDiffChunk(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: formatPRDiffChunkSummary(params.BitbucketGetPRDiffParams, chunk),
+			},
+			mcp.NewTextContent(chunk.Diff),
+		},
+	}, nil
+}
+
+// formatPRDiffChunkSummary renders the position of the chunk and how to get the next one as human readable text.
+func formatPRDiffChunkSummary(params app.BitbucketGetPRDiffParams, chunk *app.PRDiffChunk) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Diff for PR #%d in %s/%s", params.PullRequestID, params.RepoOwner, params.RepoName)
+	if chunk.TotalFiles == 0 {
+		sb.WriteString(": no changed files")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, ", files %d-%d of %d", chunk.FromFile, chunk.ToFile, chunk.TotalFiles)
+	if len(chunk.SkippedFiles) > 0 {
+		fmt.Fprintf(&sb, "\nSkipped %d files: %s", len(chunk.SkippedFiles), strings.Join(chunk.SkippedFiles, ", "))
+	}
+	if chunk.ContinuedFile != "" {
+		fmt.Fprintf(&sb, "\nDiff of %s continues from the previous chunk", chunk.ContinuedFile)
+	}
+	if chunk.TruncatedFile != "" {
+		fmt.Fprintf(&sb, "\nDiff of %s was cut to fit max_bytes and continues in the next chunk",
+			chunk.TruncatedFile)
+	}
+	if chunk.NextCursor != "" {
+		fmt.Fprintf(&sb, "\nMore of the diff remains, pass cursor %q to get the next chunk", chunk.NextCursor)
+	}
+	return sb.String()
+}
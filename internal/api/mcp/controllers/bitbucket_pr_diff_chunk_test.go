@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_GetPRDiffChunk(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should return diff chunk with cursor", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		account := "account-" + faker.Username()
+		chunk := &app.PRDiffChunk{
+			Diff:          "diff --git a/main.go b/main.go\n+a\n",
+			Files:         []string{"main.go", "big.go"},
+			SkippedFiles:  []string{"go.sum", "api.pb.go"},
+			ContinuedFile: "main.go",
+			TruncatedFile: "big.go",
+			FromFile:      3,
+			ToFile:        6,
+			TotalFiles:    10,
+			NextCursor:    "ZmlsZTo2",
+		}
+		mockService.EXPECT().GetPRDiffChunk(ctx, app.BitbucketGetPRDiffChunkParams{
+			BitbucketGetPRDiffParams: app.BitbucketGetPRDiffParams{
+				AccountName:   account,
+				RepoOwner:     "acme",
+				RepoName:      "api",
+				PullRequestID: 7,
+			},
+			MaxBytes:      2000,
+			Cursor:        "ZmlsZToy",
+			SkipGenerated: true,
+			SkipPatterns:  []string{"*.snap"},
+		}).Return(chunk, nil)
+
+		result, err := controller.newGetPRDiffServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_diff",
+				Arguments: map[string]interface{}{
+					"pr_id":          float64(7),
+					"repo_owner":     "acme",
+					"repo_name":      "api",
+					"account":        account,
+					"max_bytes":      float64(2000),
+					"cursor":         "ZmlsZToy",
+					"skip_generated": true,
+					"skip_patterns":  []interface{}{"*.snap"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Diff for PR #7 in acme/api, files 3-6 of 10"+
+			"\nSkipped 2 files: go.sum, api.pb.go"+
+			"\nDiff of main.go continues from the previous chunk"+
+			"\nDiff of big.go was cut to fit max_bytes and continues in the next chunk"+
+			"\nMore of the diff remains, pass cursor \"ZmlsZTo2\" to get the next chunk",
+			summary.Text)
+		diffContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, chunk.Diff, diffContent.Text)
+	})
+
+	t.Run("should report empty diff", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		mockService.EXPECT().GetPRDiffChunk(ctx, mock.Anything).
+			Return(&app.PRDiffChunk{Files: []string{}, FromFile: 1}, nil)
+
+		result, err := controller.newGetPRDiffServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_diff",
+				Arguments: map[string]interface{}{
+					"pr_id":      float64(7),
+					"repo_owner": "acme",
+					"repo_name":  "api",
+					"max_bytes":  float64(2000),
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Diff for PR #7 in acme/api: no changed files", summary.Text)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().GetPRDiffChunk(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newGetPRDiffServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_diff",
+				Arguments: map[string]interface{}{
+					"pr_id":          float64(7),
+					"repo_owner":     "acme",
+					"repo_name":      "api",
+					"skip_generated": true,
+				},
+			},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
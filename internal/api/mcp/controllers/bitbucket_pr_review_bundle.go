@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newGetPRReviewBundleServerTool returns a server tool for getting everything needed to review a pull request.
+func (bc *BitbucketController) newGetPRReviewBundleServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_get_pr_review_bundle",
+		mcp.WithDescription("Get everything needed to review a pull request in a single call: the pull request, "+
+			"changed files with their stats and diffs, comments, tasks and build statuses of the head commit. "+
+			"Diffs of files are included while they fit max_diff_bytes, the remaining files are summarized by "+
+			"stats and hunk headers and can be read with bitbucket_get_pr_diff."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("pull_request_id",
+			mcp.Description("Pull request ID"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("max_diff_bytes",
+			mcp.Description("Maximum size of diffs to include (optional, defaults to 50000, max 500000)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_get_pr_review_bundle request", "params", request.Params)
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+		prID, err := request.RequireInt("pull_request_id")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid pull_request_id parameter", err), nil
+		}
+
+		bundle, err := bc.bitbucketService.GetPRReviewBundle(ctx, app.BitbucketPRReviewBundleParams{
+			AccountName:   request.GetString("account", ""),
+			RepoOwner:     repoOwner,
+			RepoName:      repoName,
+			PullRequestID: prID,
+			MaxDiffBytes:  request.GetInt("max_diff_bytes", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request review bundle: %w", err)
+		}
+
+		bundleJSON, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pull request review bundle to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatPRReviewBundleSummary(repoOwner+"/"+repoName, bundle),
+				},
+				mcp.NewTextContent(string(bundleJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatPRReviewBundleSummary renders an overview of the review bundle as human readable text.
+func formatPRReviewBundleSummary(repository string, bundle *app.PRReviewBundle) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Review bundle of PR #%d", bundle.PullRequest.ID)
+	if bundle.PullRequest.Title != "" {
+		sb.WriteString(" " + bundle.PullRequest.Title)
+	}
+	linesAdded, linesRemoved := 0, 0
+	for _, file := range bundle.Files {
+		linesAdded += file.LinesAdded
+		linesRemoved += file.LinesRemoved
+	}
+	fmt.Fprintf(&sb, " in %s: %d files changed (+%d -%d)", repository, len(bundle.Files), linesAdded, linesRemoved)
+
+	comments := fmt.Sprintf("%d", len(bundle.Comments))
+	if bundle.CommentsTruncated {
+		comments = "first " + comments
+	}
+	tasks := fmt.Sprintf("%d", len(bundle.Tasks))
+	if bundle.TasksTruncated {
+		tasks = "first " + tasks
+	}
+	fmt.Fprintf(&sb, ", %s comments, %s tasks, %d build statuses", comments, tasks, len(bundle.Statuses))
+
+	if bundle.OmittedFiles > 0 {
+		fmt.Fprintf(&sb, "\nDiffs of %d files were left out to stay within %d bytes, "+
+			"read them with bitbucket_get_pr_diff", bundle.OmittedFiles, bundle.MaxDiffBytes)
+	}
+	return sb.String()
+}
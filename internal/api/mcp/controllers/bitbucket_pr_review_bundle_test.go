@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_GetPRReviewBundle(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should get review bundle", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		account := "account-" + faker.Username()
+		bundle := &app.PRReviewBundle{
+			PullRequest: &bitbucket.PullRequest{ID: 7, Title: "Add refunds"},
+			Files: []app.PRReviewBundleFile{
+				{Path: "main.go", Status: "modified", LinesAdded: 3, LinesRemoved: 1, DiffBytes: 40, Diff: "diff"},
+				{Path: "go.sum", Status: "modified", LinesAdded: 90, DiffBytes: 9000, Omitted: true,
+					Hunks: []string{"@@ -1,3 +1,93 @@"}},
+			},
+			Comments:          []app.BitbucketPRComment{{ID: 11}},
+			Tasks:             []bitbucket.PullRequestCommentTask{},
+			Statuses:          []bitbucket.CommitStatus{{Key: "build", State: "SUCCESSFUL"}},
+			CommentsTruncated: true,
+			DiffBytes:         9040,
+			IncludedDiffBytes: 40,
+			MaxDiffBytes:      1000,
+			OmittedFiles:      1,
+		}
+		mockService.EXPECT().GetPRReviewBundle(ctx, app.BitbucketPRReviewBundleParams{
+			AccountName:   account,
+			RepoOwner:     "acme",
+			RepoName:      "api",
+			PullRequestID: 7,
+			MaxDiffBytes:  1000,
+		}).Return(bundle, nil)
+
+		result, err := controller.newGetPRReviewBundleServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_review_bundle",
+				Arguments: map[string]interface{}{
+					"repo_owner":      "acme",
+					"repo_name":       "api",
+					"pull_request_id": float64(7),
+					"max_diff_bytes":  float64(1000),
+					"account":         account,
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Review bundle of PR #7 Add refunds in acme/api: 2 files changed (+93 -1), "+
+			"first 1 comments, 0 tasks, 1 build statuses"+
+			"\nDiffs of 1 files were left out to stay within 1000 bytes, read them with bitbucket_get_pr_diff",
+			summary.Text)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.PRReviewBundle
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *bundle, parsed)
+	})
+
+	t.Run("should require pull request ID", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		result, err := controller.newGetPRReviewBundleServerTool().Handler(t.Context(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_review_bundle",
+				Arguments: map[string]interface{}{
+					"repo_owner": "acme",
+					"repo_name":  "api",
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().GetPRReviewBundle(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newGetPRReviewBundleServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_get_pr_review_bundle",
+				Arguments: map[string]interface{}{
+					"repo_owner":      "acme",
+					"repo_name":       "api",
+					"pull_request_id": float64(7),
+				},
+			},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
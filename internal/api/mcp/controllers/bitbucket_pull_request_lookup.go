@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newCommitPullRequestsServerTool returns a server tool for finding pull requests that contain a commit.
+func (bc *BitbucketController) newCommitPullRequestsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_commit_pull_requests",
+		mcp.WithDescription("Find pull requests that contain a commit. For squash merge commits that no pull "+
+			"request contains, pull requests referenced by the commit message are returned instead."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("commit",
+			mcp.Description("Full or abbreviated commit hash"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_commit_pull_requests request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		commit, err := request.RequireString("commit")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid commit parameter", err), nil
+		}
+
+		result, err := bc.bitbucketService.FindCommitPullRequests(ctx, app.BitbucketCommitPullRequestsParams{
+			BitbucketRepositoryParams: repoParams,
+			Commit:                    commit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull requests of commit: %w", err)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pull requests to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatCommitPullRequestsSummary(result),
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newFindIssuePullRequestsServerTool returns a server tool for finding pull requests of a Jira issue.
+func (bc *BitbucketController) newFindIssuePullRequestsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_find_issue_pull_requests",
+		mcp.WithDescription("Find pull requests across repositories of a workspace that mention a Jira issue key "+
+			"in their title, description or source branch name"),
+		mcp.WithString("workspace",
+			mcp.Description("Workspace to search in"),
+			mcp.Required(),
+		),
+		mcp.WithString("issue_key",
+			mcp.Description("Jira issue key, e.g. PAY-123"),
+			mcp.Required(),
+		),
+		mcp.WithArray("repositories",
+			mcp.Description("Repositories to search as slugs of the workspace or full names (workspace/repo_slug) "+
+				"(optional, defaults to the 100 most recently updated repositories of the workspace)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("state",
+			mcp.Description("Only find pull requests in this state: OPEN, MERGED, DECLINED or SUPERSEDED "+
+				"(optional, defaults to all states)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pull requests to return (optional, defaults to 20, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_find_issue_pull_requests request", "params", request.Params)
+
+		workspace, err := request.RequireString("workspace")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid workspace parameter", err), nil
+		}
+		issueKey, err := request.RequireString("issue_key")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid issue_key parameter", err), nil
+		}
+		var states []string
+		if state := request.GetString("state", ""); state != "" {
+			states = []string{strings.ToUpper(state)}
+		}
+
+		result, err := bc.bitbucketService.SearchIssuePullRequests(ctx, app.BitbucketIssuePullRequestsParams{
+			AccountName:  request.GetString("account", ""),
+			Workspace:    workspace,
+			IssueKey:     issueKey,
+			Repositories: request.GetStringSlice("repositories", nil),
+			States:       states,
+			Limit:        request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull requests of issue: %w", err)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pull requests to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatIssuePullRequestsSummary(result),
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatCommitPullRequestsSummary renders pull requests of a commit as human readable text.
+func formatCommitPullRequestsSummary(result *app.CommitPullRequests) string {
+	if len(result.PullRequests) == 0 {
+		return fmt.Sprintf("No pull requests found for commit %s", result.Commit)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Pull requests of commit %s (%d):", result.Commit, len(result.PullRequests))
+	if result.FromMessage {
+		sb.WriteString(" referenced by the commit message")
+	}
+	for _, pr := range result.PullRequests {
+		fmt.Fprintf(&sb, "\n- #%d [%s] %s", pr.ID, pr.State, pr.Title)
+	}
+	return sb.String()
+}
+
+// formatIssuePullRequestsSummary renders pull requests of a Jira issue as human readable text.
+func formatIssuePullRequestsSummary(result *app.IssuePullRequests) string {
+	var sb strings.Builder
+	if len(result.PullRequests) == 0 {
+		fmt.Fprintf(&sb, "No pull requests found for %s in %d repositories",
+			result.IssueKey, result.SearchedRepositories)
+	} else {
+		count := fmt.Sprintf("%d", len(result.PullRequests))
+		if result.Truncated {
+			count = "first " + count
+		}
+		fmt.Fprintf(&sb, "Pull requests of %s (%s):", result.IssueKey, count)
+		for _, found := range result.PullRequests {
+			pr := found.PullRequest
+			fmt.Fprintf(&sb, "\n- %s#%d [%s] %s (matched in %s)",
+				found.Repository, pr.ID, pr.State, pr.Title, strings.Join(found.MatchedIn, ", "))
+		}
+	}
+	if result.RepositoriesTruncated {
+		fmt.Fprintf(&sb, "\nOnly the %d most recently updated repositories were searched, "+
+			"pass repositories to search others", result.SearchedRepositories)
+	}
+	return sb.String()
+}
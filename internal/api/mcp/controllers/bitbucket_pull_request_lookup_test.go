@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_PullRequestLookup(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("CommitPullRequests", func(t *testing.T) {
+		t.Run("should find pull requests of commit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			account := "account-" + faker.Username()
+			result := &app.CommitPullRequests{
+				Commit: "abc1234",
+				PullRequests: []bitbucket.PullRequest{
+					{ID: 12, Title: "Fix rounding", State: "MERGED"},
+				},
+				FromMessage: true,
+			}
+			mockService.EXPECT().FindCommitPullRequests(ctx, app.BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: app.BitbucketRepositoryParams{
+					AccountName: account,
+					RepoOwner:   "acme",
+					RepoName:    "payments",
+				},
+				Commit: "abc1234",
+			}).Return(result, nil)
+
+			toolResult, err := controller.newCommitPullRequestsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_commit_pull_requests",
+					Arguments: map[string]interface{}{
+						"repo_owner": "acme",
+						"repo_name":  "payments",
+						"commit":     "abc1234",
+						"account":    account,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, toolResult.Content, 2)
+			summary, ok := toolResult.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Pull requests of commit abc1234 (1): referenced by the commit message"+
+				"\n- #12 [MERGED] Fix rounding", summary.Text)
+			jsonContent, ok := toolResult.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.CommitPullRequests
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *result, parsed)
+		})
+
+		t.Run("should handle missing commit", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			toolResult, err := controller.newCommitPullRequestsServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_commit_pull_requests",
+					Arguments: map[string]interface{}{"repo_owner": "acme", "repo_name": "payments"},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, toolResult)
+			assert.True(t, toolResult.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().FindCommitPullRequests(ctx, mock.Anything).Return(nil, expectedErr)
+
+			toolResult, err := controller.newCommitPullRequestsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_commit_pull_requests",
+					Arguments: map[string]interface{}{
+						"repo_owner": "acme",
+						"repo_name":  "payments",
+						"commit":     "abc1234",
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, toolResult)
+		})
+	})
+
+	t.Run("FindIssuePullRequests", func(t *testing.T) {
+		t.Run("should find pull requests of issue", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			result := &app.IssuePullRequests{
+				IssueKey:  "PAY-12",
+				Workspace: "acme",
+				PullRequests: []app.IssuePullRequest{
+					{
+						Repository:  "acme/payments",
+						MatchedIn:   []string{"title", "branch"},
+						PullRequest: bitbucket.PullRequest{ID: 3, Title: "PAY-12 fix rounding", State: "OPEN"},
+					},
+				},
+				Truncated:             true,
+				SearchedRepositories:  100,
+				RepositoriesTruncated: true,
+			}
+			mockService.EXPECT().SearchIssuePullRequests(ctx, app.BitbucketIssuePullRequestsParams{
+				Workspace:    "acme",
+				IssueKey:     "PAY-12",
+				Repositories: []string{"payments", "web"},
+				States:       []string{"OPEN"},
+				Limit:        1,
+			}).Return(result, nil)
+
+			toolResult, err := controller.newFindIssuePullRequestsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_find_issue_pull_requests",
+					Arguments: map[string]interface{}{
+						"workspace":    "acme",
+						"issue_key":    "PAY-12",
+						"repositories": []interface{}{"payments", "web"},
+						"state":        "open",
+						"limit":        float64(1),
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, toolResult.Content, 2)
+			summary, ok := toolResult.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Pull requests of PAY-12 (first 1):"+
+				"\n- acme/payments#3 [OPEN] PAY-12 fix rounding (matched in title, branch)"+
+				"\nOnly the 100 most recently updated repositories were searched, pass repositories to search others",
+				summary.Text)
+			jsonContent, ok := toolResult.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.IssuePullRequests
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *result, parsed)
+		})
+
+		t.Run("should handle missing issue key", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			toolResult, err := controller.newFindIssuePullRequestsServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_find_issue_pull_requests",
+					Arguments: map[string]interface{}{"workspace": "acme"},
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, toolResult)
+			assert.True(t, toolResult.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().SearchIssuePullRequests(ctx, mock.Anything).Return(nil, expectedErr)
+
+			toolResult, err := controller.newFindIssuePullRequestsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_find_issue_pull_requests",
+					Arguments: map[string]interface{}{"workspace": "acme", "issue_key": "PAY-12"},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, toolResult)
+		})
+	})
+}
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListWorkspacesServerTool returns a server tool for listing workspaces of the user.
+func (bc *BitbucketController) newListWorkspacesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_list_workspaces",
+		mcp.WithDescription("List Bitbucket workspaces the user is a member of with the role of the user. "+
+			"The workspace slug is the repo_owner parameter of other tools."),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of workspaces to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_list_workspaces request", "params", request.Params)
+
+		list, err := bc.bitbucketService.ListWorkspaces(ctx, app.BitbucketListWorkspacesParams{
+			AccountName: request.GetString("account", ""),
+			Limit:       request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspaces: %w", err)
+		}
+
+		listJSON, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal workspaces to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatWorkspacesSummary(list),
+				},
+				mcp.NewTextContent(string(listJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newListRepositoriesServerTool returns a server tool for listing repositories of a workspace.
+func (bc *BitbucketController) newListRepositoriesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_list_repositories",
+		mcp.WithDescription("List repositories of a Bitbucket workspace, e.g. to find the slug of a repository "+
+			"by its name or description. The repository slug is the repo_name parameter of other tools."),
+		mcp.WithString("workspace",
+			mcp.Description("Workspace to list repositories of"),
+			mcp.Required(),
+		),
+		mcp.WithString("query",
+			mcp.Description("BBQL filter (optional), e.g. name ~ \"payments\" OR description ~ \"payments\", "+
+				"language = \"go\" or updated_on > 2024-01-01"),
+		),
+		mcp.WithString("project",
+			mcp.Description("Only list repositories of the project with this key (optional)"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort field, prefix with - for descending order (optional), e.g. name or -updated_on"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of repositories to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_list_repositories request", "params", request.Params)
+
+		workspace, err := request.RequireString("workspace")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid workspace parameter", err), nil
+		}
+
+		list, err := bc.bitbucketService.ListRepositories(ctx, app.BitbucketListRepositoriesParams{
+			AccountName: request.GetString("account", ""),
+			Workspace:   workspace,
+			Query:       request.GetString("query", ""),
+			Project:     request.GetString("project", ""),
+			Sort:        request.GetString("sort", ""),
+			Limit:       request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		listJSON, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repositories to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatRepositoriesSummary(list),
+				},
+				mcp.NewTextContent(string(listJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newGetRepositoryServerTool returns a server tool for getting details of a repository.
+func (bc *BitbucketController) newGetRepositoryServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_get_repository",
+		mcp.WithDescription("Get details of a Bitbucket repository: main branch, project, language and size"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_get_repository request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		repository, err := bc.bitbucketService.GetRepository(ctx, repoParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+
+		repositoryJSON, err := json.MarshalIndent(repository, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repository to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatRepositorySummary(repository),
+				},
+				mcp.NewTextContent(string(repositoryJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatWorkspacesSummary renders workspaces of the user as human readable text.
+func formatWorkspacesSummary(list *app.WorkspaceList) string {
+	if len(list.Workspaces) == 0 {
+		return "No workspaces found"
+	}
+	var sb strings.Builder
+	count := fmt.Sprintf("%d", len(list.Workspaces))
+	if list.Truncated {
+		count = "first " + count
+	}
+	fmt.Fprintf(&sb, "Workspaces (%s):", count)
+	for _, membership := range list.Workspaces {
+		if membership.Workspace == nil {
+			continue
+		}
+		sb.WriteString("\n- " + membership.Workspace.Slug)
+		if membership.Workspace.Name != "" && membership.Workspace.Name != membership.Workspace.Slug {
+			sb.WriteString(": " + membership.Workspace.Name)
+		}
+		if membership.Permission != "" {
+			sb.WriteString(" (" + membership.Permission + ")")
+		}
+	}
+	return sb.String()
+}
+
+// formatRepositoriesSummary renders repositories of a workspace as human readable text.
+func formatRepositoriesSummary(list *app.RepositoryList) string {
+	if len(list.Repositories) == 0 {
+		return "No repositories found in " + list.Workspace
+	}
+	var sb strings.Builder
+	count := fmt.Sprintf("%d", len(list.Repositories))
+	if list.Truncated {
+		count = "first " + count
+	}
+	fmt.Fprintf(&sb, "Repositories in %s (%s):", list.Workspace, count)
+	for _, repository := range list.Repositories {
+		sb.WriteString("\n- " + formatRepositoryLine(repository))
+	}
+	return sb.String()
+}
+
+// formatRepositoryLine renders a repository as a single line with its name, language and project.
+func formatRepositoryLine(repository bitbucket.Repository) string {
+	line := repository.FullName
+	if repository.Name != "" && !strings.HasSuffix(repository.FullName, "/"+repository.Name) {
+		line += ": " + repository.Name
+	}
+	var details []string
+	if repository.Language != "" {
+		details = append(details, repository.Language)
+	}
+	if repository.Project != nil {
+		details = append(details, "project "+repository.Project.Key)
+	}
+	if len(details) > 0 {
+		line += " [" + strings.Join(details, ", ") + "]"
+	}
+	if description, _, _ := strings.Cut(strings.TrimSpace(repository.Description), "\n"); description != "" {
+		line += " - " + description
+	}
+	return line
+}
+
+// formatRepositorySummary renders details of a repository as human readable text.
+func formatRepositorySummary(repository *bitbucket.Repository) string {
+	var sb strings.Builder
+	sb.WriteString("Repository " + formatRepositoryLine(*repository))
+	if repository.MainBranch != nil {
+		sb.WriteString("\nMain branch: " + repository.MainBranch.Name)
+	}
+	if repository.Project != nil {
+		fmt.Fprintf(&sb, "\nProject: %s (%s)", repository.Project.Key, repository.Project.Name)
+	}
+	fmt.Fprintf(&sb, "\nSize: %d bytes", repository.Size)
+	if repository.IsPrivate {
+		sb.WriteString("\nPrivate")
+	}
+	return sb.String()
+}
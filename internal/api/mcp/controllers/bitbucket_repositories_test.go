@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Repositories(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("ListWorkspaces", func(t *testing.T) {
+		t.Run("should list workspaces", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			account := "account-" + faker.Username()
+			list := &app.WorkspaceList{
+				Workspaces: []bitbucket.WorkspaceMembership{
+					{Permission: "owner", Workspace: &bitbucket.Workspace{Slug: "acme", Name: "Acme Inc"}},
+					{Permission: "member", Workspace: &bitbucket.Workspace{Slug: "oss", Name: "oss"}},
+				},
+				Truncated: true,
+			}
+			mockService.EXPECT().ListWorkspaces(ctx, app.BitbucketListWorkspacesParams{
+				AccountName: account,
+				Limit:       2,
+			}).Return(list, nil)
+
+			result, err := controller.newListWorkspacesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_list_workspaces",
+					Arguments: map[string]interface{}{"account": account, "limit": float64(2)},
+				},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Workspaces (first 2):\n- acme: Acme Inc (owner)\n- oss (member)", summary.Text)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.WorkspaceList
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *list, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListWorkspaces(ctx, mock.Anything).Return(nil, expectedErr)
+
+			result, err := controller.newListWorkspacesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_list_workspaces"},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("ListRepositories", func(t *testing.T) {
+		t.Run("should list repositories", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			list := &app.RepositoryList{
+				Workspace: "acme",
+				Repositories: []bitbucket.Repository{
+					{
+						FullName:    "acme/payments",
+						Name:        "Payments Service",
+						Language:    "go",
+						Project:     &bitbucket.Project{Key: "PAY"},
+						Description: "Handles payments\nand refunds",
+					},
+					{FullName: "acme/docs", Name: "docs"},
+				},
+			}
+			mockService.EXPECT().ListRepositories(ctx, app.BitbucketListRepositoriesParams{
+				Workspace: "acme",
+				Query:     `name ~ "pay"`,
+				Project:   "PAY",
+				Sort:      "-updated_on",
+				Limit:     10,
+			}).Return(list, nil)
+
+			result, err := controller.newListRepositoriesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_list_repositories",
+					Arguments: map[string]interface{}{
+						"workspace": "acme",
+						"query":     `name ~ "pay"`,
+						"project":   "PAY",
+						"sort":      "-updated_on",
+						"limit":     float64(10),
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Repositories in acme (2):"+
+				"\n- acme/payments: Payments Service [go, project PAY] - Handles payments"+
+				"\n- acme/docs",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.RepositoryList
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *list, parsed)
+		})
+
+		t.Run("should handle missing workspace", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newListRepositoriesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_list_repositories"},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListRepositories(ctx, mock.Anything).Return(nil, expectedErr)
+
+			result, err := controller.newListRepositoriesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_list_repositories",
+					Arguments: map[string]interface{}{"workspace": "acme"},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("GetRepository", func(t *testing.T) {
+		t.Run("should get repository", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			repoParams := app.BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "acme",
+				RepoName:    "payments",
+			}
+			repository := &bitbucket.Repository{
+				FullName:   "acme/payments",
+				Name:       "payments",
+				Language:   "go",
+				Size:       2048,
+				IsPrivate:  true,
+				MainBranch: &bitbucket.Branch{Name: "main"},
+				Project:    &bitbucket.Project{Key: "PAY", Name: "Payments"},
+			}
+			mockService.EXPECT().GetRepository(ctx, repoParams).Return(repository, nil)
+
+			result, err := controller.newGetRepositoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name: "bitbucket_get_repository",
+					Arguments: map[string]interface{}{
+						"repo_owner": repoParams.RepoOwner,
+						"repo_name":  repoParams.RepoName,
+						"account":    repoParams.AccountName,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Repository acme/payments [go, project PAY]"+
+				"\nMain branch: main"+
+				"\nProject: PAY (Payments)"+
+				"\nSize: 2048 bytes"+
+				"\nPrivate",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed bitbucket.Repository
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *repository, parsed)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name"} {
+				args := map[string]interface{}{"repo_owner": "acme", "repo_name": "payments"}
+				delete(args, missing)
+
+				result, err := controller.newGetRepositoryServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_get_repository", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().GetRepository(ctx, mock.Anything).Return(nil, expectedErr)
+
+			result, err := controller.newGetRepositoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_get_repository",
+					Arguments: map[string]interface{}{"repo_owner": "acme", "repo_name": "payments"},
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+}
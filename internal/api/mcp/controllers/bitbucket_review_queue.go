@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newMyReviewQueueServerTool returns a server tool for listing pull requests that need attention of the user.
+func (bc *BitbucketController) newMyReviewQueueServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_my_review_queue",
+		mcp.WithDescription("List open pull requests across repositories that need attention of the user: "+
+			"pull requests the user reviews and has not approved yet, and pull requests of the user with "+
+			"unresolved comments or failing builds of the head commit. The least recently updated pull requests "+
+			"come first. Looks at the given repositories, the workspace, or the watched repositories from the config."),
+		mcp.WithString("workspace",
+			mcp.Description("Workspace to look in (optional, defaults to the 100 most recently updated repositories "+
+				"of the workspace when repositories are not given)"),
+		),
+		mcp.WithArray("repositories",
+			mcp.Description("Repositories to look in as full names (workspace/repo_slug) or slugs of the workspace "+
+				"(optional)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pull requests to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_my_review_queue request", "params", request.Params)
+
+		queue, err := bc.bitbucketService.GetReviewQueue(ctx, app.BitbucketReviewQueueParams{
+			AccountName:  request.GetString("account", ""),
+			Workspace:    request.GetString("workspace", ""),
+			Repositories: request.GetStringSlice("repositories", nil),
+			Limit:        request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get review queue: %w", err)
+		}
+
+		queueJSON, err := json.MarshalIndent(queue, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal review queue to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatReviewQueueSummary(queue),
+				},
+				mcp.NewTextContent(string(queueJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatReviewQueueSummary renders the review queue as human readable text.
+func formatReviewQueueSummary(queue *app.ReviewQueue) string {
+	var sb strings.Builder
+	if len(queue.Items) == 0 {
+		fmt.Fprintf(&sb, "No pull requests need attention in %d repositories", queue.SearchedRepositories)
+	} else {
+		count := fmt.Sprintf("%d", len(queue.Items))
+		if queue.Truncated {
+			count = "first " + count
+		}
+		fmt.Fprintf(&sb, "Review queue of %s (%s):", queue.User.DisplayName, count)
+		for _, item := range queue.Items {
+			pr := item.PullRequest
+			fmt.Fprintf(&sb, "\n- %s#%d [%s] %s - %s",
+				item.Repository, pr.ID, item.Role, pr.Title, strings.Join(item.Reasons, ", "))
+			if pr.UpdatedOn != nil {
+				fmt.Fprintf(&sb, " (updated %s)", pr.UpdatedOn.Format(time.DateOnly))
+			}
+		}
+	}
+	if queue.RepositoriesTruncated {
+		fmt.Fprintf(&sb, "\nOnly the %d most recently updated repositories were looked at, "+
+			"pass repositories to look at others", queue.SearchedRepositories)
+	}
+	writeFailedRepositories(&sb, queue.FailedRepositories)
+	return sb.String()
+}
+
+// writeFailedRepositories appends repositories a cross-repository tool could not look at.
+func writeFailedRepositories(sb *strings.Builder, failed []app.FailedRepository) {
+	for _, repository := range failed {
+		fmt.Fprintf(sb, "\nFailed to look at %s: %s", repository.Repository, repository.Error)
+	}
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_MyReviewQueue(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should list review queue", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		account := "account-" + faker.Username()
+		updatedOn := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+		queue := &app.ReviewQueue{
+			User: bitbucket.Account{DisplayName: "Jane Doe", UUID: "{jane}"},
+			Items: []app.ReviewQueueItem{
+				{
+					Repository:  "acme/api",
+					Role:        app.ReviewQueueRoleReviewer,
+					Reasons:     []string{"awaiting your approval"},
+					PullRequest: bitbucket.PullRequest{ID: 7, Title: "Add refunds", UpdatedOn: &updatedOn},
+				},
+				{
+					Repository:    "acme/web",
+					Role:          app.ReviewQueueRoleAuthor,
+					Reasons:       []string{"1 failing builds"},
+					FailingBuilds: []string{"lint"},
+					PullRequest:   bitbucket.PullRequest{ID: 3, Title: "Fix layout"},
+				},
+			},
+			Truncated:             true,
+			SearchedRepositories:  100,
+			RepositoriesTruncated: true,
+			FailedRepositories:    []app.FailedRepository{{Repository: "acme/docs", Error: "access denied"}},
+		}
+		mockService.EXPECT().GetReviewQueue(ctx, app.BitbucketReviewQueueParams{
+			AccountName:  account,
+			Workspace:    "acme",
+			Repositories: []string{"api", "oss/lib"},
+			Limit:        2,
+		}).Return(queue, nil)
+
+		result, err := controller.newMyReviewQueueServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_my_review_queue",
+				Arguments: map[string]interface{}{
+					"workspace":    "acme",
+					"repositories": []interface{}{"api", "oss/lib"},
+					"limit":        float64(2),
+					"account":      account,
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Review queue of Jane Doe (first 2):"+
+			"\n- acme/api#7 [reviewer] Add refunds - awaiting your approval (updated 2026-03-04)"+
+			"\n- acme/web#3 [author] Fix layout - 1 failing builds"+
+			"\nOnly the 100 most recently updated repositories were looked at, pass repositories to look at others"+
+			"\nFailed to look at acme/docs: access denied",
+			summary.Text)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.ReviewQueue
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *queue, parsed)
+	})
+
+	t.Run("should report empty queue", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		mockService.EXPECT().GetReviewQueue(ctx, app.BitbucketReviewQueueParams{}).
+			Return(&app.ReviewQueue{SearchedRepositories: 3}, nil)
+
+		result, err := controller.newMyReviewQueueServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_my_review_queue"},
+		})
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "No pull requests need attention in 3 repositories", summary.Text)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().GetReviewQueue(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newMyReviewQueueServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_my_review_queue"},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newSearchCodeServerTool returns a server tool for searching code across repositories of a workspace.
+func (bc *BitbucketController) newSearchCodeServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_search_code",
+		mcp.WithDescription("Search code across all repositories of a Bitbucket workspace, "+
+			"e.g. to find other usages of a function changed in a pull request. "+
+			"Returns matching files with their repositories and matched lines."),
+		mcp.WithString("workspace",
+			mcp.Description("Workspace to search in"),
+			mcp.Required(),
+		),
+		mcp.WithString("query",
+			mcp.Description("Search query. Supports Bitbucket code search syntax, e.g. "+
+				"\"parseDiff repo:my-repo lang:go\", \"\\\"exact phrase\\\"\", \"ext:yml\" or \"path:src/\""),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of files to return (optional, defaults to 20, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_search_code request", "params", request.Params)
+
+		workspace, err := request.RequireString("workspace")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid workspace parameter", err), nil
+		}
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid query parameter", err), nil
+		}
+
+		results, err := bc.bitbucketService.SearchCode(ctx, app.BitbucketSearchCodeParams{
+			AccountName: request.GetString("account", ""),
+			Workspace:   workspace,
+			Query:       query,
+			Limit:       request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search code: %w", err)
+		}
+
+		resultsJSON, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal search results to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatCodeSearchSummary(results),
+				},
+				mcp.NewTextContent(string(resultsJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatCodeSearchSummary renders files matching a code search query with their matched lines.
+func formatCodeSearchSummary(results *app.CodeSearchResults) string {
+	if len(results.Files) == 0 {
+		return fmt.Sprintf("No code matching %q found in %s", results.Query, results.Workspace)
+	}
+	var sb strings.Builder
+	count := fmt.Sprintf("%d", len(results.Files))
+	if results.Truncated {
+		count = "first " + count
+	}
+	fmt.Fprintf(&sb, "Files matching %q in %s (%s):", results.Query, results.Workspace, count)
+	for _, file := range results.Files {
+		sb.WriteString("\n- ")
+		if file.Repository != "" {
+			sb.WriteString(file.Repository + ": ")
+		}
+		sb.WriteString(file.Path)
+		switch {
+		case file.MatchCount > 0:
+			fmt.Fprintf(&sb, " (%d matches)", file.MatchCount)
+		case file.PathMatched:
+			sb.WriteString(" (path matches)")
+		}
+		for _, snippet := range file.Snippets {
+			for _, line := range snippet {
+				if line.Match {
+					fmt.Fprintf(&sb, "\n    %d: %s", line.Line, strings.TrimSpace(line.Text))
+				}
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_SearchCode(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should search code", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		account := "account-" + faker.Username()
+		results := &app.CodeSearchResults{
+			Workspace: "acme",
+			Query:     "parseDiff",
+			Files: []app.CodeSearchFile{
+				{
+					Repository: "acme/api",
+					Path:       "diff.go",
+					MatchCount: 2,
+					Snippets: [][]app.CodeSearchLine{{
+						{Line: 2, Text: ""},
+						{Line: 3, Text: "\tfunc parseDiff() {", Match: true},
+					}, {
+						{Line: 10, Text: "return parseDiff()", Match: true},
+					}},
+				},
+				{Repository: "acme/docs", Path: "parseDiff.md", PathMatched: true},
+			},
+			Truncated: true,
+		}
+		mockService.EXPECT().SearchCode(ctx, app.BitbucketSearchCodeParams{
+			AccountName: account,
+			Workspace:   "acme",
+			Query:       "parseDiff",
+			Limit:       2,
+		}).Return(results, nil)
+
+		result, err := controller.newSearchCodeServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_search_code",
+				Arguments: map[string]interface{}{
+					"workspace": "acme",
+					"query":     "parseDiff",
+					"limit":     float64(2),
+					"account":   account,
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, `Files matching "parseDiff" in acme (first 2):`+
+			"\n- acme/api: diff.go (2 matches)"+
+			"\n    3: func parseDiff() {"+
+			"\n    10: return parseDiff()"+
+			"\n- acme/docs: parseDiff.md (path matches)",
+			summary.Text,
+		)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.CodeSearchResults
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *results, parsed)
+	})
+
+	t.Run("should report no matches", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		mockService.EXPECT().SearchCode(ctx, mock.Anything).
+			Return(&app.CodeSearchResults{Workspace: "acme", Query: "nothing", Files: []app.CodeSearchFile{}}, nil)
+
+		result, err := controller.newSearchCodeServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "bitbucket_search_code",
+				Arguments: map[string]interface{}{"workspace": "acme", "query": "nothing"},
+			},
+		})
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, `No code matching "nothing" found in acme`, summary.Text)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().SearchCode(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newSearchCodeServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "bitbucket_search_code",
+				Arguments: map[string]interface{}{"workspace": "acme", "query": "parseDiff"},
+			},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should handle missing required parameters", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+
+		for _, missing := range []string{"workspace", "query"} {
+			args := map[string]interface{}{"workspace": "acme", "query": "parseDiff"}
+			delete(args, missing)
+
+			result, err := controller.newSearchCodeServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_search_code", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError, "missing %s should produce error result", missing)
+		}
+	})
+}
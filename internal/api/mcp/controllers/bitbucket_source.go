@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListDirectoryServerTool returns a server tool for listing a directory of a repository.
+func (bc *BitbucketController) newListDirectoryServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_list_directory",
+		mcp.WithDescription(
+			"List files and directories of a Bitbucket repository with their size and mime type, "+
+				"e.g. to explore an unfamiliar repository. Use depth to list nested directories.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("ref",
+			mcp.Description("Commit hash or branch name to list the directory at"),
+			mcp.Required(),
+		),
+		mcp.WithString("path",
+			mcp.Description("Directory path (optional, defaults to the repository root)"),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description("Depth of nested directories to list (optional, defaults to 1, max 10)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of entries to return (optional, defaults to 200, max 1000)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_list_directory request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		ref, err := request.RequireString("ref")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid ref parameter", err), nil
+		}
+
+		listing, err := bc.bitbucketService.ListDirectory(ctx, app.BitbucketListDirectoryParams{
+			BitbucketRepositoryParams: repoParams,
+			Ref:                       ref,
+			Path:                      request.GetString("path", ""),
+			Depth:                     request.GetInt("depth", 0),
+			Limit:                     request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list directory: %w", err)
+		}
+
+		listingJSON, err := json.MarshalIndent(listing, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal directory listing to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatDirectoryListingSummary(listing),
+				},
+				mcp.NewTextContent(string(listingJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatTreeEntryLine renders a one line description of a file or a directory.
+func formatTreeEntryLine(entry bitbucket.TreeEntry) string {
+	if entry.IsDirectory() {
+		return entry.Path + "/"
+	}
+	details := []string{fmt.Sprintf("%d bytes", entry.Size)}
+	if entry.MimeType != "" {
+		details = append(details, entry.MimeType)
+	}
+	return entry.Path + " (" + strings.Join(details, ", ") + ")"
+}
+
+// formatDirectoryListingSummary renders a directory listing as human readable text.
+func formatDirectoryListingSummary(listing *app.DirectoryListing) string {
+	path := "/" + listing.Path
+	if len(listing.Entries) == 0 {
+		return fmt.Sprintf("Directory %s at %s is empty", path, listing.Ref)
+	}
+	var sb strings.Builder
+	count := fmt.Sprintf("%d", len(listing.Entries))
+	if listing.Truncated {
+		count = "first " + count
+	}
+	fmt.Fprintf(&sb, "Listing %s at %s (%s entries):", path, listing.Ref, count)
+	for _, entry := range listing.Entries {
+		sb.WriteString("\n- " + formatTreeEntryLine(entry))
+	}
+	return sb.String()
+}
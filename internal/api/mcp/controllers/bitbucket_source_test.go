@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Source(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	repoArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+		}
+	}
+
+	t.Run("bitbucket_list_directory", func(t *testing.T) {
+		t.Run("should list directory", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListDirectoryParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Ref:                       "main",
+				Path:                      "internal",
+				Depth:                     1 + rand.IntN(10),
+				Limit:                     1 + rand.IntN(1000),
+			}
+			listing := &app.DirectoryListing{
+				Ref:  params.Ref,
+				Path: params.Path,
+				Entries: []bitbucket.TreeEntry{
+					{Type: bitbucket.TreeEntryTypeDirectory, Path: "internal/app"},
+					{Type: bitbucket.TreeEntryTypeFile, Path: "internal/doc.go", Size: 42, MimeType: "text/x-go"},
+					{Type: bitbucket.TreeEntryTypeFile, Path: "internal/README"},
+				},
+				Truncated: true,
+			}
+			mockService.EXPECT().ListDirectory(ctx, params).Return(listing, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["ref"] = params.Ref
+			args["path"] = params.Path
+			args["depth"] = params.Depth
+			args["limit"] = params.Limit
+			result, err := controller.newListDirectoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_list_directory", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Listing /internal at main (first 3 entries):"+
+				"\n- internal/app/"+
+				"\n- internal/doc.go (42 bytes, text/x-go)"+
+				"\n- internal/README (0 bytes)",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.DirectoryListing
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, *listing, parsed)
+		})
+
+		t.Run("should report empty directory", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListDirectoryParams{BitbucketRepositoryParams: makeRepoParams(), Ref: faker.UUIDDigit()}
+			mockService.EXPECT().ListDirectory(ctx, params).Return(&app.DirectoryListing{
+				Ref:     params.Ref,
+				Entries: []bitbucket.TreeEntry{},
+			}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["ref"] = params.Ref
+			result, err := controller.newListDirectoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_list_directory", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Directory / at "+params.Ref+" is empty", summary.Text)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListDirectoryParams{BitbucketRepositoryParams: makeRepoParams(), Ref: faker.UUIDDigit()}
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListDirectory(ctx, params).Return(nil, expectedErr)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["ref"] = params.Ref
+			result, err := controller.newListDirectoryServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_list_directory", Arguments: args},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "ref"} {
+				args := repoArguments(makeRepoParams())
+				args["ref"] = faker.UUIDDigit()
+				delete(args, missing)
+
+				result, err := controller.newListDirectoryServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_list_directory", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+}
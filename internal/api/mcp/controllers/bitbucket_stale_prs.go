@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newStalePRsServerTool returns a server tool for finding stale pull requests and reminding about them.
+func (bc *BitbucketController) newStalePRsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_stale_prs",
+		mcp.WithDescription("Find open pull requests across repositories that are open for too long or have no "+
+			"recent activity, grouped by author and by reviewers that have not approved them yet. Optionally "+
+			"posts a reminder comment on each of them, pull requests reminded about recently are skipped. "+
+			"Looks at the given repositories, the workspace, or the watched repositories from the config."),
+		mcp.WithString("workspace",
+			mcp.Description("Workspace to look in (optional, defaults to the 100 most recently updated repositories "+
+				"of the workspace when repositories are not given)"),
+		),
+		mcp.WithArray("repositories",
+			mcp.Description("Repositories to look in as full names (workspace/repo_slug) or slugs of the workspace "+
+				"(optional)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithNumber("open_days",
+			mcp.Description("Find pull requests open for at least this many days (optional)"),
+		),
+		mcp.WithNumber("idle_days",
+			mcp.Description("Find pull requests without activity for at least this many days "+
+				"(optional, defaults to 7 when open_days is not given either)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pull requests to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithBoolean("nudge",
+			mcp.Description("Post a reminder comment on each stale pull request (optional, defaults to false)"),
+		),
+		mcp.WithString("message",
+			mcp.Description("Go template of the reminder comment (optional). Available fields: .PullRequestID, "+
+				".Title, .Author, .OpenDays, .IdleDays and .Mentions, e.g. {{join .Mentions \" \"}}"),
+		),
+		mcp.WithBoolean("tag_reviewers",
+			mcp.Description("Mention reviewers that have not approved yet in the reminder (optional, defaults to false)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_stale_prs request", "params", request.Params)
+
+		params := app.BitbucketStalePRsParams{
+			AccountName:  request.GetString("account", ""),
+			Workspace:    request.GetString("workspace", ""),
+			Repositories: request.GetStringSlice("repositories", nil),
+			OpenDays:     request.GetInt("open_days", 0),
+			IdleDays:     request.GetInt("idle_days", 0),
+			Limit:        request.GetInt("limit", 0),
+		}
+
+		var result any
+		var summary string
+		if request.GetBool("nudge", false) {
+			nudges, err := bc.bitbucketService.NudgeStalePullRequests(ctx, app.BitbucketNudgeStalePRsParams{
+				BitbucketStalePRsParams: params,
+				Message:                 request.GetString("message", ""),
+				TagReviewers:            request.GetBool("tag_reviewers", false),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to remind about stale pull requests: %w", err)
+			}
+			result = nudges
+			summary = formatStalePRsSummary(nudges.Stale) + formatStalePRNudgesSummary(nudges.Nudges)
+		} else {
+			stale, err := bc.bitbucketService.FindStalePullRequests(ctx, params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find stale pull requests: %w", err)
+			}
+			result = stale
+			summary = formatStalePRsSummary(stale)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal stale pull requests to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: summary,
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatStalePRsSummary renders stale pull requests and their groups as human readable text.
+func formatStalePRsSummary(stale *app.StalePullRequests) string {
+	var sb strings.Builder
+	if len(stale.PullRequests) == 0 {
+		fmt.Fprintf(&sb, "No stale pull requests in %d repositories", stale.SearchedRepositories)
+	} else {
+		count := fmt.Sprintf("%d", len(stale.PullRequests))
+		if stale.Truncated {
+			count = "first " + count
+		}
+		fmt.Fprintf(&sb, "Stale pull requests (%s):", count)
+		for _, item := range stale.PullRequests {
+			pr := item.PullRequest
+			fmt.Fprintf(&sb, "\n- %s#%d %s", item.Repository, pr.ID, pr.Title)
+			if pr.Author != nil {
+				sb.WriteString(" by " + pr.Author.DisplayName)
+			}
+			sb.WriteString(" - " + strings.Join(item.Reasons, ", "))
+		}
+		writeStalePRGroups(&sb, "By author", stale.ByAuthor)
+		writeStalePRGroups(&sb, "Waiting for reviewers", stale.ByReviewer)
+	}
+	if stale.RepositoriesTruncated {
+		fmt.Fprintf(&sb, "\nOnly the %d most recently updated repositories were looked at, "+
+			"pass repositories to look at others", stale.SearchedRepositories)
+	}
+	writeFailedRepositories(&sb, stale.FailedRepositories)
+	return sb.String()
+}
+
+func writeStalePRGroups(sb *strings.Builder, title string, groups []app.StalePRGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	sb.WriteString("\n" + title + ":")
+	for _, group := range groups {
+		fmt.Fprintf(sb, "\n- %s: %s", group.User.DisplayName, strings.Join(group.PullRequests, ", "))
+	}
+}
+
+// formatStalePRNudgesSummary renders the outcome of reminders as human readable text.
+func formatStalePRNudgesSummary(nudges []app.StalePRNudge) string {
+	if len(nudges) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\nReminders:")
+	for _, nudge := range nudges {
+		fmt.Fprintf(&sb, "\n- %s#%d %s", nudge.Repository, nudge.PullRequestID, nudge.Status)
+		if nudge.Details != "" {
+			sb.WriteString(": " + nudge.Details)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_StalePRs(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	jane := bitbucket.PullRequestAuthor{DisplayName: "Jane"}
+	john := bitbucket.PullRequestAuthor{DisplayName: "John"}
+	makeStale := func() *app.StalePullRequests {
+		return &app.StalePullRequests{
+			IdleDays: 7,
+			PullRequests: []app.StalePullRequest{
+				{
+					Repository:       "acme/api",
+					OpenDays:         12,
+					IdleDays:         9,
+					Reasons:          []string{"no activity for 9 days"},
+					PendingReviewers: []bitbucket.PullRequestAuthor{john},
+					PullRequest:      bitbucket.PullRequest{ID: 7, Title: "Add refunds", Author: &jane},
+				},
+			},
+			ByAuthor:             []app.StalePRGroup{{User: jane, PullRequests: []string{"acme/api#7"}}},
+			ByReviewer:           []app.StalePRGroup{{User: john, PullRequests: []string{"acme/api#7"}}},
+			SearchedRepositories: 2,
+		}
+	}
+
+	t.Run("should find stale pull requests", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		account := "account-" + faker.Username()
+		stale := makeStale()
+		stale.FailedRepositories = []app.FailedRepository{{Repository: "acme/web", Error: "access denied"}}
+		mockService.EXPECT().FindStalePullRequests(ctx, app.BitbucketStalePRsParams{
+			AccountName:  account,
+			Workspace:    "acme",
+			Repositories: []string{"api", "web"},
+			OpenDays:     30,
+			IdleDays:     7,
+			Limit:        10,
+		}).Return(stale, nil)
+
+		result, err := controller.newStalePRsServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_stale_prs",
+				Arguments: map[string]interface{}{
+					"workspace":    "acme",
+					"repositories": []interface{}{"api", "web"},
+					"open_days":    float64(30),
+					"idle_days":    float64(7),
+					"limit":        float64(10),
+					"account":      account,
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Stale pull requests (1):"+
+			"\n- acme/api#7 Add refunds by Jane - no activity for 9 days"+
+			"\nBy author:\n- Jane: acme/api#7"+
+			"\nWaiting for reviewers:\n- John: acme/api#7"+
+			"\nFailed to look at acme/web: access denied",
+			summary.Text)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.StalePullRequests
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *stale, parsed)
+	})
+
+	t.Run("should remind about stale pull requests", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		nudges := &app.StalePRNudges{
+			Stale: makeStale(),
+			Nudges: []app.StalePRNudge{
+				{Repository: "acme/api", PullRequestID: 7, Status: app.StaleNudgeStatusNudged, CommentID: 42},
+			},
+		}
+		mockService.EXPECT().NudgeStalePullRequests(ctx, app.BitbucketNudgeStalePRsParams{
+			BitbucketStalePRsParams: app.BitbucketStalePRsParams{Workspace: "acme"},
+			Message:                 "Ping {{join .Mentions \" \"}}",
+			TagReviewers:            true,
+		}).Return(nudges, nil)
+
+		result, err := controller.newStalePRsServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "bitbucket_stale_prs",
+				Arguments: map[string]interface{}{
+					"workspace":     "acme",
+					"nudge":         true,
+					"message":       "Ping {{join .Mentions \" \"}}",
+					"tag_reviewers": true,
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text, "\nReminders:\n- acme/api#7 nudged")
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.StalePRNudges
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *nudges, parsed)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().FindStalePullRequests(ctx, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newStalePRsServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_stale_prs"},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,334 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newListTagsServerTool returns a server tool for listing tags of a repository.
+func (bc *BitbucketController) newListTagsServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_tags_list",
+		mcp.WithDescription("List tags of a Bitbucket repository, newest first by default"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("filter",
+			mcp.Description("Only list tags whose name contains this text (optional)"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort field, prefix with - for descending order (optional, defaults to -target.date)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of tags to return (optional, defaults to 50, max 100)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_tags_list request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		tags, err := bc.bitbucketService.ListTags(ctx, app.BitbucketListTagsParams{
+			BitbucketRepositoryParams: repoParams,
+			Filter:                    request.GetString("filter", ""),
+			Sort:                      request.GetString("sort", ""),
+			Limit:                     request.GetInt("limit", 0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		tagsJSON, err := json.MarshalIndent(tags, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tags to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatTagsSummary(tags),
+				},
+				mcp.NewTextContent(string(tagsJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newCreateTagServerTool returns a server tool for tagging a branch or a commit.
+func (bc *BitbucketController) newCreateTagServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_tags_create",
+		mcp.WithDescription("Create a tag in a Bitbucket repository on a branch or a commit"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("tag",
+			mcp.Description("Tag name, e.g. v1.2.0"),
+			mcp.Required(),
+		),
+		mcp.WithString("target",
+			mcp.Description("Branch name or commit hash to tag"),
+			mcp.Required(),
+		),
+		mcp.WithString("message",
+			mcp.Description("Tag message (optional)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_tags_create request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		name, err := request.RequireString("tag")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid tag parameter", err), nil
+		}
+
+		target, err := request.RequireString("target")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid target parameter", err), nil
+		}
+
+		tag, err := bc.bitbucketService.CreateTag(ctx, app.BitbucketCreateTagParams{
+			BitbucketRepositoryParams: repoParams,
+			Name:                      name,
+			Target:                    target,
+			Message:                   request.GetString("message", ""),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tag: %w", err)
+		}
+
+		tagJSON, err := json.MarshalIndent(tag, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tag to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: "Tag created: " + formatTagLine(*tag),
+				},
+				mcp.NewTextContent(string(tagJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newDeleteTagServerTool returns a server tool for deleting a tag.
+func (bc *BitbucketController) newDeleteTagServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_tags_delete",
+		mcp.WithDescription("Delete a tag of a Bitbucket repository"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("tag",
+			mcp.Description("Name of the tag to delete"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_tags_delete request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		name, err := request.RequireString("tag")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid tag parameter", err), nil
+		}
+
+		if err = bc.bitbucketService.DeleteTag(ctx, app.BitbucketTagParams{
+			BitbucketRepositoryParams: repoParams,
+			Name:                      name,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to delete tag: %w", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Tag %s deleted", name)), nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newListTagChangesServerTool returns a server tool for listing changes between two tags.
+func (bc *BitbucketController) newListTagChangesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_tags_changes",
+		mcp.WithDescription(
+			"List commits and merged pull requests between two tags of a Bitbucket repository, "+
+				"e.g. to prepare release notes. The previous tag is found automatically when from is not provided.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("to",
+			mcp.Description("Tag, branch or commit to list changes up to, e.g. v1.2.0 or main for unreleased changes"),
+			mcp.Required(),
+		),
+		mcp.WithString("from",
+			mcp.Description("Tag to list changes from (optional, defaults to the tag preceding to)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_tags_changes request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		to, err := request.RequireString("to")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid to parameter", err), nil
+		}
+
+		changes, err := bc.bitbucketService.ListTagChanges(ctx, app.BitbucketListTagChangesParams{
+			BitbucketRepositoryParams: repoParams,
+			From:                      request.GetString("from", ""),
+			To:                        to,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changes between tags: %w", err)
+		}
+
+		changesJSON, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tag changes to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatTagChangesSummary(changes),
+				},
+				mcp.NewTextContent(string(changesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatTagLine renders a one line description of a tag.
+func formatTagLine(tag bitbucket.Tag) string {
+	line := tag.Name
+	if target := tag.Target; target != nil {
+		line += " at " + target.Hash
+		if target.Date != "" {
+			line += " (" + target.Date + ")"
+		}
+	}
+	return line
+}
+
+// formatTagsSummary renders tags as human readable text.
+func formatTagsSummary(tags []bitbucket.Tag) string {
+	if len(tags) == 0 {
+		return "No tags found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d tags:", len(tags))
+	for _, tag := range tags {
+		sb.WriteString("\n- " + formatTagLine(tag))
+	}
+	return sb.String()
+}
+
+// formatTagChangesSummary renders changes between tags as human readable text.
+func formatTagChangesSummary(changes *app.TagChanges) string {
+	var sb strings.Builder
+	if changes.From != "" {
+		fmt.Fprintf(&sb, "Changes from %s to %s: ", changes.From, changes.To)
+	} else {
+		fmt.Fprintf(&sb, "Changes up to %s (no previous tag): ", changes.To)
+	}
+	fmt.Fprintf(&sb, "%s, %d pull requests",
+		formatCommitCount(len(changes.Commits), changes.Truncated),
+		len(changes.PullRequests),
+	)
+	if len(changes.PullRequests) > 0 {
+		sb.WriteString("\nPull requests:")
+		for _, pr := range changes.PullRequests {
+			fmt.Fprintf(&sb, "\n- #%d %s", pr.ID, pr.Title)
+		}
+	}
+	if len(changes.Commits) > 0 {
+		sb.WriteString("\nCommits:")
+		for _, commit := range changes.Commits {
+			sb.WriteString("\n- " + formatCommitLine(commit))
+		}
+	}
+	return sb.String()
+}
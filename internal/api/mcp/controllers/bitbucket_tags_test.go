@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_Tags(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	repoArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+		}
+	}
+
+	newRandomTag := func(name string) *bitbucket.Tag {
+		return &bitbucket.Tag{
+			Type:   "tag",
+			Name:   name,
+			Target: &bitbucket.Commit{Hash: faker.UUIDDigit(), Date: "2025-01-02T03:04:05+00:00"},
+		}
+	}
+
+	t.Run("bitbucket_tags_list", func(t *testing.T) {
+		t.Run("should list tags", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListTagsParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Filter:                    "v1.",
+				Sort:                      "name",
+				Limit:                     1 + rand.IntN(100),
+			}
+			tag := newRandomTag("v1." + faker.Word())
+			mockService.EXPECT().ListTags(ctx, params).Return([]bitbucket.Tag{*tag}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["filter"] = params.Filter
+			args["sort"] = params.Sort
+			args["limit"] = params.Limit
+			result, err := controller.newListTagsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_list", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"Found 1 tags:\n- "+tag.Name+" at "+tag.Target.Hash+" ("+tag.Target.Date+")",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.Tag
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, []bitbucket.Tag{*tag}, parsed)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := app.BitbucketListTagsParams{BitbucketRepositoryParams: makeRepoParams()}
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListTags(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newListTagsServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_tags_list",
+					Arguments: repoArguments(params.BitbucketRepositoryParams),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_tags_create", func(t *testing.T) {
+		t.Run("should create tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketCreateTagParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "v" + faker.Word(),
+				Target:                    "main",
+				Message:                   faker.Sentence(),
+			}
+			tag := newRandomTag(params.Name)
+			mockService.EXPECT().CreateTag(ctx, params).Return(tag, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["tag"] = params.Name
+			args["target"] = params.Target
+			args["message"] = params.Message
+			result, err := controller.newCreateTagServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_create", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Tag created: "+params.Name+" at "+tag.Target.Hash)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "tag", "target"} {
+				args := repoArguments(makeRepoParams())
+				args["tag"] = faker.Word()
+				args["target"] = faker.Word()
+				delete(args, missing)
+
+				result, err := controller.newCreateTagServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_tags_create", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+
+	t.Run("bitbucket_tags_delete", func(t *testing.T) {
+		t.Run("should delete tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketTagParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "v" + faker.Word(),
+			}
+			mockService.EXPECT().DeleteTag(ctx, params).Return(nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["tag"] = params.Name
+			result, err := controller.newDeleteTagServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_delete", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Tag "+params.Name+" deleted", summary.Text)
+		})
+	})
+
+	t.Run("bitbucket_tags_changes", func(t *testing.T) {
+		t.Run("should list changes between tags", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				To:                        "v1.1.0",
+			}
+			commit := bitbucket.Commit{Hash: faker.UUIDDigit(), Message: "Fix bug (pull request #12)\n\nDetails"}
+			changes := &app.TagChanges{
+				From:    "v1.0.0",
+				To:      params.To,
+				Commits: []bitbucket.Commit{commit},
+				PullRequests: []bitbucket.PullRequest{
+					{ID: 12, Title: "Fix bug"},
+				},
+			}
+			mockService.EXPECT().ListTagChanges(ctx, params).Return(changes, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["to"] = params.To
+			result, err := controller.newListTagChangesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_changes", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"Changes from v1.0.0 to v1.1.0: 1 commits, 1 pull requests\n"+
+					"Pull requests:\n- #12 Fix bug\n"+
+					"Commits:\n- "+commit.Hash+" Fix bug (pull request #12)",
+				summary.Text,
+			)
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed app.TagChanges
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, changes.From, parsed.From)
+			assert.Equal(t, changes.Commits, parsed.Commits)
+		})
+
+		t.Run("should report truncated changes without previous tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				To:                        "v1.0.0",
+			}
+			mockService.EXPECT().ListTagChanges(ctx, params).Return(&app.TagChanges{
+				To:        params.To,
+				Truncated: true,
+			}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["to"] = params.To
+			result, err := controller.newListTagChangesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_changes", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"Changes up to v1.0.0 (no previous tag): at least 0 commits, 0 pull requests",
+				summary.Text,
+			)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := app.BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				To:                        "v" + faker.Word(),
+				From:                      "v" + faker.Word(),
+			}
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListTagChanges(ctx, params).Return(nil, expectedErr)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["to"] = params.To
+			args["from"] = params.From
+			result, err := controller.newListTagChangesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_tags_changes", Arguments: args},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newListTagChangesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_tags_changes",
+					Arguments: repoArguments(makeRepoParams()),
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError)
+		})
+	})
+}
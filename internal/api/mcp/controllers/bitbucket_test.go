@@ -158,8 +158,13 @@ func TestBitbucketController(t *testing.T) {
 			serverTool := controller.newGetPRDiffServerTool()
 
 			assert.Equal(t, "bitbucket_get_pr_diff", serverTool.Tool.Name)
-			assert.Equal(t, "Get the diff for a pull request in Bitbucket", serverTool.Tool.Description)
+			assert.Equal(t, "Get the diff for a pull request in Bitbucket. Large diffs can be read in chunks "+
+				"split at file boundaries using max_bytes and the returned cursor, generated and vendored files "+
+				"can be left out with skip_generated or skip_patterns.", serverTool.Tool.Description)
 			assert.NotNil(t, serverTool.Tool.InputSchema)
+			for _, name := range []string{"max_bytes", "cursor", "skip_generated", "skip_patterns"} {
+				assert.Contains(t, serverTool.Tool.InputSchema.Properties, name)
+			}
 			assert.NotNil(t, serverTool.Handler)
 		})
 		t.Run("should define GetFileContent tool correctly", func(t *testing.T) {
@@ -192,9 +197,21 @@ func TestBitbucketController(t *testing.T) {
 
 		tools := controller.NewTools()
 
-		// 15 tools: create, read, update, approve, merge, list, update, create task,
-		// get diffstat, get diff, get file content, add comment, request changes, list comments, resolve comment
-		require.Len(t, tools, 15)
+		// 57 tools: create, read, update, approve, merge, list, update, create task,
+		// get diffstat, get diff, get file content, add comment, request changes, list comments, resolve comment,
+		// check mergeable, list build statuses, set build status,
+		// list pipelines, get pipeline, trigger pipeline, stop pipeline, validate pipelines config,
+		// get pipeline failure, wait for ci,
+		// list, create, update, delete repository variables,
+		// list environments, list deployments, deployments overview,
+		// list, get, create, delete, compare branches,
+		// list, create, delete tags, tag changes,
+		// get branch restrictions, list directory,
+		// file history, blame, commit files, open change, search code,
+		// list workspaces, list repositories, get repository, whoami,
+		// commit pull requests, find issue pull requests, my review queue, stale prs,
+		// pr review bundle
+		require.Len(t, tools, 57)
 		toolNames := make([]string, len(tools))
 		for i, tool := range tools {
 			toolNames[i] = tool.Tool.Name
@@ -211,6 +228,48 @@ func TestBitbucketController(t *testing.T) {
 		assert.Contains(t, toolNames, "bitbucket_get_pr_diff")
 		assert.Contains(t, toolNames, "bitbucket_get_file_content")
 		assert.Contains(t, toolNames, "bitbucket_resolve_pr_comment")
+		assert.Contains(t, toolNames, "bitbucket_check_pr_mergeable")
+		assert.Contains(t, toolNames, "bitbucket_list_build_statuses")
+		assert.Contains(t, toolNames, "bitbucket_set_build_status")
+		assert.Contains(t, toolNames, "bitbucket_pipelines_list")
+		assert.Contains(t, toolNames, "bitbucket_pipelines_get")
+		assert.Contains(t, toolNames, "bitbucket_pipelines_trigger")
+		assert.Contains(t, toolNames, "bitbucket_pipelines_stop")
+		assert.Contains(t, toolNames, "bitbucket_pipelines_validate")
+		assert.Contains(t, toolNames, "bitbucket_get_pipeline_failure")
+		assert.Contains(t, toolNames, "bitbucket_wait_for_ci")
+		assert.Contains(t, toolNames, "bitbucket_repo_variables_list")
+		assert.Contains(t, toolNames, "bitbucket_repo_variables_create")
+		assert.Contains(t, toolNames, "bitbucket_repo_variables_update")
+		assert.Contains(t, toolNames, "bitbucket_repo_variables_delete")
+		assert.Contains(t, toolNames, "bitbucket_deployment_environments_list")
+		assert.Contains(t, toolNames, "bitbucket_deployments_list")
+		assert.Contains(t, toolNames, "bitbucket_deployments_overview")
+		assert.Contains(t, toolNames, "bitbucket_branches_list")
+		assert.Contains(t, toolNames, "bitbucket_branches_get")
+		assert.Contains(t, toolNames, "bitbucket_branches_create")
+		assert.Contains(t, toolNames, "bitbucket_branches_delete")
+		assert.Contains(t, toolNames, "bitbucket_branches_compare")
+		assert.Contains(t, toolNames, "bitbucket_tags_list")
+		assert.Contains(t, toolNames, "bitbucket_tags_create")
+		assert.Contains(t, toolNames, "bitbucket_tags_delete")
+		assert.Contains(t, toolNames, "bitbucket_tags_changes")
+		assert.Contains(t, toolNames, "bitbucket_branch_restrictions_get")
+		assert.Contains(t, toolNames, "bitbucket_list_directory")
+		assert.Contains(t, toolNames, "bitbucket_file_history")
+		assert.Contains(t, toolNames, "bitbucket_blame")
+		assert.Contains(t, toolNames, "bitbucket_commit_files")
+		assert.Contains(t, toolNames, "bitbucket_open_change")
+		assert.Contains(t, toolNames, "bitbucket_search_code")
+		assert.Contains(t, toolNames, "bitbucket_list_workspaces")
+		assert.Contains(t, toolNames, "bitbucket_list_repositories")
+		assert.Contains(t, toolNames, "bitbucket_get_repository")
+		assert.Contains(t, toolNames, "bitbucket_whoami")
+		assert.Contains(t, toolNames, "bitbucket_commit_pull_requests")
+		assert.Contains(t, toolNames, "bitbucket_find_issue_pull_requests")
+		assert.Contains(t, toolNames, "bitbucket_my_review_queue")
+		assert.Contains(t, toolNames, "bitbucket_stale_prs")
+		assert.Contains(t, toolNames, "bitbucket_get_pr_review_bundle")
 	})
 
 	t.Run("handlers", func(t *testing.T) {
@@ -1728,6 +1787,7 @@ func TestBitbucketController(t *testing.T) {
 					Title:       "PR-" + faker.Sentence(),
 					Description: faker.Paragraph(),
 					State:       "MERGED",
+					MergeCommit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()},
 				}
 
 				// Setup mock expectations
@@ -1777,6 +1837,129 @@ func TestBitbucketController(t *testing.T) {
 				assert.Contains(t, content.Text, fmt.Sprintf("Pull request #%d successfully merged", prID))
 				assert.Contains(t, content.Text, "using squash strategy")
 				assert.Contains(t, content.Text, "source branch was closed")
+				assert.Contains(t, content.Text, "Merge commit: "+expectedPR.MergeCommit.Hash)
+			})
+
+			t.Run("should pass compose_message to the service", func(t *testing.T) {
+				deps := makeMockDeps(t)
+				mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+				controller := NewBitbucketController(deps)
+				ctx := t.Context()
+
+				prID := int(faker.RandomUnixTime())%1000000 + 1
+				repoOwner := "workspace-" + faker.Username()
+				repoName := "repo-" + faker.Word()
+				mockService.EXPECT().
+					MergePR(mock.Anything, app.BitbucketMergePRParams{
+						PullRequestID:  prID,
+						RepoOwner:      repoOwner,
+						RepoName:       repoName,
+						MergeStrategy:  "squash",
+						ComposeMessage: true,
+					}).
+					Return(&bitbucket.PullRequest{ID: prID, State: "MERGED"}, nil)
+
+				request := mcp.CallToolRequest{
+					Params: mcp.CallToolParams{
+						Name: "bitbucket_merge_pr",
+						Arguments: map[string]interface{}{
+							"pr_id":           prID,
+							"repo_owner":      repoOwner,
+							"repo_name":       repoName,
+							"merge_strategy":  "squash",
+							"compose_message": true,
+						},
+					},
+				}
+
+				result, err := controller.newMergePRServerTool().Handler(ctx, request)
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.False(t, result.IsError)
+			})
+
+			t.Run("should report merge error as tool error", func(t *testing.T) {
+				deps := makeMockDeps(t)
+				mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+				controller := NewBitbucketController(deps)
+				ctx := t.Context()
+
+				prID := int(faker.RandomUnixTime())%1000000 + 1
+				mergeErr := &bitbucket.MergeError{
+					Reason:  bitbucket.MergeErrorReasonConflict,
+					Message: faker.Sentence(),
+				}
+				mockService.EXPECT().
+					MergePR(mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to merge pull request: %w", mergeErr))
+
+				request := mcp.CallToolRequest{
+					Params: mcp.CallToolParams{
+						Name: "bitbucket_merge_pr",
+						Arguments: map[string]interface{}{
+							"pr_id":      prID,
+							"repo_owner": "workspace-" + faker.Username(),
+							"repo_name":  "repo-" + faker.Word(),
+						},
+					},
+				}
+
+				result, err := controller.newMergePRServerTool().Handler(ctx, request)
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError)
+				content, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Equal(t,
+					fmt.Sprintf("Pull request #%d could not be merged (conflict): %s", prID, mergeErr.Message),
+					content.Text,
+				)
+			})
+
+			t.Run("should report restrictions that blocked the merge", func(t *testing.T) {
+				deps := makeMockDeps(t)
+				mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+				controller := NewBitbucketController(deps)
+				ctx := t.Context()
+
+				prID := int(faker.RandomUnixTime())%1000000 + 1
+				mergeErr := &bitbucket.MergeError{
+					Reason:  bitbucket.MergeErrorReasonRejected,
+					Message: faker.Sentence(),
+					BlockedBy: []string{
+						"require_approvals_to_merge (1 approvals, 2 required)",
+						"require_tasks_to_be_completed (1 unresolved tasks)",
+					},
+				}
+				mockService.EXPECT().
+					MergePR(mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to merge pull request: %w", mergeErr))
+
+				result, err := controller.newMergePRServerTool().Handler(ctx, mcp.CallToolRequest{
+					Params: mcp.CallToolParams{
+						Name: "bitbucket_merge_pr",
+						Arguments: map[string]interface{}{
+							"pr_id":      prID,
+							"repo_owner": "workspace-" + faker.Username(),
+							"repo_name":  "repo-" + faker.Word(),
+						},
+					},
+				})
+
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.True(t, result.IsError)
+				content, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Equal(t,
+					fmt.Sprintf("Pull request #%d could not be merged (rejected): %s", prID, mergeErr.Message)+
+						"\nBlocked by branch restrictions:"+
+						"\n- require_approvals_to_merge (1 approvals, 2 required)"+
+						"\n- require_tasks_to_be_completed (1 unresolved tasks)",
+					content.Text,
+				)
 			})
 
 			t.Run("should handle missing required parameters in MergePR", func(t *testing.T) {
@@ -3456,6 +3639,134 @@ func TestBitbucketController(t *testing.T) {
 			require.True(t, ok)
 			assert.Contains(t, content.Text, "Missing or invalid commit_hash parameter")
 		})
+
+		fileContentArguments := func(params app.BitbucketGetFileContentParams) map[string]interface{} {
+			return map[string]interface{}{
+				"repo_owner":  params.RepoOwner,
+				"repo_name":   params.RepoName,
+				"file_path":   params.Path,
+				"commit_hash": params.Commit,
+				"account":     params.AccountName,
+			}
+		}
+
+		makeFileContentParams := func(path string) app.BitbucketGetFileContentParams {
+			return app.BitbucketGetFileContentParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "workspace-" + faker.Username(),
+				RepoName:    "repo-" + faker.Word(),
+				Commit:      faker.UUIDDigit(),
+				Path:        path,
+			}
+		}
+
+		t.Run("should pass line range and size limit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeFileContentParams("main.go")
+			params.FromLine = 10
+			params.ToLine = 20
+			params.MaxBytes = 2048
+			args := fileContentArguments(params)
+			args["from_line"] = float64(10)
+			args["to_line"] = float64(20)
+			args["max_bytes"] = float64(2048)
+			mockService.EXPECT().GetFileContent(ctx, params).Return(&bitbucket.FileContentResult{
+				Content: "func main() {}\n",
+				Meta: bitbucket.FileContentMeta{
+					Type:       "file",
+					Encoding:   "utf-8",
+					TotalLines: 40,
+					FromLine:   10,
+					ToLine:     12,
+					Truncated:  true,
+				},
+			}, nil)
+
+			result, err := controller.newGetFileContentServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_get_file_content", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"File content for main.go at "+params.RepoOwner+"/"+params.RepoName+" (lines 10-12 of 40, truncated)",
+				summary.Text,
+			)
+			content, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "func main() {}\n", content.Text)
+		})
+
+		t.Run("should return images as image content", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeFileContentParams("logo.png")
+			data := faker.UUIDHyphenated()
+			mockService.EXPECT().GetFileContent(ctx, params).Return(&bitbucket.FileContentResult{
+				Content: data,
+				Meta: bitbucket.FileContentMeta{
+					Size:     100,
+					Type:     "file",
+					Encoding: "base64",
+					MimeType: "image/png",
+					Binary:   true,
+				},
+			}, nil)
+
+			result, err := controller.newGetFileContentServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_get_file_content", Arguments: fileContentArguments(params)},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "(image/png image, 100 bytes)")
+			image, ok := result.Content[1].(mcp.ImageContent)
+			require.True(t, ok)
+			assert.Equal(t, data, image.Data)
+			assert.Equal(t, "image/png", image.MIMEType)
+		})
+
+		t.Run("should omit content of binary files", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeFileContentParams("app.bin")
+			mockService.EXPECT().GetFileContent(ctx, params).Return(&bitbucket.FileContentResult{
+				Meta: bitbucket.FileContentMeta{
+					Size:     4096,
+					Type:     "file",
+					MimeType: "application/octet-stream",
+					Binary:   true,
+				},
+			}, nil)
+
+			result, err := controller.newGetFileContentServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_get_file_content", Arguments: fileContentArguments(params)},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"File content for app.bin at "+params.RepoOwner+"/"+params.RepoName+
+					": content omitted, binary file (application/octet-stream, 4096 bytes)",
+				summary.Text,
+			)
+		})
 	})
 
 	t.Run("bitbucket_get_pr_diff", func(t *testing.T) {
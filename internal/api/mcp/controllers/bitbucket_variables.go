@@ -0,0 +1,348 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/samber/lo"
+)
+
+// maskedVariableValue replaces variable values in logged tool arguments.
+const maskedVariableValue = "********"
+
+// newListRepositoryVariablesServerTool returns a server tool for listing repository pipeline variables.
+func (bc *BitbucketController) newListRepositoryVariablesServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_repo_variables_list",
+		mcp.WithDescription("List Bitbucket Pipelines variables of a repository. Values of secured variables are masked."),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_repo_variables_list request", "params", request.Params)
+
+		params, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		variables, err := bc.bitbucketService.ListRepositoryVariables(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repository variables: %w", err)
+		}
+
+		variablesJSON, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repository variables to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatRepositoryVariablesSummary(variables),
+				},
+				mcp.NewTextContent(string(variablesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newCreateRepositoryVariableServerTool returns a server tool for creating a repository pipeline variable.
+func (bc *BitbucketController) newCreateRepositoryVariableServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_repo_variables_create",
+		mcp.WithDescription("Create a Bitbucket Pipelines variable of a repository"),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("key",
+			mcp.Description("Variable name"),
+			mcp.Required(),
+		),
+		mcp.WithString("value",
+			mcp.Description("Variable value"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("secured",
+			mcp.Description("Whether the value is secured and hidden from logs and the API (optional, defaults to false)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_repo_variables_create request",
+			"arguments", maskVariableValueArgument(request.GetArguments()))
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		key, err := request.RequireString("key")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid key parameter", err), nil
+		}
+
+		value, err := request.RequireString("value")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid value parameter", err), nil
+		}
+
+		variable, err := bc.bitbucketService.CreateRepositoryVariable(ctx, app.BitbucketCreateRepositoryVariableParams{
+			BitbucketRepositoryParams: repoParams,
+			Key:                       key,
+			Value:                     value,
+			Secured:                   request.GetBool("secured", false),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create repository variable: %w", err)
+		}
+
+		return newRepositoryVariableResult("created", variable)
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newUpdateRepositoryVariableServerTool returns a server tool for updating a repository pipeline variable.
+func (bc *BitbucketController) newUpdateRepositoryVariableServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_repo_variables_update",
+		mcp.WithDescription(
+			"Update a Bitbucket Pipelines variable of a repository. "+
+				"The variable is identified by variable_uuid or, when not provided, by key.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("variable_uuid",
+			mcp.Description("UUID of the variable to update (optional, required to rename the variable)"),
+		),
+		mcp.WithString("key",
+			mcp.Description("Variable name"),
+			mcp.Required(),
+		),
+		mcp.WithString("value",
+			mcp.Description("New variable value"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("secured",
+			mcp.Description("Whether the value is secured (optional, keeps the current setting if not specified)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_repo_variables_update request",
+			"arguments", maskVariableValueArgument(request.GetArguments()))
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		key, err := request.RequireString("key")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid key parameter", err), nil
+		}
+
+		value, err := request.RequireString("value")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid value parameter", err), nil
+		}
+
+		params := app.BitbucketUpdateRepositoryVariableParams{
+			BitbucketRepositoryParams: repoParams,
+			VariableUUID:              request.GetString("variable_uuid", ""),
+			Key:                       key,
+			Value:                     value,
+		}
+		if _, ok := request.GetArguments()["secured"]; ok {
+			params.Secured = lo.ToPtr(request.GetBool("secured", false))
+		}
+
+		variable, err := bc.bitbucketService.UpdateRepositoryVariable(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update repository variable: %w", err)
+		}
+
+		return newRepositoryVariableResult("updated", variable)
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newDeleteRepositoryVariableServerTool returns a server tool for deleting a repository pipeline variable.
+func (bc *BitbucketController) newDeleteRepositoryVariableServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_repo_variables_delete",
+		mcp.WithDescription(
+			"Delete a Bitbucket Pipelines variable of a repository identified by variable_uuid or key",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("variable_uuid",
+			mcp.Description("UUID of the variable to delete (optional if key is provided)"),
+		),
+		mcp.WithString("key",
+			mcp.Description("Name of the variable to delete (optional if variable_uuid is provided)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_repo_variables_delete request", "params", request.Params)
+
+		repoParams, errResult := parseRepositoryParams(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		variableUUID := request.GetString("variable_uuid", "")
+		key := request.GetString("key", "")
+		if variableUUID == "" && key == "" {
+			return mcp.NewToolResultError("Either variable_uuid or key parameter must be provided"), nil
+		}
+
+		err := bc.bitbucketService.DeleteRepositoryVariable(ctx, app.BitbucketDeleteRepositoryVariableParams{
+			BitbucketRepositoryParams: repoParams,
+			VariableUUID:              variableUUID,
+			Key:                       key,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete repository variable: %w", err)
+		}
+
+		return mcp.NewToolResultText(
+			fmt.Sprintf("Repository variable %s deleted", lo.CoalesceOrEmpty(key, variableUUID)),
+		), nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// parseRepositoryParams extracts parameters identifying a repository.
+// A tool error result is returned when a required parameter is missing.
+func parseRepositoryParams(request mcp.CallToolRequest) (app.BitbucketRepositoryParams, *mcp.CallToolResult) {
+	repoOwner, err := request.RequireString("repo_owner")
+	if err != nil {
+		return app.BitbucketRepositoryParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err)
+	}
+
+	repoName, err := request.RequireString("repo_name")
+	if err != nil {
+		return app.BitbucketRepositoryParams{},
+			mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err)
+	}
+
+	return app.BitbucketRepositoryParams{
+		AccountName: request.GetString("account", ""),
+		RepoOwner:   repoOwner,
+		RepoName:    repoName,
+	}, nil
+}
+
+// maskVariableValueArgument returns a copy of tool arguments with the variable value masked,
+// so that values of secured variables never reach logs.
+func maskVariableValueArgument(arguments map[string]any) map[string]any {
+	masked := maps.Clone(arguments)
+	if _, ok := masked["value"]; ok {
+		masked["value"] = maskedVariableValue
+	}
+	return masked
+}
+
+// newRepositoryVariableResult renders a created or updated variable as a tool result.
+func newRepositoryVariableResult(action string, variable *bitbucket.PipelineVariable) (*mcp.CallToolResult, error) {
+	variableJSON, err := json.MarshalIndent(variable, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repository variable to JSON: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: fmt.Sprintf("Repository variable %s: %s", action, formatRepositoryVariable(*variable)),
+			},
+			mcp.NewTextContent(string(variableJSON)),
+		},
+	}, nil
+}
+
+// formatRepositoryVariable renders a variable as KEY=VALUE, secured values are already masked by the service.
+func formatRepositoryVariable(variable bitbucket.PipelineVariable) string {
+	line := variable.Key + "=" + variable.Value
+	if variable.Secured {
+		line += " (secured)"
+	}
+	return line + " " + variable.UUID
+}
+
+// formatRepositoryVariablesSummary renders repository variables as human readable text.
+func formatRepositoryVariablesSummary(variables []bitbucket.PipelineVariable) string {
+	if len(variables) == 0 {
+		return "No repository variables found"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d repository variables:", len(variables))
+	for _, variable := range variables {
+		sb.WriteString("\n- " + formatRepositoryVariable(variable))
+	}
+	return sb.String()
+}
@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_RepositoryVariables(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makeRepoParams := func() app.BitbucketRepositoryParams {
+		return app.BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "workspace-" + faker.Username(),
+			RepoName:    "repo-" + faker.Word(),
+		}
+	}
+
+	repoArguments := func(params app.BitbucketRepositoryParams) map[string]interface{} {
+		return map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"account":    params.AccountName,
+		}
+	}
+
+	t.Run("bitbucket_repo_variables_list", func(t *testing.T) {
+		t.Run("should list variables", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := makeRepoParams()
+			variables := []bitbucket.PipelineVariable{
+				{UUID: "{" + faker.UUIDHyphenated() + "}", Key: "PLAIN_" + faker.Word(), Value: faker.Word()},
+				{UUID: "{" + faker.UUIDHyphenated() + "}", Key: "SECRET_" + faker.Word(), Value: "********", Secured: true},
+			}
+			mockService.EXPECT().ListRepositoryVariables(ctx, params).Return(variables, nil)
+
+			result, err := controller.newListRepositoryVariablesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_repo_variables_list",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Found 2 repository variables")
+			assert.Contains(t, summary.Text, variables[0].Key+"="+variables[0].Value)
+			assert.Contains(t, summary.Text, variables[1].Key+"=******** (secured)")
+			jsonContent, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var parsed []bitbucket.PipelineVariable
+			require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+			assert.Equal(t, variables, parsed)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name"} {
+				args := repoArguments(makeRepoParams())
+				delete(args, missing)
+
+				result, err := controller.newListRepositoryVariablesServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_list", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+			params := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().ListRepositoryVariables(ctx, params).Return(nil, expectedErr)
+
+			result, err := controller.newListRepositoryVariablesServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_repo_variables_list",
+					Arguments: repoArguments(params),
+				},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_repo_variables_create", func(t *testing.T) {
+		t.Run("should create variable", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketCreateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Key:                       "KEY_" + faker.Word(),
+				Value:                     faker.Password(),
+				Secured:                   true,
+			}
+			created := &bitbucket.PipelineVariable{
+				UUID:    "{" + faker.UUIDHyphenated() + "}",
+				Key:     params.Key,
+				Value:   "********",
+				Secured: true,
+			}
+			mockService.EXPECT().CreateRepositoryVariable(ctx, params).Return(created, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["key"] = params.Key
+			args["value"] = params.Value
+			args["secured"] = true
+			result, err := controller.newCreateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_create", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t,
+				"Repository variable created: "+params.Key+"=******** (secured) "+created.UUID,
+				summary.Text,
+			)
+			assert.NotContains(t, summary.Text, params.Value)
+		})
+
+		t.Run("should handle missing required parameters", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			for _, missing := range []string{"repo_owner", "repo_name", "key", "value"} {
+				args := repoArguments(makeRepoParams())
+				args["key"] = faker.Word()
+				args["value"] = faker.Word()
+				delete(args, missing)
+
+				result, err := controller.newCreateRepositoryVariableServerTool().Handler(t.Context(), mcp.CallToolRequest{
+					Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_create", Arguments: args},
+				})
+
+				require.NoError(t, err)
+				assert.True(t, result.IsError, "missing %s should produce error result", missing)
+			}
+		})
+	})
+
+	t.Run("should not log variable values", func(t *testing.T) {
+		var logs bytes.Buffer
+		deps := makeMockDeps(t)
+		deps.RootLogger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		value := "secret-" + faker.Password()
+		mockService.EXPECT().CreateRepositoryVariable(ctx, mock.Anything).
+			Return(&bitbucket.PipelineVariable{Key: "TOKEN", Value: "********", Secured: true}, nil)
+		mockService.EXPECT().UpdateRepositoryVariable(ctx, mock.Anything).
+			Return(&bitbucket.PipelineVariable{Key: "TOKEN", Value: "********", Secured: true}, nil)
+
+		args := repoArguments(makeRepoParams())
+		args["key"] = "TOKEN"
+		args["value"] = value
+		args["secured"] = true
+		_, err := controller.newCreateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_create", Arguments: args},
+		})
+		require.NoError(t, err)
+		_, err = controller.newUpdateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_update", Arguments: args},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, logs.String(), "Received bitbucket_repo_variables_create request")
+		assert.Contains(t, logs.String(), "Received bitbucket_repo_variables_update request")
+		assert.Contains(t, logs.String(), "TOKEN")
+		assert.NotContains(t, logs.String(), value)
+		assert.Equal(t, value, args["value"], "arguments of the request must not be modified")
+	})
+
+	t.Run("bitbucket_repo_variables_update", func(t *testing.T) {
+		t.Run("should update variable keeping secured flag when not provided", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Key:                       "KEY_" + faker.Word(),
+				Value:                     faker.Word(),
+			}
+			updated := &bitbucket.PipelineVariable{
+				UUID:  "{" + faker.UUIDHyphenated() + "}",
+				Key:   params.Key,
+				Value: params.Value,
+			}
+			mockService.EXPECT().UpdateRepositoryVariable(ctx, params).Return(updated, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["key"] = params.Key
+			args["value"] = params.Value
+			result, err := controller.newUpdateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_update", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, summary.Text, "Repository variable updated: "+params.Key+"="+params.Value)
+		})
+
+		t.Run("should update variable by UUID with secured flag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				VariableUUID:              "{" + faker.UUIDHyphenated() + "}",
+				Key:                       "KEY_" + faker.Word(),
+				Value:                     faker.Word(),
+				Secured:                   lo.ToPtr(false),
+			}
+			mockService.EXPECT().UpdateRepositoryVariable(ctx, params).Return(&bitbucket.PipelineVariable{
+				UUID: params.VariableUUID,
+				Key:  params.Key,
+			}, nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["variable_uuid"] = params.VariableUUID
+			args["key"] = params.Key
+			args["value"] = params.Value
+			args["secured"] = false
+			result, err := controller.newUpdateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_update", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+		})
+
+		t.Run("should handle service error", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Key:                       "KEY_" + faker.Word(),
+				Value:                     faker.Word(),
+			}
+			expectedErr := errors.New(faker.Sentence())
+			mockService.EXPECT().UpdateRepositoryVariable(ctx, params).Return(nil, expectedErr)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["key"] = params.Key
+			args["value"] = params.Value
+			result, err := controller.newUpdateRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_update", Arguments: args},
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("bitbucket_repo_variables_delete", func(t *testing.T) {
+		t.Run("should delete variable by key", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+			controller := NewBitbucketController(deps)
+			ctx := t.Context()
+
+			params := app.BitbucketDeleteRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Key:                       "KEY_" + faker.Word(),
+			}
+			mockService.EXPECT().DeleteRepositoryVariable(ctx, params).Return(nil)
+
+			args := repoArguments(params.BitbucketRepositoryParams)
+			args["key"] = params.Key
+			result, err := controller.newDeleteRepositoryVariableServerTool().Handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "bitbucket_repo_variables_delete", Arguments: args},
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Repository variable "+params.Key+" deleted", summary.Text)
+		})
+
+		t.Run("should require variable uuid or key", func(t *testing.T) {
+			controller := NewBitbucketController(makeMockDeps(t))
+
+			result, err := controller.newDeleteRepositoryVariableServerTool().Handler(t.Context(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "bitbucket_repo_variables_delete",
+					Arguments: repoArguments(makeRepoParams()),
+				},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	})
+}
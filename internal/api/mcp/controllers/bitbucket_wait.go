@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newWaitForCIServerTool returns a server tool that blocks until a pipeline run or
+// the build statuses of a pull request complete.
+func (bc *BitbucketController) newWaitForCIServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_wait_for_ci",
+		mcp.WithDescription(
+			"Wait until a Bitbucket Pipelines run, or all build statuses of the pull request head commit, complete. "+
+				"Provide either pipeline_uuid or pr_id. Progress notifications are sent while waiting.",
+		),
+		mcp.WithString("repo_owner",
+			mcp.Description("Repository owner (username/workspace)"),
+			mcp.Required(),
+		),
+		mcp.WithString("repo_name",
+			mcp.Description("Repository name (slug)"),
+			mcp.Required(),
+		),
+		mcp.WithString("pipeline_uuid",
+			mcp.Description("Pipeline UUID to wait for (optional)"),
+		),
+		mcp.WithNumber("pr_id",
+			mcp.Description("Pull request ID to wait for build statuses of its head commit (optional)"),
+		),
+		mcp.WithNumber("timeout_seconds",
+			mcp.Description("Maximum time to wait in seconds (optional, defaults to the server configured timeout)"),
+		),
+		mcp.WithString("account",
+			mcp.Description("Atlassian account name to use (optional, uses default if not specified)"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_wait_for_ci request", "params", request.Params)
+
+		repoOwner, err := request.RequireString("repo_owner")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_owner parameter", err), nil
+		}
+
+		repoName, err := request.RequireString("repo_name")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Missing or invalid repo_name parameter", err), nil
+		}
+
+		pipelineUUID := request.GetString("pipeline_uuid", "")
+		prID := request.GetInt("pr_id", 0)
+		if (pipelineUUID != "") == (prID > 0) {
+			return mcp.NewToolResultError("Either pipeline_uuid or pr_id parameter must be provided"), nil
+		}
+
+		account := request.GetString("account", "")
+		timeout := time.Duration(request.GetInt("timeout_seconds", 0)) * time.Second
+		onProgress := bc.newProgressReporter(request)
+
+		var result any
+		var summary string
+		if pipelineUUID != "" {
+			waitResult, waitErr := bc.bitbucketService.WaitForPipeline(ctx, app.BitbucketWaitForPipelineParams{
+				BitbucketPipelineParams: app.BitbucketPipelineParams{
+					AccountName:  account,
+					RepoOwner:    repoOwner,
+					RepoName:     repoName,
+					PipelineUUID: pipelineUUID,
+				},
+				Timeout: timeout,
+			}, onProgress)
+			if waitErr != nil {
+				return nil, fmt.Errorf("failed to wait for pipeline: %w", waitErr)
+			}
+			result = waitResult
+			summary = formatPipelineWaitSummary(waitResult)
+		} else {
+			waitResult, waitErr := bc.bitbucketService.WaitForBuildStatuses(ctx, app.BitbucketWaitForBuildStatusesParams{
+				AccountName:   account,
+				RepoOwner:     repoOwner,
+				RepoName:      repoName,
+				PullRequestID: prID,
+				Timeout:       timeout,
+			}, onProgress)
+			if waitErr != nil {
+				return nil, fmt.Errorf("failed to wait for build statuses: %w", waitErr)
+			}
+			result = waitResult
+			summary = formatBuildStatusesWaitSummary(waitResult)
+		}
+
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal wait result to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: summary,
+				},
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// newProgressReporter returns a function that sends MCP progress notifications for the request.
+// Progress is not reported when the client did not ask for it with a progress token.
+func (bc *BitbucketController) newProgressReporter(request mcp.CallToolRequest) app.WaitProgressFunc {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return func(context.Context, app.WaitProgress) {}
+	}
+	progressToken := request.Params.Meta.ProgressToken
+	return func(ctx context.Context, progress app.WaitProgress) {
+		srv := server.ServerFromContext(ctx)
+		if srv == nil {
+			return
+		}
+		err := srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": progressToken,
+			"progress":      progress.Attempt,
+			"message":       progress.Message,
+		})
+		if err != nil {
+			bc.logger.DebugContext(ctx, "Failed to send progress notification", "error", err)
+		}
+	}
+}
+
+func formatPipelineWaitSummary(result *app.PipelineWaitResult) string {
+	if result.TimedOut {
+		return "Timed out waiting for pipeline " + formatPipelineLine(*result.Pipeline)
+	}
+	return "Pipeline " + formatPipelineLine(*result.Pipeline) + " completed"
+}
+
+func formatBuildStatusesWaitSummary(result *app.BuildStatusesWaitResult) string {
+	prefix := "Builds of commit " + result.Commit + " completed"
+	if result.TimedOut {
+		prefix = "Timed out waiting for builds of commit " + result.Commit
+	}
+	return prefix + "\n" + formatBuildStatusesSummary(result.Statuses)
+}
@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClientSession struct {
+	sessionID     string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *fakeClientSession) Initialize() {}
+
+func (s *fakeClientSession) Initialized() bool { return true }
+
+func (s *fakeClientSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *fakeClientSession) SessionID() string { return s.sessionID }
+
+func TestBitbucketController_WaitForCI(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	makePipelineParams := func() app.BitbucketWaitForPipelineParams {
+		return app.BitbucketWaitForPipelineParams{
+			BitbucketPipelineParams: app.BitbucketPipelineParams{
+				AccountName:  "account-" + faker.Username(),
+				RepoOwner:    "workspace-" + faker.Username(),
+				RepoName:     "repo-" + faker.Word(),
+				PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+			},
+			Timeout: 90 * time.Second,
+		}
+	}
+
+	makeBuildStatusesParams := func() app.BitbucketWaitForBuildStatusesParams {
+		return app.BitbucketWaitForBuildStatusesParams{
+			AccountName:   "account-" + faker.Username(),
+			RepoOwner:     "workspace-" + faker.Username(),
+			RepoName:      "repo-" + faker.Word(),
+			PullRequestID: 1 + rand.IntN(1000),
+		}
+	}
+
+	makeRequest := func(arguments map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "bitbucket_wait_for_ci",
+				Arguments: arguments,
+			},
+		}
+	}
+
+	t.Run("should wait for pipeline", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makePipelineParams()
+
+		waitResult := &app.PipelineWaitResult{
+			PipelineRun: app.PipelineRun{
+				Pipeline: &bitbucket.Pipeline{
+					UUID:        params.PipelineUUID,
+					BuildNumber: 12,
+					State: &bitbucket.PipelineState{
+						Name:   bitbucket.PipelineStateCompleted,
+						Result: &bitbucket.PipelineStateResult{Name: bitbucket.PipelineResultSuccessful},
+					},
+				},
+			},
+		}
+		mockService.EXPECT().WaitForPipeline(ctx, params, mock.Anything).Return(waitResult, nil)
+
+		result, err := controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner":      params.RepoOwner,
+			"repo_name":       params.RepoName,
+			"pipeline_uuid":   params.PipelineUUID,
+			"timeout_seconds": float64(90),
+			"account":         params.AccountName,
+		}))
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text, "Pipeline #12")
+		assert.Contains(t, summary.Text, "completed")
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.PipelineWaitResult
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *waitResult, parsed)
+	})
+
+	t.Run("should wait for build statuses of pull request", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makeBuildStatusesParams()
+
+		waitResult := &app.BuildStatusesWaitResult{
+			Commit: faker.UUIDDigit(),
+			Statuses: []bitbucket.CommitStatus{
+				{Key: "build-" + faker.Word(), State: bitbucket.CommitStatusStateInProgress},
+			},
+			TimedOut: true,
+		}
+		mockService.EXPECT().WaitForBuildStatuses(ctx, params, mock.Anything).Return(waitResult, nil)
+
+		result, err := controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"pr_id":      float64(params.PullRequestID),
+			"account":    params.AccountName,
+		}))
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, summary.Text, "Timed out waiting for builds of commit "+waitResult.Commit)
+		assert.Contains(t, summary.Text, waitResult.Statuses[0].Key)
+	})
+
+	t.Run("should send progress notifications", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		params := makePipelineParams()
+		params.Timeout = 0
+		progressMessage := faker.Sentence()
+
+		mockService.EXPECT().WaitForPipeline(mock.Anything, params, mock.Anything).RunAndReturn(
+			func(
+				ctx context.Context,
+				_ app.BitbucketWaitForPipelineParams,
+				onProgress app.WaitProgressFunc,
+			) (*app.PipelineWaitResult, error) {
+				onProgress(ctx, app.WaitProgress{Attempt: 1, Message: progressMessage})
+				return &app.PipelineWaitResult{
+					PipelineRun: app.PipelineRun{Pipeline: &bitbucket.Pipeline{UUID: params.PipelineUUID}},
+				}, nil
+			})
+
+		srv := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+		srv.AddTools(controller.newWaitForCIServerTool())
+		session := &fakeClientSession{
+			sessionID:     faker.UUIDHyphenated(),
+			notifications: make(chan mcp.JSONRPCNotification, 1),
+		}
+		ctx := srv.WithContext(t.Context(), session)
+		progressToken := faker.UUIDHyphenated()
+		message := fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {
+				"name": "bitbucket_wait_for_ci",
+				"arguments": {
+					"repo_owner": %q,
+					"repo_name": %q,
+					"pipeline_uuid": %q,
+					"account": %q
+				},
+				"_meta": {"progressToken": %q}
+			}
+		}`, params.RepoOwner, params.RepoName, params.PipelineUUID, params.AccountName, progressToken)
+
+		response := srv.HandleMessage(ctx, []byte(message))
+
+		require.IsType(t, mcp.JSONRPCResponse{}, response)
+		require.Len(t, session.notifications, 1)
+		notification := <-session.notifications
+		assert.Equal(t, "notifications/progress", notification.Method)
+		assert.Equal(t, progressToken, notification.Params.AdditionalFields["progressToken"])
+		assert.Equal(t, 1, notification.Params.AdditionalFields["progress"])
+		assert.Equal(t, progressMessage, notification.Params.AdditionalFields["message"])
+	})
+
+	t.Run("should not report progress without progress token", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makePipelineParams()
+		params.Timeout = 0
+
+		mockService.EXPECT().WaitForPipeline(ctx, params, mock.Anything).RunAndReturn(
+			func(
+				ctx context.Context,
+				_ app.BitbucketWaitForPipelineParams,
+				onProgress app.WaitProgressFunc,
+			) (*app.PipelineWaitResult, error) {
+				onProgress(ctx, app.WaitProgress{Attempt: 1, Message: faker.Sentence()})
+				return &app.PipelineWaitResult{
+					PipelineRun: app.PipelineRun{Pipeline: &bitbucket.Pipeline{UUID: params.PipelineUUID}},
+				}, nil
+			})
+
+		result, err := controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner":    params.RepoOwner,
+			"repo_name":     params.RepoName,
+			"pipeline_uuid": params.PipelineUUID,
+			"account":       params.AccountName,
+		}))
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("should require exactly one wait target", func(t *testing.T) {
+		controller := NewBitbucketController(makeMockDeps(t))
+		ctx := t.Context()
+
+		result, err := controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner": "owner-" + faker.Username(),
+			"repo_name":  "repo-" + faker.Word(),
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+
+		result, err = controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner":    "owner-" + faker.Username(),
+			"repo_name":     "repo-" + faker.Word(),
+			"pipeline_uuid": faker.UUIDHyphenated(),
+			"pr_id":         float64(1),
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+		params := makeBuildStatusesParams()
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().WaitForBuildStatuses(ctx, params, mock.Anything).Return(nil, expectedErr)
+
+		result, err := controller.newWaitForCIServerTool().Handler(ctx, makeRequest(map[string]interface{}{
+			"repo_owner": params.RepoOwner,
+			"repo_name":  params.RepoName,
+			"pr_id":      float64(params.PullRequestID),
+			"account":    params.AccountName,
+		}))
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newWhoAmIServerTool returns a server tool for resolving identities of configured accounts.
+func (bc *BitbucketController) newWhoAmIServerTool() server.ServerTool {
+	tool := mcp.NewTool(
+		"bitbucket_whoami",
+		mcp.WithDescription("Show the Bitbucket user, token scopes and accessible workspaces of every configured "+
+			"account. Use it to choose the value of the account parameter of other tools."),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		bc.logger.Debug("Received bitbucket_whoami request", "params", request.Params)
+
+		identities, err := bc.bitbucketService.WhoAmI(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve identities: %w", err)
+		}
+
+		identitiesJSON, err := json.MarshalIndent(identities, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal identities to JSON: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: formatIdentitiesSummary(identities),
+				},
+				mcp.NewTextContent(string(identitiesJSON)),
+			},
+		}, nil
+	}
+
+	return server.ServerTool{
+		Tool:    tool,
+		Handler: handler,
+	}
+}
+
+// formatIdentitiesSummary renders identities of configured accounts as human readable text.
+func formatIdentitiesSummary(identities *app.BitbucketIdentities) string {
+	if len(identities.Accounts) == 0 {
+		return "No accounts with Bitbucket credentials configured"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Bitbucket accounts (%d):", len(identities.Accounts))
+	for _, identity := range identities.Accounts {
+		sb.WriteString("\n- " + identity.Account)
+		if identity.Default {
+			sb.WriteString(" (default)")
+		}
+		if identity.User != nil {
+			fmt.Fprintf(&sb, ": %s", identity.User.DisplayName)
+			if identity.User.Nickname != "" {
+				fmt.Fprintf(&sb, " (%s)", identity.User.Nickname)
+			}
+			if identity.User.UUID != "" {
+				sb.WriteString(" " + identity.User.UUID)
+			}
+		}
+		if len(identity.Scopes) > 0 {
+			sb.WriteString("\n  Scopes: " + strings.Join(identity.Scopes, ", "))
+		}
+		if len(identity.Workspaces) > 0 {
+			workspaces := make([]string, 0, len(identity.Workspaces))
+			for _, membership := range identity.Workspaces {
+				if membership.Workspace != nil {
+					workspaces = append(workspaces, fmt.Sprintf("%s (%s)", membership.Workspace.Slug, membership.Permission))
+				}
+			}
+			sb.WriteString("\n  Workspaces: " + strings.Join(workspaces, ", "))
+			if identity.WorkspacesTruncated {
+				sb.WriteString(", ...")
+			}
+		}
+		if identity.Error != "" {
+			sb.WriteString("\n  Error: " + identity.Error)
+		}
+	}
+	return sb.String()
+}
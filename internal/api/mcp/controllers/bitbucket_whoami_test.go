@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/app"
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketController_WhoAmI(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketControllerDeps {
+		return BitbucketControllerDeps{
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			BitbucketService: NewMockbitbucketService(t),
+		}
+	}
+
+	t.Run("should show identities of accounts", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		identities := &app.BitbucketIdentities{Accounts: []app.BitbucketIdentity{
+			{
+				Account: "personal",
+				Default: true,
+				User:    &bitbucket.Account{DisplayName: "Jane Doe", Nickname: "jane", UUID: "{u1}"},
+				Scopes:  []string{"pullrequest:write", "repository"},
+				Workspaces: []bitbucket.WorkspaceMembership{
+					{Permission: "owner", Workspace: &bitbucket.Workspace{Slug: "jane"}},
+					{Permission: "member", Workspace: &bitbucket.Workspace{Slug: "acme"}},
+				},
+				WorkspacesTruncated: true,
+			},
+			{Account: "ci-bot", Error: "failed to get user: unauthorized"},
+		}}
+		mockService.EXPECT().WhoAmI(ctx).Return(identities, nil)
+
+		result, err := controller.newWhoAmIServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_whoami"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		summary, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Bitbucket accounts (2):"+
+			"\n- personal (default): Jane Doe (jane) {u1}"+
+			"\n  Scopes: pullrequest:write, repository"+
+			"\n  Workspaces: jane (owner), acme (member), ..."+
+			"\n- ci-bot"+
+			"\n  Error: failed to get user: unauthorized",
+			summary.Text,
+		)
+		jsonContent, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var parsed app.BitbucketIdentities
+		require.NoError(t, json.Unmarshal([]byte(jsonContent.Text), &parsed))
+		assert.Equal(t, *identities, parsed)
+	})
+
+	t.Run("should handle service error", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockService := mocks.GetMock[*MockbitbucketService](t, deps.BitbucketService)
+		controller := NewBitbucketController(deps)
+		ctx := t.Context()
+
+		expectedErr := errors.New(faker.Sentence())
+		mockService.EXPECT().WhoAmI(ctx).Return(nil, expectedErr)
+
+		result, err := controller.newWhoAmIServerTool().Handler(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "bitbucket_whoami"},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+}
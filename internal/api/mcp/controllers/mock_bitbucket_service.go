@@ -149,28 +149,28 @@ func (_c *MockbitbucketService_ApprovePR_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// CreatePR provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) CreatePR(ctx context.Context, params app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error) {
+// BlameFileLines provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) BlameFileLines(ctx context.Context, params app.BitbucketBlameParams) (*app.FileBlame, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePR")
+		panic("no return value specified for BlameFileLines")
 	}
 
-	var r0 *bitbucket.PullRequest
+	var r0 *app.FileBlame
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBlameParams) (*app.FileBlame, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreatePRParams) *bitbucket.PullRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBlameParams) *app.FileBlame); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequest)
+			r0 = ret.Get(0).(*app.FileBlame)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreatePRParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketBlameParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -179,57 +179,57 @@ func (_m *MockbitbucketService) CreatePR(ctx context.Context, params app.Bitbuck
 	return r0, r1
 }
 
-// MockbitbucketService_CreatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePR'
-type MockbitbucketService_CreatePR_Call struct {
+// MockbitbucketService_BlameFileLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlameFileLines'
+type MockbitbucketService_BlameFileLines_Call struct {
 	*mock.Call
 }
 
-// CreatePR is a helper method to define mock.On call
+// BlameFileLines is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketCreatePRParams
-func (_e *MockbitbucketService_Expecter) CreatePR(ctx interface{}, params interface{}) *MockbitbucketService_CreatePR_Call {
-	return &MockbitbucketService_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, params)}
+//   - params app.BitbucketBlameParams
+func (_e *MockbitbucketService_Expecter) BlameFileLines(ctx interface{}, params interface{}) *MockbitbucketService_BlameFileLines_Call {
+	return &MockbitbucketService_BlameFileLines_Call{Call: _e.mock.On("BlameFileLines", ctx, params)}
 }
 
-func (_c *MockbitbucketService_CreatePR_Call) Run(run func(ctx context.Context, params app.BitbucketCreatePRParams)) *MockbitbucketService_CreatePR_Call {
+func (_c *MockbitbucketService_BlameFileLines_Call) Run(run func(ctx context.Context, params app.BitbucketBlameParams)) *MockbitbucketService_BlameFileLines_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketCreatePRParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketBlameParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_CreatePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_CreatePR_Call {
+func (_c *MockbitbucketService_BlameFileLines_Call) Return(_a0 *app.FileBlame, _a1 error) *MockbitbucketService_BlameFileLines_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_CreatePR_Call) RunAndReturn(run func(context.Context, app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_CreatePR_Call {
+func (_c *MockbitbucketService_BlameFileLines_Call) RunAndReturn(run func(context.Context, app.BitbucketBlameParams) (*app.FileBlame, error)) *MockbitbucketService_BlameFileLines_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateTask provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) CreateTask(ctx context.Context, params app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error) {
+// CheckPRMergeable provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CheckPRMergeable(ctx context.Context, params app.BitbucketCheckPRMergeableParams) (*app.PRMergeCheckResult, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTask")
+		panic("no return value specified for CheckPRMergeable")
 	}
 
-	var r0 *bitbucket.PullRequestCommentTask
+	var r0 *app.PRMergeCheckResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCheckPRMergeableParams) (*app.PRMergeCheckResult, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTaskParams) *bitbucket.PullRequestCommentTask); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCheckPRMergeableParams) *app.PRMergeCheckResult); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequestCommentTask)
+			r0 = ret.Get(0).(*app.PRMergeCheckResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreateTaskParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCheckPRMergeableParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -238,57 +238,57 @@ func (_m *MockbitbucketService) CreateTask(ctx context.Context, params app.Bitbu
 	return r0, r1
 }
 
-// MockbitbucketService_CreateTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTask'
-type MockbitbucketService_CreateTask_Call struct {
+// MockbitbucketService_CheckPRMergeable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPRMergeable'
+type MockbitbucketService_CheckPRMergeable_Call struct {
 	*mock.Call
 }
 
-// CreateTask is a helper method to define mock.On call
+// CheckPRMergeable is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketCreateTaskParams
-func (_e *MockbitbucketService_Expecter) CreateTask(ctx interface{}, params interface{}) *MockbitbucketService_CreateTask_Call {
-	return &MockbitbucketService_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, params)}
+//   - params app.BitbucketCheckPRMergeableParams
+func (_e *MockbitbucketService_Expecter) CheckPRMergeable(ctx interface{}, params interface{}) *MockbitbucketService_CheckPRMergeable_Call {
+	return &MockbitbucketService_CheckPRMergeable_Call{Call: _e.mock.On("CheckPRMergeable", ctx, params)}
 }
 
-func (_c *MockbitbucketService_CreateTask_Call) Run(run func(ctx context.Context, params app.BitbucketCreateTaskParams)) *MockbitbucketService_CreateTask_Call {
+func (_c *MockbitbucketService_CheckPRMergeable_Call) Run(run func(ctx context.Context, params app.BitbucketCheckPRMergeableParams)) *MockbitbucketService_CheckPRMergeable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketCreateTaskParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCheckPRMergeableParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_CreateTask_Call) Return(_a0 *bitbucket.PullRequestCommentTask, _a1 error) *MockbitbucketService_CreateTask_Call {
+func (_c *MockbitbucketService_CheckPRMergeable_Call) Return(_a0 *app.PRMergeCheckResult, _a1 error) *MockbitbucketService_CheckPRMergeable_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_CreateTask_Call) RunAndReturn(run func(context.Context, app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error)) *MockbitbucketService_CreateTask_Call {
+func (_c *MockbitbucketService_CheckPRMergeable_Call) RunAndReturn(run func(context.Context, app.BitbucketCheckPRMergeableParams) (*app.PRMergeCheckResult, error)) *MockbitbucketService_CheckPRMergeable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileContent provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) GetFileContent(ctx context.Context, params app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error) {
+// CommitFiles provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CommitFiles(ctx context.Context, params app.BitbucketCommitFilesParams) (*app.CommittedFiles, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileContent")
+		panic("no return value specified for CommitFiles")
 	}
 
-	var r0 *bitbucket.FileContentResult
+	var r0 *app.CommittedFiles
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCommitFilesParams) (*app.CommittedFiles, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetFileContentParams) *bitbucket.FileContentResult); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCommitFilesParams) *app.CommittedFiles); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.FileContentResult)
+			r0 = ret.Get(0).(*app.CommittedFiles)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetFileContentParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCommitFilesParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -297,55 +297,57 @@ func (_m *MockbitbucketService) GetFileContent(ctx context.Context, params app.B
 	return r0, r1
 }
 
-// MockbitbucketService_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
-type MockbitbucketService_GetFileContent_Call struct {
+// MockbitbucketService_CommitFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitFiles'
+type MockbitbucketService_CommitFiles_Call struct {
 	*mock.Call
 }
 
-// GetFileContent is a helper method to define mock.On call
+// CommitFiles is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketGetFileContentParams
-func (_e *MockbitbucketService_Expecter) GetFileContent(ctx interface{}, params interface{}) *MockbitbucketService_GetFileContent_Call {
-	return &MockbitbucketService_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, params)}
+//   - params app.BitbucketCommitFilesParams
+func (_e *MockbitbucketService_Expecter) CommitFiles(ctx interface{}, params interface{}) *MockbitbucketService_CommitFiles_Call {
+	return &MockbitbucketService_CommitFiles_Call{Call: _e.mock.On("CommitFiles", ctx, params)}
 }
 
-func (_c *MockbitbucketService_GetFileContent_Call) Run(run func(ctx context.Context, params app.BitbucketGetFileContentParams)) *MockbitbucketService_GetFileContent_Call {
+func (_c *MockbitbucketService_CommitFiles_Call) Run(run func(ctx context.Context, params app.BitbucketCommitFilesParams)) *MockbitbucketService_CommitFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketGetFileContentParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCommitFilesParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_GetFileContent_Call) Return(_a0 *bitbucket.FileContentResult, _a1 error) *MockbitbucketService_GetFileContent_Call {
+func (_c *MockbitbucketService_CommitFiles_Call) Return(_a0 *app.CommittedFiles, _a1 error) *MockbitbucketService_CommitFiles_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_GetFileContent_Call) RunAndReturn(run func(context.Context, app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error)) *MockbitbucketService_GetFileContent_Call {
+func (_c *MockbitbucketService_CommitFiles_Call) RunAndReturn(run func(context.Context, app.BitbucketCommitFilesParams) (*app.CommittedFiles, error)) *MockbitbucketService_CommitFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPRDiff provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) GetPRDiff(ctx context.Context, params app.BitbucketGetPRDiffParams) (string, error) {
+// CompareBranches provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CompareBranches(ctx context.Context, params app.BitbucketCompareBranchesParams) (*app.BranchComparison, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPRDiff")
+		panic("no return value specified for CompareBranches")
 	}
 
-	var r0 string
+	var r0 *app.BranchComparison
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffParams) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCompareBranchesParams) (*app.BranchComparison, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffParams) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCompareBranchesParams) *app.BranchComparison); ok {
 		r0 = rf(ctx, params)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.BranchComparison)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetPRDiffParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCompareBranchesParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -354,57 +356,57 @@ func (_m *MockbitbucketService) GetPRDiff(ctx context.Context, params app.Bitbuc
 	return r0, r1
 }
 
-// MockbitbucketService_GetPRDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiff'
-type MockbitbucketService_GetPRDiff_Call struct {
+// MockbitbucketService_CompareBranches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareBranches'
+type MockbitbucketService_CompareBranches_Call struct {
 	*mock.Call
 }
 
-// GetPRDiff is a helper method to define mock.On call
+// CompareBranches is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketGetPRDiffParams
-func (_e *MockbitbucketService_Expecter) GetPRDiff(ctx interface{}, params interface{}) *MockbitbucketService_GetPRDiff_Call {
-	return &MockbitbucketService_GetPRDiff_Call{Call: _e.mock.On("GetPRDiff", ctx, params)}
+//   - params app.BitbucketCompareBranchesParams
+func (_e *MockbitbucketService_Expecter) CompareBranches(ctx interface{}, params interface{}) *MockbitbucketService_CompareBranches_Call {
+	return &MockbitbucketService_CompareBranches_Call{Call: _e.mock.On("CompareBranches", ctx, params)}
 }
 
-func (_c *MockbitbucketService_GetPRDiff_Call) Run(run func(ctx context.Context, params app.BitbucketGetPRDiffParams)) *MockbitbucketService_GetPRDiff_Call {
+func (_c *MockbitbucketService_CompareBranches_Call) Run(run func(ctx context.Context, params app.BitbucketCompareBranchesParams)) *MockbitbucketService_CompareBranches_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketGetPRDiffParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCompareBranchesParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_GetPRDiff_Call) Return(_a0 string, _a1 error) *MockbitbucketService_GetPRDiff_Call {
+func (_c *MockbitbucketService_CompareBranches_Call) Return(_a0 *app.BranchComparison, _a1 error) *MockbitbucketService_CompareBranches_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_GetPRDiff_Call) RunAndReturn(run func(context.Context, app.BitbucketGetPRDiffParams) (string, error)) *MockbitbucketService_GetPRDiff_Call {
+func (_c *MockbitbucketService_CompareBranches_Call) RunAndReturn(run func(context.Context, app.BitbucketCompareBranchesParams) (*app.BranchComparison, error)) *MockbitbucketService_CompareBranches_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPRDiffStat provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) GetPRDiffStat(ctx context.Context, params app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error) {
+// CreateBranch provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CreateBranch(ctx context.Context, params app.BitbucketCreateBranchParams) (*bitbucket.Branch, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPRDiffStat")
+		panic("no return value specified for CreateBranch")
 	}
 
-	var r0 *app.PaginatedDiffStat
+	var r0 *bitbucket.Branch
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateBranchParams) (*bitbucket.Branch, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffStatParams) *app.PaginatedDiffStat); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateBranchParams) *bitbucket.Branch); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*app.PaginatedDiffStat)
+			r0 = ret.Get(0).(*bitbucket.Branch)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetPRDiffStatParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreateBranchParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -413,57 +415,57 @@ func (_m *MockbitbucketService) GetPRDiffStat(ctx context.Context, params app.Bi
 	return r0, r1
 }
 
-// MockbitbucketService_GetPRDiffStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiffStat'
-type MockbitbucketService_GetPRDiffStat_Call struct {
+// MockbitbucketService_CreateBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBranch'
+type MockbitbucketService_CreateBranch_Call struct {
 	*mock.Call
 }
 
-// GetPRDiffStat is a helper method to define mock.On call
+// CreateBranch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketGetPRDiffStatParams
-func (_e *MockbitbucketService_Expecter) GetPRDiffStat(ctx interface{}, params interface{}) *MockbitbucketService_GetPRDiffStat_Call {
-	return &MockbitbucketService_GetPRDiffStat_Call{Call: _e.mock.On("GetPRDiffStat", ctx, params)}
+//   - params app.BitbucketCreateBranchParams
+func (_e *MockbitbucketService_Expecter) CreateBranch(ctx interface{}, params interface{}) *MockbitbucketService_CreateBranch_Call {
+	return &MockbitbucketService_CreateBranch_Call{Call: _e.mock.On("CreateBranch", ctx, params)}
 }
 
-func (_c *MockbitbucketService_GetPRDiffStat_Call) Run(run func(ctx context.Context, params app.BitbucketGetPRDiffStatParams)) *MockbitbucketService_GetPRDiffStat_Call {
+func (_c *MockbitbucketService_CreateBranch_Call) Run(run func(ctx context.Context, params app.BitbucketCreateBranchParams)) *MockbitbucketService_CreateBranch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketGetPRDiffStatParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCreateBranchParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_GetPRDiffStat_Call) Return(_a0 *app.PaginatedDiffStat, _a1 error) *MockbitbucketService_GetPRDiffStat_Call {
+func (_c *MockbitbucketService_CreateBranch_Call) Return(_a0 *bitbucket.Branch, _a1 error) *MockbitbucketService_CreateBranch_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_GetPRDiffStat_Call) RunAndReturn(run func(context.Context, app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error)) *MockbitbucketService_GetPRDiffStat_Call {
+func (_c *MockbitbucketService_CreateBranch_Call) RunAndReturn(run func(context.Context, app.BitbucketCreateBranchParams) (*bitbucket.Branch, error)) *MockbitbucketService_CreateBranch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPRComments provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) ListPRComments(ctx context.Context, params app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error) {
+// CreatePR provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CreatePR(ctx context.Context, params app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPRComments")
+		panic("no return value specified for CreatePR")
 	}
 
-	var r0 *app.BitbucketListPRCommentsResult
+	var r0 *bitbucket.PullRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPRCommentsParams) *app.BitbucketListPRCommentsResult); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreatePRParams) *bitbucket.PullRequest); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*app.BitbucketListPRCommentsResult)
+			r0 = ret.Get(0).(*bitbucket.PullRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListPRCommentsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreatePRParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -472,57 +474,57 @@ func (_m *MockbitbucketService) ListPRComments(ctx context.Context, params app.B
 	return r0, r1
 }
 
-// MockbitbucketService_ListPRComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRComments'
-type MockbitbucketService_ListPRComments_Call struct {
+// MockbitbucketService_CreatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePR'
+type MockbitbucketService_CreatePR_Call struct {
 	*mock.Call
 }
 
-// ListPRComments is a helper method to define mock.On call
+// CreatePR is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketListPRCommentsParams
-func (_e *MockbitbucketService_Expecter) ListPRComments(ctx interface{}, params interface{}) *MockbitbucketService_ListPRComments_Call {
-	return &MockbitbucketService_ListPRComments_Call{Call: _e.mock.On("ListPRComments", ctx, params)}
+//   - params app.BitbucketCreatePRParams
+func (_e *MockbitbucketService_Expecter) CreatePR(ctx interface{}, params interface{}) *MockbitbucketService_CreatePR_Call {
+	return &MockbitbucketService_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, params)}
 }
 
-func (_c *MockbitbucketService_ListPRComments_Call) Run(run func(ctx context.Context, params app.BitbucketListPRCommentsParams)) *MockbitbucketService_ListPRComments_Call {
+func (_c *MockbitbucketService_CreatePR_Call) Run(run func(ctx context.Context, params app.BitbucketCreatePRParams)) *MockbitbucketService_CreatePR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketListPRCommentsParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCreatePRParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_ListPRComments_Call) Return(_a0 *app.BitbucketListPRCommentsResult, _a1 error) *MockbitbucketService_ListPRComments_Call {
+func (_c *MockbitbucketService_CreatePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_CreatePR_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_ListPRComments_Call) RunAndReturn(run func(context.Context, app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error)) *MockbitbucketService_ListPRComments_Call {
+func (_c *MockbitbucketService_CreatePR_Call) RunAndReturn(run func(context.Context, app.BitbucketCreatePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_CreatePR_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTasks provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) ListTasks(ctx context.Context, params app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error) {
+// CreateRepositoryVariable provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CreateRepositoryVariable(ctx context.Context, params app.BitbucketCreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListTasks")
+		panic("no return value specified for CreateRepositoryVariable")
 	}
 
-	var r0 *bitbucket.PaginatedTasks
+	var r0 *bitbucket.PipelineVariable
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTasksParams) *bitbucket.PaginatedTasks); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateRepositoryVariableParams) *bitbucket.PipelineVariable); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PaginatedTasks)
+			r0 = ret.Get(0).(*bitbucket.PipelineVariable)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListTasksParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreateRepositoryVariableParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -531,57 +533,57 @@ func (_m *MockbitbucketService) ListTasks(ctx context.Context, params app.Bitbuc
 	return r0, r1
 }
 
-// MockbitbucketService_ListTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTasks'
-type MockbitbucketService_ListTasks_Call struct {
+// MockbitbucketService_CreateRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRepositoryVariable'
+type MockbitbucketService_CreateRepositoryVariable_Call struct {
 	*mock.Call
 }
 
-// ListTasks is a helper method to define mock.On call
+// CreateRepositoryVariable is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketListTasksParams
-func (_e *MockbitbucketService_Expecter) ListTasks(ctx interface{}, params interface{}) *MockbitbucketService_ListTasks_Call {
-	return &MockbitbucketService_ListTasks_Call{Call: _e.mock.On("ListTasks", ctx, params)}
+//   - params app.BitbucketCreateRepositoryVariableParams
+func (_e *MockbitbucketService_Expecter) CreateRepositoryVariable(ctx interface{}, params interface{}) *MockbitbucketService_CreateRepositoryVariable_Call {
+	return &MockbitbucketService_CreateRepositoryVariable_Call{Call: _e.mock.On("CreateRepositoryVariable", ctx, params)}
 }
 
-func (_c *MockbitbucketService_ListTasks_Call) Run(run func(ctx context.Context, params app.BitbucketListTasksParams)) *MockbitbucketService_ListTasks_Call {
+func (_c *MockbitbucketService_CreateRepositoryVariable_Call) Run(run func(ctx context.Context, params app.BitbucketCreateRepositoryVariableParams)) *MockbitbucketService_CreateRepositoryVariable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketListTasksParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCreateRepositoryVariableParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_ListTasks_Call) Return(_a0 *bitbucket.PaginatedTasks, _a1 error) *MockbitbucketService_ListTasks_Call {
+func (_c *MockbitbucketService_CreateRepositoryVariable_Call) Return(_a0 *bitbucket.PipelineVariable, _a1 error) *MockbitbucketService_CreateRepositoryVariable_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_ListTasks_Call) RunAndReturn(run func(context.Context, app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error)) *MockbitbucketService_ListTasks_Call {
+func (_c *MockbitbucketService_CreateRepositoryVariable_Call) RunAndReturn(run func(context.Context, app.BitbucketCreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)) *MockbitbucketService_CreateRepositoryVariable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MergePR provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) MergePR(ctx context.Context, params app.BitbucketMergePRParams) (*bitbucket.PullRequest, error) {
+// CreateTag provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CreateTag(ctx context.Context, params app.BitbucketCreateTagParams) (*bitbucket.Tag, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MergePR")
+		panic("no return value specified for CreateTag")
 	}
 
-	var r0 *bitbucket.PullRequest
+	var r0 *bitbucket.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketMergePRParams) (*bitbucket.PullRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTagParams) (*bitbucket.Tag, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketMergePRParams) *bitbucket.PullRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTagParams) *bitbucket.Tag); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequest)
+			r0 = ret.Get(0).(*bitbucket.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketMergePRParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreateTagParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -590,57 +592,57 @@ func (_m *MockbitbucketService) MergePR(ctx context.Context, params app.Bitbucke
 	return r0, r1
 }
 
-// MockbitbucketService_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
-type MockbitbucketService_MergePR_Call struct {
+// MockbitbucketService_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type MockbitbucketService_CreateTag_Call struct {
 	*mock.Call
 }
 
-// MergePR is a helper method to define mock.On call
+// CreateTag is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketMergePRParams
-func (_e *MockbitbucketService_Expecter) MergePR(ctx interface{}, params interface{}) *MockbitbucketService_MergePR_Call {
-	return &MockbitbucketService_MergePR_Call{Call: _e.mock.On("MergePR", ctx, params)}
+//   - params app.BitbucketCreateTagParams
+func (_e *MockbitbucketService_Expecter) CreateTag(ctx interface{}, params interface{}) *MockbitbucketService_CreateTag_Call {
+	return &MockbitbucketService_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, params)}
 }
 
-func (_c *MockbitbucketService_MergePR_Call) Run(run func(ctx context.Context, params app.BitbucketMergePRParams)) *MockbitbucketService_MergePR_Call {
+func (_c *MockbitbucketService_CreateTag_Call) Run(run func(ctx context.Context, params app.BitbucketCreateTagParams)) *MockbitbucketService_CreateTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketMergePRParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCreateTagParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_MergePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_MergePR_Call {
+func (_c *MockbitbucketService_CreateTag_Call) Return(_a0 *bitbucket.Tag, _a1 error) *MockbitbucketService_CreateTag_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_MergePR_Call) RunAndReturn(run func(context.Context, app.BitbucketMergePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_MergePR_Call {
+func (_c *MockbitbucketService_CreateTag_Call) RunAndReturn(run func(context.Context, app.BitbucketCreateTagParams) (*bitbucket.Tag, error)) *MockbitbucketService_CreateTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReadPR provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) ReadPR(ctx context.Context, params app.BitbucketReadPRParams) (*bitbucket.PullRequest, error) {
+// CreateTask provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) CreateTask(ctx context.Context, params app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReadPR")
+		panic("no return value specified for CreateTask")
 	}
 
-	var r0 *bitbucket.PullRequest
+	var r0 *bitbucket.PullRequestCommentTask
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReadPRParams) (*bitbucket.PullRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReadPRParams) *bitbucket.PullRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCreateTaskParams) *bitbucket.PullRequestCommentTask); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequest)
+			r0 = ret.Get(0).(*bitbucket.PullRequestCommentTask)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketReadPRParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCreateTaskParams) error); ok {
 		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -649,154 +651,2464 @@ func (_m *MockbitbucketService) ReadPR(ctx context.Context, params app.Bitbucket
 	return r0, r1
 }
 
-// MockbitbucketService_ReadPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadPR'
-type MockbitbucketService_ReadPR_Call struct {
+// MockbitbucketService_CreateTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTask'
+type MockbitbucketService_CreateTask_Call struct {
 	*mock.Call
 }
 
-// ReadPR is a helper method to define mock.On call
+// CreateTask is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketReadPRParams
-func (_e *MockbitbucketService_Expecter) ReadPR(ctx interface{}, params interface{}) *MockbitbucketService_ReadPR_Call {
-	return &MockbitbucketService_ReadPR_Call{Call: _e.mock.On("ReadPR", ctx, params)}
+//   - params app.BitbucketCreateTaskParams
+func (_e *MockbitbucketService_Expecter) CreateTask(ctx interface{}, params interface{}) *MockbitbucketService_CreateTask_Call {
+	return &MockbitbucketService_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, params)}
 }
 
-func (_c *MockbitbucketService_ReadPR_Call) Run(run func(ctx context.Context, params app.BitbucketReadPRParams)) *MockbitbucketService_ReadPR_Call {
+func (_c *MockbitbucketService_CreateTask_Call) Run(run func(ctx context.Context, params app.BitbucketCreateTaskParams)) *MockbitbucketService_CreateTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketReadPRParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketCreateTaskParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_ReadPR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_ReadPR_Call {
+func (_c *MockbitbucketService_CreateTask_Call) Return(_a0 *bitbucket.PullRequestCommentTask, _a1 error) *MockbitbucketService_CreateTask_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_ReadPR_Call) RunAndReturn(run func(context.Context, app.BitbucketReadPRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_ReadPR_Call {
+func (_c *MockbitbucketService_CreateTask_Call) RunAndReturn(run func(context.Context, app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error)) *MockbitbucketService_CreateTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RequestPRChanges provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) RequestPRChanges(ctx context.Context, params app.BitbucketRequestPRChangesParams) (string, time.Time, error) {
+// DeleteBranch provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) DeleteBranch(ctx context.Context, params app.BitbucketBranchParams) error {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RequestPRChanges")
+		panic("no return value specified for DeleteBranch")
 	}
 
-	var r0 string
-	var r1 time.Time
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRequestPRChangesParams) (string, time.Time, error)); ok {
-		return rf(ctx, params)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRequestPRChangesParams) string); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBranchParams) error); ok {
 		r0 = rf(ctx, params)
 	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRequestPRChangesParams) time.Time); ok {
-		r1 = rf(ctx, params)
-	} else {
-		r1 = ret.Get(1).(time.Time)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, app.BitbucketRequestPRChangesParams) error); ok {
-		r2 = rf(ctx, params)
-	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// MockbitbucketService_RequestPRChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPRChanges'
-type MockbitbucketService_RequestPRChanges_Call struct {
+// MockbitbucketService_DeleteBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBranch'
+type MockbitbucketService_DeleteBranch_Call struct {
 	*mock.Call
 }
 
-// RequestPRChanges is a helper method to define mock.On call
+// DeleteBranch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketRequestPRChangesParams
-func (_e *MockbitbucketService_Expecter) RequestPRChanges(ctx interface{}, params interface{}) *MockbitbucketService_RequestPRChanges_Call {
-	return &MockbitbucketService_RequestPRChanges_Call{Call: _e.mock.On("RequestPRChanges", ctx, params)}
+//   - params app.BitbucketBranchParams
+func (_e *MockbitbucketService_Expecter) DeleteBranch(ctx interface{}, params interface{}) *MockbitbucketService_DeleteBranch_Call {
+	return &MockbitbucketService_DeleteBranch_Call{Call: _e.mock.On("DeleteBranch", ctx, params)}
 }
 
-func (_c *MockbitbucketService_RequestPRChanges_Call) Run(run func(ctx context.Context, params app.BitbucketRequestPRChangesParams)) *MockbitbucketService_RequestPRChanges_Call {
+func (_c *MockbitbucketService_DeleteBranch_Call) Run(run func(ctx context.Context, params app.BitbucketBranchParams)) *MockbitbucketService_DeleteBranch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketRequestPRChangesParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketBranchParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_RequestPRChanges_Call) Return(_a0 string, _a1 time.Time, _a2 error) *MockbitbucketService_RequestPRChanges_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockbitbucketService_DeleteBranch_Call) Return(_a0 error) *MockbitbucketService_DeleteBranch_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockbitbucketService_RequestPRChanges_Call) RunAndReturn(run func(context.Context, app.BitbucketRequestPRChangesParams) (string, time.Time, error)) *MockbitbucketService_RequestPRChanges_Call {
+func (_c *MockbitbucketService_DeleteBranch_Call) RunAndReturn(run func(context.Context, app.BitbucketBranchParams) error) *MockbitbucketService_DeleteBranch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResolvePRComment provides a mock function with given fields: ctx, params
-func (_m *MockbitbucketService) ResolvePRComment(ctx context.Context, params app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error) {
+// DeleteRepositoryVariable provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) DeleteRepositoryVariable(ctx context.Context, params app.BitbucketDeleteRepositoryVariableParams) error {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResolvePRComment")
+		panic("no return value specified for DeleteRepositoryVariable")
 	}
 
-	var r0 *bitbucket.CommentResolution
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error)); ok {
-		return rf(ctx, params)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketResolvePRCommentParams) *bitbucket.CommentResolution); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketDeleteRepositoryVariableParams) error); ok {
 		r0 = rf(ctx, params)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.CommentResolution)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketResolvePRCommentParams) error); ok {
-		r1 = rf(ctx, params)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockbitbucketService_ResolvePRComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePRComment'
-type MockbitbucketService_ResolvePRComment_Call struct {
+// MockbitbucketService_DeleteRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRepositoryVariable'
+type MockbitbucketService_DeleteRepositoryVariable_Call struct {
+	*mock.Call
+}
+
+// DeleteRepositoryVariable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketDeleteRepositoryVariableParams
+func (_e *MockbitbucketService_Expecter) DeleteRepositoryVariable(ctx interface{}, params interface{}) *MockbitbucketService_DeleteRepositoryVariable_Call {
+	return &MockbitbucketService_DeleteRepositoryVariable_Call{Call: _e.mock.On("DeleteRepositoryVariable", ctx, params)}
+}
+
+func (_c *MockbitbucketService_DeleteRepositoryVariable_Call) Run(run func(ctx context.Context, params app.BitbucketDeleteRepositoryVariableParams)) *MockbitbucketService_DeleteRepositoryVariable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketDeleteRepositoryVariableParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_DeleteRepositoryVariable_Call) Return(_a0 error) *MockbitbucketService_DeleteRepositoryVariable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockbitbucketService_DeleteRepositoryVariable_Call) RunAndReturn(run func(context.Context, app.BitbucketDeleteRepositoryVariableParams) error) *MockbitbucketService_DeleteRepositoryVariable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) DeleteTag(ctx context.Context, params app.BitbucketTagParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketTagParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockbitbucketService_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type MockbitbucketService_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketTagParams
+func (_e *MockbitbucketService_Expecter) DeleteTag(ctx interface{}, params interface{}) *MockbitbucketService_DeleteTag_Call {
+	return &MockbitbucketService_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, params)}
+}
+
+func (_c *MockbitbucketService_DeleteTag_Call) Run(run func(ctx context.Context, params app.BitbucketTagParams)) *MockbitbucketService_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketTagParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_DeleteTag_Call) Return(_a0 error) *MockbitbucketService_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockbitbucketService_DeleteTag_Call) RunAndReturn(run func(context.Context, app.BitbucketTagParams) error) *MockbitbucketService_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindCommitPullRequests provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) FindCommitPullRequests(ctx context.Context, params app.BitbucketCommitPullRequestsParams) (*app.CommitPullRequests, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCommitPullRequests")
+	}
+
+	var r0 *app.CommitPullRequests
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCommitPullRequestsParams) (*app.CommitPullRequests, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketCommitPullRequestsParams) *app.CommitPullRequests); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.CommitPullRequests)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketCommitPullRequestsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_FindCommitPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindCommitPullRequests'
+type MockbitbucketService_FindCommitPullRequests_Call struct {
+	*mock.Call
+}
+
+// FindCommitPullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketCommitPullRequestsParams
+func (_e *MockbitbucketService_Expecter) FindCommitPullRequests(ctx interface{}, params interface{}) *MockbitbucketService_FindCommitPullRequests_Call {
+	return &MockbitbucketService_FindCommitPullRequests_Call{Call: _e.mock.On("FindCommitPullRequests", ctx, params)}
+}
+
+func (_c *MockbitbucketService_FindCommitPullRequests_Call) Run(run func(ctx context.Context, params app.BitbucketCommitPullRequestsParams)) *MockbitbucketService_FindCommitPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketCommitPullRequestsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_FindCommitPullRequests_Call) Return(_a0 *app.CommitPullRequests, _a1 error) *MockbitbucketService_FindCommitPullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_FindCommitPullRequests_Call) RunAndReturn(run func(context.Context, app.BitbucketCommitPullRequestsParams) (*app.CommitPullRequests, error)) *MockbitbucketService_FindCommitPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindStalePullRequests provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) FindStalePullRequests(ctx context.Context, params app.BitbucketStalePRsParams) (*app.StalePullRequests, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStalePullRequests")
+	}
+
+	var r0 *app.StalePullRequests
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketStalePRsParams) (*app.StalePullRequests, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketStalePRsParams) *app.StalePullRequests); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.StalePullRequests)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketStalePRsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_FindStalePullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindStalePullRequests'
+type MockbitbucketService_FindStalePullRequests_Call struct {
+	*mock.Call
+}
+
+// FindStalePullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketStalePRsParams
+func (_e *MockbitbucketService_Expecter) FindStalePullRequests(ctx interface{}, params interface{}) *MockbitbucketService_FindStalePullRequests_Call {
+	return &MockbitbucketService_FindStalePullRequests_Call{Call: _e.mock.On("FindStalePullRequests", ctx, params)}
+}
+
+func (_c *MockbitbucketService_FindStalePullRequests_Call) Run(run func(ctx context.Context, params app.BitbucketStalePRsParams)) *MockbitbucketService_FindStalePullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketStalePRsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_FindStalePullRequests_Call) Return(_a0 *app.StalePullRequests, _a1 error) *MockbitbucketService_FindStalePullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_FindStalePullRequests_Call) RunAndReturn(run func(context.Context, app.BitbucketStalePRsParams) (*app.StalePullRequests, error)) *MockbitbucketService_FindStalePullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranch provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetBranch(ctx context.Context, params app.BitbucketBranchParams) (*bitbucket.Branch, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranch")
+	}
+
+	var r0 *bitbucket.Branch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBranchParams) (*bitbucket.Branch, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBranchParams) *bitbucket.Branch); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Branch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketBranchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranch'
+type MockbitbucketService_GetBranch_Call struct {
+	*mock.Call
+}
+
+// GetBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketBranchParams
+func (_e *MockbitbucketService_Expecter) GetBranch(ctx interface{}, params interface{}) *MockbitbucketService_GetBranch_Call {
+	return &MockbitbucketService_GetBranch_Call{Call: _e.mock.On("GetBranch", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetBranch_Call) Run(run func(ctx context.Context, params app.BitbucketBranchParams)) *MockbitbucketService_GetBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketBranchParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetBranch_Call) Return(_a0 *bitbucket.Branch, _a1 error) *MockbitbucketService_GetBranch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetBranch_Call) RunAndReturn(run func(context.Context, app.BitbucketBranchParams) (*bitbucket.Branch, error)) *MockbitbucketService_GetBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranchPolicy provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetBranchPolicy(ctx context.Context, params app.BitbucketBranchParams) (*app.BranchPolicy, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranchPolicy")
+	}
+
+	var r0 *app.BranchPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBranchParams) (*app.BranchPolicy, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketBranchParams) *app.BranchPolicy); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.BranchPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketBranchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetBranchPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranchPolicy'
+type MockbitbucketService_GetBranchPolicy_Call struct {
+	*mock.Call
+}
+
+// GetBranchPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketBranchParams
+func (_e *MockbitbucketService_Expecter) GetBranchPolicy(ctx interface{}, params interface{}) *MockbitbucketService_GetBranchPolicy_Call {
+	return &MockbitbucketService_GetBranchPolicy_Call{Call: _e.mock.On("GetBranchPolicy", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetBranchPolicy_Call) Run(run func(ctx context.Context, params app.BitbucketBranchParams)) *MockbitbucketService_GetBranchPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketBranchParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetBranchPolicy_Call) Return(_a0 *app.BranchPolicy, _a1 error) *MockbitbucketService_GetBranchPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetBranchPolicy_Call) RunAndReturn(run func(context.Context, app.BitbucketBranchParams) (*app.BranchPolicy, error)) *MockbitbucketService_GetBranchPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeploymentsOverview provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetDeploymentsOverview(ctx context.Context, params app.BitbucketRepositoryParams) ([]app.EnvironmentDeployments, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeploymentsOverview")
+	}
+
+	var r0 []app.EnvironmentDeployments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) ([]app.EnvironmentDeployments, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) []app.EnvironmentDeployments); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]app.EnvironmentDeployments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRepositoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetDeploymentsOverview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeploymentsOverview'
+type MockbitbucketService_GetDeploymentsOverview_Call struct {
+	*mock.Call
+}
+
+// GetDeploymentsOverview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketRepositoryParams
+func (_e *MockbitbucketService_Expecter) GetDeploymentsOverview(ctx interface{}, params interface{}) *MockbitbucketService_GetDeploymentsOverview_Call {
+	return &MockbitbucketService_GetDeploymentsOverview_Call{Call: _e.mock.On("GetDeploymentsOverview", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetDeploymentsOverview_Call) Run(run func(ctx context.Context, params app.BitbucketRepositoryParams)) *MockbitbucketService_GetDeploymentsOverview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketRepositoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetDeploymentsOverview_Call) Return(_a0 []app.EnvironmentDeployments, _a1 error) *MockbitbucketService_GetDeploymentsOverview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetDeploymentsOverview_Call) RunAndReturn(run func(context.Context, app.BitbucketRepositoryParams) ([]app.EnvironmentDeployments, error)) *MockbitbucketService_GetDeploymentsOverview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileContent provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetFileContent(ctx context.Context, params app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileContent")
+	}
+
+	var r0 *bitbucket.FileContentResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetFileContentParams) *bitbucket.FileContentResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.FileContentResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetFileContentParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type MockbitbucketService_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketGetFileContentParams
+func (_e *MockbitbucketService_Expecter) GetFileContent(ctx interface{}, params interface{}) *MockbitbucketService_GetFileContent_Call {
+	return &MockbitbucketService_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetFileContent_Call) Run(run func(ctx context.Context, params app.BitbucketGetFileContentParams)) *MockbitbucketService_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketGetFileContentParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetFileContent_Call) Return(_a0 *bitbucket.FileContentResult, _a1 error) *MockbitbucketService_GetFileContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetFileContent_Call) RunAndReturn(run func(context.Context, app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error)) *MockbitbucketService_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRDiff provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPRDiff(ctx context.Context, params app.BitbucketGetPRDiffParams) (string, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRDiff")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffParams) (string, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffParams) string); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetPRDiffParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPRDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiff'
+type MockbitbucketService_GetPRDiff_Call struct {
+	*mock.Call
+}
+
+// GetPRDiff is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketGetPRDiffParams
+func (_e *MockbitbucketService_Expecter) GetPRDiff(ctx interface{}, params interface{}) *MockbitbucketService_GetPRDiff_Call {
+	return &MockbitbucketService_GetPRDiff_Call{Call: _e.mock.On("GetPRDiff", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPRDiff_Call) Run(run func(ctx context.Context, params app.BitbucketGetPRDiffParams)) *MockbitbucketService_GetPRDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketGetPRDiffParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiff_Call) Return(_a0 string, _a1 error) *MockbitbucketService_GetPRDiff_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiff_Call) RunAndReturn(run func(context.Context, app.BitbucketGetPRDiffParams) (string, error)) *MockbitbucketService_GetPRDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRDiffChunk provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPRDiffChunk(ctx context.Context, params app.BitbucketGetPRDiffChunkParams) (*app.PRDiffChunk, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRDiffChunk")
+	}
+
+	var r0 *app.PRDiffChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffChunkParams) (*app.PRDiffChunk, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffChunkParams) *app.PRDiffChunk); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PRDiffChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetPRDiffChunkParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPRDiffChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiffChunk'
+type MockbitbucketService_GetPRDiffChunk_Call struct {
+	*mock.Call
+}
+
+// GetPRDiffChunk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketGetPRDiffChunkParams
+func (_e *MockbitbucketService_Expecter) GetPRDiffChunk(ctx interface{}, params interface{}) *MockbitbucketService_GetPRDiffChunk_Call {
+	return &MockbitbucketService_GetPRDiffChunk_Call{Call: _e.mock.On("GetPRDiffChunk", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPRDiffChunk_Call) Run(run func(ctx context.Context, params app.BitbucketGetPRDiffChunkParams)) *MockbitbucketService_GetPRDiffChunk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketGetPRDiffChunkParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiffChunk_Call) Return(_a0 *app.PRDiffChunk, _a1 error) *MockbitbucketService_GetPRDiffChunk_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiffChunk_Call) RunAndReturn(run func(context.Context, app.BitbucketGetPRDiffChunkParams) (*app.PRDiffChunk, error)) *MockbitbucketService_GetPRDiffChunk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRDiffStat provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPRDiffStat(ctx context.Context, params app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRDiffStat")
+	}
+
+	var r0 *app.PaginatedDiffStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketGetPRDiffStatParams) *app.PaginatedDiffStat); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PaginatedDiffStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketGetPRDiffStatParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPRDiffStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiffStat'
+type MockbitbucketService_GetPRDiffStat_Call struct {
+	*mock.Call
+}
+
+// GetPRDiffStat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketGetPRDiffStatParams
+func (_e *MockbitbucketService_Expecter) GetPRDiffStat(ctx interface{}, params interface{}) *MockbitbucketService_GetPRDiffStat_Call {
+	return &MockbitbucketService_GetPRDiffStat_Call{Call: _e.mock.On("GetPRDiffStat", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPRDiffStat_Call) Run(run func(ctx context.Context, params app.BitbucketGetPRDiffStatParams)) *MockbitbucketService_GetPRDiffStat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketGetPRDiffStatParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiffStat_Call) Return(_a0 *app.PaginatedDiffStat, _a1 error) *MockbitbucketService_GetPRDiffStat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRDiffStat_Call) RunAndReturn(run func(context.Context, app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error)) *MockbitbucketService_GetPRDiffStat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRReviewBundle provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPRReviewBundle(ctx context.Context, params app.BitbucketPRReviewBundleParams) (*app.PRReviewBundle, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRReviewBundle")
+	}
+
+	var r0 *app.PRReviewBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPRReviewBundleParams) (*app.PRReviewBundle, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPRReviewBundleParams) *app.PRReviewBundle); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PRReviewBundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketPRReviewBundleParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPRReviewBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRReviewBundle'
+type MockbitbucketService_GetPRReviewBundle_Call struct {
+	*mock.Call
+}
+
+// GetPRReviewBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketPRReviewBundleParams
+func (_e *MockbitbucketService_Expecter) GetPRReviewBundle(ctx interface{}, params interface{}) *MockbitbucketService_GetPRReviewBundle_Call {
+	return &MockbitbucketService_GetPRReviewBundle_Call{Call: _e.mock.On("GetPRReviewBundle", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPRReviewBundle_Call) Run(run func(ctx context.Context, params app.BitbucketPRReviewBundleParams)) *MockbitbucketService_GetPRReviewBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketPRReviewBundleParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRReviewBundle_Call) Return(_a0 *app.PRReviewBundle, _a1 error) *MockbitbucketService_GetPRReviewBundle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPRReviewBundle_Call) RunAndReturn(run func(context.Context, app.BitbucketPRReviewBundleParams) (*app.PRReviewBundle, error)) *MockbitbucketService_GetPRReviewBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPipeline provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPipeline(ctx context.Context, params app.BitbucketPipelineParams) (*app.PipelineRun, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipeline")
+	}
+
+	var r0 *app.PipelineRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPipelineParams) (*app.PipelineRun, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPipelineParams) *app.PipelineRun); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PipelineRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketPipelineParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPipeline'
+type MockbitbucketService_GetPipeline_Call struct {
+	*mock.Call
+}
+
+// GetPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketPipelineParams
+func (_e *MockbitbucketService_Expecter) GetPipeline(ctx interface{}, params interface{}) *MockbitbucketService_GetPipeline_Call {
+	return &MockbitbucketService_GetPipeline_Call{Call: _e.mock.On("GetPipeline", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPipeline_Call) Run(run func(ctx context.Context, params app.BitbucketPipelineParams)) *MockbitbucketService_GetPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPipeline_Call) Return(_a0 *app.PipelineRun, _a1 error) *MockbitbucketService_GetPipeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPipeline_Call) RunAndReturn(run func(context.Context, app.BitbucketPipelineParams) (*app.PipelineRun, error)) *MockbitbucketService_GetPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPipelineFailure provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetPipelineFailure(ctx context.Context, params app.BitbucketPipelineParams) (*app.PipelineFailure, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipelineFailure")
+	}
+
+	var r0 *app.PipelineFailure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPipelineParams) (*app.PipelineFailure, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPipelineParams) *app.PipelineFailure); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PipelineFailure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketPipelineParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetPipelineFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPipelineFailure'
+type MockbitbucketService_GetPipelineFailure_Call struct {
+	*mock.Call
+}
+
+// GetPipelineFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketPipelineParams
+func (_e *MockbitbucketService_Expecter) GetPipelineFailure(ctx interface{}, params interface{}) *MockbitbucketService_GetPipelineFailure_Call {
+	return &MockbitbucketService_GetPipelineFailure_Call{Call: _e.mock.On("GetPipelineFailure", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetPipelineFailure_Call) Run(run func(ctx context.Context, params app.BitbucketPipelineParams)) *MockbitbucketService_GetPipelineFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPipelineFailure_Call) Return(_a0 *app.PipelineFailure, _a1 error) *MockbitbucketService_GetPipelineFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetPipelineFailure_Call) RunAndReturn(run func(context.Context, app.BitbucketPipelineParams) (*app.PipelineFailure, error)) *MockbitbucketService_GetPipelineFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepository provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetRepository(ctx context.Context, params app.BitbucketRepositoryParams) (*bitbucket.Repository, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepository")
+	}
+
+	var r0 *bitbucket.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) (*bitbucket.Repository, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) *bitbucket.Repository); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRepositoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepository'
+type MockbitbucketService_GetRepository_Call struct {
+	*mock.Call
+}
+
+// GetRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketRepositoryParams
+func (_e *MockbitbucketService_Expecter) GetRepository(ctx interface{}, params interface{}) *MockbitbucketService_GetRepository_Call {
+	return &MockbitbucketService_GetRepository_Call{Call: _e.mock.On("GetRepository", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetRepository_Call) Run(run func(ctx context.Context, params app.BitbucketRepositoryParams)) *MockbitbucketService_GetRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketRepositoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetRepository_Call) Return(_a0 *bitbucket.Repository, _a1 error) *MockbitbucketService_GetRepository_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetRepository_Call) RunAndReturn(run func(context.Context, app.BitbucketRepositoryParams) (*bitbucket.Repository, error)) *MockbitbucketService_GetRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewQueue provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) GetReviewQueue(ctx context.Context, params app.BitbucketReviewQueueParams) (*app.ReviewQueue, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewQueue")
+	}
+
+	var r0 *app.ReviewQueue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReviewQueueParams) (*app.ReviewQueue, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReviewQueueParams) *app.ReviewQueue); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.ReviewQueue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketReviewQueueParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_GetReviewQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewQueue'
+type MockbitbucketService_GetReviewQueue_Call struct {
+	*mock.Call
+}
+
+// GetReviewQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketReviewQueueParams
+func (_e *MockbitbucketService_Expecter) GetReviewQueue(ctx interface{}, params interface{}) *MockbitbucketService_GetReviewQueue_Call {
+	return &MockbitbucketService_GetReviewQueue_Call{Call: _e.mock.On("GetReviewQueue", ctx, params)}
+}
+
+func (_c *MockbitbucketService_GetReviewQueue_Call) Run(run func(ctx context.Context, params app.BitbucketReviewQueueParams)) *MockbitbucketService_GetReviewQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketReviewQueueParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_GetReviewQueue_Call) Return(_a0 *app.ReviewQueue, _a1 error) *MockbitbucketService_GetReviewQueue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_GetReviewQueue_Call) RunAndReturn(run func(context.Context, app.BitbucketReviewQueueParams) (*app.ReviewQueue, error)) *MockbitbucketService_GetReviewQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBranches provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListBranches(ctx context.Context, params app.BitbucketListBranchesParams) ([]bitbucket.Branch, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBranches")
+	}
+
+	var r0 []bitbucket.Branch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListBranchesParams) ([]bitbucket.Branch, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListBranchesParams) []bitbucket.Branch); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Branch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListBranchesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListBranches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBranches'
+type MockbitbucketService_ListBranches_Call struct {
+	*mock.Call
+}
+
+// ListBranches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListBranchesParams
+func (_e *MockbitbucketService_Expecter) ListBranches(ctx interface{}, params interface{}) *MockbitbucketService_ListBranches_Call {
+	return &MockbitbucketService_ListBranches_Call{Call: _e.mock.On("ListBranches", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListBranches_Call) Run(run func(ctx context.Context, params app.BitbucketListBranchesParams)) *MockbitbucketService_ListBranches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListBranchesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListBranches_Call) Return(_a0 []bitbucket.Branch, _a1 error) *MockbitbucketService_ListBranches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListBranches_Call) RunAndReturn(run func(context.Context, app.BitbucketListBranchesParams) ([]bitbucket.Branch, error)) *MockbitbucketService_ListBranches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBuildStatuses provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListBuildStatuses(ctx context.Context, params app.BitbucketListBuildStatusesParams) ([]bitbucket.CommitStatus, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBuildStatuses")
+	}
+
+	var r0 []bitbucket.CommitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListBuildStatusesParams) ([]bitbucket.CommitStatus, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListBuildStatusesParams) []bitbucket.CommitStatus); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.CommitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListBuildStatusesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListBuildStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBuildStatuses'
+type MockbitbucketService_ListBuildStatuses_Call struct {
+	*mock.Call
+}
+
+// ListBuildStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListBuildStatusesParams
+func (_e *MockbitbucketService_Expecter) ListBuildStatuses(ctx interface{}, params interface{}) *MockbitbucketService_ListBuildStatuses_Call {
+	return &MockbitbucketService_ListBuildStatuses_Call{Call: _e.mock.On("ListBuildStatuses", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListBuildStatuses_Call) Run(run func(ctx context.Context, params app.BitbucketListBuildStatusesParams)) *MockbitbucketService_ListBuildStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListBuildStatusesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListBuildStatuses_Call) Return(_a0 []bitbucket.CommitStatus, _a1 error) *MockbitbucketService_ListBuildStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListBuildStatuses_Call) RunAndReturn(run func(context.Context, app.BitbucketListBuildStatusesParams) ([]bitbucket.CommitStatus, error)) *MockbitbucketService_ListBuildStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeployments provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListDeployments(ctx context.Context, params app.BitbucketListDeploymentsParams) ([]bitbucket.Deployment, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeployments")
+	}
+
+	var r0 []bitbucket.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListDeploymentsParams) ([]bitbucket.Deployment, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListDeploymentsParams) []bitbucket.Deployment); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListDeploymentsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListDeployments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeployments'
+type MockbitbucketService_ListDeployments_Call struct {
+	*mock.Call
+}
+
+// ListDeployments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListDeploymentsParams
+func (_e *MockbitbucketService_Expecter) ListDeployments(ctx interface{}, params interface{}) *MockbitbucketService_ListDeployments_Call {
+	return &MockbitbucketService_ListDeployments_Call{Call: _e.mock.On("ListDeployments", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListDeployments_Call) Run(run func(ctx context.Context, params app.BitbucketListDeploymentsParams)) *MockbitbucketService_ListDeployments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListDeploymentsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListDeployments_Call) Return(_a0 []bitbucket.Deployment, _a1 error) *MockbitbucketService_ListDeployments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListDeployments_Call) RunAndReturn(run func(context.Context, app.BitbucketListDeploymentsParams) ([]bitbucket.Deployment, error)) *MockbitbucketService_ListDeployments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDirectory provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListDirectory(ctx context.Context, params app.BitbucketListDirectoryParams) (*app.DirectoryListing, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDirectory")
+	}
+
+	var r0 *app.DirectoryListing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListDirectoryParams) (*app.DirectoryListing, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListDirectoryParams) *app.DirectoryListing); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.DirectoryListing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListDirectoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDirectory'
+type MockbitbucketService_ListDirectory_Call struct {
+	*mock.Call
+}
+
+// ListDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListDirectoryParams
+func (_e *MockbitbucketService_Expecter) ListDirectory(ctx interface{}, params interface{}) *MockbitbucketService_ListDirectory_Call {
+	return &MockbitbucketService_ListDirectory_Call{Call: _e.mock.On("ListDirectory", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListDirectory_Call) Run(run func(ctx context.Context, params app.BitbucketListDirectoryParams)) *MockbitbucketService_ListDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListDirectoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListDirectory_Call) Return(_a0 *app.DirectoryListing, _a1 error) *MockbitbucketService_ListDirectory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListDirectory_Call) RunAndReturn(run func(context.Context, app.BitbucketListDirectoryParams) (*app.DirectoryListing, error)) *MockbitbucketService_ListDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEnvironments provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListEnvironments(ctx context.Context, params app.BitbucketRepositoryParams) ([]bitbucket.DeploymentEnvironment, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEnvironments")
+	}
+
+	var r0 []bitbucket.DeploymentEnvironment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) ([]bitbucket.DeploymentEnvironment, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) []bitbucket.DeploymentEnvironment); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.DeploymentEnvironment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRepositoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListEnvironments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEnvironments'
+type MockbitbucketService_ListEnvironments_Call struct {
+	*mock.Call
+}
+
+// ListEnvironments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketRepositoryParams
+func (_e *MockbitbucketService_Expecter) ListEnvironments(ctx interface{}, params interface{}) *MockbitbucketService_ListEnvironments_Call {
+	return &MockbitbucketService_ListEnvironments_Call{Call: _e.mock.On("ListEnvironments", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListEnvironments_Call) Run(run func(ctx context.Context, params app.BitbucketRepositoryParams)) *MockbitbucketService_ListEnvironments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketRepositoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListEnvironments_Call) Return(_a0 []bitbucket.DeploymentEnvironment, _a1 error) *MockbitbucketService_ListEnvironments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListEnvironments_Call) RunAndReturn(run func(context.Context, app.BitbucketRepositoryParams) ([]bitbucket.DeploymentEnvironment, error)) *MockbitbucketService_ListEnvironments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFileHistory provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListFileHistory(ctx context.Context, params app.BitbucketFileHistoryParams) (*app.FileHistory, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFileHistory")
+	}
+
+	var r0 *app.FileHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketFileHistoryParams) (*app.FileHistory, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketFileHistoryParams) *app.FileHistory); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.FileHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketFileHistoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListFileHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFileHistory'
+type MockbitbucketService_ListFileHistory_Call struct {
+	*mock.Call
+}
+
+// ListFileHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketFileHistoryParams
+func (_e *MockbitbucketService_Expecter) ListFileHistory(ctx interface{}, params interface{}) *MockbitbucketService_ListFileHistory_Call {
+	return &MockbitbucketService_ListFileHistory_Call{Call: _e.mock.On("ListFileHistory", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListFileHistory_Call) Run(run func(ctx context.Context, params app.BitbucketFileHistoryParams)) *MockbitbucketService_ListFileHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketFileHistoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListFileHistory_Call) Return(_a0 *app.FileHistory, _a1 error) *MockbitbucketService_ListFileHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListFileHistory_Call) RunAndReturn(run func(context.Context, app.BitbucketFileHistoryParams) (*app.FileHistory, error)) *MockbitbucketService_ListFileHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPRComments provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListPRComments(ctx context.Context, params app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPRComments")
+	}
+
+	var r0 *app.BitbucketListPRCommentsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPRCommentsParams) *app.BitbucketListPRCommentsResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.BitbucketListPRCommentsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListPRCommentsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListPRComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRComments'
+type MockbitbucketService_ListPRComments_Call struct {
+	*mock.Call
+}
+
+// ListPRComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListPRCommentsParams
+func (_e *MockbitbucketService_Expecter) ListPRComments(ctx interface{}, params interface{}) *MockbitbucketService_ListPRComments_Call {
+	return &MockbitbucketService_ListPRComments_Call{Call: _e.mock.On("ListPRComments", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListPRComments_Call) Run(run func(ctx context.Context, params app.BitbucketListPRCommentsParams)) *MockbitbucketService_ListPRComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListPRCommentsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListPRComments_Call) Return(_a0 *app.BitbucketListPRCommentsResult, _a1 error) *MockbitbucketService_ListPRComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListPRComments_Call) RunAndReturn(run func(context.Context, app.BitbucketListPRCommentsParams) (*app.BitbucketListPRCommentsResult, error)) *MockbitbucketService_ListPRComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPipelines provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListPipelines(ctx context.Context, params app.BitbucketListPipelinesParams) ([]bitbucket.Pipeline, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPipelines")
+	}
+
+	var r0 []bitbucket.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPipelinesParams) ([]bitbucket.Pipeline, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListPipelinesParams) []bitbucket.Pipeline); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListPipelinesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListPipelines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPipelines'
+type MockbitbucketService_ListPipelines_Call struct {
+	*mock.Call
+}
+
+// ListPipelines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListPipelinesParams
+func (_e *MockbitbucketService_Expecter) ListPipelines(ctx interface{}, params interface{}) *MockbitbucketService_ListPipelines_Call {
+	return &MockbitbucketService_ListPipelines_Call{Call: _e.mock.On("ListPipelines", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListPipelines_Call) Run(run func(ctx context.Context, params app.BitbucketListPipelinesParams)) *MockbitbucketService_ListPipelines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListPipelinesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListPipelines_Call) Return(_a0 []bitbucket.Pipeline, _a1 error) *MockbitbucketService_ListPipelines_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListPipelines_Call) RunAndReturn(run func(context.Context, app.BitbucketListPipelinesParams) ([]bitbucket.Pipeline, error)) *MockbitbucketService_ListPipelines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRepositories provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListRepositories(ctx context.Context, params app.BitbucketListRepositoriesParams) (*app.RepositoryList, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositories")
+	}
+
+	var r0 *app.RepositoryList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListRepositoriesParams) (*app.RepositoryList, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListRepositoriesParams) *app.RepositoryList); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.RepositoryList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListRepositoriesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListRepositories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRepositories'
+type MockbitbucketService_ListRepositories_Call struct {
+	*mock.Call
+}
+
+// ListRepositories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListRepositoriesParams
+func (_e *MockbitbucketService_Expecter) ListRepositories(ctx interface{}, params interface{}) *MockbitbucketService_ListRepositories_Call {
+	return &MockbitbucketService_ListRepositories_Call{Call: _e.mock.On("ListRepositories", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListRepositories_Call) Run(run func(ctx context.Context, params app.BitbucketListRepositoriesParams)) *MockbitbucketService_ListRepositories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListRepositoriesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListRepositories_Call) Return(_a0 *app.RepositoryList, _a1 error) *MockbitbucketService_ListRepositories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListRepositories_Call) RunAndReturn(run func(context.Context, app.BitbucketListRepositoriesParams) (*app.RepositoryList, error)) *MockbitbucketService_ListRepositories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRepositoryVariables provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListRepositoryVariables(ctx context.Context, params app.BitbucketRepositoryParams) ([]bitbucket.PipelineVariable, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositoryVariables")
+	}
+
+	var r0 []bitbucket.PipelineVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) ([]bitbucket.PipelineVariable, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRepositoryParams) []bitbucket.PipelineVariable); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.PipelineVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRepositoryParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListRepositoryVariables_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRepositoryVariables'
+type MockbitbucketService_ListRepositoryVariables_Call struct {
+	*mock.Call
+}
+
+// ListRepositoryVariables is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketRepositoryParams
+func (_e *MockbitbucketService_Expecter) ListRepositoryVariables(ctx interface{}, params interface{}) *MockbitbucketService_ListRepositoryVariables_Call {
+	return &MockbitbucketService_ListRepositoryVariables_Call{Call: _e.mock.On("ListRepositoryVariables", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListRepositoryVariables_Call) Run(run func(ctx context.Context, params app.BitbucketRepositoryParams)) *MockbitbucketService_ListRepositoryVariables_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketRepositoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListRepositoryVariables_Call) Return(_a0 []bitbucket.PipelineVariable, _a1 error) *MockbitbucketService_ListRepositoryVariables_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListRepositoryVariables_Call) RunAndReturn(run func(context.Context, app.BitbucketRepositoryParams) ([]bitbucket.PipelineVariable, error)) *MockbitbucketService_ListRepositoryVariables_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTagChanges provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListTagChanges(ctx context.Context, params app.BitbucketListTagChangesParams) (*app.TagChanges, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagChanges")
+	}
+
+	var r0 *app.TagChanges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTagChangesParams) (*app.TagChanges, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTagChangesParams) *app.TagChanges); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.TagChanges)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListTagChangesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListTagChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagChanges'
+type MockbitbucketService_ListTagChanges_Call struct {
+	*mock.Call
+}
+
+// ListTagChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListTagChangesParams
+func (_e *MockbitbucketService_Expecter) ListTagChanges(ctx interface{}, params interface{}) *MockbitbucketService_ListTagChanges_Call {
+	return &MockbitbucketService_ListTagChanges_Call{Call: _e.mock.On("ListTagChanges", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListTagChanges_Call) Run(run func(ctx context.Context, params app.BitbucketListTagChangesParams)) *MockbitbucketService_ListTagChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListTagChangesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTagChanges_Call) Return(_a0 *app.TagChanges, _a1 error) *MockbitbucketService_ListTagChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTagChanges_Call) RunAndReturn(run func(context.Context, app.BitbucketListTagChangesParams) (*app.TagChanges, error)) *MockbitbucketService_ListTagChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListTags(ctx context.Context, params app.BitbucketListTagsParams) ([]bitbucket.Tag, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []bitbucket.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTagsParams) ([]bitbucket.Tag, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTagsParams) []bitbucket.Tag); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListTagsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockbitbucketService_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListTagsParams
+func (_e *MockbitbucketService_Expecter) ListTags(ctx interface{}, params interface{}) *MockbitbucketService_ListTags_Call {
+	return &MockbitbucketService_ListTags_Call{Call: _e.mock.On("ListTags", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListTags_Call) Run(run func(ctx context.Context, params app.BitbucketListTagsParams)) *MockbitbucketService_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListTagsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTags_Call) Return(_a0 []bitbucket.Tag, _a1 error) *MockbitbucketService_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTags_Call) RunAndReturn(run func(context.Context, app.BitbucketListTagsParams) ([]bitbucket.Tag, error)) *MockbitbucketService_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTasks provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListTasks(ctx context.Context, params app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTasks")
+	}
+
+	var r0 *bitbucket.PaginatedTasks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListTasksParams) *bitbucket.PaginatedTasks); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PaginatedTasks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListTasksParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTasks'
+type MockbitbucketService_ListTasks_Call struct {
+	*mock.Call
+}
+
+// ListTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListTasksParams
+func (_e *MockbitbucketService_Expecter) ListTasks(ctx interface{}, params interface{}) *MockbitbucketService_ListTasks_Call {
+	return &MockbitbucketService_ListTasks_Call{Call: _e.mock.On("ListTasks", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListTasks_Call) Run(run func(ctx context.Context, params app.BitbucketListTasksParams)) *MockbitbucketService_ListTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListTasksParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTasks_Call) Return(_a0 *bitbucket.PaginatedTasks, _a1 error) *MockbitbucketService_ListTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListTasks_Call) RunAndReturn(run func(context.Context, app.BitbucketListTasksParams) (*bitbucket.PaginatedTasks, error)) *MockbitbucketService_ListTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWorkspaces provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ListWorkspaces(ctx context.Context, params app.BitbucketListWorkspacesParams) (*app.WorkspaceList, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkspaces")
+	}
+
+	var r0 *app.WorkspaceList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListWorkspacesParams) (*app.WorkspaceList, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketListWorkspacesParams) *app.WorkspaceList); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.WorkspaceList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketListWorkspacesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ListWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkspaces'
+type MockbitbucketService_ListWorkspaces_Call struct {
+	*mock.Call
+}
+
+// ListWorkspaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketListWorkspacesParams
+func (_e *MockbitbucketService_Expecter) ListWorkspaces(ctx interface{}, params interface{}) *MockbitbucketService_ListWorkspaces_Call {
+	return &MockbitbucketService_ListWorkspaces_Call{Call: _e.mock.On("ListWorkspaces", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ListWorkspaces_Call) Run(run func(ctx context.Context, params app.BitbucketListWorkspacesParams)) *MockbitbucketService_ListWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketListWorkspacesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ListWorkspaces_Call) Return(_a0 *app.WorkspaceList, _a1 error) *MockbitbucketService_ListWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ListWorkspaces_Call) RunAndReturn(run func(context.Context, app.BitbucketListWorkspacesParams) (*app.WorkspaceList, error)) *MockbitbucketService_ListWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePR provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) MergePR(ctx context.Context, params app.BitbucketMergePRParams) (*bitbucket.PullRequest, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergePR")
+	}
+
+	var r0 *bitbucket.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketMergePRParams) (*bitbucket.PullRequest, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketMergePRParams) *bitbucket.PullRequest); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketMergePRParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
+type MockbitbucketService_MergePR_Call struct {
+	*mock.Call
+}
+
+// MergePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketMergePRParams
+func (_e *MockbitbucketService_Expecter) MergePR(ctx interface{}, params interface{}) *MockbitbucketService_MergePR_Call {
+	return &MockbitbucketService_MergePR_Call{Call: _e.mock.On("MergePR", ctx, params)}
+}
+
+func (_c *MockbitbucketService_MergePR_Call) Run(run func(ctx context.Context, params app.BitbucketMergePRParams)) *MockbitbucketService_MergePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketMergePRParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_MergePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_MergePR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_MergePR_Call) RunAndReturn(run func(context.Context, app.BitbucketMergePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_MergePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NudgeStalePullRequests provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) NudgeStalePullRequests(ctx context.Context, params app.BitbucketNudgeStalePRsParams) (*app.StalePRNudges, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NudgeStalePullRequests")
+	}
+
+	var r0 *app.StalePRNudges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketNudgeStalePRsParams) (*app.StalePRNudges, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketNudgeStalePRsParams) *app.StalePRNudges); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.StalePRNudges)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketNudgeStalePRsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_NudgeStalePullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NudgeStalePullRequests'
+type MockbitbucketService_NudgeStalePullRequests_Call struct {
+	*mock.Call
+}
+
+// NudgeStalePullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketNudgeStalePRsParams
+func (_e *MockbitbucketService_Expecter) NudgeStalePullRequests(ctx interface{}, params interface{}) *MockbitbucketService_NudgeStalePullRequests_Call {
+	return &MockbitbucketService_NudgeStalePullRequests_Call{Call: _e.mock.On("NudgeStalePullRequests", ctx, params)}
+}
+
+func (_c *MockbitbucketService_NudgeStalePullRequests_Call) Run(run func(ctx context.Context, params app.BitbucketNudgeStalePRsParams)) *MockbitbucketService_NudgeStalePullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketNudgeStalePRsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_NudgeStalePullRequests_Call) Return(_a0 *app.StalePRNudges, _a1 error) *MockbitbucketService_NudgeStalePullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_NudgeStalePullRequests_Call) RunAndReturn(run func(context.Context, app.BitbucketNudgeStalePRsParams) (*app.StalePRNudges, error)) *MockbitbucketService_NudgeStalePullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenChange provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) OpenChange(ctx context.Context, params app.BitbucketOpenChangeParams) (*app.OpenedChange, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenChange")
+	}
+
+	var r0 *app.OpenedChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketOpenChangeParams) (*app.OpenedChange, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketOpenChangeParams) *app.OpenedChange); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.OpenedChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketOpenChangeParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_OpenChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenChange'
+type MockbitbucketService_OpenChange_Call struct {
+	*mock.Call
+}
+
+// OpenChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketOpenChangeParams
+func (_e *MockbitbucketService_Expecter) OpenChange(ctx interface{}, params interface{}) *MockbitbucketService_OpenChange_Call {
+	return &MockbitbucketService_OpenChange_Call{Call: _e.mock.On("OpenChange", ctx, params)}
+}
+
+func (_c *MockbitbucketService_OpenChange_Call) Run(run func(ctx context.Context, params app.BitbucketOpenChangeParams)) *MockbitbucketService_OpenChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketOpenChangeParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_OpenChange_Call) Return(_a0 *app.OpenedChange, _a1 error) *MockbitbucketService_OpenChange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_OpenChange_Call) RunAndReturn(run func(context.Context, app.BitbucketOpenChangeParams) (*app.OpenedChange, error)) *MockbitbucketService_OpenChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadPR provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ReadPR(ctx context.Context, params app.BitbucketReadPRParams) (*bitbucket.PullRequest, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadPR")
+	}
+
+	var r0 *bitbucket.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReadPRParams) (*bitbucket.PullRequest, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketReadPRParams) *bitbucket.PullRequest); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketReadPRParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ReadPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadPR'
+type MockbitbucketService_ReadPR_Call struct {
+	*mock.Call
+}
+
+// ReadPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketReadPRParams
+func (_e *MockbitbucketService_Expecter) ReadPR(ctx interface{}, params interface{}) *MockbitbucketService_ReadPR_Call {
+	return &MockbitbucketService_ReadPR_Call{Call: _e.mock.On("ReadPR", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ReadPR_Call) Run(run func(ctx context.Context, params app.BitbucketReadPRParams)) *MockbitbucketService_ReadPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketReadPRParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ReadPR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketService_ReadPR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ReadPR_Call) RunAndReturn(run func(context.Context, app.BitbucketReadPRParams) (*bitbucket.PullRequest, error)) *MockbitbucketService_ReadPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestPRChanges provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) RequestPRChanges(ctx context.Context, params app.BitbucketRequestPRChangesParams) (string, time.Time, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPRChanges")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRequestPRChangesParams) (string, time.Time, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketRequestPRChangesParams) string); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketRequestPRChangesParams) time.Time); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, app.BitbucketRequestPRChangesParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockbitbucketService_RequestPRChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPRChanges'
+type MockbitbucketService_RequestPRChanges_Call struct {
+	*mock.Call
+}
+
+// RequestPRChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketRequestPRChangesParams
+func (_e *MockbitbucketService_Expecter) RequestPRChanges(ctx interface{}, params interface{}) *MockbitbucketService_RequestPRChanges_Call {
+	return &MockbitbucketService_RequestPRChanges_Call{Call: _e.mock.On("RequestPRChanges", ctx, params)}
+}
+
+func (_c *MockbitbucketService_RequestPRChanges_Call) Run(run func(ctx context.Context, params app.BitbucketRequestPRChangesParams)) *MockbitbucketService_RequestPRChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketRequestPRChangesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_RequestPRChanges_Call) Return(_a0 string, _a1 time.Time, _a2 error) *MockbitbucketService_RequestPRChanges_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockbitbucketService_RequestPRChanges_Call) RunAndReturn(run func(context.Context, app.BitbucketRequestPRChangesParams) (string, time.Time, error)) *MockbitbucketService_RequestPRChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolvePRComment provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ResolvePRComment(ctx context.Context, params app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePRComment")
+	}
+
+	var r0 *bitbucket.CommentResolution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketResolvePRCommentParams) *bitbucket.CommentResolution); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.CommentResolution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketResolvePRCommentParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ResolvePRComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePRComment'
+type MockbitbucketService_ResolvePRComment_Call struct {
 	*mock.Call
 }
 
 // ResolvePRComment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params app.BitbucketResolvePRCommentParams
-func (_e *MockbitbucketService_Expecter) ResolvePRComment(ctx interface{}, params interface{}) *MockbitbucketService_ResolvePRComment_Call {
-	return &MockbitbucketService_ResolvePRComment_Call{Call: _e.mock.On("ResolvePRComment", ctx, params)}
+//   - params app.BitbucketResolvePRCommentParams
+func (_e *MockbitbucketService_Expecter) ResolvePRComment(ctx interface{}, params interface{}) *MockbitbucketService_ResolvePRComment_Call {
+	return &MockbitbucketService_ResolvePRComment_Call{Call: _e.mock.On("ResolvePRComment", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ResolvePRComment_Call) Run(run func(ctx context.Context, params app.BitbucketResolvePRCommentParams)) *MockbitbucketService_ResolvePRComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketResolvePRCommentParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ResolvePRComment_Call) Return(_a0 *bitbucket.CommentResolution, _a1 error) *MockbitbucketService_ResolvePRComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ResolvePRComment_Call) RunAndReturn(run func(context.Context, app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error)) *MockbitbucketService_ResolvePRComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchCode provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) SearchCode(ctx context.Context, params app.BitbucketSearchCodeParams) (*app.CodeSearchResults, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchCode")
+	}
+
+	var r0 *app.CodeSearchResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketSearchCodeParams) (*app.CodeSearchResults, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketSearchCodeParams) *app.CodeSearchResults); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.CodeSearchResults)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketSearchCodeParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_SearchCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchCode'
+type MockbitbucketService_SearchCode_Call struct {
+	*mock.Call
+}
+
+// SearchCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketSearchCodeParams
+func (_e *MockbitbucketService_Expecter) SearchCode(ctx interface{}, params interface{}) *MockbitbucketService_SearchCode_Call {
+	return &MockbitbucketService_SearchCode_Call{Call: _e.mock.On("SearchCode", ctx, params)}
+}
+
+func (_c *MockbitbucketService_SearchCode_Call) Run(run func(ctx context.Context, params app.BitbucketSearchCodeParams)) *MockbitbucketService_SearchCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketSearchCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_SearchCode_Call) Return(_a0 *app.CodeSearchResults, _a1 error) *MockbitbucketService_SearchCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_SearchCode_Call) RunAndReturn(run func(context.Context, app.BitbucketSearchCodeParams) (*app.CodeSearchResults, error)) *MockbitbucketService_SearchCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchIssuePullRequests provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) SearchIssuePullRequests(ctx context.Context, params app.BitbucketIssuePullRequestsParams) (*app.IssuePullRequests, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchIssuePullRequests")
+	}
+
+	var r0 *app.IssuePullRequests
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketIssuePullRequestsParams) (*app.IssuePullRequests, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketIssuePullRequestsParams) *app.IssuePullRequests); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.IssuePullRequests)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketIssuePullRequestsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_SearchIssuePullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchIssuePullRequests'
+type MockbitbucketService_SearchIssuePullRequests_Call struct {
+	*mock.Call
+}
+
+// SearchIssuePullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketIssuePullRequestsParams
+func (_e *MockbitbucketService_Expecter) SearchIssuePullRequests(ctx interface{}, params interface{}) *MockbitbucketService_SearchIssuePullRequests_Call {
+	return &MockbitbucketService_SearchIssuePullRequests_Call{Call: _e.mock.On("SearchIssuePullRequests", ctx, params)}
+}
+
+func (_c *MockbitbucketService_SearchIssuePullRequests_Call) Run(run func(ctx context.Context, params app.BitbucketIssuePullRequestsParams)) *MockbitbucketService_SearchIssuePullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketIssuePullRequestsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_SearchIssuePullRequests_Call) Return(_a0 *app.IssuePullRequests, _a1 error) *MockbitbucketService_SearchIssuePullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_SearchIssuePullRequests_Call) RunAndReturn(run func(context.Context, app.BitbucketIssuePullRequestsParams) (*app.IssuePullRequests, error)) *MockbitbucketService_SearchIssuePullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBuildStatus provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) SetBuildStatus(ctx context.Context, params app.BitbucketSetBuildStatusParams) (*bitbucket.CommitStatus, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBuildStatus")
+	}
+
+	var r0 *bitbucket.CommitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketSetBuildStatusParams) (*bitbucket.CommitStatus, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketSetBuildStatusParams) *bitbucket.CommitStatus); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.CommitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketSetBuildStatusParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_SetBuildStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBuildStatus'
+type MockbitbucketService_SetBuildStatus_Call struct {
+	*mock.Call
+}
+
+// SetBuildStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketSetBuildStatusParams
+func (_e *MockbitbucketService_Expecter) SetBuildStatus(ctx interface{}, params interface{}) *MockbitbucketService_SetBuildStatus_Call {
+	return &MockbitbucketService_SetBuildStatus_Call{Call: _e.mock.On("SetBuildStatus", ctx, params)}
+}
+
+func (_c *MockbitbucketService_SetBuildStatus_Call) Run(run func(ctx context.Context, params app.BitbucketSetBuildStatusParams)) *MockbitbucketService_SetBuildStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketSetBuildStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_SetBuildStatus_Call) Return(_a0 *bitbucket.CommitStatus, _a1 error) *MockbitbucketService_SetBuildStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_SetBuildStatus_Call) RunAndReturn(run func(context.Context, app.BitbucketSetBuildStatusParams) (*bitbucket.CommitStatus, error)) *MockbitbucketService_SetBuildStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopPipeline provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) StopPipeline(ctx context.Context, params app.BitbucketPipelineParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopPipeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketPipelineParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockbitbucketService_StopPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopPipeline'
+type MockbitbucketService_StopPipeline_Call struct {
+	*mock.Call
+}
+
+// StopPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketPipelineParams
+func (_e *MockbitbucketService_Expecter) StopPipeline(ctx interface{}, params interface{}) *MockbitbucketService_StopPipeline_Call {
+	return &MockbitbucketService_StopPipeline_Call{Call: _e.mock.On("StopPipeline", ctx, params)}
 }
 
-func (_c *MockbitbucketService_ResolvePRComment_Call) Run(run func(ctx context.Context, params app.BitbucketResolvePRCommentParams)) *MockbitbucketService_ResolvePRComment_Call {
+func (_c *MockbitbucketService_StopPipeline_Call) Run(run func(ctx context.Context, params app.BitbucketPipelineParams)) *MockbitbucketService_StopPipeline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(app.BitbucketResolvePRCommentParams))
+		run(args[0].(context.Context), args[1].(app.BitbucketPipelineParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketService_ResolvePRComment_Call) Return(_a0 *bitbucket.CommentResolution, _a1 error) *MockbitbucketService_ResolvePRComment_Call {
+func (_c *MockbitbucketService_StopPipeline_Call) Return(_a0 error) *MockbitbucketService_StopPipeline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockbitbucketService_StopPipeline_Call) RunAndReturn(run func(context.Context, app.BitbucketPipelineParams) error) *MockbitbucketService_StopPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TriggerPipeline provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) TriggerPipeline(ctx context.Context, params app.BitbucketTriggerPipelineParams) (*bitbucket.Pipeline, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerPipeline")
+	}
+
+	var r0 *bitbucket.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketTriggerPipelineParams) (*bitbucket.Pipeline, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketTriggerPipelineParams) *bitbucket.Pipeline); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketTriggerPipelineParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_TriggerPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TriggerPipeline'
+type MockbitbucketService_TriggerPipeline_Call struct {
+	*mock.Call
+}
+
+// TriggerPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketTriggerPipelineParams
+func (_e *MockbitbucketService_Expecter) TriggerPipeline(ctx interface{}, params interface{}) *MockbitbucketService_TriggerPipeline_Call {
+	return &MockbitbucketService_TriggerPipeline_Call{Call: _e.mock.On("TriggerPipeline", ctx, params)}
+}
+
+func (_c *MockbitbucketService_TriggerPipeline_Call) Run(run func(ctx context.Context, params app.BitbucketTriggerPipelineParams)) *MockbitbucketService_TriggerPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketTriggerPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_TriggerPipeline_Call) Return(_a0 *bitbucket.Pipeline, _a1 error) *MockbitbucketService_TriggerPipeline_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketService_ResolvePRComment_Call) RunAndReturn(run func(context.Context, app.BitbucketResolvePRCommentParams) (*bitbucket.CommentResolution, error)) *MockbitbucketService_ResolvePRComment_Call {
+func (_c *MockbitbucketService_TriggerPipeline_Call) RunAndReturn(run func(context.Context, app.BitbucketTriggerPipelineParams) (*bitbucket.Pipeline, error)) *MockbitbucketService_TriggerPipeline_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -860,6 +3172,65 @@ func (_c *MockbitbucketService_UpdatePR_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// UpdateRepositoryVariable provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) UpdateRepositoryVariable(ctx context.Context, params app.BitbucketUpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRepositoryVariable")
+	}
+
+	var r0 *bitbucket.PipelineVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketUpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketUpdateRepositoryVariableParams) *bitbucket.PipelineVariable); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PipelineVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketUpdateRepositoryVariableParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_UpdateRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRepositoryVariable'
+type MockbitbucketService_UpdateRepositoryVariable_Call struct {
+	*mock.Call
+}
+
+// UpdateRepositoryVariable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketUpdateRepositoryVariableParams
+func (_e *MockbitbucketService_Expecter) UpdateRepositoryVariable(ctx interface{}, params interface{}) *MockbitbucketService_UpdateRepositoryVariable_Call {
+	return &MockbitbucketService_UpdateRepositoryVariable_Call{Call: _e.mock.On("UpdateRepositoryVariable", ctx, params)}
+}
+
+func (_c *MockbitbucketService_UpdateRepositoryVariable_Call) Run(run func(ctx context.Context, params app.BitbucketUpdateRepositoryVariableParams)) *MockbitbucketService_UpdateRepositoryVariable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketUpdateRepositoryVariableParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_UpdateRepositoryVariable_Call) Return(_a0 *bitbucket.PipelineVariable, _a1 error) *MockbitbucketService_UpdateRepositoryVariable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_UpdateRepositoryVariable_Call) RunAndReturn(run func(context.Context, app.BitbucketUpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)) *MockbitbucketService_UpdateRepositoryVariable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTask provides a mock function with given fields: ctx, params
 func (_m *MockbitbucketService) UpdateTask(ctx context.Context, params app.BitbucketUpdateTaskParams) (*bitbucket.PullRequestCommentTask, error) {
 	ret := _m.Called(ctx, params)
@@ -919,6 +3290,243 @@ func (_c *MockbitbucketService_UpdateTask_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// ValidatePipelinesConfig provides a mock function with given fields: ctx, params
+func (_m *MockbitbucketService) ValidatePipelinesConfig(ctx context.Context, params app.BitbucketValidatePipelinesConfigParams) (*app.PipelinesConfigValidationResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatePipelinesConfig")
+	}
+
+	var r0 *app.PipelinesConfigValidationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketValidatePipelinesConfigParams) (*app.PipelinesConfigValidationResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketValidatePipelinesConfigParams) *app.PipelinesConfigValidationResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PipelinesConfigValidationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketValidatePipelinesConfigParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_ValidatePipelinesConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidatePipelinesConfig'
+type MockbitbucketService_ValidatePipelinesConfig_Call struct {
+	*mock.Call
+}
+
+// ValidatePipelinesConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketValidatePipelinesConfigParams
+func (_e *MockbitbucketService_Expecter) ValidatePipelinesConfig(ctx interface{}, params interface{}) *MockbitbucketService_ValidatePipelinesConfig_Call {
+	return &MockbitbucketService_ValidatePipelinesConfig_Call{Call: _e.mock.On("ValidatePipelinesConfig", ctx, params)}
+}
+
+func (_c *MockbitbucketService_ValidatePipelinesConfig_Call) Run(run func(ctx context.Context, params app.BitbucketValidatePipelinesConfigParams)) *MockbitbucketService_ValidatePipelinesConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketValidatePipelinesConfigParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_ValidatePipelinesConfig_Call) Return(_a0 *app.PipelinesConfigValidationResult, _a1 error) *MockbitbucketService_ValidatePipelinesConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_ValidatePipelinesConfig_Call) RunAndReturn(run func(context.Context, app.BitbucketValidatePipelinesConfigParams) (*app.PipelinesConfigValidationResult, error)) *MockbitbucketService_ValidatePipelinesConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WaitForBuildStatuses provides a mock function with given fields: ctx, params, onProgress
+func (_m *MockbitbucketService) WaitForBuildStatuses(ctx context.Context, params app.BitbucketWaitForBuildStatusesParams, onProgress app.WaitProgressFunc) (*app.BuildStatusesWaitResult, error) {
+	ret := _m.Called(ctx, params, onProgress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForBuildStatuses")
+	}
+
+	var r0 *app.BuildStatusesWaitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketWaitForBuildStatusesParams, app.WaitProgressFunc) (*app.BuildStatusesWaitResult, error)); ok {
+		return rf(ctx, params, onProgress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketWaitForBuildStatusesParams, app.WaitProgressFunc) *app.BuildStatusesWaitResult); ok {
+		r0 = rf(ctx, params, onProgress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.BuildStatusesWaitResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketWaitForBuildStatusesParams, app.WaitProgressFunc) error); ok {
+		r1 = rf(ctx, params, onProgress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_WaitForBuildStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForBuildStatuses'
+type MockbitbucketService_WaitForBuildStatuses_Call struct {
+	*mock.Call
+}
+
+// WaitForBuildStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketWaitForBuildStatusesParams
+//   - onProgress app.WaitProgressFunc
+func (_e *MockbitbucketService_Expecter) WaitForBuildStatuses(ctx interface{}, params interface{}, onProgress interface{}) *MockbitbucketService_WaitForBuildStatuses_Call {
+	return &MockbitbucketService_WaitForBuildStatuses_Call{Call: _e.mock.On("WaitForBuildStatuses", ctx, params, onProgress)}
+}
+
+func (_c *MockbitbucketService_WaitForBuildStatuses_Call) Run(run func(ctx context.Context, params app.BitbucketWaitForBuildStatusesParams, onProgress app.WaitProgressFunc)) *MockbitbucketService_WaitForBuildStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketWaitForBuildStatusesParams), args[2].(app.WaitProgressFunc))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_WaitForBuildStatuses_Call) Return(_a0 *app.BuildStatusesWaitResult, _a1 error) *MockbitbucketService_WaitForBuildStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_WaitForBuildStatuses_Call) RunAndReturn(run func(context.Context, app.BitbucketWaitForBuildStatusesParams, app.WaitProgressFunc) (*app.BuildStatusesWaitResult, error)) *MockbitbucketService_WaitForBuildStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WaitForPipeline provides a mock function with given fields: ctx, params, onProgress
+func (_m *MockbitbucketService) WaitForPipeline(ctx context.Context, params app.BitbucketWaitForPipelineParams, onProgress app.WaitProgressFunc) (*app.PipelineWaitResult, error) {
+	ret := _m.Called(ctx, params, onProgress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForPipeline")
+	}
+
+	var r0 *app.PipelineWaitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketWaitForPipelineParams, app.WaitProgressFunc) (*app.PipelineWaitResult, error)); ok {
+		return rf(ctx, params, onProgress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, app.BitbucketWaitForPipelineParams, app.WaitProgressFunc) *app.PipelineWaitResult); ok {
+		r0 = rf(ctx, params, onProgress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.PipelineWaitResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, app.BitbucketWaitForPipelineParams, app.WaitProgressFunc) error); ok {
+		r1 = rf(ctx, params, onProgress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_WaitForPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForPipeline'
+type MockbitbucketService_WaitForPipeline_Call struct {
+	*mock.Call
+}
+
+// WaitForPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params app.BitbucketWaitForPipelineParams
+//   - onProgress app.WaitProgressFunc
+func (_e *MockbitbucketService_Expecter) WaitForPipeline(ctx interface{}, params interface{}, onProgress interface{}) *MockbitbucketService_WaitForPipeline_Call {
+	return &MockbitbucketService_WaitForPipeline_Call{Call: _e.mock.On("WaitForPipeline", ctx, params, onProgress)}
+}
+
+func (_c *MockbitbucketService_WaitForPipeline_Call) Run(run func(ctx context.Context, params app.BitbucketWaitForPipelineParams, onProgress app.WaitProgressFunc)) *MockbitbucketService_WaitForPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(app.BitbucketWaitForPipelineParams), args[2].(app.WaitProgressFunc))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_WaitForPipeline_Call) Return(_a0 *app.PipelineWaitResult, _a1 error) *MockbitbucketService_WaitForPipeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_WaitForPipeline_Call) RunAndReturn(run func(context.Context, app.BitbucketWaitForPipelineParams, app.WaitProgressFunc) (*app.PipelineWaitResult, error)) *MockbitbucketService_WaitForPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WhoAmI provides a mock function with given fields: ctx
+func (_m *MockbitbucketService) WhoAmI(ctx context.Context) (*app.BitbucketIdentities, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WhoAmI")
+	}
+
+	var r0 *app.BitbucketIdentities
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*app.BitbucketIdentities, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *app.BitbucketIdentities); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*app.BitbucketIdentities)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketService_WhoAmI_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhoAmI'
+type MockbitbucketService_WhoAmI_Call struct {
+	*mock.Call
+}
+
+// WhoAmI is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockbitbucketService_Expecter) WhoAmI(ctx interface{}) *MockbitbucketService_WhoAmI_Call {
+	return &MockbitbucketService_WhoAmI_Call{Call: _e.mock.On("WhoAmI", ctx)}
+}
+
+func (_c *MockbitbucketService_WhoAmI_Call) Run(run func(ctx context.Context)) *MockbitbucketService_WhoAmI_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketService_WhoAmI_Call) Return(_a0 *app.BitbucketIdentities, _a1 error) *MockbitbucketService_WhoAmI_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketService_WhoAmI_Call) RunAndReturn(run func(context.Context) (*app.BitbucketIdentities, error)) *MockbitbucketService_WhoAmI_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockbitbucketService creates a new instance of MockbitbucketService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockbitbucketService(t interface {
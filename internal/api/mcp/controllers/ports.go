@@ -21,6 +21,7 @@ type bitbucketService interface {
 	CreateTask(ctx context.Context, params app.BitbucketCreateTaskParams) (*bitbucket.PullRequestCommentTask, error)
 	GetPRDiffStat(ctx context.Context, params app.BitbucketGetPRDiffStatParams) (*app.PaginatedDiffStat, error)
 	GetPRDiff(ctx context.Context, params app.BitbucketGetPRDiffParams) (string, error)
+	GetPRDiffChunk(ctx context.Context, params app.BitbucketGetPRDiffChunkParams) (*app.PRDiffChunk, error)
 	GetFileContent(ctx context.Context, params app.BitbucketGetFileContentParams) (*bitbucket.FileContentResult, error)
 	AddPRComment(ctx context.Context, params app.BitbucketAddPRCommentParams) (int64, string, error)
 	RequestPRChanges(ctx context.Context, params app.BitbucketRequestPRChangesParams) (string, time.Time, error)
@@ -32,6 +33,82 @@ type bitbucketService interface {
 		ctx context.Context,
 		params app.BitbucketResolvePRCommentParams,
 	) (*bitbucket.CommentResolution, error)
+	CheckPRMergeable(ctx context.Context, params app.BitbucketCheckPRMergeableParams) (*app.PRMergeCheckResult, error)
+	ListBuildStatuses(ctx context.Context, params app.BitbucketListBuildStatusesParams) ([]bitbucket.CommitStatus, error)
+	SetBuildStatus(ctx context.Context, params app.BitbucketSetBuildStatusParams) (*bitbucket.CommitStatus, error)
+	ListPipelines(ctx context.Context, params app.BitbucketListPipelinesParams) ([]bitbucket.Pipeline, error)
+	GetPipeline(ctx context.Context, params app.BitbucketPipelineParams) (*app.PipelineRun, error)
+	TriggerPipeline(ctx context.Context, params app.BitbucketTriggerPipelineParams) (*bitbucket.Pipeline, error)
+	StopPipeline(ctx context.Context, params app.BitbucketPipelineParams) error
+	GetPipelineFailure(ctx context.Context, params app.BitbucketPipelineParams) (*app.PipelineFailure, error)
+	ValidatePipelinesConfig(
+		ctx context.Context,
+		params app.BitbucketValidatePipelinesConfigParams,
+	) (*app.PipelinesConfigValidationResult, error)
+	WaitForPipeline(
+		ctx context.Context,
+		params app.BitbucketWaitForPipelineParams,
+		onProgress app.WaitProgressFunc,
+	) (*app.PipelineWaitResult, error)
+	WaitForBuildStatuses(
+		ctx context.Context,
+		params app.BitbucketWaitForBuildStatusesParams,
+		onProgress app.WaitProgressFunc,
+	) (*app.BuildStatusesWaitResult, error)
+	ListRepositoryVariables(
+		ctx context.Context,
+		params app.BitbucketRepositoryParams,
+	) ([]bitbucket.PipelineVariable, error)
+	CreateRepositoryVariable(
+		ctx context.Context,
+		params app.BitbucketCreateRepositoryVariableParams,
+	) (*bitbucket.PipelineVariable, error)
+	UpdateRepositoryVariable(
+		ctx context.Context,
+		params app.BitbucketUpdateRepositoryVariableParams,
+	) (*bitbucket.PipelineVariable, error)
+	DeleteRepositoryVariable(ctx context.Context, params app.BitbucketDeleteRepositoryVariableParams) error
+	ListEnvironments(
+		ctx context.Context,
+		params app.BitbucketRepositoryParams,
+	) ([]bitbucket.DeploymentEnvironment, error)
+	ListDeployments(ctx context.Context, params app.BitbucketListDeploymentsParams) ([]bitbucket.Deployment, error)
+	GetDeploymentsOverview(
+		ctx context.Context,
+		params app.BitbucketRepositoryParams,
+	) ([]app.EnvironmentDeployments, error)
+	ListBranches(ctx context.Context, params app.BitbucketListBranchesParams) ([]bitbucket.Branch, error)
+	GetBranch(ctx context.Context, params app.BitbucketBranchParams) (*bitbucket.Branch, error)
+	CreateBranch(ctx context.Context, params app.BitbucketCreateBranchParams) (*bitbucket.Branch, error)
+	DeleteBranch(ctx context.Context, params app.BitbucketBranchParams) error
+	CompareBranches(ctx context.Context, params app.BitbucketCompareBranchesParams) (*app.BranchComparison, error)
+	ListTags(ctx context.Context, params app.BitbucketListTagsParams) ([]bitbucket.Tag, error)
+	CreateTag(ctx context.Context, params app.BitbucketCreateTagParams) (*bitbucket.Tag, error)
+	DeleteTag(ctx context.Context, params app.BitbucketTagParams) error
+	ListTagChanges(ctx context.Context, params app.BitbucketListTagChangesParams) (*app.TagChanges, error)
+	GetBranchPolicy(ctx context.Context, params app.BitbucketBranchParams) (*app.BranchPolicy, error)
+	ListDirectory(ctx context.Context, params app.BitbucketListDirectoryParams) (*app.DirectoryListing, error)
+	ListFileHistory(ctx context.Context, params app.BitbucketFileHistoryParams) (*app.FileHistory, error)
+	BlameFileLines(ctx context.Context, params app.BitbucketBlameParams) (*app.FileBlame, error)
+	CommitFiles(ctx context.Context, params app.BitbucketCommitFilesParams) (*app.CommittedFiles, error)
+	OpenChange(ctx context.Context, params app.BitbucketOpenChangeParams) (*app.OpenedChange, error)
+	SearchCode(ctx context.Context, params app.BitbucketSearchCodeParams) (*app.CodeSearchResults, error)
+	ListWorkspaces(ctx context.Context, params app.BitbucketListWorkspacesParams) (*app.WorkspaceList, error)
+	ListRepositories(ctx context.Context, params app.BitbucketListRepositoriesParams) (*app.RepositoryList, error)
+	GetRepository(ctx context.Context, params app.BitbucketRepositoryParams) (*bitbucket.Repository, error)
+	WhoAmI(ctx context.Context) (*app.BitbucketIdentities, error)
+	FindCommitPullRequests(
+		ctx context.Context,
+		params app.BitbucketCommitPullRequestsParams,
+	) (*app.CommitPullRequests, error)
+	SearchIssuePullRequests(
+		ctx context.Context,
+		params app.BitbucketIssuePullRequestsParams,
+	) (*app.IssuePullRequests, error)
+	GetReviewQueue(ctx context.Context, params app.BitbucketReviewQueueParams) (*app.ReviewQueue, error)
+	FindStalePullRequests(ctx context.Context, params app.BitbucketStalePRsParams) (*app.StalePullRequests, error)
+	NudgeStalePullRequests(ctx context.Context, params app.BitbucketNudgeStalePRsParams) (*app.StalePRNudges, error)
+	GetPRReviewBundle(ctx context.Context, params app.BitbucketPRReviewBundleParams) (*app.PRReviewBundle, error)
 }
 
 // Ensure that app.BitbucketService implements bitbucketService.
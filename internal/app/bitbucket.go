@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
 	"go.uber.org/dig"
 )
 
@@ -22,26 +23,85 @@ const (
 
 // BitbucketService provides business logic for Bitbucket operations.
 type BitbucketService struct {
-	client      bitbucketClient
-	authFactory bitbucketAuthFactory
-	logger      *slog.Logger
+	client            bitbucketClient
+	authFactory       bitbucketAuthFactory
+	accountsRepo      AtlassianAccountsRepository
+	logger            *slog.Logger
+	mergePollInterval time.Duration
+	mergeTimeout      time.Duration
+
+	squashMessageTemplatePath string
+
+	waitPollInterval    time.Duration
+	waitMaxPollInterval time.Duration
+	waitTimeout         time.Duration
+
+	watchedRepositories []string
+
+	diffSkipPatterns []string
+
+	now func() time.Time
 }
 
 // BitbucketServiceDeps contains dependencies for the Bitbucket service.
 type BitbucketServiceDeps struct {
 	dig.In
 
-	Client      bitbucketClient
-	AuthFactory bitbucketAuthFactory
-	RootLogger  *slog.Logger
+	Client       bitbucketClient
+	AuthFactory  bitbucketAuthFactory
+	AccountsRepo AtlassianAccountsRepository
+	RootLogger   *slog.Logger
+
+	// MergePollInterval is the delay between checks of an asynchronous merge task
+	MergePollInterval time.Duration `name:"config.atlassian.bitbucket.mergePollInterval"`
+
+	// MergeTimeout is the maximum time to wait for an asynchronous merge to complete
+	MergeTimeout time.Duration `name:"config.atlassian.bitbucket.mergeTimeout"`
+
+	// SquashMessageTemplatePath is the path of the squash message template in repositories
+	SquashMessageTemplatePath string `name:"config.atlassian.bitbucket.squashMessageTemplatePath"`
+
+	// WaitPollInterval is the initial delay between checks when waiting for CI
+	WaitPollInterval time.Duration `name:"config.atlassian.bitbucket.waitPollInterval"`
+
+	// WaitMaxPollInterval is the maximum delay between checks when waiting for CI
+	WaitMaxPollInterval time.Duration `name:"config.atlassian.bitbucket.waitMaxPollInterval"`
+
+	// WaitTimeout is the default and maximum time to wait for CI
+	WaitTimeout time.Duration `name:"config.atlassian.bitbucket.waitTimeout"`
+
+	// WatchedRepositories are full names (workspace/repo_slug) of repositories used by cross-repository
+	// tools when neither a workspace nor repositories are given
+	WatchedRepositories []string `name:"config.atlassian.bitbucket.watchedRepositories"`
+
+	// DiffSkipPatterns are globs of generated and vendored files left out of diffs when asked to
+	DiffSkipPatterns []string `name:"config.atlassian.bitbucket.diffSkipPatterns"`
+
+	// Now returns the current time (optional, defaults to time.Now)
+	Now func() time.Time `optional:"true"`
 }
 
 // NewBitbucketService creates a new Bitbucket service.
 func NewBitbucketService(deps BitbucketServiceDeps) *BitbucketService {
 	return &BitbucketService{
-		client:      deps.Client,
-		authFactory: deps.AuthFactory,
-		logger:      deps.RootLogger.WithGroup("app.bitbucket-service"),
+		client:            deps.Client,
+		authFactory:       deps.AuthFactory,
+		accountsRepo:      deps.AccountsRepo,
+		logger:            deps.RootLogger.WithGroup("app.bitbucket-service"),
+		mergePollInterval: deps.MergePollInterval,
+		mergeTimeout:      deps.MergeTimeout,
+
+		squashMessageTemplatePath: deps.SquashMessageTemplatePath,
+
+		waitPollInterval:    deps.WaitPollInterval,
+		waitMaxPollInterval: deps.WaitMaxPollInterval,
+		waitTimeout:         deps.WaitTimeout,
+
+		watchedRepositories: deps.WatchedRepositories,
+
+		diffSkipPatterns: deps.DiffSkipPatterns,
+
+		now: lo.Ternary(deps.Now != nil, deps.Now, time.Now),
 	}
 }
 
@@ -168,6 +228,10 @@ type BitbucketMergePRParams struct {
 
 	// Merge strategy (merge_commit, squash, fast_forward)
 	MergeStrategy string `json:"merge_strategy,omitempty"`
+
+	// Whether to generate the merge commit message from the pull request title, commits and Jira keys.
+	// Can not be combined with Message.
+	ComposeMessage bool `json:"compose_message,omitempty"`
 }
 
 // BitbucketListTasksParams contains parameters for listing tasks on a pull request.
@@ -537,14 +601,26 @@ func (s *BitbucketService) MergePR(ctx context.Context, params BitbucketMergePRP
 	if params.MergeStrategy != "" && !isValidMergeStrategy(params.MergeStrategy) {
 		return nil, errors.New("invalid merge strategy: must be one of merge_commit, squash, or fast_forward")
 	}
+	if params.ComposeMessage && params.Message != "" {
+		return nil, errors.New("message can not be provided when composing the merge message")
+	}
 
 	// Get token provider from auth factory
 	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
 
+	message := params.Message
+	if params.ComposeMessage {
+		composed, err := s.composeMergeMessage(ctx, tokenProvider, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose merge message: %w", err)
+		}
+		message = composed
+	}
+
 	// Create merge parameters
 	mergeParams := &bitbucket.PullRequestMergeParameters{
 		CloseSourceBranch: params.CloseSourceBranch,
-		Message:           params.Message,
+		Message:           message,
 	}
 
 	// Only add merge strategy if specified
@@ -552,12 +628,28 @@ func (s *BitbucketService) MergePR(ctx context.Context, params BitbucketMergePRP
 		mergeParams.MergeStrategy = params.MergeStrategy
 	}
 
-	// Call the client to merge the pull request
-	pr, err := s.client.MergePR(ctx, tokenProvider, bitbucket.MergePRParams{
+	// Call the client to merge the pull request. The merge is requested asynchronously
+	// so that long merges are polled instead of failing with a timeout.
+	result, err := s.client.MergePR(ctx, tokenProvider, bitbucket.MergePRParams{
 		Username:        params.RepoOwner,
 		RepoSlug:        params.RepoName,
 		PullRequestID:   params.PullRequestID,
 		MergeParameters: mergeParams,
+		Async:           true,
+	})
+	if err != nil {
+		s.explainMergeRejection(ctx, tokenProvider, params, err)
+		return nil, fmt.Errorf("failed to merge pull request: %w", err)
+	}
+	if result.PullRequest != nil {
+		return result.PullRequest, nil
+	}
+
+	pr, err := s.waitForMergeTask(ctx, tokenProvider, bitbucket.GetMergeTaskStatusParams{
+		Username:      params.RepoOwner,
+		RepoSlug:      params.RepoName,
+		PullRequestID: params.PullRequestID,
+		TaskID:        result.TaskID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge pull request: %w", err)
@@ -566,6 +658,53 @@ func (s *BitbucketService) MergePR(ctx context.Context, params BitbucketMergePRP
 	return pr, nil
 }
 
+// waitForMergeTask polls the merge task until it completes or the merge timeout is reached.
+func (s *BitbucketService) waitForMergeTask(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params bitbucket.GetMergeTaskStatusParams,
+) (*bitbucket.PullRequest, error) {
+	s.logger.InfoContext(ctx, "Waiting for merge task to complete",
+		slog.String("task_id", params.TaskID),
+		slog.Int("pr_id", params.PullRequestID))
+
+	deadline := time.NewTimer(s.mergeTimeout)
+	defer deadline.Stop()
+
+	for {
+		status, err := s.client.GetMergeTaskStatus(ctx, tokenProvider, params)
+		if err != nil {
+			return nil, err
+		}
+		if status.TaskStatus == bitbucket.MergeTaskStatusSuccess {
+			if status.MergeResult != nil {
+				return status.MergeResult, nil
+			}
+			// Merge result is expected on success, fetch the pull request to report the merge commit
+			pr, prErr := s.client.GetPR(ctx, tokenProvider, bitbucket.GetPRParams{
+				Username:      params.Username,
+				RepoSlug:      params.RepoSlug,
+				PullRequestID: params.PullRequestID,
+			})
+			if prErr != nil {
+				return nil, fmt.Errorf("failed to get merged pull request: %w", prErr)
+			}
+			return pr, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, &bitbucket.MergeError{
+				Reason:  bitbucket.MergeErrorReasonTimeout,
+				Message: fmt.Sprintf("merge task %s did not complete within %s", params.TaskID, s.mergeTimeout),
+			}
+		case <-time.After(s.mergePollInterval):
+		}
+	}
+}
+
 // isValidMergeStrategy checks if the provided merge strategy is valid.
 func isValidMergeStrategy(strategy string) bool {
 	validStrategies := map[string]bool{
@@ -794,40 +933,6 @@ func (s *BitbucketService) GetPRDiff(
 	return diffResult, nil
 }
 
-type BitbucketGetFileContentParams struct {
-	AccountName string
-	RepoOwner   string
-	RepoName    string
-	Commit      string
-	Path        string
-}
-
-func (s *BitbucketService) GetFileContent(
-	ctx context.Context,
-	params BitbucketGetFileContentParams,
-) (*bitbucket.FileContentResult, error) {
-	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
-	clientParams := bitbucket.GetFileContentParams{
-		RepoOwner:  params.RepoOwner,
-		RepoName:   params.RepoName,
-		CommitHash: params.Commit,
-		FilePath:   params.Path,
-	}
-	fileContent, err := s.client.GetFileContent(ctx, tokenProvider, clientParams)
-	if err != nil {
-		return nil, err
-	}
-	// Minimal stub: meta fields are hardcoded for now
-	return &bitbucket.FileContentResult{
-		Content: fileContent.Content,
-		Meta: bitbucket.FileContentMeta{
-			Size:     len(fileContent.Content),
-			Type:     "file",
-			Encoding: "utf-8",
-		},
-	}, nil
-}
-
 type BitbucketAddPRCommentParams struct {
 	AccountName   string
 	RepoOwner     string
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+// BranchAccessList lists users and groups allowed to perform a restricted action.
+type BranchAccessList struct {
+	Users  []string `json:"users"`
+	Groups []string `json:"groups"`
+}
+
+// BranchPolicy is the combined effect of the branch restrictions that apply to a branch.
+type BranchPolicy struct {
+	// Branch is the branch name or pattern the policy was resolved for.
+	Branch string `json:"branch"`
+
+	RequiredApprovals                int  `json:"required_approvals"`
+	RequiredDefaultReviewerApprovals int  `json:"required_default_reviewer_approvals"`
+	RequiredPassingBuilds            int  `json:"required_passing_builds"`
+	RequireTasksCompleted            bool `json:"require_tasks_completed"`
+	RequireNoChangesRequested        bool `json:"require_no_changes_requested"`
+
+	// PushAllowedTo lists who can push to the branch, nil when anyone with write access can push.
+	PushAllowedTo *BranchAccessList `json:"push_allowed_to,omitempty"`
+
+	// MergeAllowedTo lists who can merge pull requests into the branch,
+	// nil when anyone with write access can merge.
+	MergeAllowedTo *BranchAccessList `json:"merge_allowed_to,omitempty"`
+
+	ForcePushPrevented bool `json:"force_push_prevented"`
+	DeletionPrevented  bool `json:"deletion_prevented"`
+
+	// Restrictions are the branch restrictions the policy was built from.
+	Restrictions []bitbucket.BranchRestriction `json:"restrictions"`
+}
+
+// newBranchPolicy combines restrictions that apply to the branch into a policy.
+// When several restrictions of the same kind apply, the strictest one wins.
+func newBranchPolicy(restrictions []bitbucket.BranchRestriction, branch string) BranchPolicy {
+	policy := BranchPolicy{Branch: branch, Restrictions: []bitbucket.BranchRestriction{}}
+	for _, restriction := range restrictions {
+		if !restriction.MatchesBranch(branch) {
+			continue
+		}
+		policy.Restrictions = append(policy.Restrictions, restriction)
+		value := lo.FromPtr(restriction.Value)
+		switch restriction.Kind {
+		case bitbucket.BranchRestrictionRequireApprovals:
+			policy.RequiredApprovals = max(policy.RequiredApprovals, value)
+		case bitbucket.BranchRestrictionRequireDefaultReviewerApprovals:
+			policy.RequiredDefaultReviewerApprovals = max(policy.RequiredDefaultReviewerApprovals, value)
+		case bitbucket.BranchRestrictionRequirePassingBuilds:
+			policy.RequiredPassingBuilds = max(policy.RequiredPassingBuilds, value)
+		case bitbucket.BranchRestrictionRequireTasksCompleted:
+			policy.RequireTasksCompleted = true
+		case bitbucket.BranchRestrictionRequireNoChangesRequested:
+			policy.RequireNoChangesRequested = true
+		case bitbucket.BranchRestrictionPush:
+			policy.PushAllowedTo = appendBranchAccess(policy.PushAllowedTo, restriction)
+		case bitbucket.BranchRestrictionRestrictMerges:
+			policy.MergeAllowedTo = appendBranchAccess(policy.MergeAllowedTo, restriction)
+		case bitbucket.BranchRestrictionForce:
+			policy.ForcePushPrevented = true
+		case bitbucket.BranchRestrictionDelete:
+			policy.DeletionPrevented = true
+		}
+	}
+	return policy
+}
+
+func appendBranchAccess(access *BranchAccessList, restriction bitbucket.BranchRestriction) *BranchAccessList {
+	if access == nil {
+		access = &BranchAccessList{Users: []string{}, Groups: []string{}}
+	}
+	for _, user := range restriction.Users {
+		access.Users = append(access.Users, lo.CoalesceOrEmpty(user.DisplayName, user.Nickname, user.UUID))
+	}
+	for _, group := range restriction.Groups {
+		access.Groups = append(access.Groups, lo.CoalesceOrEmpty(group.Name, group.Slug))
+	}
+	return access
+}
+
+// String renders users and groups of the access list.
+func (a BranchAccessList) String() string {
+	names := slices.Clone(a.Users)
+	for _, group := range a.Groups {
+		names = append(names, "group "+group)
+	}
+	if len(names) == 0 {
+		return "nobody"
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetBranchPolicy reads branch restrictions of a repository and resolves the policy that applies to a branch.
+// Reading branch restrictions requires admin access to the repository.
+func (s *BitbucketService) GetBranchPolicy(ctx context.Context, params BitbucketBranchParams) (*BranchPolicy, error) {
+	s.logger.InfoContext(ctx, "Getting branch policy",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Name))
+
+	if err := validateBranchParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	restrictions, err := s.client.ListBranchRestrictions(ctx, tokenProvider, bitbucket.ListBranchRestrictionsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch restrictions: %w", err)
+	}
+
+	policy := newBranchPolicy(restrictions, params.Name)
+	return &policy, nil
+}
+
+// explainMergeRejection lists branch restrictions that block the merge on a rejected merge error.
+// The explanation is best effort, failures to gather it are logged and the error is left as is.
+func (s *BitbucketService) explainMergeRejection(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketMergePRParams,
+	err error,
+) {
+	var mergeErr *bitbucket.MergeError
+	if !errors.As(err, &mergeErr) || mergeErr.Reason != bitbucket.MergeErrorReasonRejected {
+		return
+	}
+
+	result, policy, checkErr := s.checkPRMergeable(ctx, tokenProvider, BitbucketCheckPRMergeableParams{
+		AccountName:   params.AccountName,
+		RepoOwner:     params.RepoOwner,
+		RepoName:      params.RepoName,
+		PullRequestID: params.PullRequestID,
+	})
+	if checkErr != nil {
+		s.logger.WarnContext(ctx, "Failed to explain merge rejection", slog.Any("error", checkErr))
+		return
+	}
+	mergeErr.BlockedBy = blockingRestrictions(result, policy)
+}
+
+// blockingRestrictions describes restrictions of failed merge checks. Restrictions that can not be
+// verified are reported only when no failed check explains the rejection.
+func blockingRestrictions(result *PRMergeCheckResult, policy BranchPolicy) []string {
+	var blockedBy []string
+	for _, check := range result.Checks {
+		if !check.Passed && check.Restriction != "" {
+			details := strings.TrimSuffix(check.Details, enforcedSuffix(true))
+			blockedBy = append(blockedBy, fmt.Sprintf("%s (%s)", check.Restriction, details))
+		}
+	}
+	if len(blockedBy) > 0 {
+		return blockedBy
+	}
+	if policy.RequiredDefaultReviewerApprovals > 0 {
+		blockedBy = append(blockedBy, fmt.Sprintf("%s (%d approvals of default reviewers required)",
+			bitbucket.BranchRestrictionRequireDefaultReviewerApprovals, policy.RequiredDefaultReviewerApprovals))
+	}
+	if access := policy.MergeAllowedTo; access != nil {
+		blockedBy = append(blockedBy, fmt.Sprintf("%s (only %s can merge)",
+			bitbucket.BranchRestrictionRestrictMerges, access))
+	}
+	return blockedBy
+}
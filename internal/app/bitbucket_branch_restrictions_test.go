@@ -0,0 +1,250 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_BranchRestrictions(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newRestriction := func(kind, pattern string, value *int) bitbucket.BranchRestriction {
+		return bitbucket.BranchRestriction{
+			ID:              int(faker.RandomUnixTime()) % 10000,
+			Kind:            kind,
+			BranchMatchKind: bitbucket.BranchMatchKindGlob,
+			Pattern:         pattern,
+			Value:           value,
+		}
+	}
+
+	t.Run("GetBranchPolicy", func(t *testing.T) {
+		t.Run("should combine restrictions that apply to the branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams(), Name: "release/1.0"}
+			tokenProvider := setupTokenProvider(t, deps, params.AccountName)
+
+			push := newRestriction(bitbucket.BranchRestrictionPush, "release/*", nil)
+			push.Users = []bitbucket.Account{{DisplayName: "Release Manager"}}
+			push.Groups = append(push.Groups, struct {
+				Name string `json:"name,omitempty"`
+				Slug string `json:"slug,omitempty"`
+			}{Slug: "releasers"})
+			matching := []bitbucket.BranchRestriction{
+				newRestriction(bitbucket.BranchRestrictionRequireApprovals, "*", lo.ToPtr(1)),
+				newRestriction(bitbucket.BranchRestrictionRequireApprovals, "release/*", lo.ToPtr(2)),
+				newRestriction(bitbucket.BranchRestrictionRequireDefaultReviewerApprovals, "*", lo.ToPtr(1)),
+				newRestriction(bitbucket.BranchRestrictionRequirePassingBuilds, "release/*", lo.ToPtr(3)),
+				newRestriction(bitbucket.BranchRestrictionRequireTasksCompleted, "*", nil),
+				newRestriction(bitbucket.BranchRestrictionRequireNoChangesRequested, "*", nil),
+				push,
+				newRestriction(bitbucket.BranchRestrictionForce, "*", nil),
+				newRestriction(bitbucket.BranchRestrictionDelete, "release/*", nil),
+			}
+			restrictions := append([]bitbucket.BranchRestriction{
+				newRestriction(bitbucket.BranchRestrictionRestrictMerges, "main", nil),
+			}, matching...)
+			mockClient.EXPECT().ListBranchRestrictions(mock.Anything, tokenProvider, bitbucket.ListBranchRestrictionsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+			}).Return(restrictions, nil)
+			service := NewBitbucketService(deps)
+
+			policy, err := service.GetBranchPolicy(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, &BranchPolicy{
+				Branch:                           params.Name,
+				RequiredApprovals:                2,
+				RequiredDefaultReviewerApprovals: 1,
+				RequiredPassingBuilds:            3,
+				RequireTasksCompleted:            true,
+				RequireNoChangesRequested:        true,
+				PushAllowedTo: &BranchAccessList{
+					Users:  []string{"Release Manager"},
+					Groups: []string{"releasers"},
+				},
+				ForcePushPrevented: true,
+				DeletionPrevented:  true,
+				Restrictions:       matching,
+			}, policy)
+		})
+
+		t.Run("should fail when restrictions can not be listed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams(), Name: "main"}
+			setupTokenProvider(t, deps, params.AccountName)
+			expectedErr := errors.New(faker.Sentence())
+			mockClient.EXPECT().ListBranchRestrictions(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			policy, err := service.GetBranchPolicy(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, policy)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.GetBranchPolicy(t.Context(), BitbucketBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "branch name is required")
+		})
+	})
+
+	t.Run("MergePR", func(t *testing.T) {
+		type fixture struct {
+			params       BitbucketMergePRParams
+			pr           *bitbucket.PullRequest
+			tasks        *bitbucket.PaginatedTasks
+			restrictions []bitbucket.BranchRestriction
+		}
+
+		makeFixture := func() fixture {
+			repoParams := makeRepoParams()
+			pr := bitbucket.NewRandomPullRequest()
+			pr.Participants = []bitbucket.Participant{*bitbucket.NewRandomParticipant(true)}
+			pr.Source.Commit = &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()}
+			return fixture{
+				params: BitbucketMergePRParams{
+					AccountName:   repoParams.AccountName,
+					RepoOwner:     repoParams.RepoOwner,
+					RepoName:      repoParams.RepoName,
+					PullRequestID: pr.ID,
+				},
+				pr:    pr,
+				tasks: &bitbucket.PaginatedTasks{},
+			}
+		}
+
+		setupRejectedMerge := func(t *testing.T, deps BitbucketServiceDeps, f fixture) *bitbucket.MergeError {
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			tokenProvider := setupTokenProvider(t, deps, f.params.AccountName)
+			mergeErr := &bitbucket.MergeError{
+				Reason:  bitbucket.MergeErrorReasonRejected,
+				Message: faker.Sentence(),
+			}
+			mockClient.EXPECT().MergePR(mock.Anything, tokenProvider, mock.Anything).Return(nil, mergeErr)
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, mock.Anything).Return(f.pr, nil)
+			mockClient.EXPECT().ListPullRequestTasks(mock.Anything, tokenProvider, mock.Anything).Return(f.tasks, nil)
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, mock.Anything).Return(nil, nil)
+			mockClient.EXPECT().GetPRDiffStat(mock.Anything, tokenProvider, mock.Anything).Return(&struct {
+				Size    int                  `json:"size,omitempty"`
+				Page    int                  `json:"page,omitempty"`
+				PageLen int                  `json:"pagelen,omitempty"`
+				Values  []bitbucket.DiffStat `json:"values"`
+			}{}, nil)
+			mockClient.EXPECT().ListBranchRestrictions(mock.Anything, tokenProvider, mock.Anything).
+				Return(f.restrictions, nil)
+			return mergeErr
+		}
+
+		t.Run("should explain which restrictions blocked the merge", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			f := makeFixture()
+			f.tasks = &bitbucket.PaginatedTasks{Size: 2}
+			destination := f.pr.Destination.Branch.Name
+			f.restrictions = []bitbucket.BranchRestriction{
+				newRestriction(bitbucket.BranchRestrictionRequireApprovals, destination, lo.ToPtr(2)),
+				newRestriction(bitbucket.BranchRestrictionRequireTasksCompleted, "*", nil),
+				newRestriction(bitbucket.BranchRestrictionRequireNoChangesRequested, "*", nil),
+				newRestriction(bitbucket.BranchRestrictionRestrictMerges, "*", nil),
+			}
+			mergeErr := setupRejectedMerge(t, deps, f)
+			service := NewBitbucketService(deps)
+
+			result, err := service.MergePR(t.Context(), f.params)
+
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, mergeErr)
+			assert.Equal(t, []string{
+				"require_approvals_to_merge (1 approvals, 2 required)",
+				"require_tasks_to_be_completed (2 unresolved tasks)",
+			}, mergeErr.BlockedBy)
+			assert.Contains(t, err.Error(), "blocked by require_approvals_to_merge")
+		})
+
+		t.Run("should report restrictions that can not be verified", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			f := makeFixture()
+			restrictMerges := newRestriction(bitbucket.BranchRestrictionRestrictMerges, "*", nil)
+			restrictMerges.Users = []bitbucket.Account{{Nickname: "lead"}}
+			f.restrictions = []bitbucket.BranchRestriction{
+				newRestriction(bitbucket.BranchRestrictionRequireApprovals, "*", lo.ToPtr(1)),
+				newRestriction(bitbucket.BranchRestrictionRequireDefaultReviewerApprovals, "*", lo.ToPtr(1)),
+				restrictMerges,
+			}
+			mergeErr := setupRejectedMerge(t, deps, f)
+			service := NewBitbucketService(deps)
+
+			_, err := service.MergePR(t.Context(), f.params)
+
+			require.ErrorIs(t, err, mergeErr)
+			assert.Equal(t, []string{
+				"require_default_reviewer_approvals_to_merge (1 approvals of default reviewers required)",
+				"restrict_merges (only lead can merge)",
+			}, mergeErr.BlockedBy)
+		})
+
+		t.Run("should keep merge error when explanation fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			f := makeFixture()
+			tokenProvider := setupTokenProvider(t, deps, f.params.AccountName)
+			mergeErr := &bitbucket.MergeError{Reason: bitbucket.MergeErrorReasonRejected, Message: faker.Sentence()}
+			mockClient.EXPECT().MergePR(mock.Anything, tokenProvider, mock.Anything).Return(nil, mergeErr)
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, mock.Anything).
+				Return(nil, errors.New(faker.Sentence()))
+			service := NewBitbucketService(deps)
+
+			_, err := service.MergePR(t.Context(), f.params)
+
+			require.ErrorIs(t, err, mergeErr)
+			assert.Empty(t, mergeErr.BlockedBy)
+		})
+
+		t.Run("should not explain merges that were not rejected", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			f := makeFixture()
+			tokenProvider := setupTokenProvider(t, deps, f.params.AccountName)
+			mergeErr := &bitbucket.MergeError{Reason: bitbucket.MergeErrorReasonConflict, Message: faker.Sentence()}
+			mockClient.EXPECT().MergePR(mock.Anything, tokenProvider, mock.Anything).Return(nil, mergeErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.MergePR(t.Context(), f.params)
+
+			require.ErrorIs(t, err, mergeErr)
+			assert.Empty(t, mergeErr.BlockedBy)
+		})
+	})
+}
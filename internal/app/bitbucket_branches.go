@@ -0,0 +1,323 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+const (
+	// branchesDefaultLimit is the number of branches listed when no limit is given.
+	branchesDefaultLimit = 50
+
+	// branchesMaxLimit is the maximum page size accepted by the branches API.
+	branchesMaxLimit = 100
+
+	// branchCompareMaxCommits limits the number of commits counted in each direction of a comparison.
+	branchCompareMaxCommits = 500
+
+	// branchCompareListedCommits is the number of commits ahead of the destination included in a comparison.
+	branchCompareListedCommits = 20
+
+	// commitsPageLen is the page size used when listing commits.
+	commitsPageLen = 100
+)
+
+// BitbucketListBranchesParams contains parameters for listing branches of a repository.
+type BitbucketListBranchesParams struct {
+	BitbucketRepositoryParams
+
+	// Only list branches whose name contains this text (optional)
+	Filter string `json:"filter,omitempty"`
+
+	// Sort field, prefix with - for descending order, e.g. name or -target.date (optional)
+	Sort string `json:"sort,omitempty"`
+
+	// Maximum number of branches to return (optional)
+	Limit int `json:"limit,omitempty"`
+}
+
+// BitbucketBranchParams identifies a branch of a repository.
+type BitbucketBranchParams struct {
+	BitbucketRepositoryParams
+
+	// Branch name
+	Name string `json:"name"`
+}
+
+// BitbucketCreateBranchParams contains parameters for creating a branch.
+type BitbucketCreateBranchParams struct {
+	BitbucketRepositoryParams
+
+	// Name of the new branch
+	Name string `json:"name"`
+
+	// Branch name or commit hash the new branch starts from
+	From string `json:"from"`
+}
+
+// BitbucketCompareBranchesParams contains parameters for comparing two branches.
+type BitbucketCompareBranchesParams struct {
+	BitbucketRepositoryParams
+
+	// Branch that is compared, e.g. a feature branch
+	Source string `json:"source"`
+
+	// Branch the source is compared against, e.g. main
+	Destination string `json:"destination"`
+}
+
+// BranchComparison describes how far two branches have diverged.
+type BranchComparison struct {
+	Source      *bitbucket.Branch `json:"source"`
+	Destination *bitbucket.Branch `json:"destination"`
+
+	// Ahead is the number of commits on the source branch that are not on the destination branch.
+	Ahead int `json:"ahead"`
+
+	// Behind is the number of commits on the destination branch that are not on the source branch.
+	Behind int `json:"behind"`
+
+	// AheadTruncated and BehindTruncated are true when counting stopped early,
+	// the corresponding count is a lower bound then.
+	AheadTruncated  bool `json:"ahead_truncated,omitempty"`
+	BehindTruncated bool `json:"behind_truncated,omitempty"`
+
+	// AheadCommits are the most recent commits ahead of the destination branch, newest first.
+	AheadCommits []bitbucket.Commit `json:"ahead_commits"`
+}
+
+// ListBranches lists open branches of a repository.
+func (s *BitbucketService) ListBranches(
+	ctx context.Context,
+	params BitbucketListBranchesParams,
+) ([]bitbucket.Branch, error) {
+	s.logger.InfoContext(ctx, "Listing branches",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("filter", params.Filter))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = branchesDefaultLimit
+	}
+	limit = min(limit, branchesMaxLimit)
+
+	listParams := bitbucket.ListBranchesParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Sort:      params.Sort,
+		PageLen:   limit,
+	}
+	if params.Filter != "" {
+		listParams.Query = "name ~ " + strconv.Quote(params.Filter)
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	page, err := s.client.ListBranches(ctx, tokenProvider, listParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	return page.Values, nil
+}
+
+// GetBranch returns a branch of a repository. It fails when the branch does not exist.
+func (s *BitbucketService) GetBranch(ctx context.Context, params BitbucketBranchParams) (*bitbucket.Branch, error) {
+	s.logger.InfoContext(ctx, "Getting branch",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Name))
+
+	if err := validateBranchParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	return s.getBranch(ctx, tokenProvider, params.BitbucketRepositoryParams, params.Name)
+}
+
+// CreateBranch creates a branch starting from another branch or a commit.
+func (s *BitbucketService) CreateBranch(
+	ctx context.Context,
+	params BitbucketCreateBranchParams,
+) (*bitbucket.Branch, error) {
+	s.logger.InfoContext(ctx, "Creating branch",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Name),
+		slog.String("from", params.From))
+
+	if err := validateBranchParams(BitbucketBranchParams{
+		BitbucketRepositoryParams: params.BitbucketRepositoryParams,
+		Name:                      params.Name,
+	}); err != nil {
+		return nil, err
+	}
+	if params.From == "" {
+		return nil, errors.New("source branch or commit is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	branch, err := s.client.CreateBranch(ctx, tokenProvider, bitbucket.CreateBranchParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Name:      params.Name,
+		Target:    params.From,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return branch, nil
+}
+
+// DeleteBranch deletes a branch of a repository.
+func (s *BitbucketService) DeleteBranch(ctx context.Context, params BitbucketBranchParams) error {
+	s.logger.InfoContext(ctx, "Deleting branch",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Name))
+
+	if err := validateBranchParams(params); err != nil {
+		return err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	if err := s.client.DeleteBranch(ctx, tokenProvider, bitbucket.DeleteBranchParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Name:      params.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+
+	return nil
+}
+
+// CompareBranches counts commits the source branch is ahead of and behind the destination branch.
+func (s *BitbucketService) CompareBranches(
+	ctx context.Context,
+	params BitbucketCompareBranchesParams,
+) (*BranchComparison, error) {
+	s.logger.InfoContext(ctx, "Comparing branches",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("source", params.Source),
+		slog.String("destination", params.Destination))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Source == "" {
+		return nil, errors.New("source branch is required")
+	}
+	if params.Destination == "" {
+		return nil, errors.New("destination branch is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	source, err := s.getBranch(ctx, tokenProvider, params.BitbucketRepositoryParams, params.Source)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.getBranch(ctx, tokenProvider, params.BitbucketRepositoryParams, params.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	// Heads are compared rather than names so both directions see the same snapshot.
+	sourceHead, destinationHead := branchHead(source), branchHead(destination)
+	ahead, err := s.listCommitsBetween(ctx, tokenProvider, params.BitbucketRepositoryParams, sourceHead, destinationHead)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := s.listCommitsBetween(ctx, tokenProvider, params.BitbucketRepositoryParams, destinationHead, sourceHead)
+	if err != nil {
+		return nil, err
+	}
+
+	// One commit over the limit is listed to tell a branch of exactly the limit from a longer one.
+	aheadTruncated, behindTruncated := len(ahead) > branchCompareMaxCommits, len(behind) > branchCompareMaxCommits
+	ahead = ahead[:min(len(ahead), branchCompareMaxCommits)]
+	behind = behind[:min(len(behind), branchCompareMaxCommits)]
+
+	return &BranchComparison{
+		Source:          source,
+		Destination:     destination,
+		Ahead:           len(ahead),
+		Behind:          len(behind),
+		AheadTruncated:  aheadTruncated,
+		BehindTruncated: behindTruncated,
+		AheadCommits:    ahead[:min(len(ahead), branchCompareListedCommits)],
+	}, nil
+}
+
+func (s *BitbucketService) getBranch(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	name string,
+) (*bitbucket.Branch, error) {
+	branch, err := s.client.GetBranch(ctx, tokenProvider, bitbucket.GetBranchParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Name:      name,
+	})
+	if err != nil {
+		var httpErr *middleware.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("branch %s not found: %w", name, err)
+		}
+		return nil, fmt.Errorf("failed to get branch %s: %w", name, err)
+	}
+	return branch, nil
+}
+
+// listCommitsBetween lists commits reachable from include but not from exclude, newest first.
+func (s *BitbucketService) listCommitsBetween(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	include string,
+	exclude string,
+) ([]bitbucket.Commit, error) {
+	commits, err := s.client.ListCommits(ctx, tokenProvider, bitbucket.ListCommitsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Include:   []string{include},
+		Exclude:   []string{exclude},
+		PageLen:   commitsPageLen,
+		Limit:     branchCompareMaxCommits + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	if commits == nil {
+		commits = []bitbucket.Commit{}
+	}
+	return commits, nil
+}
+
+// branchHead returns the head commit hash of a branch, falling back to its name.
+func branchHead(branch *bitbucket.Branch) string {
+	if branch.Target != nil && branch.Target.Hash != "" {
+		return branch.Target.Hash
+	}
+	return branch.Name
+}
+
+func validateBranchParams(params BitbucketBranchParams) error {
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return err
+	}
+	if params.Name == "" {
+		return errors.New("branch name is required")
+	}
+	return nil
+}
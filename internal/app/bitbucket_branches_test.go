@@ -0,0 +1,322 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Branches(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newBranch := func(name string) *bitbucket.Branch {
+		return &bitbucket.Branch{
+			Type:   "branch",
+			Name:   name,
+			Target: &bitbucket.Commit{Hash: faker.UUIDDigit()},
+		}
+	}
+
+	newCommits := func(count int) []bitbucket.Commit {
+		return lo.Times(count, func(_ int) bitbucket.Commit {
+			return bitbucket.Commit{Hash: faker.UUIDDigit()}
+		})
+	}
+
+	t.Run("ListBranches", func(t *testing.T) {
+		t.Run("should list branches matching filter", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketListBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Filter:                    "feature/" + faker.Word(),
+				Sort:                      "-target.date",
+				Limit:                     branchesMaxLimit + 1,
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			branches := []bitbucket.Branch{*newBranch(params.Filter + "-1"), *newBranch(params.Filter + "-2")}
+
+			mockClient.EXPECT().ListBranches(mock.Anything, tokenProvider, bitbucket.ListBranchesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Query:     `name ~ "` + params.Filter + `"`,
+				Sort:      params.Sort,
+				PageLen:   branchesMaxLimit,
+			}).Return(&bitbucket.Paginated[bitbucket.Branch]{Values: branches}, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListBranches(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, branches, got)
+		})
+
+		t.Run("should use default limit without filter", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketListBranchesParams{BitbucketRepositoryParams: makeRepoParams()}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			expectedErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListBranches(mock.Anything, tokenProvider, bitbucket.ListBranchesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				PageLen:   branchesDefaultLimit,
+			}).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListBranches(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+	})
+
+	t.Run("GetBranch", func(t *testing.T) {
+		t.Run("should get branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams(), Name: "feature/" + faker.Word()}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			branch := newBranch(params.Name)
+
+			mockClient.EXPECT().GetBranch(mock.Anything, tokenProvider, bitbucket.GetBranchParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Name:      params.Name,
+			}).Return(branch, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.GetBranch(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, branch, got)
+		})
+
+		t.Run("should report missing branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams(), Name: "feature/" + faker.Word()}
+			setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			notFoundErr := &middleware.HTTPError{StatusCode: http.StatusNotFound}
+
+			mockClient.EXPECT().GetBranch(mock.Anything, mock.Anything, mock.Anything).Return(nil, notFoundErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.GetBranch(t.Context(), params)
+
+			require.ErrorIs(t, err, notFoundErr)
+			assert.ErrorContains(t, err, "branch "+params.Name+" not found")
+		})
+
+		t.Run("should require branch name", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.GetBranch(t.Context(), BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams()})
+
+			require.ErrorContains(t, err, "branch name is required")
+		})
+	})
+
+	t.Run("CreateBranch", func(t *testing.T) {
+		t.Run("should create branch from another branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketCreateBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "feature/" + faker.Word(),
+				From:                      "main",
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			branch := newBranch(params.Name)
+
+			mockClient.EXPECT().CreateBranch(mock.Anything, tokenProvider, bitbucket.CreateBranchParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Name:      params.Name,
+				Target:    params.From,
+			}).Return(branch, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.CreateBranch(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, branch, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.CreateBranch(t.Context(), BitbucketCreateBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				From:                      "main",
+			})
+			require.ErrorContains(t, err, "branch name is required")
+
+			_, err = service.CreateBranch(t.Context(), BitbucketCreateBranchParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      faker.Word(),
+			})
+			require.ErrorContains(t, err, "source branch or commit is required")
+		})
+	})
+
+	t.Run("DeleteBranch", func(t *testing.T) {
+		t.Run("should delete branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketBranchParams{BitbucketRepositoryParams: makeRepoParams(), Name: "feature/" + faker.Word()}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			expectedErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().DeleteBranch(mock.Anything, tokenProvider, bitbucket.DeleteBranchParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Name:      params.Name,
+			}).Return(expectedErr)
+			service := NewBitbucketService(deps)
+
+			err := service.DeleteBranch(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+	})
+
+	t.Run("CompareBranches", func(t *testing.T) {
+		setupCompare := func(
+			t *testing.T,
+			deps BitbucketServiceDeps,
+			params BitbucketCompareBranchesParams,
+			ahead []bitbucket.Commit,
+			behind []bitbucket.Commit,
+		) (*bitbucket.Branch, *bitbucket.Branch) {
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			source := newBranch(params.Source)
+			destination := newBranch(params.Destination)
+			for _, branch := range []*bitbucket.Branch{source, destination} {
+				mockClient.EXPECT().GetBranch(mock.Anything, tokenProvider, bitbucket.GetBranchParams{
+					Workspace: params.RepoOwner,
+					RepoSlug:  params.RepoName,
+					Name:      branch.Name,
+				}).Return(branch, nil)
+			}
+			listParams := bitbucket.ListCommitsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				PageLen:   commitsPageLen,
+				Limit:     branchCompareMaxCommits + 1,
+			}
+			aheadParams := listParams
+			aheadParams.Include = []string{source.Target.Hash}
+			aheadParams.Exclude = []string{destination.Target.Hash}
+			mockClient.EXPECT().ListCommits(mock.Anything, tokenProvider, aheadParams).Return(ahead, nil)
+			behindParams := listParams
+			behindParams.Include = []string{destination.Target.Hash}
+			behindParams.Exclude = []string{source.Target.Hash}
+			mockClient.EXPECT().ListCommits(mock.Anything, tokenProvider, behindParams).Return(behind, nil)
+			return source, destination
+		}
+
+		t.Run("should count commits ahead and behind", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "feature/" + faker.Word(),
+				Destination:               "main",
+			}
+			ahead := newCommits(branchCompareListedCommits + 5)
+			behind := newCommits(3)
+			source, destination := setupCompare(t, deps, params, ahead, behind)
+			service := NewBitbucketService(deps)
+
+			got, err := service.CompareBranches(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, &BranchComparison{
+				Source:       source,
+				Destination:  destination,
+				Ahead:        len(ahead),
+				Behind:       len(behind),
+				AheadCommits: ahead[:branchCompareListedCommits],
+			}, got)
+		})
+
+		t.Run("should report truncated counts", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "feature/" + faker.Word(),
+				Destination:               "main",
+			}
+			setupCompare(t, deps, params, newCommits(branchCompareMaxCommits), newCommits(branchCompareMaxCommits+1))
+			service := NewBitbucketService(deps)
+
+			got, err := service.CompareBranches(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, branchCompareMaxCommits, got.Ahead)
+			assert.Equal(t, branchCompareMaxCommits, got.Behind)
+			assert.False(t, got.AheadTruncated)
+			assert.True(t, got.BehindTruncated)
+			assert.Len(t, got.AheadCommits, branchCompareListedCommits)
+		})
+
+		t.Run("should fail when branch does not exist", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "feature/" + faker.Word(),
+				Destination:               "main",
+			}
+			setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			mockClient.EXPECT().GetBranch(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			service := NewBitbucketService(deps)
+
+			_, err := service.CompareBranches(t.Context(), params)
+
+			require.ErrorContains(t, err, "branch "+params.Source+" not found")
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.CompareBranches(t.Context(), BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Destination:               "main",
+			})
+			require.ErrorContains(t, err, "source branch is required")
+
+			_, err = service.CompareBranches(t.Context(), BitbucketCompareBranchesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Source:                    "feature",
+			})
+			require.ErrorContains(t, err, "destination branch is required")
+		})
+	})
+}
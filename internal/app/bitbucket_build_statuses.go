@@ -0,0 +1,207 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+// BitbucketListBuildStatusesParams contains parameters for listing build statuses.
+// Exactly one of PullRequestID or Commit must be provided.
+type BitbucketListBuildStatusesParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Pull request ID to list statuses of all its commits
+	PullRequestID int `json:"pull_request_id,omitempty"`
+
+	// Commit hash to list statuses of a single commit
+	Commit string `json:"commit,omitempty"`
+}
+
+// BitbucketSetBuildStatusParams contains parameters for reporting a build status of a commit.
+type BitbucketSetBuildStatusParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Commit hash the status is reported for
+	Commit string `json:"commit"`
+
+	// Key identifying the build, unique per commit
+	Key string `json:"key"`
+
+	// State of the build (SUCCESSFUL, FAILED, INPROGRESS, STOPPED)
+	State string `json:"state"`
+
+	// URL of the build results. Required to create a status, the existing status
+	// is updated when empty.
+	URL string `json:"url,omitempty"`
+
+	// Name of the build (optional)
+	Name string `json:"name,omitempty"`
+
+	// Description of the build results (optional)
+	Description string `json:"description,omitempty"`
+
+	// Name of the ref the build was run for (optional)
+	RefName string `json:"refname,omitempty"`
+}
+
+// ListBuildStatuses lists build statuses reported for a pull request or a commit.
+func (s *BitbucketService) ListBuildStatuses(
+	ctx context.Context,
+	params BitbucketListBuildStatusesParams,
+) ([]bitbucket.CommitStatus, error) {
+	s.logger.InfoContext(ctx, "Listing build statuses",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.Int("pr_id", params.PullRequestID),
+		slog.String("commit", params.Commit))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if (params.PullRequestID > 0) == (params.Commit != "") {
+		return nil, errors.New("either pull request ID or commit is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+
+	if params.Commit != "" {
+		statuses, err := s.client.ListCommitStatuses(ctx, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Commit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commit statuses: %w", err)
+		}
+		return statuses, nil
+	}
+
+	statuses, err := s.client.ListPRStatuses(ctx, tokenProvider, bitbucket.ListPRStatusesParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		PullReqID: params.PullRequestID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// SetBuildStatus reports a build status for a commit. A new status is created (or an existing
+// one with the same key overwritten) when URL is provided, otherwise the existing status is updated.
+func (s *BitbucketService) SetBuildStatus(
+	ctx context.Context,
+	params BitbucketSetBuildStatusParams,
+) (*bitbucket.CommitStatus, error) {
+	s.logger.InfoContext(ctx, "Setting build status",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("commit", params.Commit),
+		slog.String("key", params.Key),
+		slog.String("state", params.State))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if params.Commit == "" {
+		return nil, errors.New("commit is required")
+	}
+	if params.Key == "" {
+		return nil, errors.New("build status key is required")
+	}
+	if !isValidBuildStatusState(params.State) {
+		return nil, errors.New("invalid build status state: must be one of SUCCESSFUL, FAILED, INPROGRESS or STOPPED")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	payload := bitbucket.CommitStatusPayload{
+		Key:         params.Key,
+		State:       params.State,
+		URL:         params.URL,
+		Name:        params.Name,
+		Description: params.Description,
+		RefName:     params.RefName,
+	}
+
+	if params.URL == "" {
+		status, err := s.client.UpdateCommitStatus(ctx, tokenProvider, bitbucket.UpdateCommitStatusParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Commit,
+			Key:       params.Key,
+			Status:    payload,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update build status: %w", err)
+		}
+		return status, nil
+	}
+
+	status, err := s.client.CreateCommitStatus(ctx, tokenProvider, bitbucket.CreateCommitStatusParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    params.Commit,
+		Status:    payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build status: %w", err)
+	}
+	return status, nil
+}
+
+func isValidBuildStatusState(state string) bool {
+	switch state {
+	case bitbucket.CommitStatusStateSuccessful,
+		bitbucket.CommitStatusStateFailed,
+		bitbucket.CommitStatusStateInProgress,
+		bitbucket.CommitStatusStateStopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// listHeadCommitStatuses lists build statuses of the pull request head commit. Statuses of commits
+// replaced by later pushes are left out, so an outdated failed build does not count against the pull request.
+func (s *BitbucketService) listHeadCommitStatuses(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	workspace string,
+	repoSlug string,
+	pr *bitbucket.PullRequest,
+) ([]bitbucket.CommitStatus, error) {
+	if pr.Source.Commit == nil || pr.Source.Commit.Hash == "" {
+		return nil, fmt.Errorf("head commit of pull request %d is not available", pr.ID)
+	}
+	statuses, err := s.client.ListCommitStatuses(ctx, tokenProvider, bitbucket.ListCommitStatusesParams{
+		Workspace: workspace,
+		RepoSlug:  repoSlug,
+		Commit:    pr.Source.Commit.Hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statuses of commit %s: %w", pr.Source.Commit.Hash, err)
+	}
+	return statuses, nil
+}
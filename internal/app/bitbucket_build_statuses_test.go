@@ -0,0 +1,246 @@
+package app
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_BuildStatuses(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	newRandomStatuses := func() []bitbucket.CommitStatus {
+		return []bitbucket.CommitStatus{
+			{Key: "build-" + faker.Word(), State: bitbucket.CommitStatusStateSuccessful},
+			{Key: "lint-" + faker.Word(), State: bitbucket.CommitStatusStateFailed},
+		}
+	}
+
+	t.Run("ListBuildStatuses", func(t *testing.T) {
+		t.Run("should list statuses of a pull request", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketListBuildStatusesParams{
+				AccountName:   "account-" + faker.Username(),
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(1000),
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			statuses := newRandomStatuses()
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().ListPRStatuses(mock.Anything, tokenProvider, bitbucket.ListPRStatusesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				PullReqID: params.PullRequestID,
+			}).Return(statuses, nil)
+
+			got, err := NewBitbucketService(deps).ListBuildStatuses(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, statuses, got)
+		})
+
+		t.Run("should list statuses of a commit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketListBuildStatusesParams{
+				RepoOwner: "owner-" + faker.Username(),
+				RepoName:  "repo-" + faker.Username(),
+				Commit:    faker.UUIDDigit(),
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			statuses := newRandomStatuses()
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, bitbucket.ListCommitStatusesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Commit:    params.Commit,
+			}).Return(statuses, nil)
+
+			got, err := NewBitbucketService(deps).ListBuildStatuses(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, statuses, got)
+		})
+
+		t.Run("should fail when client fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			clientErr := errors.New(faker.Sentence())
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, mock.Anything, mock.Anything).Return(nil, clientErr)
+
+			_, err := NewBitbucketService(deps).ListBuildStatuses(t.Context(), BitbucketListBuildStatusesParams{
+				RepoOwner: "owner-" + faker.Username(),
+				RepoName:  "repo-" + faker.Username(),
+				Commit:    faker.UUIDDigit(),
+			})
+
+			require.ErrorIs(t, err, clientErr)
+		})
+
+		t.Run("should validate parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListBuildStatuses(t.Context(), BitbucketListBuildStatusesParams{RepoName: "repo"})
+			require.ErrorContains(t, err, "repository owner is required")
+
+			_, err = service.ListBuildStatuses(t.Context(), BitbucketListBuildStatusesParams{RepoOwner: "owner"})
+			require.ErrorContains(t, err, "repository name is required")
+
+			_, err = service.ListBuildStatuses(t.Context(), BitbucketListBuildStatusesParams{
+				RepoOwner: "owner",
+				RepoName:  "repo",
+			})
+			require.ErrorContains(t, err, "either pull request ID or commit is required")
+
+			_, err = service.ListBuildStatuses(t.Context(), BitbucketListBuildStatusesParams{
+				RepoOwner:     "owner",
+				RepoName:      "repo",
+				PullRequestID: 1,
+				Commit:        faker.UUIDDigit(),
+			})
+			require.ErrorContains(t, err, "either pull request ID or commit is required")
+		})
+	})
+
+	t.Run("SetBuildStatus", func(t *testing.T) {
+		makeParams := func() BitbucketSetBuildStatusParams {
+			return BitbucketSetBuildStatusParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+				Commit:      faker.UUIDDigit(),
+				Key:         "build-" + faker.Word(),
+				State:       bitbucket.CommitStatusStateInProgress,
+				URL:         faker.URL(),
+				Name:        faker.Sentence(),
+				Description: faker.Sentence(),
+				RefName:     "feature/" + faker.Word(),
+			}
+		}
+
+		t.Run("should create status when url is provided", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			created := &bitbucket.CommitStatus{Key: params.Key, State: params.State, UUID: faker.UUIDHyphenated()}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().CreateCommitStatus(mock.Anything, tokenProvider, bitbucket.CreateCommitStatusParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Commit:    params.Commit,
+				Status: bitbucket.CommitStatusPayload{
+					Key:         params.Key,
+					State:       params.State,
+					URL:         params.URL,
+					Name:        params.Name,
+					Description: params.Description,
+					RefName:     params.RefName,
+				},
+			}).Return(created, nil)
+
+			got, err := NewBitbucketService(deps).SetBuildStatus(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, created, got)
+		})
+
+		t.Run("should update existing status when url is not provided", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			params.URL = ""
+			params.State = bitbucket.CommitStatusStateSuccessful
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			updated := &bitbucket.CommitStatus{Key: params.Key, State: params.State}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().UpdateCommitStatus(mock.Anything, tokenProvider, bitbucket.UpdateCommitStatusParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Commit:    params.Commit,
+				Key:       params.Key,
+				Status: bitbucket.CommitStatusPayload{
+					Key:         params.Key,
+					State:       params.State,
+					Name:        params.Name,
+					Description: params.Description,
+					RefName:     params.RefName,
+				},
+			}).Return(updated, nil)
+
+			got, err := NewBitbucketService(deps).SetBuildStatus(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, updated, got)
+		})
+
+		t.Run("should fail when client fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			clientErr := errors.New(faker.Sentence())
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().CreateCommitStatus(mock.Anything, mock.Anything, mock.Anything).Return(nil, clientErr)
+
+			_, err := NewBitbucketService(deps).SetBuildStatus(t.Context(), params)
+
+			require.ErrorIs(t, err, clientErr)
+		})
+
+		t.Run("should validate parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+			testCases := []struct {
+				name   string
+				modify func(*BitbucketSetBuildStatusParams)
+				errMsg string
+			}{
+				{"missing owner", func(p *BitbucketSetBuildStatusParams) { p.RepoOwner = "" }, "repository owner is required"},
+				{"missing name", func(p *BitbucketSetBuildStatusParams) { p.RepoName = "" }, "repository name is required"},
+				{"missing commit", func(p *BitbucketSetBuildStatusParams) { p.Commit = "" }, "commit is required"},
+				{"missing key", func(p *BitbucketSetBuildStatusParams) { p.Key = "" }, "build status key is required"},
+				{"invalid state", func(p *BitbucketSetBuildStatusParams) { p.State = "DONE" }, "invalid build status state"},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					params := makeParams()
+					tc.modify(&params)
+
+					_, err := service.SetBuildStatus(t.Context(), params)
+
+					require.ErrorContains(t, err, tc.errMsg)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/samber/lo"
+)
+
+// BitbucketCommitFilesParams contains parameters for committing file changes to a branch.
+type BitbucketCommitFilesParams struct {
+	BitbucketRepositoryParams
+
+	// Branch to commit to
+	Branch string `json:"branch"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Author in "Name <email>" format (optional, defaults to the authenticated user)
+	Author string `json:"author,omitempty"`
+
+	// ExpectedHead is the commit the branch must point at (optional). The commit is rejected when
+	// the branch moved, so that changes pushed in the meantime are not overwritten.
+	ExpectedHead string `json:"expected_head,omitempty"`
+
+	// Files added or modified with their complete new content
+	Files []bitbucket.FileChange `json:"files,omitempty"`
+
+	// Paths of deleted files
+	Delete []string `json:"delete,omitempty"`
+}
+
+// CommittedFiles describes a commit created from file changes.
+type CommittedFiles struct {
+	Branch  string   `json:"branch"`
+	Commit  string   `json:"commit"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
+
+// CommitFiles creates a commit on a branch that adds, modifies and deletes files,
+// e.g. to apply a review suggestion without a local clone.
+func (s *BitbucketService) CommitFiles(
+	ctx context.Context,
+	params BitbucketCommitFilesParams,
+) (*CommittedFiles, error) {
+	s.logger.InfoContext(ctx, "Committing files",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Branch),
+		slog.Int("files", len(params.Files)),
+		slog.Int("deleted", len(params.Delete)))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Branch == "" {
+		return nil, errors.New("branch is required")
+	}
+	if strings.TrimSpace(params.Message) == "" {
+		return nil, errors.New("commit message is required")
+	}
+	files, deleted, err := normalizeFileChanges(params.Files, params.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	var parents []string
+	if params.ExpectedHead != "" {
+		parents = []string{params.ExpectedHead}
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	commit, err := s.client.CommitFiles(ctx, tokenProvider, bitbucket.CommitFilesParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		Branch:       params.Branch,
+		Message:      params.Message,
+		Author:       params.Author,
+		Parents:      parents,
+		Files:        files,
+		DeletedPaths: deleted,
+	})
+	if err != nil {
+		var httpErr *middleware.HTTPError
+		if params.ExpectedHead != "" && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("branch %s is no longer at %s, re-read the files and retry: %w",
+				params.Branch, params.ExpectedHead, err)
+		}
+		return nil, fmt.Errorf("failed to commit files: %w", err)
+	}
+
+	return &CommittedFiles{
+		Branch: params.Branch,
+		Commit: commit.Hash,
+		Updated: lo.Map(files, func(file bitbucket.FileChange, _ int) string {
+			return file.Path
+		}),
+		Deleted: deleted,
+	}, nil
+}
+
+// normalizeFileChanges trims slashes of changed paths and makes sure that each path is changed once.
+func normalizeFileChanges(
+	files []bitbucket.FileChange,
+	deleted []string,
+) ([]bitbucket.FileChange, []string, error) {
+	if len(files) == 0 && len(deleted) == 0 {
+		return nil, nil, errors.New("at least one file must be changed or deleted")
+	}
+	seen := make(map[string]bool, len(files)+len(deleted))
+	normalize := func(path string) (string, error) {
+		path = strings.Trim(path, "/")
+		if path == "" {
+			return "", errors.New("file path must not be empty")
+		}
+		if seen[path] {
+			return "", fmt.Errorf("%s is changed more than once", path)
+		}
+		seen[path] = true
+		return path, nil
+	}
+
+	normalizedFiles := make([]bitbucket.FileChange, len(files))
+	for i, file := range files {
+		path, err := normalize(file.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		normalizedFiles[i] = bitbucket.FileChange{Path: path, Content: file.Content}
+	}
+	normalizedDeleted := make([]string, len(deleted))
+	for i, deletedPath := range deleted {
+		path, err := normalize(deletedPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		normalizedDeleted[i] = path
+	}
+	return normalizedFiles, normalizedDeleted, nil
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_CommitFiles(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeParams := func() BitbucketCommitFilesParams {
+		return BitbucketCommitFilesParams{
+			BitbucketRepositoryParams: BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+			},
+			Branch:  "feature/" + faker.Word(),
+			Message: faker.Sentence(),
+		}
+	}
+
+	t.Run("should commit changed and deleted files", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Author = faker.Name() + " <" + faker.Email() + ">"
+		params.ExpectedHead = faker.UUIDDigit()
+		params.Files = []bitbucket.FileChange{
+			{Path: "/README.md", Content: faker.Paragraph()},
+			{Path: "docs/guide.md", Content: faker.Paragraph()},
+		}
+		params.Delete = []string{"docs/old.md/"}
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		hash := faker.UUIDDigit()
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, tokenProvider, bitbucket.CommitFilesParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Branch:    params.Branch,
+			Message:   params.Message,
+			Author:    params.Author,
+			Parents:   []string{params.ExpectedHead},
+			Files: []bitbucket.FileChange{
+				{Path: "README.md", Content: params.Files[0].Content},
+				{Path: "docs/guide.md", Content: params.Files[1].Content},
+			},
+			DeletedPaths: []string{"docs/old.md"},
+		}).Return(&bitbucket.Commit{Hash: hash}, nil)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CommitFiles(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &CommittedFiles{
+			Branch:  params.Branch,
+			Commit:  hash,
+			Updated: []string{"README.md", "docs/guide.md"},
+			Deleted: []string{"docs/old.md"},
+		}, result)
+	})
+
+	t.Run("should explain that branch moved away from expected head", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.ExpectedHead = faker.UUIDDigit()
+		params.Delete = []string{"old.md"}
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		conflictErr := &middleware.HTTPError{StatusCode: http.StatusConflict}
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(clientParams bitbucket.CommitFilesParams) bool {
+				return len(clientParams.Parents) == 1 && clientParams.Parents[0] == params.ExpectedHead
+			},
+		)).Return(nil, conflictErr)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CommitFiles(t.Context(), params)
+
+		require.ErrorIs(t, err, conflictErr)
+		require.ErrorContains(t, err, "branch "+params.Branch+" is no longer at "+params.ExpectedHead)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should fail when commit can not be created", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Files = []bitbucket.FileChange{{Path: "a.go", Content: "package a\n"}}
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		expectedErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(clientParams bitbucket.CommitFilesParams) bool { return clientParams.Parents == nil },
+		)).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CommitFiles(t.Context(), params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should validate parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+		withFile := func(params BitbucketCommitFilesParams) BitbucketCommitFilesParams {
+			params.Files = []bitbucket.FileChange{{Path: "a.go"}}
+			return params
+		}
+
+		noBranch := withFile(makeParams())
+		noBranch.Branch = ""
+		_, err := service.CommitFiles(t.Context(), noBranch)
+		require.ErrorContains(t, err, "branch is required")
+
+		noMessage := withFile(makeParams())
+		noMessage.Message = " "
+		_, err = service.CommitFiles(t.Context(), noMessage)
+		require.ErrorContains(t, err, "commit message is required")
+
+		_, err = service.CommitFiles(t.Context(), makeParams())
+		require.ErrorContains(t, err, "at least one file must be changed or deleted")
+
+		emptyPath := makeParams()
+		emptyPath.Delete = []string{"/"}
+		_, err = service.CommitFiles(t.Context(), emptyPath)
+		require.ErrorContains(t, err, "file path must not be empty")
+
+		duplicate := withFile(makeParams())
+		duplicate.Delete = []string{"/a.go"}
+		_, err = service.CommitFiles(t.Context(), duplicate)
+		require.ErrorContains(t, err, "a.go is changed more than once")
+	})
+}
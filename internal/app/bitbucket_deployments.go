@@ -0,0 +1,211 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+const (
+	// deploymentsDefaultLimit is the number of deployments listed when no limit is given.
+	deploymentsDefaultLimit = 10
+
+	// deploymentsMaxLimit is the maximum page size accepted by the deployments API.
+	deploymentsMaxLimit = 100
+
+	// deploymentsSortNewestFirst lists the most recent deployments first.
+	deploymentsSortNewestFirst = "-state.started_on"
+
+	// deploymentsOverviewPageLen is the number of recent deployments inspected per environment
+	// to find the one that is currently deployed.
+	deploymentsOverviewPageLen = 20
+)
+
+// BitbucketListDeploymentsParams contains parameters for listing deployments of an environment.
+type BitbucketListDeploymentsParams struct {
+	BitbucketRepositoryParams
+
+	// Environment UUID, name or slug
+	Environment string `json:"environment"`
+
+	// Maximum number of deployments to return, newest first (optional)
+	Limit int `json:"limit,omitempty"`
+}
+
+// EnvironmentDeployments describes what is deployed to an environment.
+type EnvironmentDeployments struct {
+	Environment bitbucket.DeploymentEnvironment `json:"environment"`
+
+	// Deployed is the last successful deployment, i.e. what is currently running in the environment.
+	Deployed *bitbucket.Deployment `json:"deployed,omitempty"`
+
+	// Latest is the most recent deployment, it differs from Deployed when it is
+	// in progress or has failed.
+	Latest *bitbucket.Deployment `json:"latest,omitempty"`
+}
+
+// ListEnvironments lists deployment environments of a repository ordered by rank,
+// e.g. Test, Staging, Production.
+func (s *BitbucketService) ListEnvironments(
+	ctx context.Context,
+	params BitbucketRepositoryParams,
+) ([]bitbucket.DeploymentEnvironment, error) {
+	s.logger.InfoContext(ctx, "Listing deployment environments",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName))
+
+	if err := validateRepositoryParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	return s.listEnvironments(ctx, tokenProvider, params)
+}
+
+// ListDeployments lists the most recent deployments of an environment, newest first.
+func (s *BitbucketService) ListDeployments(
+	ctx context.Context,
+	params BitbucketListDeploymentsParams,
+) ([]bitbucket.Deployment, error) {
+	s.logger.InfoContext(ctx, "Listing deployments",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("environment", params.Environment))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Environment == "" {
+		return nil, errors.New("environment is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = deploymentsDefaultLimit
+	}
+	limit = min(limit, deploymentsMaxLimit)
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	environments, err := s.listEnvironments(ctx, tokenProvider, params.BitbucketRepositoryParams)
+	if err != nil {
+		return nil, err
+	}
+	environment, found := lo.Find(environments, func(e bitbucket.DeploymentEnvironment) bool {
+		return e.UUID == normalizeUUID(params.Environment) ||
+			strings.EqualFold(e.Name, params.Environment) ||
+			strings.EqualFold(e.Slug, params.Environment)
+	})
+	if !found {
+		return nil, fmt.Errorf("environment %s not found", params.Environment)
+	}
+
+	return s.listEnvironmentDeployments(ctx, tokenProvider, params.BitbucketRepositoryParams, environment.UUID, limit)
+}
+
+// GetDeploymentsOverview reports what is currently deployed to each environment of a repository.
+func (s *BitbucketService) GetDeploymentsOverview(
+	ctx context.Context,
+	params BitbucketRepositoryParams,
+) ([]EnvironmentDeployments, error) {
+	s.logger.InfoContext(ctx, "Getting deployments overview",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName))
+
+	if err := validateRepositoryParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	environments, err := s.listEnvironments(ctx, tokenProvider, params)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := make([]EnvironmentDeployments, 0, len(environments))
+	for _, environment := range environments {
+		deployments, listErr := s.listEnvironmentDeployments(
+			ctx, tokenProvider, params, environment.UUID, deploymentsOverviewPageLen)
+		if listErr != nil {
+			return nil, listErr
+		}
+		item := EnvironmentDeployments{Environment: environment}
+		if len(deployments) > 0 {
+			item.Latest = &deployments[0]
+		}
+		if deployed, found := lo.Find(deployments, isSuccessfulDeployment); found {
+			item.Deployed = &deployed
+		}
+		overview = append(overview, item)
+	}
+
+	return overview, nil
+}
+
+func (s *BitbucketService) listEnvironments(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+) ([]bitbucket.DeploymentEnvironment, error) {
+	environments, err := s.client.ListEnvironments(ctx, tokenProvider, bitbucket.ListEnvironmentsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+	slices.SortStableFunc(environments, func(a, b bitbucket.DeploymentEnvironment) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+	return environments, nil
+}
+
+// listEnvironmentDeployments returns recent deployments of the environment, newest first.
+func (s *BitbucketService) listEnvironmentDeployments(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	environmentUUID string,
+	limit int,
+) ([]bitbucket.Deployment, error) {
+	page, err := s.client.ListDeployments(ctx, tokenProvider, bitbucket.ListDeploymentsParams{
+		Workspace:   params.RepoOwner,
+		RepoSlug:    params.RepoName,
+		Environment: environmentUUID,
+		Sort:        deploymentsSortNewestFirst,
+		PageLen:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	// Deployments of other environments are dropped in case the filter is not applied by the API.
+	deployments := lo.Filter(page.Values, func(d bitbucket.Deployment, _ int) bool {
+		return d.Environment == nil || d.Environment.UUID == environmentUUID
+	})
+	slices.SortStableFunc(deployments, func(a, b bitbucket.Deployment) int {
+		return deploymentTime(b).Compare(deploymentTime(a))
+	})
+	return deployments, nil
+}
+
+func deploymentTime(deployment bitbucket.Deployment) time.Time {
+	if deployment.State == nil {
+		return time.Time{}
+	}
+	if deployment.State.StartDate != nil {
+		return *deployment.State.StartDate
+	}
+	return lo.FromPtr(deployment.State.CompletionDate)
+}
+
+func isSuccessfulDeployment(deployment bitbucket.Deployment) bool {
+	return deployment.State != nil &&
+		deployment.State.Name == bitbucket.DeploymentStateCompleted &&
+		deployment.State.Status != nil &&
+		deployment.State.Status.Name == bitbucket.DeploymentStatusSuccessful
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Deployments(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newEnvironment := func(name string, rank int) bitbucket.DeploymentEnvironment {
+		return bitbucket.DeploymentEnvironment{
+			UUID: "{" + faker.UUIDHyphenated() + "}",
+			Name: name,
+			Slug: "slug-" + faker.Word(),
+			Rank: rank,
+		}
+	}
+
+	newDeployment := func(
+		environment bitbucket.DeploymentEnvironment,
+		status string,
+		startedAt time.Time,
+	) bitbucket.Deployment {
+		return bitbucket.Deployment{
+			UUID: "{" + faker.UUIDHyphenated() + "}",
+			State: &bitbucket.DeploymentState{
+				Name:      bitbucket.DeploymentStateCompleted,
+				Status:    &bitbucket.DeploymentStateStatus{Name: status},
+				StartDate: &startedAt,
+			},
+			Environment: &bitbucket.DeploymentEnvironment{UUID: environment.UUID},
+		}
+	}
+
+	setupEnvironments := func(
+		t *testing.T,
+		deps BitbucketServiceDeps,
+		params BitbucketRepositoryParams,
+		environments []bitbucket.DeploymentEnvironment,
+	) bitbucket.TokenProvider {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().ListEnvironments(mock.Anything, tokenProvider, bitbucket.ListEnvironmentsParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+		}).Return(environments, nil)
+		return tokenProvider
+	}
+
+	expectDeployments := func(
+		t *testing.T,
+		deps BitbucketServiceDeps,
+		tokenProvider bitbucket.TokenProvider,
+		params BitbucketRepositoryParams,
+		environmentUUID string,
+		pageLen int,
+		deployments []bitbucket.Deployment,
+	) {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockClient.EXPECT().ListDeployments(mock.Anything, tokenProvider, bitbucket.ListDeploymentsParams{
+			Workspace:   params.RepoOwner,
+			RepoSlug:    params.RepoName,
+			Environment: environmentUUID,
+			Sort:        deploymentsSortNewestFirst,
+			PageLen:     pageLen,
+		}).Return(&bitbucket.Paginated[bitbucket.Deployment]{Values: deployments}, nil)
+	}
+
+	t.Run("ListEnvironments", func(t *testing.T) {
+		t.Run("should list environments ordered by rank", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			production := newEnvironment("Production", 2)
+			test := newEnvironment("Test", 0)
+			staging := newEnvironment("Staging", 1)
+			setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{production, test, staging})
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListEnvironments(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, []bitbucket.DeploymentEnvironment{test, staging, production}, got)
+		})
+
+		t.Run("should fail when client fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeRepoParams()
+			expectedErr := errors.New(faker.Sentence())
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().ListEnvironments(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListEnvironments(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListEnvironments(t.Context(), BitbucketRepositoryParams{RepoName: faker.Word()})
+
+			require.ErrorContains(t, err, "repository owner is required")
+		})
+	})
+
+	t.Run("ListDeployments", func(t *testing.T) {
+		t.Run("should list deployments of environment found by name", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			staging := newEnvironment("Staging", 1)
+			production := newEnvironment("Production", 2)
+			tokenProvider := setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{staging, production})
+			now := time.Now()
+			older := newDeployment(production, bitbucket.DeploymentStatusSuccessful, now.Add(-time.Hour))
+			newer := newDeployment(production, bitbucket.DeploymentStatusFailed, now)
+			foreign := newDeployment(staging, bitbucket.DeploymentStatusSuccessful, now)
+			expectDeployments(t, deps, tokenProvider, params, production.UUID, deploymentsDefaultLimit,
+				[]bitbucket.Deployment{older, foreign, newer})
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListDeployments(t.Context(), BitbucketListDeploymentsParams{
+				BitbucketRepositoryParams: params,
+				Environment:               "production",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []bitbucket.Deployment{newer, older}, got)
+		})
+
+		t.Run("should find environment by UUID and cap limit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			staging := newEnvironment("Staging", 1)
+			tokenProvider := setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{staging})
+			expectDeployments(t, deps, tokenProvider, params, staging.UUID, deploymentsMaxLimit, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListDeployments(t.Context(), BitbucketListDeploymentsParams{
+				BitbucketRepositoryParams: params,
+				Environment:               staging.UUID[1 : len(staging.UUID)-1],
+				Limit:                     deploymentsMaxLimit + 1,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+
+		t.Run("should fail when environment is not found", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{newEnvironment("Test", 0)})
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListDeployments(t.Context(), BitbucketListDeploymentsParams{
+				BitbucketRepositoryParams: params,
+				Environment:               "missing-" + faker.Word(),
+			})
+
+			require.ErrorContains(t, err, "not found")
+		})
+
+		t.Run("should require environment", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListDeployments(t.Context(), BitbucketListDeploymentsParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "environment is required")
+		})
+	})
+
+	t.Run("GetDeploymentsOverview", func(t *testing.T) {
+		t.Run("should report deployed and latest deployment per environment", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			staging := newEnvironment("Staging", 1)
+			production := newEnvironment("Production", 2)
+			tokenProvider := setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{production, staging})
+			now := time.Now()
+			stagingDeployed := newDeployment(staging, bitbucket.DeploymentStatusSuccessful, now)
+			productionDeployed := newDeployment(production, bitbucket.DeploymentStatusSuccessful, now.Add(-time.Hour))
+			productionFailed := newDeployment(production, bitbucket.DeploymentStatusFailed, now)
+			expectDeployments(t, deps, tokenProvider, params, staging.UUID, deploymentsOverviewPageLen,
+				[]bitbucket.Deployment{stagingDeployed})
+			expectDeployments(t, deps, tokenProvider, params, production.UUID, deploymentsOverviewPageLen,
+				[]bitbucket.Deployment{productionFailed, productionDeployed})
+			service := NewBitbucketService(deps)
+
+			got, err := service.GetDeploymentsOverview(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, []EnvironmentDeployments{
+				{Environment: staging, Deployed: &stagingDeployed, Latest: &stagingDeployed},
+				{Environment: production, Deployed: &productionDeployed, Latest: &productionFailed},
+			}, got)
+		})
+
+		t.Run("should report environments without deployments", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			test := newEnvironment("Test", 0)
+			tokenProvider := setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{test})
+			expectDeployments(t, deps, tokenProvider, params, test.UUID, deploymentsOverviewPageLen, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.GetDeploymentsOverview(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, []EnvironmentDeployments{{Environment: test}}, got)
+		})
+
+		t.Run("should fail when deployments can not be listed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := makeRepoParams()
+			setupEnvironments(t, deps, params, []bitbucket.DeploymentEnvironment{newEnvironment("Test", 0)})
+			expectedErr := errors.New(faker.Sentence())
+			mockClient.EXPECT().ListDeployments(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			got, err := service.GetDeploymentsOverview(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, got)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+)
+
+const (
+	// diffFileHeaderPrefix starts the part of a unified git diff that changes a single file.
+	diffFileHeaderPrefix = "diff --git "
+
+	// diffHunkHeaderPrefix starts a hunk of a unified diff.
+	diffHunkHeaderPrefix = "@@ "
+)
+
+// diffFile is the part of a unified diff that changes a single file.
+type diffFile struct {
+	// Path of the file, the new path for renamed files.
+	Path string
+
+	// Text of the diff of the file including its header lines.
+	Text string
+}
+
+// splitDiffFiles splits a unified git diff into the parts changing individual files.
+// Text preceding the first file header is kept with the first file.
+func splitDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	var starts []int
+	offset := 0
+	for line := range strings.Lines(diff) {
+		if strings.HasPrefix(line, diffFileHeaderPrefix) {
+			files = append(files, diffFile{Path: diffHeaderPath(line)})
+			starts = append(starts, offset)
+		}
+		offset += len(line)
+	}
+	if len(files) == 0 {
+		if strings.TrimSpace(diff) == "" {
+			return nil
+		}
+		return []diffFile{{Text: diff}}
+	}
+	starts[0] = 0
+	for i := range files {
+		end := len(diff)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		files[i].Text = diff[starts[i]:end]
+	}
+	return files
+}
+
+// diffHeaderPath extracts the new path from a "diff --git a/<old> b/<new>" header line.
+func diffHeaderPath(header string) string {
+	header = strings.TrimRight(strings.TrimPrefix(header, diffFileHeaderPrefix), "\r\n")
+	if idx := strings.LastIndex(header, " b/"); idx >= 0 {
+		return header[idx+len(" b/"):]
+	}
+	return header
+}
+
+// diffHunkHeaders returns hunk header lines of the diff of a file, at most limit of them.
+func diffHunkHeaders(text string, limit int) []string {
+	var headers []string
+	for line := range strings.Lines(text) {
+		if len(headers) >= limit {
+			break
+		}
+		if strings.HasPrefix(line, diffHunkHeaderPrefix) {
+			headers = append(headers, strings.TrimRight(line, "\r\n"))
+		}
+	}
+	return headers
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDiffFiles(t *testing.T) {
+	t.Run("should split diff at file boundaries", func(t *testing.T) {
+		first := "diff --git a/main.go b/main.go\n" +
+			"--- a/main.go\n+++ b/main.go\n" +
+			"@@ -1,2 +1,2 @@ package main\n-old\n+new\n"
+		second := "diff --git a/docs/old name.md b/docs/new name.md\n" +
+			"similarity index 90%\nrename from docs/old name.md\nrename to docs/new name.md\n"
+
+		files := splitDiffFiles(first + second)
+
+		assert.Equal(t, []diffFile{
+			{Path: "main.go", Text: first},
+			{Path: "docs/new name.md", Text: second},
+		}, files)
+	})
+
+	t.Run("should keep text before the first file header with the first file", func(t *testing.T) {
+		diff := "preamble\ndiff --git a/a.txt b/a.txt\n+a"
+
+		files := splitDiffFiles(diff)
+
+		assert.Equal(t, []diffFile{{Path: "a.txt", Text: diff}}, files)
+	})
+
+	t.Run("should handle diff without file headers", func(t *testing.T) {
+		assert.Equal(t, []diffFile{{Text: "@@ -1 +1 @@\n"}}, splitDiffFiles("@@ -1 +1 @@\n"))
+		assert.Empty(t, splitDiffFiles(""))
+	})
+}
+
+func TestDiffHunkHeaders(t *testing.T) {
+	text := "diff --git a/a.go b/a.go\n" +
+		"@@ -1,3 +1,3 @@ func a()\n-x\n+y\n" +
+		"@@ -10,2 +10,3 @@ func b()\n+z\n" +
+		"@@ -20 +21 @@\n-w\n"
+
+	assert.Equal(t, []string{"@@ -1,3 +1,3 @@ func a()", "@@ -10,2 +10,3 @@ func b()"}, diffHunkHeaders(text, 2))
+}
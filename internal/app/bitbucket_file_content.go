@@ -0,0 +1,262 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+const (
+	fileContentDefaultMaxBytes = 100 * 1024
+	fileContentMaxBytes        = 1024 * 1024
+
+	// fileContentRangeFetchBytes limits how much of a file is read to select a range of lines.
+	fileContentRangeFetchBytes = 10 * 1024 * 1024
+
+	// fileImageMaxBytes limits the size of images returned as image content.
+	fileImageMaxBytes = 1024 * 1024
+
+	// binarySniffLen is the number of leading bytes checked for NUL bytes, the same heuristic git uses.
+	binarySniffLen = 8000
+
+	// fileAttributeBinary is the source attribute Bitbucket sets on binary files.
+	fileAttributeBinary = "binary"
+
+	fileEncodingUTF8    = "utf-8"
+	fileEncodingUTF16LE = "utf-16le"
+	fileEncodingUTF16BE = "utf-16be"
+	fileEncodingLatin1  = "iso-8859-1"
+	fileEncodingBase64  = "base64"
+)
+
+type BitbucketGetFileContentParams struct {
+	AccountName string
+	RepoOwner   string
+	RepoName    string
+	Commit      string
+	Path        string
+
+	// FromLine and ToLine select a 1-based inclusive range of lines (optional)
+	FromLine int
+	ToLine   int
+
+	// Maximum size of the returned content in bytes (optional, defaults to 100KB, max 1MB)
+	MaxBytes int
+}
+
+// GetFileContent retrieves the content of a file at a commit. Text is decoded to UTF-8 and cut at the
+// size limit on a line boundary, images are returned base64 encoded and content of other binary
+// files is omitted.
+func (s *BitbucketService) GetFileContent(
+	ctx context.Context,
+	params BitbucketGetFileContentParams,
+) (*bitbucket.FileContentResult, error) {
+	s.logger.InfoContext(ctx, "Getting file content",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("commit", params.Commit),
+		slog.String("path", params.Path),
+		slog.Int("from_line", params.FromLine),
+		slog.Int("to_line", params.ToLine))
+
+	if params.FromLine < 0 || params.ToLine < 0 || (params.ToLine > 0 && params.ToLine < params.FromLine) {
+		return nil, fmt.Errorf("invalid line range %d-%d", params.FromLine, params.ToLine)
+	}
+	maxBytes := min(params.MaxBytes, fileContentMaxBytes)
+	if maxBytes <= 0 {
+		maxBytes = fileContentDefaultMaxBytes
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	meta, err := s.client.GetFileMeta(ctx, tokenProvider, bitbucket.GetFileMetaParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    params.Commit,
+		Path:      params.Path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+	if meta.IsDirectory() {
+		return nil, fmt.Errorf("%s is a directory", params.Path)
+	}
+
+	result := &bitbucket.FileContentResult{
+		Meta: bitbucket.FileContentMeta{
+			Size:     int(meta.Size),
+			Type:     "file",
+			MimeType: meta.MimeType,
+		},
+	}
+	isImage := isImageMimeType(meta.MimeType)
+	if lo.Contains(meta.Attributes, fileAttributeBinary) && !isImage {
+		result.Meta.Binary = true
+		return result, nil
+	}
+	if isImage && meta.Size > fileImageMaxBytes {
+		result.Meta.Binary = true
+		result.Meta.Truncated = true
+		return result, nil
+	}
+
+	isRange := params.FromLine > 0 || params.ToLine > 0
+	fetchBytes := lo.Ternary(isRange, fileContentRangeFetchBytes, maxBytes)
+	fileContent, err := s.client.GetFileContent(ctx, tokenProvider, bitbucket.GetFileContentParams{
+		RepoOwner:  params.RepoOwner,
+		RepoName:   params.RepoName,
+		CommitHash: params.Commit,
+		FilePath:   params.Path,
+		MaxBytes:   int64(lo.Ternary(isImage, fileImageMaxBytes, fetchBytes)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file content: %w", err)
+	}
+
+	if isImage {
+		result.Meta.Binary = true
+		result.Meta.Encoding = fileEncodingBase64
+		result.Content = base64.StdEncoding.EncodeToString([]byte(fileContent.Content))
+		return result, nil
+	}
+
+	text, encoding, isText := decodeFileText([]byte(fileContent.Content), fileContent.Truncated)
+	if !isText {
+		result.Meta.Binary = true
+		return result, nil
+	}
+	result.Meta.Encoding = encoding
+
+	err = selectFileLines(result, text, fileContent.Truncated, params.FromLine, params.ToLine, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isImageMimeType reports whether the file can be returned as image content.
+// SVG images are text and are returned as such.
+func isImageMimeType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "image/") && mimeType != "image/svg+xml"
+}
+
+// decodeFileText detects encoding of the data and decodes it to UTF-8. Data with NUL bytes
+// and no UTF-16 byte order mark is considered binary.
+func decodeFileText(data []byte, truncated bool) (string, string, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], false), fileEncodingUTF16LE, true
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], true), fileEncodingUTF16BE, true
+	}
+
+	if bytes.IndexByte(data[:min(len(data), binarySniffLen)], 0) >= 0 {
+		return "", "", false
+	}
+	if truncated && !utf8.Valid(data) {
+		// The cut may split a multi-byte character
+		for cut := 1; cut < utf8.UTFMax && cut < len(data); cut++ {
+			if utf8.Valid(data[:len(data)-cut]) {
+				data = data[:len(data)-cut]
+				break
+			}
+		}
+	}
+	if utf8.Valid(data) {
+		return string(data), fileEncodingUTF8, true
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes), fileEncodingLatin1, true
+}
+
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// selectFileLines puts the requested range of lines of the text into the result, keeping
+// whole lines within maxBytes. A marker line is appended when the content is truncated.
+func selectFileLines(
+	result *bitbucket.FileContentResult,
+	text string,
+	textTruncated bool,
+	fromLine, toLine, maxBytes int,
+) error {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" || (textTruncated && len(lines) > 1) {
+		// Drop the empty remainder after the last newline or the partially read last line
+		lines = lines[:len(lines)-1]
+	}
+	if !textTruncated {
+		result.Meta.TotalLines = len(lines)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	from := max(fromLine, 1)
+	if from > len(lines) {
+		if textTruncated {
+			return fmt.Errorf("line %d is beyond the first %d lines that can be read", from, len(lines))
+		}
+		return fmt.Errorf("line %d is beyond the end of the file with %d lines", from, len(lines))
+	}
+	to := len(lines)
+	if toLine > 0 {
+		to = min(toLine, len(lines))
+	}
+	truncated := textTruncated && (toLine == 0 || toLine > len(lines))
+
+	var content strings.Builder
+	for i := from - 1; i < to; i++ {
+		if content.Len()+len(lines[i]) > maxBytes {
+			if i == from-1 {
+				// A single line longer than the limit is cut
+				content.WriteString(strings.ToValidUTF8(lines[i][:maxBytes], ""))
+				i++
+			}
+			to = i
+			truncated = true
+			break
+		}
+		content.WriteString(lines[i])
+	}
+
+	result.Content = content.String()
+	result.Meta.FromLine = from
+	result.Meta.ToLine = to
+	result.Meta.Truncated = truncated
+	if truncated {
+		result.Content += formatTruncatedMarker(to, result.Meta.TotalLines)
+	}
+	return nil
+}
+
+// formatTruncatedMarker renders a line that tells where the content was cut and how to read further.
+func formatTruncatedMarker(lastLine, totalLines int) string {
+	total := ""
+	if totalLines > 0 {
+		total = fmt.Sprintf(" of %d", totalLines)
+	}
+	return fmt.Sprintf("\n[truncated after line %d%s, use from_line %d to read further]\n",
+		lastLine, total, lastLine+1)
+}
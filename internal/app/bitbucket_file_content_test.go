@@ -0,0 +1,359 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_GetFileContent(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeParams := func() BitbucketGetFileContentParams {
+		return BitbucketGetFileContentParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+			Commit:      faker.UUIDDigit(),
+			Path:        "src/" + faker.Word() + ".txt",
+		}
+	}
+
+	type fileFixture struct {
+		meta      *bitbucket.TreeEntry
+		content   string
+		truncated bool
+	}
+
+	// setupFile mocks metadata and content lookups, the content is not expected when it is empty.
+	setupFile := func(
+		t *testing.T,
+		deps BitbucketServiceDeps,
+		params BitbucketGetFileContentParams,
+		f fileFixture,
+		maxBytes int64,
+	) {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().GetFileMeta(mock.Anything, tokenProvider, bitbucket.GetFileMetaParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Commit,
+			Path:      params.Path,
+		}).Return(f.meta, nil)
+		if f.content == "" {
+			return
+		}
+		mockClient.EXPECT().GetFileContent(mock.Anything, tokenProvider, bitbucket.GetFileContentParams{
+			RepoOwner:  params.RepoOwner,
+			RepoName:   params.RepoName,
+			CommitHash: params.Commit,
+			FilePath:   params.Path,
+			MaxBytes:   maxBytes,
+		}).Return(&bitbucket.FileContent{Content: f.content, Truncated: f.truncated}, nil)
+	}
+
+	newTextMeta := func(path string, size int) *bitbucket.TreeEntry {
+		return &bitbucket.TreeEntry{
+			Type:     bitbucket.TreeEntryTypeFile,
+			Path:     path,
+			Size:     int64(size),
+			MimeType: "text/plain",
+		}
+	}
+
+	numberedLines := func(count int) string {
+		var sb strings.Builder
+		for i := 1; i <= count; i++ {
+			sb.WriteString("line " + strings.Repeat("x", i%7) + "\n")
+		}
+		return sb.String()
+	}
+
+	t.Run("should select range of lines", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		params.FromLine = 2
+		params.ToLine = 3
+		content := "one\ntwo\nthree\nfour\n"
+		setupFile(t, deps, params, fileFixture{
+			meta:    newTextMeta(params.Path, len(content)),
+			content: content,
+		}, fileContentRangeFetchBytes)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &bitbucket.FileContentResult{
+			Content: "two\nthree\n",
+			Meta: bitbucket.FileContentMeta{
+				Size:       len(content),
+				Type:       "file",
+				Encoding:   fileEncodingUTF8,
+				MimeType:   "text/plain",
+				TotalLines: 4,
+				FromLine:   2,
+				ToLine:     3,
+			},
+		}, result)
+	})
+
+	t.Run("should cut content at line boundary and add marker", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		params.MaxBytes = 40
+		content := numberedLines(20)
+		setupFile(t, deps, params, fileFixture{
+			meta:    newTextMeta(params.Path, len(content)),
+			content: content,
+		}, 40)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		lines := strings.SplitAfter(content, "\n")
+		assert.True(t, result.Meta.Truncated)
+		assert.Equal(t, 1, result.Meta.FromLine)
+		assert.Equal(t, 4, result.Meta.ToLine)
+		assert.Equal(t, 20, result.Meta.TotalLines)
+		assert.Equal(t,
+			strings.Join(lines[:4], "")+"\n[truncated after line 4 of 20, use from_line 5 to read further]\n",
+			result.Content,
+		)
+	})
+
+	t.Run("should drop partially read line of truncated file", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		setupFile(t, deps, params, fileFixture{
+			meta:      newTextMeta(params.Path, fileContentDefaultMaxBytes*2),
+			content:   "first\nsecond\nthi",
+			truncated: true,
+		}, fileContentDefaultMaxBytes)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n\n[truncated after line 2, use from_line 3 to read further]\n", result.Content)
+		assert.Zero(t, result.Meta.TotalLines)
+		assert.True(t, result.Meta.Truncated)
+	})
+
+	t.Run("should cut single line longer than the limit", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		params.MaxBytes = 5
+		content := "ab€cdef\nnext\n"
+		setupFile(t, deps, params, fileFixture{
+			meta:    newTextMeta(params.Path, len(content)),
+			content: content,
+		}, 5)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ab€\n[truncated after line 1 of 2, use from_line 2 to read further]\n", result.Content)
+	})
+
+	t.Run("should return image as base64", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		params.Path = "logo.png"
+		data := "\x89PNG\r\n\x1a\n\x00\x00"
+		meta := &bitbucket.TreeEntry{
+			Type:       bitbucket.TreeEntryTypeFile,
+			Path:       params.Path,
+			Size:       int64(len(data)),
+			MimeType:   "image/png",
+			Attributes: []string{fileAttributeBinary},
+		}
+		setupFile(t, deps, params, fileFixture{meta: meta, content: data}, fileImageMaxBytes)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &bitbucket.FileContentResult{
+			Content: base64.StdEncoding.EncodeToString([]byte(data)),
+			Meta: bitbucket.FileContentMeta{
+				Size:     len(data),
+				Type:     "file",
+				Encoding: fileEncodingBase64,
+				MimeType: "image/png",
+				Binary:   true,
+			},
+		}, result)
+	})
+
+	t.Run("should omit content of large images", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		setupFile(t, deps, params, fileFixture{meta: &bitbucket.TreeEntry{
+			Type:     bitbucket.TreeEntryTypeFile,
+			Size:     fileImageMaxBytes + 1,
+			MimeType: "image/jpeg",
+		}}, 0)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Content)
+		assert.True(t, result.Meta.Binary)
+		assert.True(t, result.Meta.Truncated)
+	})
+
+	t.Run("should omit content of binary files", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		setupFile(t, deps, params, fileFixture{meta: &bitbucket.TreeEntry{
+			Type:       bitbucket.TreeEntryTypeFile,
+			Size:       1024,
+			MimeType:   "application/octet-stream",
+			Attributes: []string{fileAttributeBinary},
+		}}, 0)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &bitbucket.FileContentResult{
+			Meta: bitbucket.FileContentMeta{
+				Size:     1024,
+				Type:     "file",
+				MimeType: "application/octet-stream",
+				Binary:   true,
+			},
+		}, result)
+	})
+
+	t.Run("should detect binary content", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		content := "ELF\x00\x01\x02"
+		setupFile(t, deps, params, fileFixture{
+			meta:    newTextMeta(params.Path, len(content)),
+			content: content,
+		}, fileContentDefaultMaxBytes)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Content)
+		assert.True(t, result.Meta.Binary)
+	})
+
+	t.Run("should decode text encodings", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			content  string
+			expected string
+			encoding string
+		}{
+			{name: "utf-8 with bom", content: "\xEF\xBB\xBFhé\n", expected: "hé\n", encoding: fileEncodingUTF8},
+			{name: "utf-16le", content: "\xFF\xFEh\x00\xE9\x00\n\x00", expected: "hé\n", encoding: fileEncodingUTF16LE},
+			{name: "utf-16be", content: "\xFE\xFF\x00h\x00\xE9\x00\n", expected: "hé\n", encoding: fileEncodingUTF16BE},
+			{name: "iso-8859-1", content: "h\xE9\n", expected: "hé\n", encoding: fileEncodingLatin1},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				deps := makeMockDeps(t)
+				params := makeParams()
+				setupFile(t, deps, params, fileFixture{
+					meta:    newTextMeta(params.Path, len(tc.content)),
+					content: tc.content,
+				}, fileContentDefaultMaxBytes)
+				service := NewBitbucketService(deps)
+
+				result, err := service.GetFileContent(t.Context(), params)
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, result.Content)
+				assert.Equal(t, tc.encoding, result.Meta.Encoding)
+			})
+		}
+	})
+
+	t.Run("should fail when line is beyond the end of the file", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		params.FromLine = 10
+		content := numberedLines(3)
+		setupFile(t, deps, params, fileFixture{
+			meta:    newTextMeta(params.Path, len(content)),
+			content: content,
+		}, fileContentRangeFetchBytes)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.ErrorContains(t, err, "line 10 is beyond the end of the file with 3 lines")
+		assert.Nil(t, result)
+	})
+
+	t.Run("should fail for directories", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		params := makeParams()
+		setupFile(t, deps, params, fileFixture{meta: &bitbucket.TreeEntry{
+			Type: bitbucket.TreeEntryTypeDirectory,
+			Path: params.Path,
+		}}, 0)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.ErrorContains(t, err, params.Path+" is a directory")
+		assert.Nil(t, result)
+	})
+
+	t.Run("should fail when metadata can not be read", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		params := makeParams()
+		expectedErr := errors.New(faker.Sentence())
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).
+			Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+		mockClient.EXPECT().GetFileMeta(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		result, err := service.GetFileContent(t.Context(), params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should validate line range", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+		params := makeParams()
+		params.FromLine = 5
+		params.ToLine = 2
+
+		_, err := service.GetFileContent(t.Context(), params)
+
+		require.ErrorContains(t, err, "invalid line range 5-2")
+	})
+}
@@ -0,0 +1,433 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/samber/lo"
+)
+
+const (
+	fileHistoryDefaultLimit = 20
+	fileHistoryMaxLimit     = 100
+
+	// fileHistoryFields adds commit details that file history entries omit by default.
+	fileHistoryFields = "+values.commit.author,+values.commit.date,+values.commit.message"
+
+	// blameDefaultMaxCommits and blameMaxCommits limit how many versions of a file are compared.
+	blameDefaultMaxCommits = 20
+	blameMaxCommits        = 50
+
+	// blameMaxLines limits the size of the blamed range of lines.
+	blameMaxLines = 500
+
+	// blameMaxDiffCells limits the size of the table used to match changed lines of two versions.
+	// Larger changes are treated as rewrites of all changed lines.
+	blameMaxDiffCells = 1_000_000
+
+	// blamePullRequestsLimit limits pull requests listed per commit.
+	blamePullRequestsLimit = 10
+)
+
+// BitbucketFileHistoryParams contains parameters for listing the history of a file.
+type BitbucketFileHistoryParams struct {
+	BitbucketRepositoryParams
+
+	// Commit hash or branch name to list the history from
+	Ref string `json:"ref"`
+
+	// Path of the file
+	Path string `json:"path"`
+
+	// Maximum number of commits to return (optional, defaults to 20, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// FileHistory lists commits that modified a file.
+type FileHistory struct {
+	Ref  string `json:"ref"`
+	Path string `json:"path"`
+
+	// Entries are versions of the file at commits that modified it, newest first.
+	// Paths of older entries differ when the file was renamed.
+	Entries []bitbucket.TreeEntry `json:"entries"`
+
+	// Truncated is true when there are more commits than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BitbucketBlameParams contains parameters for finding commits that last changed lines of a file.
+type BitbucketBlameParams struct {
+	BitbucketRepositoryParams
+
+	// Commit hash or branch name to blame the file at
+	Ref string `json:"ref"`
+
+	// Path of the file
+	Path string `json:"path"`
+
+	// FromLine and ToLine select a 1-based inclusive range of lines, ToLine defaults to FromLine
+	FromLine int `json:"from_line"`
+	ToLine   int `json:"to_line,omitempty"`
+
+	// Maximum number of file versions to compare (optional, defaults to 20, max 50)
+	MaxCommits int `json:"max_commits,omitempty"`
+}
+
+// FileBlame attributes a range of lines of a file to commits that last changed them.
+type FileBlame struct {
+	Ref      string `json:"ref"`
+	Path     string `json:"path"`
+	FromLine int    `json:"from_line"`
+	ToLine   int    `json:"to_line"`
+
+	// Ranges are consecutive lines last changed by the same commit, in line order.
+	Ranges []BlameRange `json:"ranges"`
+
+	// Commits that last changed the lines, newest first.
+	Commits []BlameCommit `json:"commits"`
+
+	// Incomplete is true when the history was not compared to the end. Lines attributed to
+	// the oldest compared commit were changed by it or by an earlier commit.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// BlameRange is a range of lines last changed by a commit.
+type BlameRange struct {
+	FromLine int    `json:"from_line"`
+	ToLine   int    `json:"to_line"`
+	Commit   string `json:"commit"`
+}
+
+// BlameCommit is a commit that last changed some of the blamed lines.
+type BlameCommit struct {
+	Commit bitbucket.Commit `json:"commit"`
+	Author string           `json:"author,omitempty"`
+
+	// Path of the file at the commit, differs from the blamed path when the file was renamed since.
+	Path string `json:"path"`
+
+	// Lines is the number of blamed lines last changed by the commit.
+	Lines int `json:"lines"`
+
+	// PullRequests the commit was reviewed in.
+	PullRequests []bitbucket.PullRequest `json:"pull_requests"`
+}
+
+// ListFileHistory lists commits that modified a file, newest first. Renames are followed.
+func (s *BitbucketService) ListFileHistory(
+	ctx context.Context,
+	params BitbucketFileHistoryParams,
+) (*FileHistory, error) {
+	s.logger.InfoContext(ctx, "Listing file history",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("ref", params.Ref),
+		slog.String("path", params.Path))
+
+	if err := validateFileRefParams(params.BitbucketRepositoryParams, params.Ref, params.Path); err != nil {
+		return nil, err
+	}
+	limit := min(params.Limit, fileHistoryMaxLimit)
+	if limit <= 0 {
+		limit = fileHistoryDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	entries, err := s.listFileHistory(ctx, tokenProvider, params.BitbucketRepositoryParams, params.Ref, params.Path, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileHistory{
+		Ref:       params.Ref,
+		Path:      strings.Trim(params.Path, "/"),
+		Entries:   entries[:min(len(entries), limit)],
+		Truncated: len(entries) > limit,
+	}, nil
+}
+
+// BlameFileLines finds commits that last changed a range of lines of a file and pull requests
+// they were reviewed in. Versions of the file are compared walking the history from the newest,
+// until every line of the range is attributed.
+func (s *BitbucketService) BlameFileLines(
+	ctx context.Context,
+	params BitbucketBlameParams,
+) (*FileBlame, error) {
+	s.logger.InfoContext(ctx, "Blaming file lines",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("ref", params.Ref),
+		slog.String("path", params.Path),
+		slog.Int("from_line", params.FromLine),
+		slog.Int("to_line", params.ToLine))
+
+	if err := validateFileRefParams(params.BitbucketRepositoryParams, params.Ref, params.Path); err != nil {
+		return nil, err
+	}
+	toLine := lo.Ternary(params.ToLine == 0, params.FromLine, params.ToLine)
+	if params.FromLine <= 0 || toLine < params.FromLine {
+		return nil, fmt.Errorf("invalid line range %d-%d", params.FromLine, toLine)
+	}
+	if toLine-params.FromLine >= blameMaxLines {
+		return nil, fmt.Errorf("at most %d lines can be blamed at once", blameMaxLines)
+	}
+	maxCommits := min(params.MaxCommits, blameMaxCommits)
+	if maxCommits <= 0 {
+		maxCommits = blameDefaultMaxCommits
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	history, err := s.listFileHistory(
+		ctx, tokenProvider, params.BitbucketRepositoryParams, params.Ref, params.Path, maxCommits)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no history of %s found at %s", params.Path, params.Ref)
+	}
+	historyTruncated := len(history) > maxCommits
+	history = history[:min(len(history), maxCommits)]
+
+	lines, err := s.readBlameVersion(ctx, tokenProvider, params.BitbucketRepositoryParams, history[0])
+	if err != nil {
+		return nil, err
+	}
+	if toLine > len(lines) {
+		return nil, fmt.Errorf("line %d is beyond the end of the file with %d lines", toLine, len(lines))
+	}
+
+	blame := &FileBlame{Ref: params.Ref, Path: history[0].Path, FromLine: params.FromLine, ToLine: toLine}
+
+	// open maps indexes of not yet attributed lines in the compared version to blamed line numbers
+	open := make(map[int]int, toLine-params.FromLine+1)
+	for line := params.FromLine; line <= toLine; line++ {
+		open[line-1] = line
+	}
+	attributed := make(map[int]int, len(open))
+	for i := 0; len(open) > 0; i++ {
+		if i == len(history)-1 {
+			for _, line := range open {
+				attributed[line] = i
+			}
+			blame.Incomplete = historyTruncated
+			break
+		}
+		older, olderErr := s.readBlameVersion(ctx, tokenProvider, params.BitbucketRepositoryParams, history[i+1])
+		if olderErr != nil {
+			return nil, olderErr
+		}
+		matches := matchLines(lines, older)
+		next := make(map[int]int, len(open))
+		for index, line := range open {
+			if matches[index] < 0 {
+				attributed[line] = i
+			} else {
+				next[matches[index]] = line
+			}
+		}
+		open, lines = next, older
+	}
+
+	if err = s.collectBlame(ctx, tokenProvider, params.BitbucketRepositoryParams, blame, history, attributed); err != nil {
+		return nil, err
+	}
+	return blame, nil
+}
+
+func validateFileRefParams(params BitbucketRepositoryParams, ref, path string) error {
+	if err := validateRepositoryParams(params); err != nil {
+		return err
+	}
+	if ref == "" {
+		return errors.New("ref is required")
+	}
+	if strings.Trim(path, "/") == "" {
+		return errors.New("path is required")
+	}
+	return nil
+}
+
+// listFileHistory lists up to limit+1 versions of a file so that callers can tell whether the
+// history is longer than the limit.
+func (s *BitbucketService) listFileHistory(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	ref, path string,
+	limit int,
+) ([]bitbucket.TreeEntry, error) {
+	entries, err := s.client.ListFileHistory(ctx, tokenProvider, bitbucket.ListFileHistoryParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    ref,
+		Path:      strings.Trim(path, "/"),
+		Fields:    fileHistoryFields,
+		PageLen:   limit + 1,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file history: %w", err)
+	}
+	return lo.Filter(entries, func(entry bitbucket.TreeEntry, _ int) bool {
+		return entry.Commit != nil
+	}), nil
+}
+
+// readBlameVersion reads lines of a version of the file from its history.
+func (s *BitbucketService) readBlameVersion(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	entry bitbucket.TreeEntry,
+) ([]string, error) {
+	content, err := s.client.GetFileContent(ctx, tokenProvider, bitbucket.GetFileContentParams{
+		RepoOwner:  params.RepoOwner,
+		RepoName:   params.RepoName,
+		CommitHash: entry.Commit.Hash,
+		FilePath:   entry.Path,
+		MaxBytes:   fileContentMaxBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s at %s: %w", entry.Path, entry.Commit.Hash, err)
+	}
+	if content.Truncated {
+		return nil, fmt.Errorf("%s at %s is larger than %d bytes", entry.Path, entry.Commit.Hash, fileContentMaxBytes)
+	}
+	text, _, isText := decodeFileText([]byte(content.Content), false)
+	if !isText {
+		return nil, fmt.Errorf("%s at %s is a binary file", entry.Path, entry.Commit.Hash)
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// matchLines matches lines of a newer version of a file to lines of an older version. The result
+// holds the index of the matching older line for each newer line, or -1 for lines that changed.
+func matchLines(newer, older []string) []int {
+	matches := make([]int, len(newer))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(newer) && prefix < len(older) && newer[prefix] == older[prefix] {
+		matches[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(newer)-prefix && suffix < len(older)-prefix &&
+		newer[len(newer)-1-suffix] == older[len(older)-1-suffix] {
+		matches[len(newer)-1-suffix] = len(older) - 1 - suffix
+		suffix++
+	}
+
+	changedNewer := newer[prefix : len(newer)-suffix]
+	changedOlder := older[prefix : len(older)-suffix]
+	if len(changedNewer) == 0 || len(changedOlder) == 0 || len(changedNewer)*len(changedOlder) > blameMaxDiffCells {
+		return matches
+	}
+
+	// lengths holds lengths of longest common subsequences of changedNewer[i:] and changedOlder[j:]
+	cols := len(changedOlder) + 1
+	lengths := make([]int32, (len(changedNewer)+1)*cols)
+	for i := len(changedNewer) - 1; i >= 0; i-- {
+		for j := len(changedOlder) - 1; j >= 0; j-- {
+			if changedNewer[i] == changedOlder[j] {
+				lengths[i*cols+j] = lengths[(i+1)*cols+j+1] + 1
+			} else {
+				lengths[i*cols+j] = max(lengths[(i+1)*cols+j], lengths[i*cols+j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(changedNewer) && j < len(changedOlder); {
+		switch {
+		case changedNewer[i] == changedOlder[j]:
+			matches[prefix+i] = prefix + j
+			i++
+			j++
+		case lengths[(i+1)*cols+j] >= lengths[i*cols+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matches
+}
+
+// collectBlame groups attributed lines into ranges and lists commits that changed them.
+func (s *BitbucketService) collectBlame(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	blame *FileBlame,
+	history []bitbucket.TreeEntry,
+	attributed map[int]int,
+) error {
+	lineCounts := make(map[int]int)
+	blame.Ranges = []BlameRange{}
+	for line := blame.FromLine; line <= blame.ToLine; line++ {
+		index := attributed[line]
+		lineCounts[index]++
+		hash := history[index].Commit.Hash
+		if last := len(blame.Ranges) - 1; last >= 0 && blame.Ranges[last].Commit == hash {
+			blame.Ranges[last].ToLine = line
+			continue
+		}
+		blame.Ranges = append(blame.Ranges, BlameRange{FromLine: line, ToLine: line, Commit: hash})
+	}
+
+	blame.Commits = []BlameCommit{}
+	for index, entry := range history {
+		if lineCounts[index] == 0 {
+			continue
+		}
+		pullRequests, err := s.getCommitPullRequests(ctx, tokenProvider, params, *entry.Commit)
+		if err != nil {
+			return err
+		}
+		blame.Commits = append(blame.Commits, BlameCommit{
+			Commit:       *entry.Commit,
+			Author:       entry.Commit.Author.Name(),
+			Path:         entry.Path,
+			Lines:        lineCounts[index],
+			PullRequests: pullRequests,
+		})
+	}
+	return nil
+}
+
+// getCommitPullRequests returns pull requests a commit was reviewed in. When pull request commit
+// links are not indexed, pull requests referenced by the commit message are returned instead.
+func (s *BitbucketService) getCommitPullRequests(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	commit bitbucket.Commit,
+) ([]bitbucket.PullRequest, error) {
+	pullRequests, err := s.client.ListCommitPullRequests(ctx, tokenProvider, bitbucket.ListCommitPullRequestsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    commit.Hash,
+		PageLen:   blamePullRequestsLimit,
+		Limit:     blamePullRequestsLimit,
+	})
+	if err != nil {
+		var httpErr *middleware.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return s.getReferencedPullRequests(ctx, tokenProvider, params, []bitbucket.Commit{commit})
+		}
+		return nil, fmt.Errorf("failed to list pull requests of commit %s: %w", commit.Hash, err)
+	}
+	if pullRequests == nil {
+		pullRequests = []bitbucket.PullRequest{}
+	}
+	return pullRequests[:min(len(pullRequests), blamePullRequestsLimit)], nil
+}
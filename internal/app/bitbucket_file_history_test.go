@@ -0,0 +1,369 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_FileHistory(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newVersion := func(hash, path string) bitbucket.TreeEntry {
+		return bitbucket.TreeEntry{
+			Type: bitbucket.TreeEntryTypeFile,
+			Path: path,
+			Commit: &bitbucket.Commit{
+				Hash:    hash,
+				Message: faker.Sentence(),
+				Author:  &bitbucket.CommitAuthor{Raw: faker.Name() + " <" + faker.Email() + ">"},
+			},
+		}
+	}
+
+	setupContent := func(
+		mockClient *MockbitbucketClient,
+		tokenProvider bitbucket.TokenProvider,
+		params BitbucketRepositoryParams,
+		version bitbucket.TreeEntry,
+		content string,
+	) {
+		mockClient.EXPECT().GetFileContent(mock.Anything, tokenProvider, bitbucket.GetFileContentParams{
+			RepoOwner:  params.RepoOwner,
+			RepoName:   params.RepoName,
+			CommitHash: version.Commit.Hash,
+			FilePath:   version.Path,
+			MaxBytes:   fileContentMaxBytes,
+		}).Return(&bitbucket.FileContent{Content: content}, nil)
+	}
+
+	t.Run("ListFileHistory", func(t *testing.T) {
+		t.Run("should list history and report truncation", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			versions := []bitbucket.TreeEntry{
+				newVersion("c3", "app/main.go"),
+				newVersion("c2", "app/main.go"),
+				newVersion("c1", "main.go"),
+			}
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, tokenProvider, bitbucket.ListFileHistoryParams{
+				Workspace: repoParams.RepoOwner,
+				RepoSlug:  repoParams.RepoName,
+				Commit:    "main",
+				Path:      "app/main.go",
+				Fields:    fileHistoryFields,
+				PageLen:   3,
+				Limit:     3,
+			}).Return(versions, nil)
+			service := NewBitbucketService(deps)
+
+			history, err := service.ListFileHistory(t.Context(), BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "/app/main.go",
+				Limit:                     2,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &FileHistory{
+				Ref:       "main",
+				Path:      "app/main.go",
+				Entries:   versions[:2],
+				Truncated: true,
+			}, history)
+		})
+
+		t.Run("should fail when history can not be listed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			setupTokenProvider(t, deps, repoParams.AccountName)
+			expectedErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			history, err := service.ListFileHistory(t.Context(), BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, history)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListFileHistory(t.Context(), BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Path:                      "main.go",
+			})
+			require.ErrorContains(t, err, "ref is required")
+
+			_, err = service.ListFileHistory(t.Context(), BitbucketFileHistoryParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Ref:                       "main",
+				Path:                      "/",
+			})
+			require.ErrorContains(t, err, "path is required")
+		})
+	})
+
+	t.Run("BlameFileLines", func(t *testing.T) {
+		t.Run("should attribute lines to commits that last changed them", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			c3 := newVersion("c3", "app/main.go")
+			c2 := newVersion("c2", "main.go")
+			c2.Commit.Message = "Rename value (pull request #5)"
+			c1 := newVersion("c1", "main.go")
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, tokenProvider, bitbucket.ListFileHistoryParams{
+				Workspace: repoParams.RepoOwner,
+				RepoSlug:  repoParams.RepoName,
+				Commit:    "main",
+				Path:      "app/main.go",
+				Fields:    fileHistoryFields,
+				PageLen:   blameDefaultMaxCommits + 1,
+				Limit:     blameDefaultMaxCommits + 1,
+			}).Return([]bitbucket.TreeEntry{c3, c2, c1}, nil)
+			setupContent(mockClient, tokenProvider, repoParams, c3, "header\na\nB\nc\nd\n")
+			setupContent(mockClient, tokenProvider, repoParams, c2, "a\nB\nc\nd\n")
+			setupContent(mockClient, tokenProvider, repoParams, c1, "a\nb\nc\nd\n")
+
+			c3PR := bitbucket.PullRequest{ID: 7, Title: faker.Sentence()}
+			c2PR := &bitbucket.PullRequest{ID: 5, Title: faker.Sentence()}
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, bitbucket.ListCommitPullRequestsParams{
+				Workspace: repoParams.RepoOwner,
+				RepoSlug:  repoParams.RepoName,
+				Commit:    "c3",
+				PageLen:   blamePullRequestsLimit,
+				Limit:     blamePullRequestsLimit,
+			}).Return([]bitbucket.PullRequest{c3PR}, nil)
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, mock.MatchedBy(
+				func(params bitbucket.ListCommitPullRequestsParams) bool { return params.Commit == "c2" },
+			)).Return(nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+				Username:      repoParams.RepoOwner,
+				RepoSlug:      repoParams.RepoName,
+				PullRequestID: 5,
+			}).Return(c2PR, nil)
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, mock.MatchedBy(
+				func(params bitbucket.ListCommitPullRequestsParams) bool { return params.Commit == "c1" },
+			)).Return(nil, nil)
+			service := NewBitbucketService(deps)
+
+			blame, err := service.BlameFileLines(t.Context(), BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "app/main.go",
+				FromLine:                  1,
+				ToLine:                    4,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &FileBlame{
+				Ref:      "main",
+				Path:     "app/main.go",
+				FromLine: 1,
+				ToLine:   4,
+				Ranges: []BlameRange{
+					{FromLine: 1, ToLine: 1, Commit: "c3"},
+					{FromLine: 2, ToLine: 2, Commit: "c1"},
+					{FromLine: 3, ToLine: 3, Commit: "c2"},
+					{FromLine: 4, ToLine: 4, Commit: "c1"},
+				},
+				Commits: []BlameCommit{
+					{
+						Commit:       *c3.Commit,
+						Author:       c3.Commit.Author.Raw,
+						Path:         "app/main.go",
+						Lines:        1,
+						PullRequests: []bitbucket.PullRequest{c3PR},
+					},
+					{
+						Commit:       *c2.Commit,
+						Author:       c2.Commit.Author.Raw,
+						Path:         "main.go",
+						Lines:        1,
+						PullRequests: []bitbucket.PullRequest{*c2PR},
+					},
+					{
+						Commit:       *c1.Commit,
+						Author:       c1.Commit.Author.Raw,
+						Path:         "main.go",
+						Lines:        2,
+						PullRequests: []bitbucket.PullRequest{},
+					},
+				},
+			}, blame)
+		})
+
+		t.Run("should stop at the commit limit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			c2 := newVersion("c2", "main.go")
+			c2.Commit.Author.User = &bitbucket.Account{DisplayName: faker.Name()}
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, tokenProvider, mock.MatchedBy(
+				func(params bitbucket.ListFileHistoryParams) bool { return params.Limit == 2 },
+			)).Return([]bitbucket.TreeEntry{c2, newVersion("c1", "main.go")}, nil)
+			setupContent(mockClient, tokenProvider, repoParams, c2, "a\nb\n")
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, mock.Anything).Return(nil, nil)
+			service := NewBitbucketService(deps)
+
+			blame, err := service.BlameFileLines(t.Context(), BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+				FromLine:                  2,
+				MaxCommits:                1,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, blame.Incomplete)
+			assert.Equal(t, []BlameRange{{FromLine: 2, ToLine: 2, Commit: "c2"}}, blame.Ranges)
+			require.Len(t, blame.Commits, 1)
+			assert.Equal(t, c2.Commit.Author.User.DisplayName, blame.Commits[0].Author)
+		})
+
+		t.Run("should fail when line is beyond the end of the file", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			c1 := newVersion("c1", "main.go")
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, tokenProvider, mock.Anything).
+				Return([]bitbucket.TreeEntry{c1}, nil)
+			setupContent(mockClient, tokenProvider, repoParams, c1, "a\nb\n")
+			service := NewBitbucketService(deps)
+
+			blame, err := service.BlameFileLines(t.Context(), BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+				FromLine:                  2,
+				ToLine:                    3,
+			})
+
+			require.ErrorContains(t, err, "line 3 is beyond the end of the file with 2 lines")
+			assert.Nil(t, blame)
+		})
+
+		t.Run("should fail for binary files", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			c1 := newVersion("c1", "app.bin")
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, tokenProvider, mock.Anything).
+				Return([]bitbucket.TreeEntry{c1}, nil)
+			setupContent(mockClient, tokenProvider, repoParams, c1, "\x00\x01")
+			service := NewBitbucketService(deps)
+
+			_, err := service.BlameFileLines(t.Context(), BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "app.bin",
+				FromLine:                  1,
+			})
+
+			require.ErrorContains(t, err, "app.bin at c1 is a binary file")
+		})
+
+		t.Run("should fail when file has no history", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			setupTokenProvider(t, deps, repoParams.AccountName)
+
+			mockClient.EXPECT().ListFileHistory(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			service := NewBitbucketService(deps)
+
+			_, err := service.BlameFileLines(t.Context(), BitbucketBlameParams{
+				BitbucketRepositoryParams: repoParams,
+				Ref:                       "main",
+				Path:                      "main.go",
+				FromLine:                  1,
+			})
+
+			require.ErrorContains(t, err, "no history of main.go found at main")
+		})
+
+		t.Run("should validate line range", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+			params := BitbucketBlameParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Ref:                       "main",
+				Path:                      "main.go",
+			}
+
+			_, err := service.BlameFileLines(t.Context(), params)
+			require.ErrorContains(t, err, "invalid line range 0-0")
+
+			params.FromLine = 1
+			params.ToLine = blameMaxLines + 1
+			_, err = service.BlameFileLines(t.Context(), params)
+			require.ErrorContains(t, err, "at most 500 lines can be blamed at once")
+		})
+	})
+}
+
+func TestMatchLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		newer    []string
+		older    []string
+		expected []int
+	}{
+		{name: "same", newer: []string{"a", "b"}, older: []string{"a", "b"}, expected: []int{0, 1}},
+		{name: "inserted", newer: []string{"a", "x", "b"}, older: []string{"a", "b"}, expected: []int{0, -1, 1}},
+		{name: "deleted", newer: []string{"a", "c"}, older: []string{"a", "b", "c"}, expected: []int{0, 2}},
+		{
+			name:     "changed in the middle",
+			newer:    []string{"a", "x", "c", "y", "e"},
+			older:    []string{"a", "b", "c", "d", "e"},
+			expected: []int{0, -1, 2, -1, 4},
+		},
+		{name: "new file", newer: []string{"a"}, older: []string{}, expected: []int{-1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchLines(tc.newer, tc.older))
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+// Merge check names reported by CheckPRMergeable.
+const (
+	MergeCheckOpen               = "open"
+	MergeCheckNotDraft           = "not_draft"
+	MergeCheckApprovals          = "approvals"
+	MergeCheckNoChangesRequested = "no_changes_requested"
+	MergeCheckTasksResolved      = "tasks_resolved"
+	MergeCheckBuildsPassing      = "builds_passing"
+	MergeCheckNoConflicts        = "no_conflicts"
+)
+
+const (
+	// mergeCheckTasksPageLen is the page size used when counting unresolved tasks.
+	mergeCheckTasksPageLen = 100
+
+	// participantStateChangesRequested is the participant state of a reviewer that requested changes.
+	participantStateChangesRequested = "changes_requested"
+
+	// pullRequestStateOpen is the state of a pull request that can still be merged.
+	pullRequestStateOpen = "OPEN"
+)
+
+// BitbucketCheckPRMergeableParams contains parameters for the merge preflight check.
+type BitbucketCheckPRMergeableParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Pull request ID
+	PullRequestID int `json:"pull_request_id"`
+}
+
+// PRMergeCheck is a single item of the merge preflight checklist.
+type PRMergeCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Details string `json:"details"`
+
+	// Restriction is the kind of the branch restriction that enforces the check, if any.
+	Restriction string `json:"restriction,omitempty"`
+}
+
+// PRMergeCheckResult is the merge preflight checklist of a pull request.
+type PRMergeCheckResult struct {
+	PullRequestID     int            `json:"pull_request_id"`
+	DestinationBranch string         `json:"destination_branch"`
+	Mergeable         bool           `json:"mergeable"`
+	Checks            []PRMergeCheck `json:"checks"`
+	Warnings          []string       `json:"warnings,omitempty"`
+}
+
+// CheckPRMergeable gathers approvals, tasks, build statuses, conflicts, draft state and
+// branch restrictions of a pull request and reports whether it is ready to be merged.
+func (s *BitbucketService) CheckPRMergeable(
+	ctx context.Context,
+	params BitbucketCheckPRMergeableParams,
+) (*PRMergeCheckResult, error) {
+	s.logger.InfoContext(ctx, "Checking if pull request is mergeable",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.Int("pr_id", params.PullRequestID))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if params.PullRequestID <= 0 {
+		return nil, errors.New("pull request ID must be positive")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	result, _, err := s.checkPRMergeable(ctx, tokenProvider, params)
+	return result, err
+}
+
+// checkPRMergeable builds the merge checklist of a pull request. The branch policy of the
+// destination branch is returned along with the checklist, it is empty when branch
+// restrictions can not be read.
+func (s *BitbucketService) checkPRMergeable(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketCheckPRMergeableParams,
+) (*PRMergeCheckResult, BranchPolicy, error) {
+	pr, err := s.client.GetPR(ctx, tokenProvider, bitbucket.GetPRParams{
+		Username:      params.RepoOwner,
+		RepoSlug:      params.RepoName,
+		PullRequestID: params.PullRequestID,
+	})
+	if err != nil {
+		return nil, BranchPolicy{}, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	tasks, err := s.client.ListPullRequestTasks(ctx, tokenProvider, bitbucket.ListPullRequestTasksParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		PullReqID: params.PullRequestID,
+		Query:     fmt.Sprintf("state=%q", TaskStateUnresolved),
+		PageLen:   mergeCheckTasksPageLen,
+	})
+	if err != nil {
+		return nil, BranchPolicy{}, fmt.Errorf("failed to list pull request tasks: %w", err)
+	}
+
+	statuses, err := s.listHeadCommitStatuses(ctx, tokenProvider, params.RepoOwner, params.RepoName, pr)
+	if err != nil {
+		return nil, BranchPolicy{}, err
+	}
+
+	diffStat, err := s.client.GetPRDiffStat(ctx, tokenProvider, bitbucket.GetPRDiffStatParams{
+		RepoOwner: params.RepoOwner,
+		RepoName:  params.RepoName,
+		PRID:      params.PullRequestID,
+	})
+	if err != nil {
+		return nil, BranchPolicy{}, fmt.Errorf("failed to get pull request diffstat: %w", err)
+	}
+
+	result := &PRMergeCheckResult{PullRequestID: pr.ID}
+	if pr.Destination != nil {
+		result.DestinationBranch = pr.Destination.Branch.Name
+	}
+
+	// Reading branch restrictions requires admin access to the repository, so
+	// the check is still performed without them when they are not available.
+	restrictions, err := s.client.ListBranchRestrictions(ctx, tokenProvider, bitbucket.ListBranchRestrictionsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to list branch restrictions", slog.Any("error", err))
+		result.Warnings = append(result.Warnings,
+			"branch restrictions could not be read, required counts are not enforced: "+err.Error())
+	}
+	policy := newBranchPolicy(restrictions, result.DestinationBranch)
+
+	result.Checks = []PRMergeCheck{
+		checkPROpen(pr),
+		checkPRNotDraft(pr),
+		checkPRApprovals(pr, policy),
+		checkPRNoChangesRequested(pr, policy),
+		checkPRTasksResolved(tasks, policy),
+		checkPRBuildsPassing(statuses, policy),
+		checkPRNoConflicts(diffStat.Values),
+	}
+	result.Mergeable = true
+	for _, check := range result.Checks {
+		result.Mergeable = result.Mergeable && check.Passed
+	}
+
+	return result, policy, nil
+}
+
+// enforcedBy returns the restriction kind when the check is enforced by branch restrictions.
+func enforcedBy(enforced bool, kind string) string {
+	return lo.Ternary(enforced, kind, "")
+}
+
+func enforcedSuffix(enforced bool) string {
+	if enforced {
+		return " (enforced by branch restrictions)"
+	}
+	return ""
+}
+
+func checkPROpen(pr *bitbucket.PullRequest) PRMergeCheck {
+	return PRMergeCheck{
+		Name:    MergeCheckOpen,
+		Passed:  pr.State == pullRequestStateOpen,
+		Details: "pull request state is " + pr.State,
+	}
+}
+
+func checkPRNotDraft(pr *bitbucket.PullRequest) PRMergeCheck {
+	isDraft := pr.Draft != nil && *pr.Draft
+	details := "pull request is not a draft"
+	if isDraft {
+		details = "pull request is a draft"
+	}
+	return PRMergeCheck{Name: MergeCheckNotDraft, Passed: !isDraft, Details: details}
+}
+
+func checkPRApprovals(pr *bitbucket.PullRequest, policy BranchPolicy) PRMergeCheck {
+	approvals := 0
+	for _, participant := range pr.Participants {
+		if participant.Approved {
+			approvals++
+		}
+	}
+	return PRMergeCheck{
+		Name:    MergeCheckApprovals,
+		Passed:  approvals >= policy.RequiredApprovals,
+		Details: fmt.Sprintf("%d approvals, %d required", approvals, policy.RequiredApprovals),
+		Restriction: enforcedBy(policy.RequiredApprovals > 0,
+			bitbucket.BranchRestrictionRequireApprovals),
+	}
+}
+
+func checkPRNoChangesRequested(pr *bitbucket.PullRequest, policy BranchPolicy) PRMergeCheck {
+	var requestedBy []string
+	for _, participant := range pr.Participants {
+		if participant.State == participantStateChangesRequested {
+			requestedBy = append(requestedBy, participant.User.DisplayName)
+		}
+	}
+	details := "no changes requested"
+	if len(requestedBy) > 0 {
+		details = "changes requested by " + strings.Join(requestedBy, ", ")
+	}
+	return PRMergeCheck{
+		Name:    MergeCheckNoChangesRequested,
+		Passed:  len(requestedBy) == 0,
+		Details: details + enforcedSuffix(policy.RequireNoChangesRequested),
+		Restriction: enforcedBy(policy.RequireNoChangesRequested,
+			bitbucket.BranchRestrictionRequireNoChangesRequested),
+	}
+}
+
+func checkPRTasksResolved(tasks *bitbucket.PaginatedTasks, policy BranchPolicy) PRMergeCheck {
+	unresolved := 0
+	for _, task := range tasks.Values {
+		if task.State == TaskStateUnresolved {
+			unresolved++
+		}
+	}
+	unresolved = max(unresolved, tasks.Size)
+	return PRMergeCheck{
+		Name:    MergeCheckTasksResolved,
+		Passed:  unresolved == 0,
+		Details: fmt.Sprintf("%d unresolved tasks", unresolved) + enforcedSuffix(policy.RequireTasksCompleted),
+		Restriction: enforcedBy(policy.RequireTasksCompleted,
+			bitbucket.BranchRestrictionRequireTasksCompleted),
+	}
+}
+
+func checkPRBuildsPassing(statuses []bitbucket.CommitStatus, policy BranchPolicy) PRMergeCheck {
+	var successful int
+	var notPassing []string
+	for _, status := range statuses {
+		if status.State == bitbucket.CommitStatusStateSuccessful {
+			successful++
+			continue
+		}
+		notPassing = append(notPassing, fmt.Sprintf("%s is %s", lo.CoalesceOrEmpty(status.Name, status.Key), status.State))
+	}
+	details := fmt.Sprintf("%d of %d builds successful", successful, len(statuses))
+	if policy.RequiredPassingBuilds > 0 {
+		details += fmt.Sprintf(", %d required", policy.RequiredPassingBuilds)
+	}
+	if len(notPassing) > 0 {
+		details += "; " + strings.Join(notPassing, ", ")
+	}
+	return PRMergeCheck{
+		Name:    MergeCheckBuildsPassing,
+		Passed:  len(notPassing) == 0 && successful >= policy.RequiredPassingBuilds,
+		Details: details,
+		Restriction: enforcedBy(policy.RequiredPassingBuilds > 0,
+			bitbucket.BranchRestrictionRequirePassingBuilds),
+	}
+}
+
+func checkPRNoConflicts(diffStats []bitbucket.DiffStat) PRMergeCheck {
+	var conflicted []string
+	for _, diffStat := range diffStats {
+		if !isConflictDiffStatStatus(diffStat.Status) {
+			continue
+		}
+		path := diffStat.Path
+		if path == "" && diffStat.New != nil {
+			path = diffStat.New.Path
+		}
+		if path == "" && diffStat.Old != nil {
+			path = diffStat.Old.Path
+		}
+		conflicted = append(conflicted, fmt.Sprintf("%s (%s)", path, diffStat.Status))
+	}
+	details := "no conflicts"
+	if len(conflicted) > 0 {
+		details = "conflicts in " + strings.Join(conflicted, ", ")
+	}
+	return PRMergeCheck{Name: MergeCheckNoConflicts, Passed: len(conflicted) == 0, Details: details}
+}
+
+// isConflictDiffStatStatus reports whether the diffstat status indicates a merge conflict.
+func isConflictDiffStatStatus(status string) bool {
+	return strings.Contains(status, "conflict") || status == "local deleted" || status == "remote deleted"
+}
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_CheckPRMergeable(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	type fixture struct {
+		params       BitbucketCheckPRMergeableParams
+		pr           *bitbucket.PullRequest
+		tasks        *bitbucket.PaginatedTasks
+		statuses     []bitbucket.CommitStatus
+		diffStat     []bitbucket.DiffStat
+		restrictions []bitbucket.BranchRestriction
+	}
+
+	makeFixture := func() fixture {
+		pr := bitbucket.NewRandomPullRequest()
+		pr.Participants = []bitbucket.Participant{*bitbucket.NewRandomParticipant(true)}
+		pr.Source.Commit = &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()}
+		return fixture{
+			params: BitbucketCheckPRMergeableParams{
+				AccountName:   "account-" + faker.Username(),
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: pr.ID,
+			},
+			pr:    pr,
+			tasks: &bitbucket.PaginatedTasks{},
+			statuses: []bitbucket.CommitStatus{
+				{Key: "build-" + faker.Word(), State: bitbucket.CommitStatusStateSuccessful},
+			},
+			diffStat: []bitbucket.DiffStat{{Status: "modified", Path: faker.Word() + ".go"}},
+		}
+	}
+
+	setupMocks := func(t *testing.T, deps BitbucketServiceDeps, f fixture, restrictionsErr error) {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, f.params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+			Username:      f.params.RepoOwner,
+			RepoSlug:      f.params.RepoName,
+			PullRequestID: f.params.PullRequestID,
+		}).Return(f.pr, nil)
+		mockClient.EXPECT().ListPullRequestTasks(mock.Anything, tokenProvider, bitbucket.ListPullRequestTasksParams{
+			Workspace: f.params.RepoOwner,
+			RepoSlug:  f.params.RepoName,
+			PullReqID: f.params.PullRequestID,
+			Query:     `state="UNRESOLVED"`,
+			PageLen:   mergeCheckTasksPageLen,
+		}).Return(f.tasks, nil)
+		mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: f.params.RepoOwner,
+			RepoSlug:  f.params.RepoName,
+			Commit:    f.pr.Source.Commit.Hash,
+		}).Return(f.statuses, nil)
+		mockClient.EXPECT().GetPRDiffStat(mock.Anything, tokenProvider, bitbucket.GetPRDiffStatParams{
+			RepoOwner: f.params.RepoOwner,
+			RepoName:  f.params.RepoName,
+			PRID:      f.params.PullRequestID,
+		}).Return(&struct {
+			Size    int                  `json:"size,omitempty"`
+			Page    int                  `json:"page,omitempty"`
+			PageLen int                  `json:"pagelen,omitempty"`
+			Values  []bitbucket.DiffStat `json:"values"`
+		}{Values: f.diffStat}, nil)
+		mockClient.EXPECT().ListBranchRestrictions(mock.Anything, tokenProvider, bitbucket.ListBranchRestrictionsParams{
+			Workspace: f.params.RepoOwner,
+			RepoSlug:  f.params.RepoName,
+		}).Return(f.restrictions, restrictionsErr)
+	}
+
+	findCheck := func(t *testing.T, result *PRMergeCheckResult, name string) PRMergeCheck {
+		check, found := lo.Find(result.Checks, func(c PRMergeCheck) bool { return c.Name == name })
+		require.True(t, found, "check %s not found", name)
+		return check
+	}
+
+	t.Run("should report mergeable pull request", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		f := makeFixture()
+		setupMocks(t, deps, f, nil)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CheckPRMergeable(t.Context(), f.params)
+
+		require.NoError(t, err)
+		assert.True(t, result.Mergeable)
+		assert.Equal(t, f.pr.ID, result.PullRequestID)
+		assert.Equal(t, f.pr.Destination.Branch.Name, result.DestinationBranch)
+		assert.Empty(t, result.Warnings)
+		assert.Len(t, result.Checks, 7)
+		for _, check := range result.Checks {
+			assert.True(t, check.Passed, "check %s should pass: %s", check.Name, check.Details)
+		}
+	})
+
+	t.Run("should only check builds of the head commit", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		f := makeFixture()
+		setupMocks(t, deps, f, nil)
+		// Statuses of the whole pull request include a failed build of a commit replaced by a later push
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockClient.EXPECT().ListPRStatuses(mock.Anything, mock.Anything, mock.Anything).Return([]bitbucket.CommitStatus{
+			{
+				Key:    f.statuses[0].Key,
+				State:  bitbucket.CommitStatusStateFailed,
+				Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()},
+			},
+			f.statuses[0],
+		}, nil).Maybe()
+		service := NewBitbucketService(deps)
+
+		result, err := service.CheckPRMergeable(t.Context(), f.params)
+
+		require.NoError(t, err)
+		assert.True(t, result.Mergeable)
+		assert.Equal(t, "1 of 1 builds successful", findCheck(t, result, MergeCheckBuildsPassing).Details)
+	})
+
+	t.Run("should fail checks that block the merge", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		f := makeFixture()
+		f.pr.Draft = lo.ToPtr(true)
+		changesRequested := bitbucket.NewRandomParticipant(false)
+		f.pr.Participants = append(f.pr.Participants, *changesRequested)
+		f.tasks = &bitbucket.PaginatedTasks{
+			Size: 1,
+			Values: []bitbucket.PullRequestCommentTask{
+				{PullRequestTask: bitbucket.PullRequestTask{Task: bitbucket.Task{State: TaskStateUnresolved}}},
+			},
+		}
+		failedBuild := "build-" + faker.Word()
+		f.statuses = append(f.statuses, bitbucket.CommitStatus{Key: failedBuild, State: bitbucket.CommitStatusStateFailed})
+		conflictedPath := faker.Word() + ".go"
+		f.diffStat = append(f.diffStat, bitbucket.DiffStat{Status: "merge conflict", Path: conflictedPath})
+		requiredApprovals := 2 + rand.IntN(3)
+		f.restrictions = []bitbucket.BranchRestriction{
+			{
+				Kind:            bitbucket.BranchRestrictionRequireApprovals,
+				BranchMatchKind: bitbucket.BranchMatchKindGlob,
+				Pattern:         f.pr.Destination.Branch.Name,
+				Value:           lo.ToPtr(requiredApprovals),
+			},
+			{
+				Kind:            bitbucket.BranchRestrictionRequireTasksCompleted,
+				BranchMatchKind: bitbucket.BranchMatchKindGlob,
+				Pattern:         "*",
+			},
+			{
+				// Not applicable to the destination branch
+				Kind:            bitbucket.BranchRestrictionRequirePassingBuilds,
+				BranchMatchKind: bitbucket.BranchMatchKindGlob,
+				Pattern:         "release/*",
+				Value:           lo.ToPtr(10),
+			},
+		}
+		setupMocks(t, deps, f, nil)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CheckPRMergeable(t.Context(), f.params)
+
+		require.NoError(t, err)
+		assert.False(t, result.Mergeable)
+		assert.True(t, findCheck(t, result, MergeCheckOpen).Passed)
+		assert.False(t, findCheck(t, result, MergeCheckNotDraft).Passed)
+		assert.Equal(t, PRMergeCheck{
+			Name:        MergeCheckApprovals,
+			Passed:      false,
+			Details:     fmt.Sprintf("1 approvals, %d required", requiredApprovals),
+			Restriction: bitbucket.BranchRestrictionRequireApprovals,
+		}, findCheck(t, result, MergeCheckApprovals))
+		changes := findCheck(t, result, MergeCheckNoChangesRequested)
+		assert.False(t, changes.Passed)
+		assert.Contains(t, changes.Details, changesRequested.User.DisplayName)
+		tasks := findCheck(t, result, MergeCheckTasksResolved)
+		assert.Equal(t, PRMergeCheck{
+			Name:        MergeCheckTasksResolved,
+			Passed:      false,
+			Details:     "1 unresolved tasks (enforced by branch restrictions)",
+			Restriction: bitbucket.BranchRestrictionRequireTasksCompleted,
+		}, tasks)
+		builds := findCheck(t, result, MergeCheckBuildsPassing)
+		assert.False(t, builds.Passed)
+		assert.Contains(t, builds.Details, failedBuild+" is FAILED")
+		assert.NotContains(t, builds.Details, "required")
+		assert.Empty(t, builds.Restriction)
+		conflicts := findCheck(t, result, MergeCheckNoConflicts)
+		assert.False(t, conflicts.Passed)
+		assert.Contains(t, conflicts.Details, conflictedPath)
+	})
+
+	t.Run("should report warning when branch restrictions are not available", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		f := makeFixture()
+		setupMocks(t, deps, f, errors.New(faker.Sentence()))
+		service := NewBitbucketService(deps)
+
+		result, err := service.CheckPRMergeable(t.Context(), f.params)
+
+		require.NoError(t, err)
+		assert.True(t, result.Mergeable)
+		assert.Len(t, result.Warnings, 1)
+	})
+
+	t.Run("should fail when pull request can not be loaded", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		f := makeFixture()
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, f.params.AccountName).
+			Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+		expectedErr := errors.New(faker.Sentence())
+		mockClient.EXPECT().GetPR(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		result, err := service.CheckPRMergeable(t.Context(), f.params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should validate required parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+		validParams := makeFixture().params
+
+		noOwner := validParams
+		noOwner.RepoOwner = ""
+		_, err := service.CheckPRMergeable(t.Context(), noOwner)
+		require.ErrorContains(t, err, "repository owner is required")
+
+		noName := validParams
+		noName.RepoName = ""
+		_, err = service.CheckPRMergeable(t.Context(), noName)
+		require.ErrorContains(t, err, "repository name is required")
+
+		noID := validParams
+		noID.PullRequestID = 0
+		_, err = service.CheckPRMergeable(t.Context(), noID)
+		require.ErrorContains(t, err, "pull request ID must be positive")
+	})
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+// BitbucketOpenChangeParams contains parameters for proposing file changes as a pull request.
+type BitbucketOpenChangeParams struct {
+	BitbucketRepositoryParams
+
+	// Name of the new branch
+	Branch string `json:"branch"`
+
+	// Branch the new branch starts from and the pull request targets
+	Base string `json:"base"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Author in "Name <email>" format (optional, defaults to the authenticated user)
+	Author string `json:"author,omitempty"`
+
+	// Files added or modified with their complete new content
+	Files []bitbucket.FileChange `json:"files,omitempty"`
+
+	// Paths of deleted files
+	Delete []string `json:"delete,omitempty"`
+
+	// Title of the pull request (optional, defaults to the first line of the commit message)
+	Title string `json:"title,omitempty"`
+
+	// Description of the pull request
+	Description string `json:"description,omitempty"`
+
+	// Reviewer usernames (optional)
+	Reviewers []string `json:"reviewers,omitempty"`
+
+	// Whether to close the branch after merging
+	CloseSourceBranch bool `json:"close_source_branch,omitempty"`
+
+	// Whether to create the pull request as a draft
+	Draft *bool `json:"draft,omitempty"`
+}
+
+// OpenedChange describes the branch, the commit and the pull request of a proposed change.
+type OpenedChange struct {
+	Branch      *bitbucket.Branch      `json:"branch"`
+	Commit      *CommittedFiles        `json:"commit"`
+	PullRequest *bitbucket.PullRequest `json:"pull_request"`
+}
+
+// OpenChange creates a branch from the base branch, commits file changes to it and opens a pull
+// request into the base branch. The branch is deleted when committing or opening the pull request fails.
+func (s *BitbucketService) OpenChange(
+	ctx context.Context,
+	params BitbucketOpenChangeParams,
+) (*OpenedChange, error) {
+	s.logger.InfoContext(ctx, "Opening change",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Branch),
+		slog.String("base", params.Base))
+
+	branchParams := BitbucketBranchParams{BitbucketRepositoryParams: params.BitbucketRepositoryParams, Name: params.Branch}
+	if err := validateBranchParams(branchParams); err != nil {
+		return nil, err
+	}
+	if params.Base == "" {
+		return nil, errors.New("base branch is required")
+	}
+	if strings.TrimSpace(params.Message) == "" {
+		return nil, errors.New("commit message is required")
+	}
+	if _, _, err := normalizeFileChanges(params.Files, params.Delete); err != nil {
+		return nil, err
+	}
+	title := params.Title
+	if title == "" {
+		title, _, _ = strings.Cut(strings.TrimSpace(params.Message), "\n")
+	}
+
+	branch, err := s.CreateBranch(ctx, BitbucketCreateBranchParams{
+		BitbucketRepositoryParams: params.BitbucketRepositoryParams,
+		Name:                      params.Branch,
+		From:                      params.Base,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The commit is rejected if anything was pushed to the new branch in the meantime
+	var expectedHead string
+	if branch.Target != nil {
+		expectedHead = branch.Target.Hash
+	}
+	committed, err := s.CommitFiles(ctx, BitbucketCommitFilesParams{
+		BitbucketRepositoryParams: params.BitbucketRepositoryParams,
+		Branch:                    params.Branch,
+		Message:                   params.Message,
+		Author:                    params.Author,
+		ExpectedHead:              expectedHead,
+		Files:                     params.Files,
+		Delete:                    params.Delete,
+	})
+	if err != nil {
+		return nil, s.rollbackOpenChange(ctx, branchParams, err)
+	}
+
+	pr, err := s.CreatePR(ctx, BitbucketCreatePRParams{
+		AccountName:       params.AccountName,
+		RepoOwner:         params.RepoOwner,
+		RepoName:          params.RepoName,
+		Title:             title,
+		Description:       params.Description,
+		SourceBranch:      params.Branch,
+		DestBranch:        params.Base,
+		CloseSourceBranch: params.CloseSourceBranch,
+		Reviewers:         params.Reviewers,
+		Draft:             params.Draft,
+	})
+	if err != nil {
+		return nil, s.rollbackOpenChange(ctx, branchParams, fmt.Errorf("failed to create pull request: %w", err))
+	}
+
+	return &OpenedChange{Branch: branch, Commit: committed, PullRequest: pr}, nil
+}
+
+// rollbackOpenChange deletes the branch created for a change that could not be opened.
+// The branch is deleted even if the context is canceled, so that no half-made change is left behind.
+func (s *BitbucketService) rollbackOpenChange(ctx context.Context, params BitbucketBranchParams, cause error) error {
+	s.logger.WarnContext(ctx, "Rolling back change",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Name),
+		slog.Any("cause", cause))
+
+	if err := s.DeleteBranch(context.WithoutCancel(ctx), params); err != nil {
+		return fmt.Errorf("%w; rolling back branch %s also failed: %w", cause, params.Name, err)
+	}
+	return fmt.Errorf("%w; branch %s was deleted", cause, params.Name)
+}
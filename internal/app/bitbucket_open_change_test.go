@@ -0,0 +1,199 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_OpenChange(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeParams := func() BitbucketOpenChangeParams {
+		return BitbucketOpenChangeParams{
+			BitbucketRepositoryParams: BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+			},
+			Branch:  "fix/" + faker.Word(),
+			Base:    "main",
+			Message: "Fix typo in readme\n\nDetails of the fix",
+			Files:   []bitbucket.FileChange{{Path: "README.md", Content: faker.Paragraph()}},
+		}
+	}
+
+	// setupBranch expects the branch of the change to be created from the base branch.
+	setupBranch := func(
+		mockClient *MockbitbucketClient,
+		tokenProvider bitbucket.TokenProvider,
+		params BitbucketOpenChangeParams,
+	) *bitbucket.Branch {
+		branch := &bitbucket.Branch{Name: params.Branch, Target: &bitbucket.Commit{Hash: faker.UUIDDigit()}}
+		mockClient.EXPECT().CreateBranch(mock.Anything, tokenProvider, bitbucket.CreateBranchParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Name:      params.Branch,
+			Target:    params.Base,
+		}).Return(branch, nil)
+		return branch
+	}
+
+	t.Run("should create branch, commit files and open pull request", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Delete = []string{"docs/old.md"}
+		params.Description = faker.Paragraph()
+		params.Reviewers = []string{faker.Username()}
+		params.CloseSourceBranch = true
+		params.Draft = lo.ToPtr(true)
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		branch := setupBranch(mockClient, tokenProvider, params)
+		commitHash := faker.UUIDDigit()
+		pr := &bitbucket.PullRequest{ID: 42, Title: "Fix typo in readme"}
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, tokenProvider, bitbucket.CommitFilesParams{
+			Workspace:    params.RepoOwner,
+			RepoSlug:     params.RepoName,
+			Branch:       params.Branch,
+			Message:      params.Message,
+			Parents:      []string{branch.Target.Hash},
+			Files:        params.Files,
+			DeletedPaths: params.Delete,
+		}).Return(&bitbucket.Commit{Hash: commitHash}, nil)
+		mockClient.EXPECT().CreatePR(mock.Anything, tokenProvider, bitbucket.CreatePRParams{
+			Username: params.RepoOwner,
+			RepoSlug: params.RepoName,
+			Request: &bitbucket.PullRequest{
+				Title:             "Fix typo in readme",
+				Description:       params.Description,
+				CloseSourceBranch: true,
+				Source: bitbucket.PullRequestSource{
+					Branch: bitbucket.PullRequestBranch{Name: params.Branch},
+				},
+				Destination: &bitbucket.PullRequestDestination{
+					Branch: bitbucket.PullRequestBranch{Name: "main"},
+				},
+				Reviewers: []bitbucket.PullRequestAuthor{{Username: params.Reviewers[0]}},
+				Draft:     lo.ToPtr(true),
+			},
+		}).Return(pr, nil)
+		service := NewBitbucketService(deps)
+
+		change, err := service.OpenChange(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &OpenedChange{
+			Branch: branch,
+			Commit: &CommittedFiles{
+				Branch:  params.Branch,
+				Commit:  commitHash,
+				Updated: []string{"README.md"},
+				Deleted: []string{"docs/old.md"},
+			},
+			PullRequest: pr,
+		}, change)
+	})
+
+	t.Run("should delete branch when commit fails", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		setupBranch(mockClient, tokenProvider, params)
+		commitErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, tokenProvider, mock.Anything).Return(nil, commitErr)
+		mockClient.EXPECT().DeleteBranch(mock.Anything, tokenProvider, bitbucket.DeleteBranchParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Name:      params.Branch,
+		}).Return(nil)
+		service := NewBitbucketService(deps)
+
+		change, err := service.OpenChange(t.Context(), params)
+
+		require.ErrorIs(t, err, commitErr)
+		require.ErrorContains(t, err, "branch "+params.Branch+" was deleted")
+		assert.Nil(t, change)
+	})
+
+	t.Run("should report failed rollback when pull request can not be created", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		setupBranch(mockClient, tokenProvider, params)
+		prErr := errors.New(faker.Sentence())
+		deleteErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().CommitFiles(mock.Anything, tokenProvider, mock.Anything).
+			Return(&bitbucket.Commit{Hash: faker.UUIDDigit()}, nil)
+		mockClient.EXPECT().CreatePR(mock.Anything, tokenProvider, mock.Anything).Return(nil, prErr)
+		mockClient.EXPECT().DeleteBranch(mock.Anything, tokenProvider, mock.Anything).Return(deleteErr)
+		service := NewBitbucketService(deps)
+
+		change, err := service.OpenChange(t.Context(), params)
+
+		require.ErrorIs(t, err, prErr)
+		require.ErrorIs(t, err, deleteErr)
+		require.ErrorContains(t, err, "failed to create pull request")
+		require.ErrorContains(t, err, "rolling back branch "+params.Branch+" also failed")
+		assert.Nil(t, change)
+	})
+
+	t.Run("should not delete branch that could not be created", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		branchErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().CreateBranch(mock.Anything, mock.Anything, mock.Anything).Return(nil, branchErr)
+		service := NewBitbucketService(deps)
+
+		change, err := service.OpenChange(t.Context(), params)
+
+		require.ErrorIs(t, err, branchErr)
+		assert.Nil(t, change)
+	})
+
+	t.Run("should validate parameters before creating branch", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		noBranch := makeParams()
+		noBranch.Branch = ""
+		_, err := service.OpenChange(t.Context(), noBranch)
+		require.ErrorContains(t, err, "branch name is required")
+
+		noBase := makeParams()
+		noBase.Base = ""
+		_, err = service.OpenChange(t.Context(), noBase)
+		require.ErrorContains(t, err, "base branch is required")
+
+		noMessage := makeParams()
+		noMessage.Message = ""
+		_, err = service.OpenChange(t.Context(), noMessage)
+		require.ErrorContains(t, err, "commit message is required")
+
+		noFiles := makeParams()
+		noFiles.Files = nil
+		_, err = service.OpenChange(t.Context(), noFiles)
+		require.ErrorContains(t, err, "at least one file must be changed or deleted")
+	})
+}
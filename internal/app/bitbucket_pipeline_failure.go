@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+// pipelineStepLogTailBytes is the size of the log tail analyzed for each failed step.
+const pipelineStepLogTailBytes = 512 * 1024
+
+// PipelineStepFailure is the failure summary of a single pipeline step.
+type PipelineStepFailure struct {
+	StepUUID string `json:"step_uuid"`
+	StepName string `json:"step_name"`
+	Result   string `json:"result"`
+
+	PipelineLogExcerpt
+
+	// LogTruncated is true when only the end of the log was analyzed.
+	LogTruncated bool `json:"log_truncated,omitempty"`
+
+	// LogError is set when the step log could not be retrieved.
+	LogError string `json:"log_error,omitempty"`
+}
+
+// PipelineFailure is the failure summary of a pipeline run.
+type PipelineFailure struct {
+	Pipeline    *bitbucket.Pipeline   `json:"pipeline"`
+	FailedSteps []PipelineStepFailure `json:"failed_steps"`
+}
+
+// GetPipelineFailure retrieves logs of failed steps of a pipeline run and extracts the failing sections.
+// Steps whose logs can not be retrieved are reported with LogError instead of failing the whole call.
+func (s *BitbucketService) GetPipelineFailure(
+	ctx context.Context,
+	params BitbucketPipelineParams,
+) (*PipelineFailure, error) {
+	s.logger.InfoContext(ctx, "Getting pipeline failure",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("pipeline_uuid", params.PipelineUUID))
+
+	if err := validatePipelineParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	run, err := s.getPipelineRun(ctx, tokenProvider, params)
+	if err != nil {
+		return nil, err
+	}
+
+	failure := &PipelineFailure{Pipeline: run.Pipeline, FailedSteps: []PipelineStepFailure{}}
+	for _, step := range run.Steps {
+		if !isFailedPipelineState(step.State) {
+			continue
+		}
+		stepFailure := PipelineStepFailure{
+			StepUUID: step.UUID,
+			StepName: step.Name,
+			Result:   step.State.Result.Name,
+		}
+		log, logErr := s.client.GetPipelineStepLog(ctx, tokenProvider, bitbucket.GetPipelineStepLogParams{
+			Workspace:    params.RepoOwner,
+			RepoSlug:     params.RepoName,
+			PipelineUUID: normalizeUUID(params.PipelineUUID),
+			StepUUID:     step.UUID,
+			TailBytes:    pipelineStepLogTailBytes,
+		})
+		if logErr != nil {
+			s.logger.WarnContext(ctx, "Failed to get pipeline step log",
+				slog.String("step_uuid", step.UUID),
+				slog.Any("error", logErr))
+			stepFailure.LogError = logErr.Error()
+		} else {
+			stepFailure.PipelineLogExcerpt = extractPipelineLogFailure(log.Content, log.Truncated)
+			stepFailure.LogTruncated = log.Truncated
+		}
+		failure.FailedSteps = append(failure.FailedSteps, stepFailure)
+	}
+
+	return failure, nil
+}
+
+// isFailedPipelineState reports whether a pipeline or step completed unsuccessfully.
+func isFailedPipelineState(state *bitbucket.PipelineState) bool {
+	if state == nil || state.Result == nil {
+		return false
+	}
+	return state.Result.Name == bitbucket.PipelineResultFailed || state.Result.Name == bitbucket.PipelineResultError
+}
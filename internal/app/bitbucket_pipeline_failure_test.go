@@ -0,0 +1,117 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_GetPipelineFailure(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	newStep := func(result string) bitbucket.PipelineStep {
+		return bitbucket.PipelineStep{
+			UUID: "{" + faker.UUIDHyphenated() + "}",
+			Name: "step-" + faker.Word(),
+			State: &bitbucket.PipelineState{
+				Name:   bitbucket.PipelineStateCompleted,
+				Result: &bitbucket.PipelineStateResult{Name: result},
+			},
+		}
+	}
+
+	t.Run("should summarize failed steps", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		params := BitbucketPipelineParams{
+			AccountName:  "account-" + faker.Username(),
+			RepoOwner:    "owner-" + faker.Username(),
+			RepoName:     "repo-" + faker.Username(),
+			PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+		}
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+		pipeline := &bitbucket.Pipeline{UUID: params.PipelineUUID}
+		passed := newStep(bitbucket.PipelineResultSuccessful)
+		failed := newStep(bitbucket.PipelineResultFailed)
+		errored := newStep(bitbucket.PipelineResultError)
+		pending := bitbucket.PipelineStep{UUID: faker.UUIDHyphenated(), State: &bitbucket.PipelineState{Name: "PENDING"}}
+		logErr := errors.New(faker.Sentence())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().GetPipeline(mock.Anything, tokenProvider, mock.Anything).Return(pipeline, nil)
+		mockClient.EXPECT().ListPipelineSteps(mock.Anything, tokenProvider, mock.Anything).
+			Return([]bitbucket.PipelineStep{passed, failed, errored, pending}, nil)
+		mockClient.EXPECT().GetPipelineStepLog(mock.Anything, tokenProvider, bitbucket.GetPipelineStepLogParams{
+			Workspace:    params.RepoOwner,
+			RepoSlug:     params.RepoName,
+			PipelineUUID: params.PipelineUUID,
+			StepUUID:     failed.UUID,
+			TailBytes:    pipelineStepLogTailBytes,
+		}).Return(&bitbucket.PipelineStepLog{Content: "cut\n--- FAIL: TestX\nFAIL", Truncated: true}, nil)
+		mockClient.EXPECT().GetPipelineStepLog(mock.Anything, tokenProvider, mock.MatchedBy(
+			func(p bitbucket.GetPipelineStepLogParams) bool { return p.StepUUID == errored.UUID },
+		)).Return(nil, logErr)
+
+		got, err := NewBitbucketService(deps).GetPipelineFailure(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineFailure{
+			Pipeline: pipeline,
+			FailedSteps: []PipelineStepFailure{
+				{
+					StepUUID:           failed.UUID,
+					StepName:           failed.Name,
+					Result:             bitbucket.PipelineResultFailed,
+					PipelineLogExcerpt: PipelineLogExcerpt{Tail: []string{"--- FAIL: TestX", "FAIL"}},
+					LogTruncated:       true,
+				},
+				{
+					StepUUID: errored.UUID,
+					StepName: errored.Name,
+					Result:   bitbucket.PipelineResultError,
+					LogError: logErr.Error(),
+				},
+			},
+		}, got)
+	})
+
+	t.Run("should fail when pipeline can not be loaded", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		expectedErr := errors.New(faker.Sentence())
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+		mockClient.EXPECT().GetPipeline(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+		got, err := NewBitbucketService(deps).GetPipelineFailure(t.Context(), BitbucketPipelineParams{
+			RepoOwner:    "owner-" + faker.Username(),
+			RepoName:     "repo-" + faker.Username(),
+			PipelineUUID: faker.UUIDHyphenated(),
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should validate required parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		_, err := service.GetPipelineFailure(t.Context(), BitbucketPipelineParams{RepoOwner: "owner", RepoName: "repo"})
+
+		require.ErrorContains(t, err, "pipeline UUID is required")
+	})
+}
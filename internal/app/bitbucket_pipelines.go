@@ -0,0 +1,283 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+const (
+	// pipelinesDefaultLimit is the number of pipelines listed when no limit is given.
+	pipelinesDefaultLimit = 20
+
+	// pipelinesMaxLimit is the maximum page size accepted by the pipelines API.
+	pipelinesMaxLimit = 100
+
+	// pipelinesSortNewestFirst lists the most recent pipelines first.
+	pipelinesSortNewestFirst = "-created_on"
+)
+
+// BitbucketListPipelinesParams contains parameters for listing pipeline runs.
+type BitbucketListPipelinesParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Branch to list pipelines of (optional)
+	Branch string `json:"branch,omitempty"`
+
+	// Status to filter pipelines by, e.g. PENDING, BUILDING, PASSED or FAILED (optional)
+	Status string `json:"status,omitempty"`
+
+	// Maximum number of pipelines to return, newest first (optional)
+	Limit int `json:"limit,omitempty"`
+}
+
+// BitbucketPipelineParams identifies a single pipeline run.
+type BitbucketPipelineParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Pipeline UUID, with or without the surrounding braces
+	PipelineUUID string `json:"pipeline_uuid"`
+}
+
+// BitbucketTriggerPipelineParams contains parameters for triggering a pipeline run.
+// At least one of Branch or Commit must be provided.
+type BitbucketTriggerPipelineParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Branch to run the pipeline on
+	Branch string `json:"branch,omitempty"`
+
+	// Commit hash to run the pipeline on. Combined with Branch to run on a specific commit of a branch.
+	Commit string `json:"commit,omitempty"`
+
+	// Name of a custom pipeline defined in bitbucket-pipelines.yml (optional)
+	CustomPipeline string `json:"custom_pipeline,omitempty"`
+
+	// Variables passed to the pipeline (optional)
+	Variables []bitbucket.PipelineVariable `json:"variables,omitempty"`
+}
+
+// PipelineRun is a pipeline together with its steps.
+type PipelineRun struct {
+	Pipeline *bitbucket.Pipeline      `json:"pipeline"`
+	Steps    []bitbucket.PipelineStep `json:"steps"`
+}
+
+// ListPipelines lists the most recent pipeline runs of a repository.
+func (s *BitbucketService) ListPipelines(
+	ctx context.Context,
+	params BitbucketListPipelinesParams,
+) ([]bitbucket.Pipeline, error) {
+	s.logger.InfoContext(ctx, "Listing pipelines",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Branch),
+		slog.String("status", params.Status))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = pipelinesDefaultLimit
+	}
+	limit = min(limit, pipelinesMaxLimit)
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	page, err := s.client.ListPipelines(ctx, tokenProvider, bitbucket.ListPipelinesParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Branch:    params.Branch,
+		Status:    params.Status,
+		Sort:      pipelinesSortNewestFirst,
+		PageLen:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	return page.Values, nil
+}
+
+// GetPipeline retrieves a pipeline run together with its steps.
+func (s *BitbucketService) GetPipeline(
+	ctx context.Context,
+	params BitbucketPipelineParams,
+) (*PipelineRun, error) {
+	s.logger.InfoContext(ctx, "Getting pipeline",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("pipeline_uuid", params.PipelineUUID))
+
+	if err := validatePipelineParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	return s.getPipelineRun(ctx, tokenProvider, params)
+}
+
+func (s *BitbucketService) getPipelineRun(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketPipelineParams,
+) (*PipelineRun, error) {
+	pipelineUUID := normalizeUUID(params.PipelineUUID)
+
+	pipeline, err := s.client.GetPipeline(ctx, tokenProvider, bitbucket.GetPipelineParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		PipelineUUID: pipelineUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	steps, err := s.client.ListPipelineSteps(ctx, tokenProvider, bitbucket.ListPipelineStepsParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		PipelineUUID: pipelineUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline steps: %w", err)
+	}
+
+	return &PipelineRun{Pipeline: pipeline, Steps: steps}, nil
+}
+
+// TriggerPipeline starts a pipeline run on a branch or a commit.
+func (s *BitbucketService) TriggerPipeline(
+	ctx context.Context,
+	params BitbucketTriggerPipelineParams,
+) (*bitbucket.Pipeline, error) {
+	s.logger.InfoContext(ctx, "Triggering pipeline",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("branch", params.Branch),
+		slog.String("commit", params.Commit),
+		slog.String("custom_pipeline", params.CustomPipeline))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if params.Branch == "" && params.Commit == "" {
+		return nil, errors.New("either branch or commit is required")
+	}
+	for _, variable := range params.Variables {
+		if variable.Key == "" {
+			return nil, errors.New("pipeline variable key is required")
+		}
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	pipeline, err := s.client.TriggerPipeline(ctx, tokenProvider, bitbucket.TriggerPipelineParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Target:    newPipelineTarget(params),
+		Variables: params.Variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+// StopPipeline stops a running pipeline.
+func (s *BitbucketService) StopPipeline(
+	ctx context.Context,
+	params BitbucketPipelineParams,
+) error {
+	s.logger.InfoContext(ctx, "Stopping pipeline",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("pipeline_uuid", params.PipelineUUID))
+
+	if err := validatePipelineParams(params); err != nil {
+		return err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	err := s.client.StopPipeline(ctx, tokenProvider, bitbucket.StopPipelineParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		PipelineUUID: normalizeUUID(params.PipelineUUID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop pipeline: %w", err)
+	}
+
+	return nil
+}
+
+func validatePipelineParams(params BitbucketPipelineParams) error {
+	if params.RepoOwner == "" {
+		return errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return errors.New("repository name is required")
+	}
+	if params.PipelineUUID == "" {
+		return errors.New("pipeline UUID is required")
+	}
+	return nil
+}
+
+// normalizeUUID wraps the UUID in braces as expected by the pipelines and deployments APIs.
+func normalizeUUID(uuid string) string {
+	if strings.HasPrefix(uuid, "{") {
+		return uuid
+	}
+	return "{" + uuid + "}"
+}
+
+func newPipelineTarget(params BitbucketTriggerPipelineParams) bitbucket.PipelineTarget {
+	target := bitbucket.PipelineTarget{Type: bitbucket.PipelineTargetTypeCommit}
+	if params.Branch != "" {
+		target.Type = bitbucket.PipelineTargetTypeRef
+		target.RefType = bitbucket.PipelineRefTypeBranch
+		target.RefName = params.Branch
+	}
+	if params.Commit != "" {
+		target.Commit = &bitbucket.PipelineCommit{Type: "commit", Hash: params.Commit}
+	}
+	switch {
+	case params.CustomPipeline != "":
+		target.Selector = &bitbucket.PipelineSelector{
+			Type:    bitbucket.PipelineSelectorCustom,
+			Pattern: params.CustomPipeline,
+		}
+	case params.Branch == "":
+		// Commit targets have no branch to pick the pipeline by, so the default pipeline runs.
+		target.Selector = &bitbucket.PipelineSelector{Type: bitbucket.PipelineSelectorDefault}
+	}
+	return target
+}
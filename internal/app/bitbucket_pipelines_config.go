@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+// pipelinesConfigDefaultPath is the location of the pipelines configuration in a repository.
+const pipelinesConfigDefaultPath = "bitbucket-pipelines.yml"
+
+// BitbucketValidatePipelinesConfigParams contains parameters for validating a pipelines configuration.
+// The configuration is validated from Content when provided, otherwise it is read from the repository.
+type BitbucketValidatePipelinesConfigParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace), required when Content is empty
+	RepoOwner string `json:"repo_owner,omitempty"`
+
+	// Repository name (slug), required when Content is empty
+	RepoName string `json:"repo_name,omitempty"`
+
+	// Branch, tag or commit to read the configuration from, required when Content is empty
+	Ref string `json:"ref,omitempty"`
+
+	// Path of the configuration file in the repository (optional, defaults to bitbucket-pipelines.yml)
+	Path string `json:"path,omitempty"`
+
+	// Inline configuration content (optional)
+	Content string `json:"content,omitempty"`
+}
+
+// PipelinesConfigValidationResult is the outcome of a pipelines configuration validation.
+type PipelinesConfigValidationResult struct {
+	// Source describes where the configuration was read from.
+	Source string `json:"source"`
+
+	// Valid is true when there are no error diagnostics. Warnings do not make the configuration invalid.
+	Valid bool `json:"valid"`
+
+	Diagnostics []PipelinesConfigDiagnostic `json:"diagnostics"`
+}
+
+// ValidatePipelinesConfig validates a bitbucket-pipelines.yml against the pipelines schema
+// and checks references to steps, caches, services and YAML anchors.
+func (s *BitbucketService) ValidatePipelinesConfig(
+	ctx context.Context,
+	params BitbucketValidatePipelinesConfigParams,
+) (*PipelinesConfigValidationResult, error) {
+	path := lo.CoalesceOrEmpty(params.Path, pipelinesConfigDefaultPath)
+	s.logger.InfoContext(ctx, "Validating pipelines configuration",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("ref", params.Ref),
+		slog.Bool("inline", params.Content != ""))
+
+	content := params.Content
+	source := "inline content"
+	if content == "" {
+		if params.RepoOwner == "" {
+			return nil, errors.New("repository owner is required")
+		}
+		if params.RepoName == "" {
+			return nil, errors.New("repository name is required")
+		}
+		if params.Ref == "" {
+			return nil, errors.New("either content or ref is required")
+		}
+
+		tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+		fileContent, err := s.client.GetFileContent(ctx, tokenProvider, bitbucket.GetFileContentParams{
+			RepoOwner:  params.RepoOwner,
+			RepoName:   params.RepoName,
+			CommitHash: params.Ref,
+			FilePath:   path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipelines configuration: %w", err)
+		}
+		content = fileContent.Content
+		source = fmt.Sprintf("%s/%s/%s@%s", params.RepoOwner, params.RepoName, path, params.Ref)
+	}
+
+	diagnostics, err := validatePipelinesConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pipelines configuration: %w", err)
+	}
+
+	return &PipelinesConfigValidationResult{
+		Source: source,
+		Valid: !lo.ContainsBy(diagnostics, func(d PipelinesConfigDiagnostic) bool {
+			return d.Severity == PipelinesConfigSeverityError
+		}),
+		Diagnostics: lo.Ternary(diagnostics == nil, []PipelinesConfigDiagnostic{}, diagnostics),
+	}, nil
+}
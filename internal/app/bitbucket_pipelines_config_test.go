@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_ValidatePipelinesConfig(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketValidatePipelinesConfigParams {
+		return BitbucketValidatePipelinesConfigParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+			Ref:         "branch-" + faker.Word(),
+		}
+	}
+
+	const validConfig = "pipelines:\n  default:\n    - step:\n        script: [make]\n"
+
+	t.Run("should validate inline content", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		result, err := service.ValidatePipelinesConfig(t.Context(), BitbucketValidatePipelinesConfigParams{
+			Content: validConfig,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelinesConfigValidationResult{
+			Source:      "inline content",
+			Valid:       true,
+			Diagnostics: []PipelinesConfigDiagnostic{},
+		}, result)
+	})
+
+	t.Run("should validate configuration of the repository", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		params := makeRepoParams()
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().GetFileContent(mock.Anything, tokenProvider, bitbucket.GetFileContentParams{
+			RepoOwner:  params.RepoOwner,
+			RepoName:   params.RepoName,
+			CommitHash: params.Ref,
+			FilePath:   pipelinesConfigDefaultPath,
+		}).Return(&bitbucket.FileContent{
+			Content: validConfig + "    - step:\n        caches: [unknown]\n        script: [make]\n",
+		}, nil)
+		service := NewBitbucketService(deps)
+
+		result, err := service.ValidatePipelinesConfig(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t,
+			params.RepoOwner+"/"+params.RepoName+"/bitbucket-pipelines.yml@"+params.Ref,
+			result.Source,
+		)
+		require.Len(t, result.Diagnostics, 1)
+		assert.Equal(t, 6, result.Diagnostics[0].Line)
+	})
+
+	t.Run("should treat warnings as valid", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		result, err := service.ValidatePipelinesConfig(t.Context(), BitbucketValidatePipelinesConfigParams{
+			Content: "definitions:\n  image: &image golang\n" + validConfig,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		require.Len(t, result.Diagnostics, 1)
+		assert.Equal(t, PipelinesConfigSeverityWarning, result.Diagnostics[0].Severity)
+	})
+
+	t.Run("should fail when configuration can not be read", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		params := makeRepoParams()
+		params.Path = "ci/" + faker.Word() + ".yml"
+		expectedErr := errors.New(faker.Sentence())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).
+			Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+		mockClient.EXPECT().GetFileContent(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(p bitbucket.GetFileContentParams) bool { return p.FilePath == params.Path },
+		)).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		result, err := service.ValidatePipelinesConfig(t.Context(), params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should validate required parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+		validParams := makeRepoParams()
+
+		noOwner := validParams
+		noOwner.RepoOwner = ""
+		_, err := service.ValidatePipelinesConfig(t.Context(), noOwner)
+		require.ErrorContains(t, err, "repository owner is required")
+
+		noName := validParams
+		noName.RepoName = ""
+		_, err = service.ValidatePipelinesConfig(t.Context(), noName)
+		require.ErrorContains(t, err, "repository name is required")
+
+		noRef := validParams
+		noRef.Ref = ""
+		_, err = service.ValidatePipelinesConfig(t.Context(), noRef)
+		require.ErrorContains(t, err, "either content or ref is required")
+	})
+}
@@ -0,0 +1,309 @@
+package app
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Pipelines(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	newRandomPipeline := func() *bitbucket.Pipeline {
+		return &bitbucket.Pipeline{
+			UUID:        "{" + faker.UUIDHyphenated() + "}",
+			BuildNumber: 1 + rand.IntN(1000),
+			State:       &bitbucket.PipelineState{Name: bitbucket.PipelineStatePending},
+		}
+	}
+
+	t.Run("ListPipelines", func(t *testing.T) {
+		t.Run("should list pipelines newest first", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketListPipelinesParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+				Branch:      "feature/" + faker.Word(),
+				Status:      "FAILED",
+				Limit:       1 + rand.IntN(pipelinesMaxLimit),
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			pipelines := []bitbucket.Pipeline{*newRandomPipeline(), *newRandomPipeline()}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().ListPipelines(mock.Anything, tokenProvider, bitbucket.ListPipelinesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Branch:    params.Branch,
+				Status:    params.Status,
+				Sort:      pipelinesSortNewestFirst,
+				PageLen:   params.Limit,
+			}).Return(&bitbucket.Paginated[bitbucket.Pipeline]{Values: pipelines}, nil)
+
+			got, err := NewBitbucketService(deps).ListPipelines(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, pipelines, got)
+		})
+
+		t.Run("should apply default and maximum limit", func(t *testing.T) {
+			for _, tc := range []struct {
+				limit    int
+				expected int
+			}{
+				{limit: 0, expected: pipelinesDefaultLimit},
+				{limit: pipelinesMaxLimit + 1 + rand.IntN(100), expected: pipelinesMaxLimit},
+			} {
+				deps := makeMockDeps(t)
+				mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+				mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+				params := BitbucketListPipelinesParams{
+					RepoOwner: "owner-" + faker.Username(),
+					RepoName:  "repo-" + faker.Username(),
+					Limit:     tc.limit,
+				}
+				mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+				mockClient.EXPECT().ListPipelines(mock.Anything, mock.Anything, mock.MatchedBy(
+					func(p bitbucket.ListPipelinesParams) bool { return p.PageLen == tc.expected },
+				)).Return(&bitbucket.Paginated[bitbucket.Pipeline]{}, nil)
+
+				_, err := NewBitbucketService(deps).ListPipelines(t.Context(), params)
+
+				require.NoError(t, err)
+			}
+		})
+
+		t.Run("should fail when client fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			expectedErr := errors.New(faker.Sentence())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().ListPipelines(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+			got, err := NewBitbucketService(deps).ListPipelines(t.Context(), BitbucketListPipelinesParams{
+				RepoOwner: "owner-" + faker.Username(),
+				RepoName:  "repo-" + faker.Username(),
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListPipelines(t.Context(), BitbucketListPipelinesParams{RepoName: "repo"})
+			require.ErrorContains(t, err, "repository owner is required")
+
+			_, err = service.ListPipelines(t.Context(), BitbucketListPipelinesParams{RepoOwner: "owner"})
+			require.ErrorContains(t, err, "repository name is required")
+		})
+	})
+
+	t.Run("GetPipeline", func(t *testing.T) {
+		t.Run("should get pipeline with steps", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			pipelineUUID := faker.UUIDHyphenated()
+			params := BitbucketPipelineParams{
+				AccountName:  "account-" + faker.Username(),
+				RepoOwner:    "owner-" + faker.Username(),
+				RepoName:     "repo-" + faker.Username(),
+				PipelineUUID: pipelineUUID,
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			pipeline := newRandomPipeline()
+			steps := []bitbucket.PipelineStep{{UUID: "{" + faker.UUIDHyphenated() + "}", Name: faker.Word()}}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().GetPipeline(mock.Anything, tokenProvider, bitbucket.GetPipelineParams{
+				Workspace:    params.RepoOwner,
+				RepoSlug:     params.RepoName,
+				PipelineUUID: "{" + pipelineUUID + "}",
+			}).Return(pipeline, nil)
+			mockClient.EXPECT().ListPipelineSteps(mock.Anything, tokenProvider, bitbucket.ListPipelineStepsParams{
+				Workspace:    params.RepoOwner,
+				RepoSlug:     params.RepoName,
+				PipelineUUID: "{" + pipelineUUID + "}",
+			}).Return(steps, nil)
+
+			got, err := NewBitbucketService(deps).GetPipeline(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, &PipelineRun{Pipeline: pipeline, Steps: steps}, got)
+		})
+
+		t.Run("should fail when steps can not be listed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			expectedErr := errors.New(faker.Sentence())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().GetPipeline(mock.Anything, mock.Anything, mock.Anything).Return(newRandomPipeline(), nil)
+			mockClient.EXPECT().ListPipelineSteps(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+			got, err := NewBitbucketService(deps).GetPipeline(t.Context(), BitbucketPipelineParams{
+				RepoOwner:    "owner-" + faker.Username(),
+				RepoName:     "repo-" + faker.Username(),
+				PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.GetPipeline(t.Context(), BitbucketPipelineParams{RepoOwner: "owner", RepoName: "repo"})
+			require.ErrorContains(t, err, "pipeline UUID is required")
+		})
+	})
+
+	t.Run("TriggerPipeline", func(t *testing.T) {
+		t.Run("should trigger custom pipeline on a branch", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketTriggerPipelineParams{
+				AccountName:    "account-" + faker.Username(),
+				RepoOwner:      "owner-" + faker.Username(),
+				RepoName:       "repo-" + faker.Username(),
+				Branch:         "feature/" + faker.Word(),
+				CustomPipeline: "deploy-" + faker.Word(),
+				Variables:      []bitbucket.PipelineVariable{{Key: "ENV", Value: faker.Word()}},
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			pipeline := newRandomPipeline()
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().TriggerPipeline(mock.Anything, tokenProvider, bitbucket.TriggerPipelineParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Target: bitbucket.PipelineTarget{
+					Type:    bitbucket.PipelineTargetTypeRef,
+					RefType: bitbucket.PipelineRefTypeBranch,
+					RefName: params.Branch,
+					Selector: &bitbucket.PipelineSelector{
+						Type:    bitbucket.PipelineSelectorCustom,
+						Pattern: params.CustomPipeline,
+					},
+				},
+				Variables: params.Variables,
+			}).Return(pipeline, nil)
+
+			got, err := NewBitbucketService(deps).TriggerPipeline(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, pipeline, got)
+		})
+
+		t.Run("should trigger pipeline on a commit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketTriggerPipelineParams{
+				RepoOwner: "owner-" + faker.Username(),
+				RepoName:  "repo-" + faker.Username(),
+				Commit:    faker.UUIDDigit(),
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			pipeline := newRandomPipeline()
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+			mockClient.EXPECT().TriggerPipeline(mock.Anything, tokenProvider, bitbucket.TriggerPipelineParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Target: bitbucket.PipelineTarget{
+					Type:     bitbucket.PipelineTargetTypeCommit,
+					Commit:   &bitbucket.PipelineCommit{Type: "commit", Hash: params.Commit},
+					Selector: &bitbucket.PipelineSelector{Type: bitbucket.PipelineSelectorDefault},
+				},
+			}).Return(pipeline, nil)
+
+			got, err := NewBitbucketService(deps).TriggerPipeline(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, pipeline, got)
+		})
+
+		t.Run("should validate parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.TriggerPipeline(t.Context(), BitbucketTriggerPipelineParams{
+				RepoOwner: "owner",
+				RepoName:  "repo",
+			})
+			require.ErrorContains(t, err, "either branch or commit is required")
+
+			_, err = service.TriggerPipeline(t.Context(), BitbucketTriggerPipelineParams{
+				RepoOwner: "owner",
+				RepoName:  "repo",
+				Branch:    "main",
+				Variables: []bitbucket.PipelineVariable{{Value: faker.Word()}},
+			})
+			require.ErrorContains(t, err, "pipeline variable key is required")
+		})
+	})
+
+	t.Run("StopPipeline", func(t *testing.T) {
+		t.Run("should stop pipeline", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketPipelineParams{
+				RepoOwner:    "owner-" + faker.Username(),
+				RepoName:     "repo-" + faker.Username(),
+				PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+			mockClient.EXPECT().StopPipeline(mock.Anything, tokenProvider, bitbucket.StopPipelineParams{
+				Workspace:    params.RepoOwner,
+				RepoSlug:     params.RepoName,
+				PipelineUUID: params.PipelineUUID,
+			}).Return(nil)
+
+			err := NewBitbucketService(deps).StopPipeline(t.Context(), params)
+
+			require.NoError(t, err)
+		})
+
+		t.Run("should fail when client fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			expectedErr := errors.New(faker.Sentence())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().StopPipeline(mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
+
+			err := NewBitbucketService(deps).StopPipeline(t.Context(), BitbucketPipelineParams{
+				RepoOwner:    "owner-" + faker.Username(),
+				RepoName:     "repo-" + faker.Username(),
+				PipelineUUID: faker.UUIDHyphenated(),
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+	})
+}
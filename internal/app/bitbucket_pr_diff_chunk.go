@@ -0,0 +1,307 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+)
+
+// BitbucketGetPRDiffChunkParams contains parameters for getting a pull request diff in chunks of whole files.
+type BitbucketGetPRDiffChunkParams struct {
+	BitbucketGetPRDiffParams
+
+	// Maximum size of the chunk in bytes (optional, the rest of the diff is returned when 0)
+	MaxBytes int
+
+	// Cursor returned with the previous chunk (optional, starts with the first file when empty)
+	Cursor string
+
+	// SkipGenerated leaves out files matching the configured skip patterns, e.g. lockfiles
+	SkipGenerated bool
+
+	// SkipPatterns are additional globs of files to leave out (optional)
+	SkipPatterns []string
+}
+
+// PRDiffChunk is a part of a pull request diff that ends at a file boundary, or in the middle of
+// a file whose diff alone exceeds the chunk size.
+type PRDiffChunk struct {
+	Diff string `json:"diff"`
+
+	// Files whose diff is included in the chunk, fully or partially.
+	Files []string `json:"files"`
+
+	// SkippedFiles are files of the chunk left out because they match skip patterns.
+	SkippedFiles []string `json:"skipped_files,omitempty"`
+
+	// ContinuedFile is a file cut by the previous chunk, the chunk starts with the rest of its diff.
+	ContinuedFile string `json:"continued_file,omitempty"`
+
+	// TruncatedFile is a file whose diff exceeded the chunk size and was cut, the next chunk continues it.
+	TruncatedFile string `json:"truncated_file,omitempty"`
+
+	// FromFile and ToFile are 1-based positions of the first and last file of the chunk.
+	FromFile int `json:"from_file"`
+	ToFile   int `json:"to_file"`
+
+	// TotalFiles is the number of files changed by the pull request.
+	TotalFiles int `json:"total_files"`
+
+	// NextCursor is passed to get the next chunk, empty when this is the last one.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+const (
+	// diffCursorPrefix precedes the position of the next chunk in a decoded cursor.
+	diffCursorPrefix = "file:"
+
+	// diffCursorParts is the number of colon separated parts following the prefix:
+	// file, offset, source commit and destination commit.
+	diffCursorParts = 4
+)
+
+// diffCursor is the position in a pull request diff where the next chunk starts. It holds commits
+// of the pull request the diff was taken at, so a cursor is rejected once the pull request changes.
+type diffCursor struct {
+	// File is the 0-based position of the file.
+	File int
+
+	// Offset is the number of bytes of the file diff returned with previous chunks.
+	Offset int
+
+	SourceCommit      string
+	DestinationCommit string
+}
+
+// GetPRDiffChunk returns the part of the pull request diff starting at the cursor. The chunk is split
+// at file boundaries to stay within the maximum size. A file that alone exceeds it is cut at a line
+// boundary and the next chunk continues it where it was cut.
+func (s *BitbucketService) GetPRDiffChunk(
+	ctx context.Context,
+	params BitbucketGetPRDiffChunkParams,
+) (*PRDiffChunk, error) {
+	s.logger.InfoContext(ctx, "Getting pull request diff chunk",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.Int("pr_id", params.PullRequestID),
+		slog.Int("max_bytes", params.MaxBytes))
+
+	if params.MaxBytes < 0 {
+		return nil, errors.New("max bytes must not be negative")
+	}
+	cursor, err := decodeDiffCursor(params.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	patterns := params.SkipPatterns
+	if params.SkipGenerated {
+		patterns = append(patterns[:len(patterns):len(patterns)], s.diffSkipPatterns...)
+	}
+	for _, pattern := range patterns {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid skip pattern %q: %w", pattern, err)
+		}
+	}
+
+	diff, revision, err := s.fetchPRDiffRevision(ctx, params.BitbucketGetPRDiffParams)
+	if err != nil {
+		return nil, err
+	}
+	if params.Cursor != "" && (cursor.SourceCommit != revision.SourceCommit ||
+		cursor.DestinationCommit != revision.DestinationCommit) {
+		return nil, errors.New("cursor does not match the pull request, it has changed since the cursor " +
+			"was returned, start over without cursor")
+	}
+	files := splitDiffFiles(diff)
+	if cursor.File > len(files) ||
+		(cursor.Offset > 0 && (cursor.File == len(files) || cursor.Offset >= len(files[cursor.File].Text))) {
+		return nil, errors.New("cursor is past the end of the diff, the pull request may have changed")
+	}
+
+	chunk, next := writePRDiffChunk(files, cursor, patterns, params.MaxBytes)
+	if next.File < len(files) {
+		next.SourceCommit = revision.SourceCommit
+		next.DestinationCommit = revision.DestinationCommit
+		chunk.NextCursor = encodeDiffCursor(next)
+	}
+	return chunk, nil
+}
+
+// fetchPRDiffRevision fetches the pull request diff together with source and destination
+// commits of the pull request, the commits are returned as a cursor at the first file.
+func (s *BitbucketService) fetchPRDiffRevision(
+	ctx context.Context,
+	params BitbucketGetPRDiffParams,
+) (string, diffCursor, error) {
+	var diff string
+	var revision diffCursor
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		var err error
+		diff, err = s.GetPRDiff(groupCtx, params)
+		return err
+	})
+	group.Go(func() error {
+		pr, err := s.client.GetPR(groupCtx, s.authFactory.getTokenProvider(groupCtx, params.AccountName),
+			bitbucket.GetPRParams{
+				Username:      params.RepoOwner,
+				RepoSlug:      params.RepoName,
+				PullRequestID: params.PullRequestID,
+			})
+		if err != nil {
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+		if pr.Source.Commit != nil {
+			revision.SourceCommit = pr.Source.Commit.Hash
+		}
+		if pr.Destination != nil && pr.Destination.Commit != nil {
+			revision.DestinationCommit = pr.Destination.Commit.Hash
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return "", diffCursor{}, err
+	}
+	return diff, revision, nil
+}
+
+// writePRDiffChunk collects diffs of files starting at the cursor until the maximum size is reached
+// and returns the chunk together with the position the next chunk starts at.
+func writePRDiffChunk(files []diffFile, cursor diffCursor, patterns []string, maxBytes int) (*PRDiffChunk, diffCursor) {
+	chunk := &PRDiffChunk{Files: []string{}, FromFile: cursor.File + 1, TotalFiles: len(files)}
+	var sb strings.Builder
+	next := diffCursor{File: cursor.File}
+	for ; next.File < len(files); next.File++ {
+		file := files[next.File]
+		offset := lo.Ternary(next.File == cursor.File, cursor.Offset, 0)
+		if matchesDiffSkipPatterns(patterns, file.Path) {
+			chunk.SkippedFiles = append(chunk.SkippedFiles, file.Path)
+			continue
+		}
+		if offset > 0 {
+			chunk.ContinuedFile = file.Path
+		}
+		text := file.Text[offset:]
+		if maxBytes > 0 && sb.Len()+len(text) > maxBytes {
+			if sb.Len() > 0 {
+				break
+			}
+			// The file alone exceeds the chunk size, it is cut so that every chunk makes progress
+			// and the next chunk continues it.
+			cut := truncateDiffText(text, maxBytes)
+			sb.WriteString(cut)
+			chunk.Files = append(chunk.Files, file.Path)
+			chunk.TruncatedFile = file.Path
+			next.Offset = offset + len(cut)
+			break
+		}
+		sb.WriteString(text)
+		chunk.Files = append(chunk.Files, file.Path)
+	}
+	chunk.Diff = sb.String()
+	chunk.ToFile = next.File + lo.Ternary(next.Offset > 0, 1, 0)
+	return chunk, next
+}
+
+// encodeDiffCursor encodes the position of the next chunk as an opaque cursor for clients.
+func encodeDiffCursor(cursor diffCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(diffCursorPrefix + strings.Join([]string{
+		strconv.Itoa(cursor.File),
+		strconv.Itoa(cursor.Offset),
+		cursor.SourceCommit,
+		cursor.DestinationCommit,
+	}, ":")))
+}
+
+// decodeDiffCursor returns the position a cursor points at, the first file for an empty cursor.
+func decodeDiffCursor(cursor string) (diffCursor, error) {
+	if cursor == "" {
+		return diffCursor{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return diffCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	position, ok := strings.CutPrefix(string(data), diffCursorPrefix)
+	if !ok {
+		return diffCursor{}, errors.New("invalid cursor")
+	}
+	parts := strings.Split(position, ":")
+	if len(parts) != diffCursorParts {
+		return diffCursor{}, errors.New("invalid cursor")
+	}
+	file, fileErr := strconv.Atoi(parts[0])
+	offset, offsetErr := strconv.Atoi(parts[1])
+	if fileErr != nil || offsetErr != nil || file < 0 || offset < 0 {
+		return diffCursor{}, errors.New("invalid cursor")
+	}
+	return diffCursor{File: file, Offset: offset, SourceCommit: parts[2], DestinationCommit: parts[3]}, nil
+}
+
+// matchesDiffSkipPatterns reports whether the file matches any of the skip patterns. Patterns without
+// a slash match the file name, patterns ending with /** match files under a directory, other patterns
+// match the whole path.
+func matchesDiffSkipPatterns(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if matchesDiffSkipDirectory(dir, filePath) {
+				return true
+			}
+			continue
+		}
+		name := filePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(filePath)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDiffSkipDirectory reports whether the file is under the directory. A directory without
+// a slash matches at any depth, e.g. vendor matches vendor/a.go and lib/vendor/a.go.
+func matchesDiffSkipDirectory(dir, filePath string) bool {
+	dirs := strings.Split(path.Dir(filePath), "/")
+	if strings.Contains(dir, "/") {
+		depth := strings.Count(dir, "/") + 1
+		if len(dirs) < depth {
+			return false
+		}
+		matched, _ := path.Match(dir, strings.Join(dirs[:depth], "/"))
+		return matched
+	}
+	for _, d := range dirs {
+		if matched, _ := path.Match(dir, d); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateDiffText cuts the diff of a file to at most maxBytes, at a line boundary when possible,
+// otherwise at a character boundary. At least one character is kept so that every chunk makes progress.
+func truncateDiffText(text string, maxBytes int) string {
+	cut := text[:maxBytes]
+	if idx := strings.LastIndex(cut, "\n"); idx > 0 {
+		return cut[:idx+1]
+	}
+	size := maxBytes
+	for size > 0 && !utf8.RuneStart(text[size]) {
+		size--
+	}
+	if size == 0 {
+		_, size = utf8.DecodeRuneInString(text)
+	}
+	return text[:size]
+}
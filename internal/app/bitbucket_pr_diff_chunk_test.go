@@ -0,0 +1,258 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_GetPRDiffChunk(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:           NewMockbitbucketClient(t),
+			AuthFactory:      NewMockbitbucketAuthFactory(t),
+			RootLogger:       diag.RootTestLogger().With("test", t.Name()),
+			DiffSkipPatterns: []string{"go.sum", "*.pb.go", "vendor/**"},
+		}
+	}
+
+	makeFileDiff := func(filePath string, lines int) string {
+		return "diff --git a/" + filePath + " b/" + filePath + "\n@@ -1 +1 @@\n" + strings.Repeat("+line\n", lines)
+	}
+	mainDiff := makeFileDiff("main.go", 2)
+	sumDiff := makeFileDiff("go.sum", 50)
+	apiDiff := makeFileDiff("api/service.pb.go", 50)
+	vendorDiff := makeFileDiff("lib/vendor/dep.go", 5)
+	utilDiff := makeFileDiff("util/util.go", 3)
+	readmeDiff := makeFileDiff("README.md", 1)
+	fullDiff := mainDiff + sumDiff + apiDiff + vendorDiff + utilDiff + readmeDiff
+
+	sourceCommit := faker.UUIDDigit()
+	destinationCommit := faker.UUIDDigit()
+
+	// setupDiff sets up the diff and the pull request commits the diff is taken at.
+	setupDiff := func(t *testing.T, deps BitbucketServiceDeps, accountName string, diff string) *bitbucket.PullRequest {
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, accountName).Return(tokenProvider)
+		mockClient.EXPECT().GetPRDiff(mock.Anything, tokenProvider, bitbucket.GetPRDiffParams{
+			RepoOwner: "acme",
+			RepoName:  "api",
+			PRID:      7,
+		}).Return(diff, nil)
+		pr := bitbucket.NewRandomPullRequest(func(pr *bitbucket.PullRequest) {
+			pr.ID = 7
+			pr.Source.Commit = &bitbucket.PullRequestCommit{Hash: sourceCommit}
+			pr.Destination.Commit = &bitbucket.PullRequestCommit{Hash: destinationCommit}
+		})
+		mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+			Username:      "acme",
+			RepoSlug:      "api",
+			PullRequestID: 7,
+		}).Return(pr, nil)
+		return pr
+	}
+
+	makeParams := func(accountName string) BitbucketGetPRDiffChunkParams {
+		return BitbucketGetPRDiffChunkParams{
+			BitbucketGetPRDiffParams: BitbucketGetPRDiffParams{
+				AccountName:   accountName,
+				RepoOwner:     "acme",
+				RepoName:      "api",
+				PullRequestID: 7,
+			},
+		}
+	}
+
+	t.Run("should page through diff at file boundaries skipping generated files", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		accountName := "account-" + faker.Username()
+		setupDiff(t, deps, accountName, fullDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams(accountName)
+		params.MaxBytes = len(mainDiff) + len(utilDiff)
+		params.SkipGenerated = true
+		first, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, mainDiff+utilDiff, first.Diff)
+		assert.Equal(t, []string{"main.go", "util/util.go"}, first.Files)
+		assert.Equal(t, []string{"go.sum", "api/service.pb.go", "lib/vendor/dep.go"}, first.SkippedFiles)
+		assert.Equal(t, 1, first.FromFile)
+		assert.Equal(t, 5, first.ToFile)
+		assert.Equal(t, 6, first.TotalFiles)
+		require.NotEmpty(t, first.NextCursor)
+
+		params.Cursor = first.NextCursor
+		second, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PRDiffChunk{
+			Diff:       readmeDiff,
+			Files:      []string{"README.md"},
+			FromFile:   6,
+			ToFile:     6,
+			TotalFiles: 6,
+		}, second)
+	})
+
+	t.Run("should continue a file that alone exceeds the chunk size", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		setupDiff(t, deps, "", sumDiff+mainDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams("")
+		params.MaxBytes = 100
+		first, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(first.Diff), 100)
+		assert.True(t, strings.HasPrefix(sumDiff, first.Diff))
+		assert.True(t, strings.HasSuffix(first.Diff, "\n"))
+		assert.Equal(t, "go.sum", first.TruncatedFile)
+		assert.Empty(t, first.ContinuedFile)
+		assert.Equal(t, []string{"go.sum"}, first.Files)
+		assert.Equal(t, 1, first.ToFile)
+		assert.Equal(t, encodeDiffCursor(diffCursor{
+			Offset:            len(first.Diff),
+			SourceCommit:      sourceCommit,
+			DestinationCommit: destinationCommit,
+		}), first.NextCursor)
+
+		params.Cursor = first.NextCursor
+		second, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, sumDiff[len(first.Diff):len(first.Diff)+len(second.Diff)], second.Diff)
+		assert.Equal(t, "go.sum", second.ContinuedFile)
+		assert.Equal(t, 1, second.FromFile)
+
+		diff := first.Diff + second.Diff
+		for chunk := second; chunk.NextCursor != ""; {
+			params.Cursor = chunk.NextCursor
+			chunk, err = service.GetPRDiffChunk(t.Context(), params)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(chunk.Diff), 100)
+			diff += chunk.Diff
+		}
+		assert.Equal(t, sumDiff+mainDiff, diff)
+	})
+
+	t.Run("should cut a line longer than the chunk size at a character boundary", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		longLineDiff := "diff --git a/a.txt b/a.txt\n+" + strings.Repeat("ж", 40) + "\n"
+		setupDiff(t, deps, "", longLineDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams("")
+		params.MaxBytes = 10
+		diff := ""
+		for {
+			chunk, err := service.GetPRDiffChunk(t.Context(), params)
+			require.NoError(t, err)
+			require.True(t, utf8.ValidString(chunk.Diff))
+			diff += chunk.Diff
+			if chunk.NextCursor == "" {
+				break
+			}
+			params.Cursor = chunk.NextCursor
+		}
+		assert.Equal(t, longLineDiff, diff)
+	})
+
+	t.Run("should skip files matching given patterns only", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		setupDiff(t, deps, "", fullDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams("")
+		params.SkipPatterns = []string{"*.md", "util/**"}
+		chunk, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, mainDiff+sumDiff+apiDiff+vendorDiff, chunk.Diff)
+		assert.Equal(t, []string{"util/util.go", "README.md"}, chunk.SkippedFiles)
+		assert.Empty(t, chunk.NextCursor)
+	})
+
+	t.Run("should reject cursor past the end of the diff", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		setupDiff(t, deps, "", mainDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams("")
+		params.Cursor = encodeDiffCursor(diffCursor{
+			File:              5,
+			SourceCommit:      sourceCommit,
+			DestinationCommit: destinationCommit,
+		})
+		_, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.ErrorContains(t, err, "cursor is past the end of the diff")
+	})
+
+	t.Run("should reject cursor of another revision of the pull request", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		setupDiff(t, deps, "", mainDiff+utilDiff)
+		service := NewBitbucketService(deps)
+
+		params := makeParams("")
+		params.Cursor = encodeDiffCursor(diffCursor{
+			File:              1,
+			SourceCommit:      faker.UUIDDigit(),
+			DestinationCommit: destinationCommit,
+		})
+		_, err := service.GetPRDiffChunk(t.Context(), params)
+
+		require.ErrorContains(t, err, "cursor does not match the pull request")
+	})
+
+	t.Run("should reject invalid cursor and patterns", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		params := makeParams("")
+		params.Cursor = "not a cursor"
+		_, err := service.GetPRDiffChunk(t.Context(), params)
+		require.ErrorContains(t, err, "invalid cursor")
+
+		params = makeParams("")
+		params.SkipPatterns = []string{"[a-"}
+		_, err = service.GetPRDiffChunk(t.Context(), params)
+		require.ErrorContains(t, err, "invalid skip pattern")
+	})
+}
+
+func TestMatchesDiffSkipPatterns(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filePath string
+		want     bool
+	}{
+		{"go.sum", "go.sum", true},
+		{"go.sum", "tools/go.sum", true},
+		{"*.min.js", "web/static/app.min.js", true},
+		{"*.min.js", "web/static/app.js", false},
+		{"vendor/**", "vendor/github.com/lib/a.go", true},
+		{"vendor/**", "lib/vendor/a.go", true},
+		{"vendor/**", "vendors.go", false},
+		{"web/dist/**", "web/dist/js/app.js", true},
+		{"web/dist/**", "dist/app.js", false},
+		{"docs/*.md", "docs/readme.md", true},
+		{"docs/*.md", "api/docs/readme.md", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.filePath, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesDiffSkipPatterns([]string{tt.pattern}, tt.filePath))
+		})
+	}
+}
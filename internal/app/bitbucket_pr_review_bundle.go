@@ -0,0 +1,295 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	prReviewBundleDefaultMaxDiffBytes = 50_000
+	prReviewBundleMaxMaxDiffBytes     = 500_000
+
+	// prReviewBundleConcurrency limits requests made to Bitbucket at the same time.
+	prReviewBundleConcurrency = 4
+
+	// prReviewBundlePageLen is the number of comments and tasks included in the bundle.
+	prReviewBundlePageLen = 100
+
+	// prReviewBundleMaxHunks limits hunk headers listed for a file left out of the bundle.
+	prReviewBundleMaxHunks = 20
+)
+
+// BitbucketPRReviewBundleParams contains parameters for getting everything needed to review a pull request.
+type BitbucketPRReviewBundleParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Pull request ID
+	PullRequestID int `json:"pull_request_id"`
+
+	// Maximum size of diffs included in the bundle (optional, defaults to 50000, max 500000)
+	MaxDiffBytes int `json:"max_diff_bytes,omitempty"`
+}
+
+// PRReviewBundle holds the pull request together with its changes, comments, tasks and build statuses.
+type PRReviewBundle struct {
+	PullRequest *bitbucket.PullRequest `json:"pull_request"`
+
+	// Files changed by the pull request in diffstat order.
+	Files []PRReviewBundleFile `json:"files"`
+
+	Comments []BitbucketPRComment               `json:"comments"`
+	Tasks    []bitbucket.PullRequestCommentTask `json:"tasks"`
+
+	// Statuses are build statuses of the head commit of the pull request.
+	Statuses []bitbucket.CommitStatus `json:"statuses"`
+
+	// CommentsTruncated is true when the pull request has more comments than included.
+	CommentsTruncated bool `json:"comments_truncated,omitempty"`
+
+	// TasksTruncated is true when the pull request has more tasks than included.
+	TasksTruncated bool `json:"tasks_truncated,omitempty"`
+
+	// DiffBytes is the size of the whole diff of the pull request.
+	DiffBytes int `json:"diff_bytes"`
+
+	// IncludedDiffBytes is the size of diffs included in the bundle.
+	IncludedDiffBytes int `json:"included_diff_bytes"`
+
+	// MaxDiffBytes is the budget diffs of files were included within.
+	MaxDiffBytes int `json:"max_diff_bytes"`
+
+	// OmittedFiles is the number of files whose diff did not fit the budget.
+	OmittedFiles int `json:"omitted_files,omitempty"`
+}
+
+// PRReviewBundleFile is a file changed by a pull request.
+type PRReviewBundleFile struct {
+	Path string `json:"path"`
+
+	// OldPath is the previous path of a renamed file.
+	OldPath string `json:"old_path,omitempty"`
+
+	// Status of the change, e.g. added, removed, modified or renamed
+	Status string `json:"status,omitempty"`
+
+	LinesAdded   int `json:"lines_added"`
+	LinesRemoved int `json:"lines_removed"`
+
+	// DiffBytes is the size of the diff of the file.
+	DiffBytes int `json:"diff_bytes"`
+
+	// Diff of the file, empty when omitted.
+	Diff string `json:"diff,omitempty"`
+
+	// Omitted is true when the diff of the file did not fit the budget.
+	Omitted bool `json:"omitted,omitempty"`
+
+	// Hunks are hunk headers of an omitted diff, to decide whether to read it separately.
+	Hunks []string `json:"hunks,omitempty"`
+}
+
+// prReviewBundleData holds responses the review bundle is assembled from.
+type prReviewBundleData struct {
+	pr       *bitbucket.PullRequest
+	diffStat []bitbucket.DiffStat
+	diff     string
+	comments *bitbucket.ListPRCommentsResponse
+	tasks    *bitbucket.PaginatedTasks
+	statuses []bitbucket.CommitStatus
+}
+
+// GetPRReviewBundle fetches the pull request, its diffstat, diff, comments, tasks and build statuses
+// concurrently. Diffs of files are included in diffstat order while they fit the size budget,
+// the remaining files are summarized by their stats and hunk headers.
+func (s *BitbucketService) GetPRReviewBundle(
+	ctx context.Context,
+	params BitbucketPRReviewBundleParams,
+) (*PRReviewBundle, error) {
+	s.logger.InfoContext(ctx, "Getting pull request review bundle",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.Int("pr_id", params.PullRequestID))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if params.PullRequestID <= 0 {
+		return nil, errors.New("pull request ID must be positive")
+	}
+	maxDiffBytes := min(params.MaxDiffBytes, prReviewBundleMaxMaxDiffBytes)
+	if maxDiffBytes <= 0 {
+		maxDiffBytes = prReviewBundleDefaultMaxDiffBytes
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	data, err := s.fetchPRReviewBundleData(ctx, tokenProvider, params)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &PRReviewBundle{
+		PullRequest:       data.pr,
+		Comments:          make([]BitbucketPRComment, 0, len(data.comments.Values)),
+		Tasks:             data.tasks.Values,
+		Statuses:          data.statuses,
+		CommentsTruncated: data.comments.Next != "",
+		TasksTruncated:    data.tasks.Next != "",
+		DiffBytes:         len(data.diff),
+		MaxDiffBytes:      maxDiffBytes,
+	}
+	for _, c := range data.comments.Values {
+		bundle.Comments = append(bundle.Comments, prCommentToBitbucketPRComment(c))
+	}
+	bundle.Files = prReviewBundleFiles(data.diffStat, splitDiffFiles(data.diff))
+	for i := range bundle.Files {
+		file := &bundle.Files[i]
+		if file.DiffBytes <= maxDiffBytes-bundle.IncludedDiffBytes {
+			bundle.IncludedDiffBytes += file.DiffBytes
+			continue
+		}
+		file.Hunks = diffHunkHeaders(file.Diff, prReviewBundleMaxHunks)
+		file.Diff = ""
+		file.Omitted = true
+		bundle.OmittedFiles++
+	}
+	return bundle, nil
+}
+
+// fetchPRReviewBundleData fetches everything the review bundle is assembled from concurrently.
+func (s *BitbucketService) fetchPRReviewBundleData(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketPRReviewBundleParams,
+) (*prReviewBundleData, error) {
+	var data prReviewBundleData
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(prReviewBundleConcurrency)
+	group.Go(func() error {
+		var err error
+		data.pr, err = s.client.GetPR(groupCtx, tokenProvider, bitbucket.GetPRParams{
+			Username:      params.RepoOwner,
+			RepoSlug:      params.RepoName,
+			PullRequestID: params.PullRequestID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+		// Statuses of the pull request include builds of replaced commits, so only the head commit is looked at.
+		data.statuses, err = s.listHeadCommitStatuses(groupCtx, tokenProvider, params.RepoOwner, params.RepoName, data.pr)
+		if err != nil {
+			return fmt.Errorf("failed to list build statuses: %w", err)
+		}
+		return nil
+	})
+	group.Go(func() error {
+		diffStat, err := s.client.GetPRDiffStat(groupCtx, tokenProvider, bitbucket.GetPRDiffStatParams{
+			RepoOwner: params.RepoOwner,
+			RepoName:  params.RepoName,
+			PRID:      params.PullRequestID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get diffstat: %w", err)
+		}
+		data.diffStat = diffStat.Values
+		return nil
+	})
+	group.Go(func() error {
+		var err error
+		data.diff, err = s.client.GetPRDiff(groupCtx, tokenProvider, bitbucket.GetPRDiffParams{
+			RepoOwner: params.RepoOwner,
+			RepoName:  params.RepoName,
+			PRID:      params.PullRequestID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get diff: %w", err)
+		}
+		return nil
+	})
+	group.Go(func() error {
+		var err error
+		data.comments, err = s.client.ListPRComments(groupCtx, tokenProvider, bitbucket.ListPRCommentsParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			PRID:      int64(params.PullRequestID),
+			PageLen:   prReviewBundlePageLen,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list comments: %w", err)
+		}
+		return nil
+	})
+	group.Go(func() error {
+		var err error
+		data.tasks, err = s.client.ListPullRequestTasks(groupCtx, tokenProvider, bitbucket.ListPullRequestTasksParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			PullReqID: params.PullRequestID,
+			PageLen:   prReviewBundlePageLen,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// prReviewBundleFiles matches diffstat entries with diffs of files. Diffs without
+// a diffstat entry are appended at the end.
+func prReviewBundleFiles(diffStat []bitbucket.DiffStat, diffs []diffFile) []PRReviewBundleFile {
+	diffsByPath := make(map[string]string, len(diffs))
+	for _, diff := range diffs {
+		diffsByPath[diff.Path] += diff.Text
+	}
+
+	files := make([]PRReviewBundleFile, 0, len(diffStat))
+	for _, stat := range diffStat {
+		file := PRReviewBundleFile{
+			Status:       stat.Status,
+			LinesAdded:   stat.LinesAdded,
+			LinesRemoved: stat.LinesRemoved,
+		}
+		if stat.Old != nil {
+			file.Path = stat.Old.Path
+		}
+		if stat.New != nil {
+			file.OldPath = file.Path
+			file.Path = stat.New.Path
+		}
+		if file.OldPath == file.Path {
+			file.OldPath = ""
+		}
+		if diff, ok := diffsByPath[file.Path]; ok {
+			file.Diff = diff
+			file.DiffBytes = len(diff)
+			delete(diffsByPath, file.Path)
+		}
+		files = append(files, file)
+	}
+	for _, diff := range diffs {
+		text, ok := diffsByPath[diff.Path]
+		if !ok {
+			continue
+		}
+		files = append(files, PRReviewBundleFile{Path: diff.Path, Diff: text, DiffBytes: len(text)})
+		delete(diffsByPath, diff.Path)
+	}
+	return files
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_GetPRReviewBundle(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	type diffStatResult = struct {
+		Size    int                  `json:"size,omitempty"`
+		Page    int                  `json:"page,omitempty"`
+		PageLen int                  `json:"pagelen,omitempty"`
+		Values  []bitbucket.DiffStat `json:"values"`
+	}
+
+	t.Run("should bundle pull request data and omit diffs over the budget", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		accountName := "account-" + faker.Username()
+		tokenProvider := setupTokenProvider(t, deps, accountName)
+
+		smallDiff := "diff --git a/main.go b/main.go\n@@ -1 +1 @@\n-a\n+b\n"
+		largeDiff := "diff --git a/go.sum b/go.sum\n@@ -1,3 +1,3 @@\n" + strings.Repeat("+line\n", 20) +
+			"@@ -40 +40 @@\n-x\n"
+		renamedDiff := "diff --git a/old.md b/new.md\nrename from old.md\nrename to new.md\n"
+		pr := &bitbucket.PullRequest{
+			ID:     7,
+			Title:  "Add refunds",
+			Source: bitbucket.PullRequestSource{Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()}},
+		}
+		comment := bitbucket.PRComment{ID: 11}
+		comment.Content.Raw = "Looks good"
+		task := bitbucket.PullRequestCommentTask{}
+		task.ID = 3
+		tasks := &bitbucket.PaginatedTasks{
+			Next:   "https://api.bitbucket.org/next",
+			Values: []bitbucket.PullRequestCommentTask{task},
+		}
+		statuses := []bitbucket.CommitStatus{{Key: "build", State: bitbucket.CommitStatusStateSuccessful}}
+
+		mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+			Username:      "acme",
+			RepoSlug:      "api",
+			PullRequestID: 7,
+		}).Return(pr, nil)
+		mockClient.EXPECT().GetPRDiffStat(mock.Anything, tokenProvider, bitbucket.GetPRDiffStatParams{
+			RepoOwner: "acme",
+			RepoName:  "api",
+			PRID:      7,
+		}).Return(&diffStatResult{Values: []bitbucket.DiffStat{
+			{Status: "modified", LinesAdded: 1, LinesRemoved: 1,
+				Old: &bitbucket.CommitFile{Path: "main.go"}, New: &bitbucket.CommitFile{Path: "main.go"}},
+			{Status: "modified", LinesAdded: 20, LinesRemoved: 1,
+				Old: &bitbucket.CommitFile{Path: "go.sum"}, New: &bitbucket.CommitFile{Path: "go.sum"}},
+			{Status: "renamed", Old: &bitbucket.CommitFile{Path: "old.md"}, New: &bitbucket.CommitFile{Path: "new.md"}},
+		}}, nil)
+		mockClient.EXPECT().GetPRDiff(mock.Anything, tokenProvider, bitbucket.GetPRDiffParams{
+			RepoOwner: "acme",
+			RepoName:  "api",
+			PRID:      7,
+		}).Return(smallDiff+largeDiff+renamedDiff, nil)
+		mockClient.EXPECT().ListPRComments(mock.Anything, tokenProvider, bitbucket.ListPRCommentsParams{
+			Workspace: "acme",
+			RepoSlug:  "api",
+			PRID:      7,
+			PageLen:   prReviewBundlePageLen,
+		}).Return(&bitbucket.ListPRCommentsResponse{Values: []bitbucket.PRComment{comment}}, nil)
+		mockClient.EXPECT().ListPullRequestTasks(mock.Anything, tokenProvider, bitbucket.ListPullRequestTasksParams{
+			Workspace: "acme",
+			RepoSlug:  "api",
+			PullReqID: 7,
+			PageLen:   prReviewBundlePageLen,
+		}).Return(tasks, nil)
+		mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: "acme",
+			RepoSlug:  "api",
+			Commit:    pr.Source.Commit.Hash,
+		}).Return(statuses, nil)
+		service := NewBitbucketService(deps)
+
+		maxDiffBytes := len(smallDiff) + len(renamedDiff)
+		bundle, err := service.GetPRReviewBundle(t.Context(), BitbucketPRReviewBundleParams{
+			AccountName:   accountName,
+			RepoOwner:     "acme",
+			RepoName:      "api",
+			PullRequestID: 7,
+			MaxDiffBytes:  maxDiffBytes,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &PRReviewBundle{
+			PullRequest: pr,
+			Files: []PRReviewBundleFile{
+				{Path: "main.go", Status: "modified", LinesAdded: 1, LinesRemoved: 1,
+					DiffBytes: len(smallDiff), Diff: smallDiff},
+				{Path: "go.sum", Status: "modified", LinesAdded: 20, LinesRemoved: 1,
+					DiffBytes: len(largeDiff), Omitted: true, Hunks: []string{"@@ -1,3 +1,3 @@", "@@ -40 +40 @@"}},
+				{Path: "new.md", OldPath: "old.md", Status: "renamed",
+					DiffBytes: len(renamedDiff), Diff: renamedDiff},
+			},
+			Comments:          []BitbucketPRComment{prCommentToBitbucketPRComment(comment)},
+			Tasks:             tasks.Values,
+			Statuses:          statuses,
+			TasksTruncated:    true,
+			DiffBytes:         len(smallDiff) + len(largeDiff) + len(renamedDiff),
+			IncludedDiffBytes: maxDiffBytes,
+			MaxDiffBytes:      maxDiffBytes,
+			OmittedFiles:      1,
+		}, bundle)
+	})
+
+	t.Run("should return error when a request fails", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		setupTokenProvider(t, deps, "")
+		statusesErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().GetPR(mock.Anything, mock.Anything, mock.Anything).Return(&bitbucket.PullRequest{
+			Source: bitbucket.PullRequestSource{Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()}},
+		}, nil)
+		mockClient.EXPECT().GetPRDiffStat(mock.Anything, mock.Anything, mock.Anything).
+			Return(&diffStatResult{}, nil).Maybe()
+		mockClient.EXPECT().GetPRDiff(mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+		mockClient.EXPECT().ListPRComments(mock.Anything, mock.Anything, mock.Anything).
+			Return(&bitbucket.ListPRCommentsResponse{}, nil).Maybe()
+		mockClient.EXPECT().ListPullRequestTasks(mock.Anything, mock.Anything, mock.Anything).
+			Return(&bitbucket.PaginatedTasks{}, nil).Maybe()
+		mockClient.EXPECT().ListCommitStatuses(mock.Anything, mock.Anything, mock.Anything).Return(nil, statusesErr)
+		service := NewBitbucketService(deps)
+
+		bundle, err := service.GetPRReviewBundle(t.Context(), BitbucketPRReviewBundleParams{
+			RepoOwner:     "acme",
+			RepoName:      "api",
+			PullRequestID: 7,
+		})
+
+		require.ErrorIs(t, err, statusesErr)
+		assert.Nil(t, bundle)
+	})
+
+	t.Run("should validate parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		_, err := service.GetPRReviewBundle(t.Context(), BitbucketPRReviewBundleParams{RepoName: "api"})
+
+		require.ErrorContains(t, err, "repository owner is required")
+	})
+}
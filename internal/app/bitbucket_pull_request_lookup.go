@@ -0,0 +1,294 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// commitPullRequestsLimit limits pull requests listed for a commit.
+	commitPullRequestsLimit = 20
+
+	issuePullRequestsDefaultLimit = 20
+	issuePullRequestsMaxLimit     = 100
+
+	// issueSearchRepositoryLimit limits pull requests fetched from a single repository.
+	issueSearchRepositoryLimit = 50
+
+	// issueSearchConcurrency limits repositories searched at the same time.
+	issueSearchConcurrency = 8
+
+	// issueSearchFields adds descriptions that pull request lists omit by default.
+	issueSearchFields = "+values.description"
+)
+
+// issueKeyPattern matches Jira issue keys such as PAY-123.
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// allPullRequestStates are states pull requests are searched in when no states are given.
+var allPullRequestStates = []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+
+// BitbucketCommitPullRequestsParams contains parameters for finding pull requests that contain a commit.
+type BitbucketCommitPullRequestsParams struct {
+	BitbucketRepositoryParams
+
+	// Full or abbreviated commit hash
+	Commit string `json:"commit"`
+}
+
+// CommitPullRequests lists pull requests that contain a commit.
+type CommitPullRequests struct {
+	Commit       string                  `json:"commit"`
+	PullRequests []bitbucket.PullRequest `json:"pull_requests"`
+
+	// FromMessage is true when pull requests were found by the reference Bitbucket adds to merge commit
+	// messages, e.g. because the commit is a squash merge that no pull request contains.
+	FromMessage bool `json:"from_message,omitempty"`
+}
+
+// BitbucketIssuePullRequestsParams contains parameters for finding pull requests that reference a Jira issue.
+type BitbucketIssuePullRequestsParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Workspace to search in
+	Workspace string `json:"workspace"`
+
+	// Jira issue key, e.g. PAY-123
+	IssueKey string `json:"issue_key"`
+
+	// Repositories to search as slugs of the workspace or full names (workspace/repo_slug)
+	// (optional, defaults to the most recently updated repositories of the workspace)
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Pull request states to search: OPEN, MERGED, DECLINED or SUPERSEDED (optional, defaults to all)
+	States []string `json:"states,omitempty"`
+
+	// Maximum number of pull requests to return (optional, defaults to 20, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// IssuePullRequests lists pull requests that reference a Jira issue.
+type IssuePullRequests struct {
+	IssueKey  string `json:"issue_key"`
+	Workspace string `json:"workspace"`
+
+	// PullRequests referencing the issue, most recently updated first.
+	PullRequests []IssuePullRequest `json:"pull_requests"`
+
+	// Truncated is true when more pull requests reference the issue than listed.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// SearchedRepositories is the number of repositories searched.
+	SearchedRepositories int `json:"searched_repositories"`
+
+	// RepositoriesTruncated is true when the workspace has more repositories than searched.
+	RepositoriesTruncated bool `json:"repositories_truncated,omitempty"`
+}
+
+// IssuePullRequest is a pull request that references a Jira issue.
+type IssuePullRequest struct {
+	// Repository full name (workspace/repo_slug)
+	Repository string `json:"repository"`
+
+	// MatchedIn lists where the issue key was found: title, description or branch.
+	MatchedIn []string `json:"matched_in"`
+
+	PullRequest bitbucket.PullRequest `json:"pull_request"`
+}
+
+// FindCommitPullRequests finds pull requests that contain a commit. When Bitbucket knows of none,
+// e.g. for a squash merge commit, pull requests referenced by the commit message are returned.
+func (s *BitbucketService) FindCommitPullRequests(
+	ctx context.Context,
+	params BitbucketCommitPullRequestsParams,
+) (*CommitPullRequests, error) {
+	s.logger.InfoContext(ctx, "Finding pull requests of commit",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("commit", params.Commit))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Commit == "" {
+		return nil, errors.New("commit is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	pullRequests, err := s.client.ListCommitPullRequests(ctx, tokenProvider, bitbucket.ListCommitPullRequestsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    params.Commit,
+		PageLen:   commitPullRequestsLimit,
+		Limit:     commitPullRequestsLimit,
+	})
+	if err != nil {
+		// Bitbucket responds with 404 until pull request commit links of the repository are indexed
+		var httpErr *middleware.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to list pull requests of commit %s: %w", params.Commit, err)
+		}
+	}
+	if len(pullRequests) > 0 {
+		return &CommitPullRequests{
+			Commit:       params.Commit,
+			PullRequests: pullRequests[:min(len(pullRequests), commitPullRequestsLimit)],
+		}, nil
+	}
+
+	commit, err := s.client.GetCommit(ctx, tokenProvider, bitbucket.GetCommitParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    params.Commit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", params.Commit, err)
+	}
+	referenced, err := s.getReferencedPullRequests(
+		ctx, tokenProvider, params.BitbucketRepositoryParams, []bitbucket.Commit{*commit})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitPullRequests{
+		Commit:       commit.Hash,
+		PullRequests: referenced,
+		FromMessage:  len(referenced) > 0,
+	}, nil
+}
+
+// SearchIssuePullRequests finds pull requests that mention a Jira issue key in their title,
+// description or source branch across repositories of a workspace.
+func (s *BitbucketService) SearchIssuePullRequests(
+	ctx context.Context,
+	params BitbucketIssuePullRequestsParams,
+) (*IssuePullRequests, error) {
+	s.logger.InfoContext(ctx, "Searching pull requests of issue",
+		slog.String("workspace", params.Workspace),
+		slog.String("issue_key", params.IssueKey))
+
+	if params.Workspace == "" {
+		return nil, errors.New("workspace is required")
+	}
+	issueKey := strings.ToUpper(strings.TrimSpace(params.IssueKey))
+	if !issueKeyPattern.MatchString(issueKey) {
+		return nil, fmt.Errorf("invalid issue key %q, expected a key like PAY-123", params.IssueKey)
+	}
+	limit := min(params.Limit, issuePullRequestsMaxLimit)
+	if limit <= 0 {
+		limit = issuePullRequestsDefaultLimit
+	}
+	states := params.States
+	if len(states) == 0 {
+		states = allPullRequestStates
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	repositories, repositoriesTruncated, err := s.resolveRepositoryScope(
+		ctx, tokenProvider, params.Workspace, params.Repositories)
+	if err != nil {
+		return nil, err
+	}
+	result := &IssuePullRequests{
+		IssueKey:              issueKey,
+		Workspace:             params.Workspace,
+		SearchedRepositories:  len(repositories),
+		RepositoriesTruncated: repositoriesTruncated,
+	}
+
+	found, err := s.searchIssuePullRequests(ctx, tokenProvider, repositories, issueKey, states)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(found, func(a, b IssuePullRequest) int {
+		return cmp.Compare(pullRequestUpdatedOn(b.PullRequest), pullRequestUpdatedOn(a.PullRequest))
+	})
+	result.PullRequests = found[:min(len(found), limit)]
+	result.Truncated = len(found) > limit
+	return result, nil
+}
+
+// searchIssuePullRequests searches repositories concurrently for pull requests that mention the issue key.
+func (s *BitbucketService) searchIssuePullRequests(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	repositories []repositoryRef,
+	issueKey string,
+	states []string,
+) ([]IssuePullRequest, error) {
+	// BBQL ~ is a case-insensitive substring match, so PAY-12 also finds PAY-123 and needs to be rechecked
+	quotedKey := strconv.Quote(issueKey)
+	query := "title ~ " + quotedKey + " OR description ~ " + quotedKey + " OR source.branch.name ~ " + quotedKey
+	keyPattern := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9])` + regexp.QuoteMeta(issueKey) + `($|[^0-9])`)
+
+	perRepository := make([][]IssuePullRequest, len(repositories))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(issueSearchConcurrency)
+	for i, repository := range repositories {
+		group.Go(func() error {
+			pullRequests, err := s.client.ListPullRequests(groupCtx, tokenProvider, bitbucket.ListPullRequestsParams{
+				Workspace: repository.Workspace,
+				RepoSlug:  repository.Slug,
+				States:    states,
+				Query:     query,
+				Sort:      "-updated_on",
+				Fields:    issueSearchFields,
+				PageLen:   issueSearchRepositoryLimit,
+				Limit:     issueSearchRepositoryLimit,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to search pull requests of %s: %w", repository.fullName(), err)
+			}
+			for _, pr := range pullRequests {
+				var matchedIn []string
+				if keyPattern.MatchString(pr.Title) {
+					matchedIn = append(matchedIn, "title")
+				}
+				if keyPattern.MatchString(pr.Description) {
+					matchedIn = append(matchedIn, "description")
+				}
+				if keyPattern.MatchString(pr.Source.Branch.Name) {
+					matchedIn = append(matchedIn, "branch")
+				}
+				if len(matchedIn) > 0 {
+					perRepository[i] = append(perRepository[i],
+						IssuePullRequest{Repository: repository.fullName(), MatchedIn: matchedIn, PullRequest: pr})
+				}
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return slices.Concat(perRepository...), nil
+}
+
+// repositorySlug returns the slug of a repository, falling back to the last part of its full name.
+func repositorySlug(repository bitbucket.Repository) string {
+	if repository.Slug != "" {
+		return repository.Slug
+	}
+	_, slug, _ := strings.Cut(repository.FullName, "/")
+	return slug
+}
+
+// pullRequestUpdatedOn returns the Unix time of the last update of a pull request, zero when unknown.
+func pullRequestUpdatedOn(pr bitbucket.PullRequest) int64 {
+	if pr.UpdatedOn == nil {
+		return 0
+	}
+	return pr.UpdatedOn.UnixNano()
+}
@@ -0,0 +1,293 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_PullRequestLookup(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	t.Run("FindCommitPullRequests", func(t *testing.T) {
+		t.Run("should return pull requests containing the commit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			commit := faker.UUIDDigit()
+			pullRequests := []bitbucket.PullRequest{{ID: 7, Title: faker.Sentence()}}
+
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider,
+				bitbucket.ListCommitPullRequestsParams{
+					Workspace: repoParams.RepoOwner,
+					RepoSlug:  repoParams.RepoName,
+					Commit:    commit,
+					PageLen:   commitPullRequestsLimit,
+					Limit:     commitPullRequestsLimit,
+				}).Return(pullRequests, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.FindCommitPullRequests(t.Context(), BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: repoParams,
+				Commit:                    commit,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &CommitPullRequests{Commit: commit, PullRequests: pullRequests}, got)
+		})
+
+		t.Run("should fall back to commit message references when not indexed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			commit := faker.UUIDDigit()
+			pr := &bitbucket.PullRequest{ID: 12, Title: faker.Sentence()}
+
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, mock.Anything).
+				Return(nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			mockClient.EXPECT().GetCommit(mock.Anything, tokenProvider, bitbucket.GetCommitParams{
+				Workspace: repoParams.RepoOwner,
+				RepoSlug:  repoParams.RepoName,
+				Commit:    commit[:7],
+			}).Return(&bitbucket.Commit{Hash: commit, Message: "Fix rounding (pull request #12)"}, nil)
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+				Username:      repoParams.RepoOwner,
+				RepoSlug:      repoParams.RepoName,
+				PullRequestID: 12,
+			}).Return(pr, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.FindCommitPullRequests(t.Context(), BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: repoParams,
+				Commit:                    commit[:7],
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &CommitPullRequests{
+				Commit:       commit,
+				PullRequests: []bitbucket.PullRequest{*pr},
+				FromMessage:  true,
+			}, got)
+		})
+
+		t.Run("should return empty list when commit references no pull requests", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			setupTokenProvider(t, deps, repoParams.AccountName)
+			commit := faker.UUIDDigit()
+
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, mock.Anything, mock.Anything).
+				Return([]bitbucket.PullRequest{}, nil)
+			mockClient.EXPECT().GetCommit(mock.Anything, mock.Anything, mock.Anything).
+				Return(&bitbucket.Commit{Hash: commit, Message: faker.Sentence()}, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.FindCommitPullRequests(t.Context(), BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: repoParams,
+				Commit:                    commit,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &CommitPullRequests{Commit: commit, PullRequests: []bitbucket.PullRequest{}}, got)
+		})
+
+		t.Run("should return error when listing fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			setupTokenProvider(t, deps, repoParams.AccountName)
+			listErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, listErr)
+			service := NewBitbucketService(deps)
+
+			got, err := service.FindCommitPullRequests(t.Context(), BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: repoParams,
+				Commit:                    faker.UUIDDigit(),
+			})
+
+			require.ErrorIs(t, err, listErr)
+			assert.Nil(t, got)
+		})
+
+		t.Run("should require commit", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			got, err := service.FindCommitPullRequests(t.Context(), BitbucketCommitPullRequestsParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "commit is required")
+			assert.Nil(t, got)
+		})
+	})
+
+	t.Run("SearchIssuePullRequests", func(t *testing.T) {
+		at := func(hours int) *time.Time {
+			value := time.Date(2026, 1, 1, hours, 0, 0, 0, time.UTC)
+			return &value
+		}
+
+		t.Run("should search recently updated repositories and keep exact key matches", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			accountName := "account-" + faker.Username()
+			tokenProvider := setupTokenProvider(t, deps, accountName)
+			workspace := "ws-" + faker.Username()
+			query := `title ~ "PAY-12" OR description ~ "PAY-12" OR source.branch.name ~ "PAY-12"`
+
+			mockClient.EXPECT().ListRepositories(mock.Anything, tokenProvider, bitbucket.ListRepositoriesParams{
+				Workspace: workspace,
+				Sort:      "-updated_on",
+				PageLen:   discoveryMaxPageLen,
+				Limit:     scopeMaxRepositories + 1,
+			}).Return([]bitbucket.Repository{{Slug: "api"}, {FullName: workspace + "/web"}}, nil)
+
+			apiPR := bitbucket.PullRequest{ID: 1, Title: "PAY-12: fix rounding", UpdatedOn: at(1)}
+			otherKeyPR := bitbucket.PullRequest{ID: 2, Title: "PAY-123: unrelated", UpdatedOn: at(5)}
+			webPR := bitbucket.PullRequest{
+				ID:          3,
+				Title:       faker.Sentence(),
+				Description: "Part of pay-12",
+				Source:      bitbucket.PullRequestSource{Branch: bitbucket.PullRequestBranch{Name: "feature/PAY-12-ui"}},
+				UpdatedOn:   at(3),
+			}
+			expectSearch := func(repository string, result []bitbucket.PullRequest) {
+				mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, bitbucket.ListPullRequestsParams{
+					Workspace: workspace,
+					RepoSlug:  repository,
+					States:    allPullRequestStates,
+					Query:     query,
+					Sort:      "-updated_on",
+					Fields:    issueSearchFields,
+					PageLen:   issueSearchRepositoryLimit,
+					Limit:     issueSearchRepositoryLimit,
+				}).Return(result, nil)
+			}
+			expectSearch("api", []bitbucket.PullRequest{otherKeyPR, apiPR})
+			expectSearch("web", []bitbucket.PullRequest{webPR})
+			service := NewBitbucketService(deps)
+
+			got, err := service.SearchIssuePullRequests(t.Context(), BitbucketIssuePullRequestsParams{
+				AccountName: accountName,
+				Workspace:   workspace,
+				IssueKey:    " pay-12 ",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &IssuePullRequests{
+				IssueKey:  "PAY-12",
+				Workspace: workspace,
+				PullRequests: []IssuePullRequest{
+					{Repository: workspace + "/web", MatchedIn: []string{"description", "branch"}, PullRequest: webPR},
+					{Repository: workspace + "/api", MatchedIn: []string{"title"}, PullRequest: apiPR},
+				},
+				SearchedRepositories: 2,
+			}, got)
+		})
+
+		t.Run("should search given repositories and states and truncate to limit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			tokenProvider := setupTokenProvider(t, deps, "")
+			workspace := "ws-" + faker.Username()
+			pullRequests := []bitbucket.PullRequest{
+				{ID: 2, Title: "OPS-7 second", UpdatedOn: at(2)},
+				{ID: 1, Title: "OPS-7 first", UpdatedOn: at(1)},
+			}
+
+			mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, mock.MatchedBy(
+				func(p bitbucket.ListPullRequestsParams) bool {
+					return p.Workspace == workspace && p.RepoSlug == "infra" &&
+						assert.ObjectsAreEqual([]string{"OPEN"}, p.States)
+				},
+			)).Return(pullRequests, nil)
+			mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, mock.MatchedBy(
+				func(p bitbucket.ListPullRequestsParams) bool {
+					return p.Workspace == "ops" && p.RepoSlug == "deploy"
+				},
+			)).Return([]bitbucket.PullRequest{}, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.SearchIssuePullRequests(t.Context(), BitbucketIssuePullRequestsParams{
+				Workspace:    workspace,
+				IssueKey:     "OPS-7",
+				Repositories: []string{"infra", "ops/deploy"},
+				States:       []string{"OPEN"},
+				Limit:        1,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &IssuePullRequests{
+				IssueKey:  "OPS-7",
+				Workspace: workspace,
+				PullRequests: []IssuePullRequest{
+					{Repository: workspace + "/infra", MatchedIn: []string{"title"}, PullRequest: pullRequests[0]},
+				},
+				Truncated:            true,
+				SearchedRepositories: 2,
+			}, got)
+		})
+
+		t.Run("should return error when a repository search fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			setupTokenProvider(t, deps, "")
+			searchErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.Anything).Return(nil, searchErr)
+			service := NewBitbucketService(deps)
+
+			got, err := service.SearchIssuePullRequests(t.Context(), BitbucketIssuePullRequestsParams{
+				Workspace:    "ws-" + faker.Username(),
+				IssueKey:     "OPS-7",
+				Repositories: []string{"infra"},
+			})
+
+			require.ErrorIs(t, err, searchErr)
+			require.ErrorContains(t, err, "infra")
+			assert.Nil(t, got)
+		})
+
+		t.Run("should validate params", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.SearchIssuePullRequests(t.Context(), BitbucketIssuePullRequestsParams{IssueKey: "OPS-7"})
+			require.ErrorContains(t, err, "workspace is required")
+
+			_, err = service.SearchIssuePullRequests(t.Context(), BitbucketIssuePullRequestsParams{
+				Workspace: "ws-" + faker.Username(),
+				IssueKey:  "rounding bug",
+			})
+			require.ErrorContains(t, err, "invalid issue key")
+		})
+	})
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	workspacesDefaultLimit = 50
+	workspacesMaxLimit     = 100
+
+	repositoriesDefaultLimit = 50
+	repositoriesMaxLimit     = 100
+
+	// discoveryMaxPageLen is the maximum page size accepted by the workspaces and repositories APIs.
+	discoveryMaxPageLen = 100
+
+	// scopeMaxRepositories limits repositories of a workspace that cross-repository tools look at.
+	scopeMaxRepositories = 100
+)
+
+// repositoryRef identifies a repository by its workspace and slug.
+type repositoryRef struct {
+	Workspace string
+	Slug      string
+}
+
+func (r repositoryRef) fullName() string {
+	return r.Workspace + "/" + r.Slug
+}
+
+// FailedRepository is a repository a cross-repository tool could not look at.
+// Results of the other repositories are returned regardless.
+type FailedRepository struct {
+	// Repository full name (workspace/repo_slug)
+	Repository string `json:"repository"`
+
+	Error string `json:"error"`
+}
+
+// BitbucketListWorkspacesParams contains parameters for listing workspaces of the user.
+type BitbucketListWorkspacesParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Maximum number of workspaces to return (optional, defaults to 50, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// WorkspaceList lists workspaces the user is a member of.
+type WorkspaceList struct {
+	// Workspaces with the role of the user in each of them, ordered by slug.
+	Workspaces []bitbucket.WorkspaceMembership `json:"workspaces"`
+
+	// Truncated is true when the user is a member of more workspaces than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BitbucketListRepositoriesParams contains parameters for listing repositories of a workspace.
+type BitbucketListRepositoriesParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Workspace to list repositories of
+	Workspace string `json:"workspace"`
+
+	// BBQL filter, e.g. name ~ "payments" (optional)
+	Query string `json:"query,omitempty"`
+
+	// Only list repositories of the project with this key (optional)
+	Project string `json:"project,omitempty"`
+
+	// Sort field, prefix with - for descending order, e.g. name or -updated_on (optional)
+	Sort string `json:"sort,omitempty"`
+
+	// Maximum number of repositories to return (optional, defaults to 50, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// RepositoryList lists repositories of a workspace.
+type RepositoryList struct {
+	Workspace    string                 `json:"workspace"`
+	Repositories []bitbucket.Repository `json:"repositories"`
+
+	// Truncated is true when more repositories match than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ListWorkspaces lists workspaces the user is a member of.
+func (s *BitbucketService) ListWorkspaces(
+	ctx context.Context,
+	params BitbucketListWorkspacesParams,
+) (*WorkspaceList, error) {
+	s.logger.InfoContext(ctx, "Listing workspaces")
+
+	limit := min(params.Limit, workspacesMaxLimit)
+	if limit <= 0 {
+		limit = workspacesDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	memberships, err := s.client.ListWorkspaces(ctx, tokenProvider, bitbucket.ListWorkspacesParams{
+		Sort:    "workspace.slug",
+		PageLen: min(limit+1, discoveryMaxPageLen),
+		Limit:   limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	return &WorkspaceList{
+		Workspaces: memberships[:min(len(memberships), limit)],
+		Truncated:  len(memberships) > limit,
+	}, nil
+}
+
+// ListRepositories lists repositories of a workspace visible to the user.
+func (s *BitbucketService) ListRepositories(
+	ctx context.Context,
+	params BitbucketListRepositoriesParams,
+) (*RepositoryList, error) {
+	s.logger.InfoContext(ctx, "Listing repositories",
+		slog.String("workspace", params.Workspace),
+		slog.String("query", params.Query),
+		slog.String("project", params.Project))
+
+	if params.Workspace == "" {
+		return nil, errors.New("workspace is required")
+	}
+	limit := min(params.Limit, repositoriesMaxLimit)
+	if limit <= 0 {
+		limit = repositoriesDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	repositories, err := s.client.ListRepositories(ctx, tokenProvider, bitbucket.ListRepositoriesParams{
+		Workspace: params.Workspace,
+		Query:     repositoriesQuery(params.Query, params.Project),
+		Sort:      params.Sort,
+		PageLen:   min(limit+1, discoveryMaxPageLen),
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	return &RepositoryList{
+		Workspace:    params.Workspace,
+		Repositories: repositories[:min(len(repositories), limit)],
+		Truncated:    len(repositories) > limit,
+	}, nil
+}
+
+// GetRepository returns a repository with its main branch, project and language.
+func (s *BitbucketService) GetRepository(
+	ctx context.Context,
+	params BitbucketRepositoryParams,
+) (*bitbucket.Repository, error) {
+	s.logger.InfoContext(ctx, "Getting repository",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName))
+
+	if err := validateRepositoryParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	repository, err := s.client.GetRepository(ctx, tokenProvider, bitbucket.GetRepositoryParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	return repository, nil
+}
+
+// repositoriesQuery combines a BBQL filter with a filter by project key.
+func repositoriesQuery(query, project string) string {
+	query = strings.TrimSpace(query)
+	if project == "" {
+		return query
+	}
+	projectCondition := "project.key = " + strconv.Quote(project)
+	if query == "" {
+		return projectCondition
+	}
+	return "(" + query + ") AND " + projectCondition
+}
+
+// resolveRepositoryScope returns repositories cross-repository tools look at. Repositories are given
+// as full names (workspace/repo_slug) or as slugs of the workspace. Without repositories, the most
+// recently updated repositories of the workspace are used, and without a workspace, the watched
+// repositories from the config. The flag is true when the workspace has more repositories than returned.
+func (s *BitbucketService) resolveRepositoryScope(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	workspace string,
+	repositories []string,
+) ([]repositoryRef, bool, error) {
+	if len(repositories) == 0 && workspace != "" {
+		listed, err := s.client.ListRepositories(ctx, tokenProvider, bitbucket.ListRepositoriesParams{
+			Workspace: workspace,
+			Sort:      "-updated_on",
+			PageLen:   min(scopeMaxRepositories+1, discoveryMaxPageLen),
+			Limit:     scopeMaxRepositories + 1,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		refs := make([]repositoryRef, 0, min(len(listed), scopeMaxRepositories))
+		for _, repository := range listed[:min(len(listed), scopeMaxRepositories)] {
+			refs = append(refs, repositoryRef{Workspace: workspace, Slug: repositorySlug(repository)})
+		}
+		return refs, len(listed) > scopeMaxRepositories, nil
+	}
+
+	if len(repositories) == 0 {
+		repositories = s.watchedRepositories
+	}
+	if len(repositories) == 0 {
+		return nil, false, errors.New("workspace or repositories are required when no watched repositories are configured")
+	}
+	refs := make([]repositoryRef, 0, len(repositories))
+	for _, repository := range repositories {
+		repoWorkspace, slug, found := strings.Cut(repository, "/")
+		if !found {
+			repoWorkspace, slug = workspace, repository
+		}
+		if repoWorkspace == "" || slug == "" {
+			return nil, false, fmt.Errorf("invalid repository %q, expected workspace/repo_slug", repository)
+		}
+		refs = append(refs, repositoryRef{Workspace: repoWorkspace, Slug: slug})
+	}
+	return refs, false, nil
+}
+
+// collectPerRepository calls fetch for every repository concurrently and concatenates results in
+// the order of repositories. Repositories that fail are reported as failed, an error is returned
+// only when all of them fail or the context is done.
+func collectPerRepository[T any](
+	ctx context.Context,
+	logger *slog.Logger,
+	repositories []repositoryRef,
+	concurrency int,
+	fetch func(ctx context.Context, repository repositoryRef) ([]T, error),
+) ([]T, []FailedRepository, error) {
+	perRepository := make([][]T, len(repositories))
+	errs := make([]error, len(repositories))
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+	for i, repository := range repositories {
+		group.Go(func() error {
+			perRepository[i], errs[i] = fetch(ctx, repository)
+			return nil
+		})
+	}
+	_ = group.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(repositories) > 0 && !slices.Contains(errs, nil) {
+		return nil, nil, fmt.Errorf("%s: %w", repositories[0].fullName(), errs[0])
+	}
+
+	var failed []FailedRepository
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		logger.WarnContext(ctx, "Failed to look at repository",
+			slog.String("repository", repositories[i].fullName()),
+			slog.Any("error", err))
+		failed = append(failed, FailedRepository{Repository: repositories[i].fullName(), Error: err.Error()})
+	}
+	return slices.Concat(perRepository...), failed, nil
+}
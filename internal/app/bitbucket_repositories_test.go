@@ -0,0 +1,212 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Repositories(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	t.Run("ListWorkspaces", func(t *testing.T) {
+		t.Run("should list workspaces and report truncation", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			accountName := "account-" + faker.Username()
+			tokenProvider := setupTokenProvider(t, deps, accountName)
+			memberships := []bitbucket.WorkspaceMembership{
+				{Permission: "owner", Workspace: &bitbucket.Workspace{Slug: "acme"}},
+				{Permission: "member", Workspace: &bitbucket.Workspace{Slug: "oss"}},
+			}
+
+			mockClient.EXPECT().ListWorkspaces(mock.Anything, tokenProvider, bitbucket.ListWorkspacesParams{
+				Sort:    "workspace.slug",
+				PageLen: 2,
+				Limit:   2,
+			}).Return(memberships, nil)
+			service := NewBitbucketService(deps)
+
+			list, err := service.ListWorkspaces(t.Context(), BitbucketListWorkspacesParams{
+				AccountName: accountName,
+				Limit:       1,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &WorkspaceList{Workspaces: memberships[:1], Truncated: true}, list)
+		})
+
+		t.Run("should return error when listing fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			setupTokenProvider(t, deps, "")
+			listErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListWorkspaces(mock.Anything, mock.Anything, mock.MatchedBy(
+				func(p bitbucket.ListWorkspacesParams) bool {
+					return p.Limit == workspacesDefaultLimit+1
+				},
+			)).Return(nil, listErr)
+			service := NewBitbucketService(deps)
+
+			list, err := service.ListWorkspaces(t.Context(), BitbucketListWorkspacesParams{})
+
+			require.ErrorIs(t, err, listErr)
+			assert.Nil(t, list)
+		})
+	})
+
+	t.Run("ListRepositories", func(t *testing.T) {
+		t.Run("should combine query with project filter", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketListRepositoriesParams{
+				AccountName: "account-" + faker.Username(),
+				Workspace:   "ws-" + faker.Username(),
+				Query:       `name ~ "pay" OR description ~ "pay"`,
+				Project:     "PAY",
+				Sort:        "-updated_on",
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.AccountName)
+			repositories := []bitbucket.Repository{{FullName: params.Workspace + "/payments"}}
+
+			mockClient.EXPECT().ListRepositories(mock.Anything, tokenProvider, bitbucket.ListRepositoriesParams{
+				Workspace: params.Workspace,
+				Query:     `(name ~ "pay" OR description ~ "pay") AND project.key = "PAY"`,
+				Sort:      "-updated_on",
+				PageLen:   repositoriesDefaultLimit + 1,
+				Limit:     repositoriesDefaultLimit + 1,
+			}).Return(repositories, nil)
+			service := NewBitbucketService(deps)
+
+			list, err := service.ListRepositories(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, &RepositoryList{Workspace: params.Workspace, Repositories: repositories}, list)
+		})
+
+		t.Run("should cap limit and report truncation", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			workspace := "ws-" + faker.Username()
+			tokenProvider := setupTokenProvider(t, deps, "")
+			repositories := make([]bitbucket.Repository, repositoriesMaxLimit+1)
+
+			mockClient.EXPECT().ListRepositories(mock.Anything, tokenProvider, bitbucket.ListRepositoriesParams{
+				Workspace: workspace,
+				PageLen:   discoveryMaxPageLen,
+				Limit:     repositoriesMaxLimit + 1,
+			}).Return(repositories, nil)
+			service := NewBitbucketService(deps)
+
+			list, err := service.ListRepositories(t.Context(), BitbucketListRepositoriesParams{
+				Workspace: workspace,
+				Limit:     1000,
+			})
+
+			require.NoError(t, err)
+			assert.Len(t, list.Repositories, repositoriesMaxLimit)
+			assert.True(t, list.Truncated)
+		})
+
+		t.Run("should require workspace", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListRepositories(t.Context(), BitbucketListRepositoriesParams{})
+
+			require.ErrorContains(t, err, "workspace is required")
+		})
+	})
+
+	t.Run("GetRepository", func(t *testing.T) {
+		t.Run("should get repository", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.AccountName)
+			repository := &bitbucket.Repository{
+				FullName:   params.RepoOwner + "/" + params.RepoName,
+				MainBranch: &bitbucket.Branch{Name: "main"},
+			}
+
+			mockClient.EXPECT().GetRepository(mock.Anything, tokenProvider, bitbucket.GetRepositoryParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+			}).Return(repository, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.GetRepository(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, repository, got)
+		})
+
+		t.Run("should validate repository", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.GetRepository(t.Context(), BitbucketRepositoryParams{RepoOwner: faker.Username()})
+
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("resolveRepositoryScope", func(t *testing.T) {
+		t.Run("should list most recently updated repositories of workspace", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.WatchedRepositories = []string{"oss/lib"}
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			listed := make([]bitbucket.Repository, scopeMaxRepositories+1)
+			for i := range listed {
+				listed[i] = bitbucket.Repository{Slug: faker.Username()}
+			}
+			listed[1] = bitbucket.Repository{FullName: "acme/web"}
+
+			mockClient.EXPECT().ListRepositories(mock.Anything, tokenProvider, bitbucket.ListRepositoriesParams{
+				Workspace: "acme",
+				Sort:      "-updated_on",
+				PageLen:   discoveryMaxPageLen,
+				Limit:     scopeMaxRepositories + 1,
+			}).Return(listed, nil)
+			service := NewBitbucketService(deps)
+
+			refs, truncated, err := service.resolveRepositoryScope(t.Context(), tokenProvider, "acme", nil)
+
+			require.NoError(t, err)
+			assert.True(t, truncated)
+			require.Len(t, refs, scopeMaxRepositories)
+			assert.Equal(t, repositoryRef{Workspace: "acme", Slug: listed[0].Slug}, refs[0])
+			assert.Equal(t, "acme/web", refs[1].fullName())
+		})
+
+		t.Run("should combine slugs with workspace and prefer given repositories", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.WatchedRepositories = []string{"oss/lib"}
+			service := NewBitbucketService(deps)
+
+			refs, truncated, err := service.resolveRepositoryScope(
+				t.Context(), nil, "acme", []string{"api", "oss/tools"})
+
+			require.NoError(t, err)
+			assert.False(t, truncated)
+			assert.Equal(t, []repositoryRef{{Workspace: "acme", Slug: "api"}, {Workspace: "oss", Slug: "tools"}}, refs)
+		})
+	})
+}
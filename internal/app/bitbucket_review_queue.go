@@ -0,0 +1,279 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+const (
+	reviewQueueDefaultLimit = 50
+	reviewQueueMaxLimit     = 100
+
+	// reviewQueueRepositoryLimit limits open pull requests fetched from a single repository.
+	reviewQueueRepositoryLimit = 50
+
+	// reviewQueueConcurrency limits repositories looked at the same time.
+	reviewQueueConcurrency = 8
+
+	// reviewQueueCommentsPageLen is the number of comments read per page when counting unresolved comments.
+	reviewQueueCommentsPageLen = 100
+
+	// reviewQueueFields adds reviewers and participants that pull request lists omit by default.
+	reviewQueueFields = "+values.reviewers,+values.participants"
+)
+
+// Roles of the user in a pull request of the review queue.
+const (
+	ReviewQueueRoleReviewer = "reviewer"
+	ReviewQueueRoleAuthor   = "author"
+)
+
+// BitbucketReviewQueueParams contains parameters for building the review queue of the user.
+type BitbucketReviewQueueParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Workspace to look in (optional if repositories are given or watched repositories are configured)
+	Workspace string `json:"workspace,omitempty"`
+
+	// Repositories to look in as full names (workspace/repo_slug) or slugs of the workspace (optional)
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Maximum number of pull requests to return (optional, defaults to 50, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// ReviewQueue lists open pull requests that need attention of the user.
+type ReviewQueue struct {
+	User bitbucket.Account `json:"user"`
+
+	// Items are pull requests needing attention, least recently updated first.
+	Items []ReviewQueueItem `json:"items"`
+
+	// Truncated is true when more pull requests need attention than listed.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// SearchedRepositories is the number of repositories looked at.
+	SearchedRepositories int `json:"searched_repositories"`
+
+	// RepositoriesTruncated is true when the workspace has more repositories than looked at.
+	RepositoriesTruncated bool `json:"repositories_truncated,omitempty"`
+
+	// FailedRepositories could not be looked at, pull requests of other repositories are listed regardless.
+	FailedRepositories []FailedRepository `json:"failed_repositories,omitempty"`
+}
+
+// ReviewQueueItem is a pull request that needs attention of the user.
+type ReviewQueueItem struct {
+	// Repository full name (workspace/repo_slug)
+	Repository string `json:"repository"`
+
+	// Role of the user: reviewer or author
+	Role string `json:"role"`
+
+	// Reasons the pull request needs attention
+	Reasons []string `json:"reasons"`
+
+	// UnresolvedComments is the number of unresolved comment threads of others, only for authors.
+	UnresolvedComments int `json:"unresolved_comments,omitempty"`
+
+	// FailingBuilds are names of failed builds, only for authors.
+	FailingBuilds []string `json:"failing_builds,omitempty"`
+
+	PullRequest bitbucket.PullRequest `json:"pull_request"`
+}
+
+// GetReviewQueue lists open pull requests where the user is a reviewer and has not approved yet,
+// or the author and has unresolved comments or failing builds. Repositories are looked at concurrently,
+// repositories that fail are reported and the queue of the others is returned.
+func (s *BitbucketService) GetReviewQueue(
+	ctx context.Context,
+	params BitbucketReviewQueueParams,
+) (*ReviewQueue, error) {
+	s.logger.InfoContext(ctx, "Building review queue",
+		slog.String("workspace", params.Workspace),
+		slog.Int("repositories", len(params.Repositories)))
+
+	limit := min(params.Limit, reviewQueueMaxLimit)
+	if limit <= 0 {
+		limit = reviewQueueDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	currentUser, err := s.client.GetCurrentUser(ctx, tokenProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser.User.UUID == "" {
+		return nil, errors.New("current user has no UUID, review queue requires a user token")
+	}
+	repositories, repositoriesTruncated, err := s.resolveRepositoryScope(
+		ctx, tokenProvider, params.Workspace, params.Repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	items, failedRepositories, err := collectPerRepository(ctx, s.logger, repositories, reviewQueueConcurrency,
+		func(ctx context.Context, repository repositoryRef) ([]ReviewQueueItem, error) {
+			return s.getRepositoryReviewQueue(ctx, tokenProvider, repository, currentUser.User.UUID)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	slices.SortStableFunc(items, func(a, b ReviewQueueItem) int {
+		return cmp.Compare(pullRequestUpdatedOn(a.PullRequest), pullRequestUpdatedOn(b.PullRequest))
+	})
+	return &ReviewQueue{
+		User:                  currentUser.User,
+		Items:                 items[:min(len(items), limit)],
+		Truncated:             len(items) > limit,
+		SearchedRepositories:  len(repositories),
+		RepositoriesTruncated: repositoriesTruncated,
+		FailedRepositories:    failedRepositories,
+	}, nil
+}
+
+// getRepositoryReviewQueue returns open pull requests of a repository that need attention of the user.
+func (s *BitbucketService) getRepositoryReviewQueue(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	repository repositoryRef,
+	userUUID string,
+) ([]ReviewQueueItem, error) {
+	quotedUUID := strconv.Quote(userUUID)
+	pullRequests, err := s.client.ListPullRequests(ctx, tokenProvider, bitbucket.ListPullRequestsParams{
+		Workspace: repository.Workspace,
+		RepoSlug:  repository.Slug,
+		States:    []string{"OPEN"},
+		Query:     "reviewers.uuid = " + quotedUUID + " OR author.uuid = " + quotedUUID,
+		Fields:    reviewQueueFields,
+		PageLen:   reviewQueueRepositoryLimit,
+		Limit:     reviewQueueRepositoryLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	var items []ReviewQueueItem
+	for _, pr := range pullRequests {
+		if pr.Author != nil && pr.Author.UUID == userUUID {
+			item, authorErr := s.getAuthorReviewQueueItem(ctx, tokenProvider, repository, pr, userUUID)
+			if authorErr != nil {
+				return nil, authorErr
+			}
+			if len(item.Reasons) > 0 {
+				items = append(items, item)
+			}
+			continue
+		}
+		if reasons := reviewerQueueReasons(pr, userUUID); len(reasons) > 0 {
+			items = append(items, ReviewQueueItem{
+				Repository:  repository.fullName(),
+				Role:        ReviewQueueRoleReviewer,
+				Reasons:     reasons,
+				PullRequest: pr,
+			})
+		}
+	}
+	return items, nil
+}
+
+// reviewerQueueReasons returns why a pull request needs a review of the user, none when approved.
+func reviewerQueueReasons(pr bitbucket.PullRequest, userUUID string) []string {
+	participant, found := lo.Find(pr.Participants, func(p bitbucket.Participant) bool {
+		return p.User.UUID == userUUID
+	})
+	switch {
+	case found && participant.Approved:
+		return nil
+	case found && participant.State == participantStateChangesRequested:
+		return []string{"you requested changes"}
+	default:
+		return []string{"awaiting your approval"}
+	}
+}
+
+// getAuthorReviewQueueItem returns the review queue item of a pull request authored by the user.
+// The item has no reasons when there are no unresolved comments of others and no failing builds.
+func (s *BitbucketService) getAuthorReviewQueueItem(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	repository repositoryRef,
+	pr bitbucket.PullRequest,
+	userUUID string,
+) (ReviewQueueItem, error) {
+	item := ReviewQueueItem{
+		Repository:  repository.fullName(),
+		Role:        ReviewQueueRoleAuthor,
+		PullRequest: pr,
+	}
+
+	if pr.CommentCount > 0 {
+		unresolved, err := s.countUnresolvedComments(ctx, tokenProvider, repository, pr.ID, userUUID)
+		if err != nil {
+			return item, err
+		}
+		item.UnresolvedComments = unresolved
+	}
+	if item.UnresolvedComments > 0 {
+		item.Reasons = append(item.Reasons, fmt.Sprintf("%d unresolved comments", item.UnresolvedComments))
+	}
+
+	statuses, err := s.listHeadCommitStatuses(ctx, tokenProvider, repository.Workspace, repository.Slug, &pr)
+	if err != nil {
+		return item, err
+	}
+	for _, status := range statuses {
+		if status.State == bitbucket.CommitStatusStateFailed {
+			item.FailingBuilds = append(item.FailingBuilds, lo.CoalesceOrEmpty(status.Name, status.Key))
+		}
+	}
+	if len(item.FailingBuilds) > 0 {
+		item.Reasons = append(item.Reasons, fmt.Sprintf("%d failing builds", len(item.FailingBuilds)))
+	}
+	return item, nil
+}
+
+// countUnresolvedComments counts unresolved top level comments of others on all pages of pull request comments.
+func (s *BitbucketService) countUnresolvedComments(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	repository repositoryRef,
+	prID int,
+	userUUID string,
+) (int, error) {
+	unresolved := 0
+	for page := 1; ; page++ {
+		comments, err := s.client.ListPRComments(ctx, tokenProvider, bitbucket.ListPRCommentsParams{
+			Workspace: repository.Workspace,
+			RepoSlug:  repository.Slug,
+			PRID:      int64(prID),
+			Page:      page,
+			PageLen:   reviewQueueCommentsPageLen,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list comments of pull request %d: %w", prID, err)
+		}
+		for _, comment := range comments.Values {
+			if comment.Parent != nil || comment.Pending || bitbucket.ResolvedStateFromResolutionJSON(comment.Resolution) {
+				continue
+			}
+			if comment.Author != nil && comment.Author.UUID == userUUID {
+				continue
+			}
+			unresolved++
+		}
+		if comments.Next == "" || len(comments.Values) == 0 {
+			return unresolved, nil
+		}
+	}
+}
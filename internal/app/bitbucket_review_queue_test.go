@@ -0,0 +1,252 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_GetReviewQueue(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	at := func(hours int) *time.Time {
+		value := time.Date(2026, 1, 1, hours, 0, 0, 0, time.UTC)
+		return &value
+	}
+
+	t.Run("should collect pull requests needing attention across repositories", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		accountName := "account-" + faker.Username()
+		tokenProvider := setupTokenProvider(t, deps, accountName)
+		me := bitbucket.Account{UUID: "{" + faker.UUIDHyphenated() + "}", DisplayName: faker.Name()}
+		other := bitbucket.PullRequestAuthor{UUID: "{" + faker.UUIDHyphenated() + "}"}
+		query := `reviewers.uuid = "` + me.UUID + `" OR author.uuid = "` + me.UUID + `"`
+
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, tokenProvider).
+			Return(&bitbucket.CurrentUser{User: me}, nil)
+
+		toReview := bitbucket.PullRequest{ID: 1, Author: &other, UpdatedOn: at(5)}
+		changesRequested := bitbucket.PullRequest{
+			ID:     2,
+			Author: &other,
+			Participants: []bitbucket.Participant{
+				{User: bitbucket.PullRequestAuthor{UUID: me.UUID}, State: participantStateChangesRequested},
+			},
+			UpdatedOn: at(1),
+		}
+		approved := bitbucket.PullRequest{
+			ID:           3,
+			Author:       &other,
+			Participants: []bitbucket.Participant{{User: bitbucket.PullRequestAuthor{UUID: me.UUID}, Approved: true}},
+		}
+		mine := bitbucket.PullRequest{
+			ID:           4,
+			Author:       &bitbucket.PullRequestAuthor{UUID: me.UUID},
+			CommentCount: 4,
+			UpdatedOn:    at(3),
+			Source: bitbucket.PullRequestSource{
+				Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()},
+			},
+		}
+		mineClean := bitbucket.PullRequest{
+			ID:     5,
+			Author: &bitbucket.PullRequestAuthor{UUID: me.UUID},
+			Source: bitbucket.PullRequestSource{
+				Commit: &bitbucket.PullRequestCommit{Hash: faker.UUIDDigit()},
+			},
+		}
+
+		expectList := func(workspace, slug string, result []bitbucket.PullRequest) {
+			mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, bitbucket.ListPullRequestsParams{
+				Workspace: workspace,
+				RepoSlug:  slug,
+				States:    []string{"OPEN"},
+				Query:     query,
+				Fields:    reviewQueueFields,
+				PageLen:   reviewQueueRepositoryLimit,
+				Limit:     reviewQueueRepositoryLimit,
+			}).Return(result, nil)
+		}
+		expectList("acme", "api", []bitbucket.PullRequest{toReview, approved, mine})
+		expectList("oss", "lib", []bitbucket.PullRequest{changesRequested, mineClean})
+
+		var firstComments, lastComments bitbucket.ListPRCommentsResponse
+		require.NoError(t, json.Unmarshal([]byte(`{"values": [
+			{"id": 1, "user": {"uuid": "`+other.UUID+`"}},
+			{"id": 2, "user": {"uuid": "`+other.UUID+`"}, "resolution": {"type": "comment_resolution"}}
+		], "next": "https://api.bitbucket.org/2.0/page=2"}`), &firstComments))
+		require.NoError(t, json.Unmarshal([]byte(`{"values": [
+			{"id": 3, "user": {"uuid": "`+other.UUID+`"}, "parent": {"id": 1}},
+			{"id": 4, "user": {"uuid": "`+me.UUID+`"}},
+			{"id": 5, "user": {"uuid": "`+other.UUID+`"}}
+		]}`), &lastComments))
+		for page, comments := range []*bitbucket.ListPRCommentsResponse{&firstComments, &lastComments} {
+			mockClient.EXPECT().ListPRComments(mock.Anything, tokenProvider, bitbucket.ListPRCommentsParams{
+				Workspace: "acme",
+				RepoSlug:  "api",
+				PRID:      4,
+				Page:      page + 1,
+				PageLen:   reviewQueueCommentsPageLen,
+			}).Return(comments, nil)
+		}
+		mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: "acme",
+			RepoSlug:  "api",
+			Commit:    mine.Source.Commit.Hash,
+		}).Return([]bitbucket.CommitStatus{
+			{Key: "lint", State: bitbucket.CommitStatusStateFailed},
+			{Key: "test", Name: "Unit tests", State: bitbucket.CommitStatusStateSuccessful},
+		}, nil)
+		mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: "oss",
+			RepoSlug:  "lib",
+			Commit:    mineClean.Source.Commit.Hash,
+		}).Return([]bitbucket.CommitStatus{{Key: "test", State: bitbucket.CommitStatusStateSuccessful}}, nil)
+		service := NewBitbucketService(deps)
+
+		queue, err := service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{
+			AccountName:  accountName,
+			Repositories: []string{"acme/api", "oss/lib"},
+			Limit:        2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ReviewQueue{
+			User: me,
+			Items: []ReviewQueueItem{
+				{
+					Repository:  "oss/lib",
+					Role:        ReviewQueueRoleReviewer,
+					Reasons:     []string{"you requested changes"},
+					PullRequest: changesRequested,
+				},
+				{
+					Repository:         "acme/api",
+					Role:               ReviewQueueRoleAuthor,
+					Reasons:            []string{"2 unresolved comments", "1 failing builds"},
+					UnresolvedComments: 2,
+					FailingBuilds:      []string{"lint"},
+					PullRequest:        mine,
+				},
+			},
+			Truncated:            true,
+			SearchedRepositories: 2,
+		}, queue)
+	})
+
+	t.Run("should use watched repositories when no scope is given", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		deps.WatchedRepositories = []string{"acme/api"}
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		setupTokenProvider(t, deps, "")
+		me := bitbucket.Account{UUID: "{" + faker.UUIDHyphenated() + "}"}
+
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, mock.Anything).
+			Return(&bitbucket.CurrentUser{User: me}, nil)
+		mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(p bitbucket.ListPullRequestsParams) bool {
+				return p.Workspace == "acme" && p.RepoSlug == "api"
+			},
+		)).Return([]bitbucket.PullRequest{}, nil)
+		service := NewBitbucketService(deps)
+
+		queue, err := service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{})
+
+		require.NoError(t, err)
+		assert.Empty(t, queue.Items)
+		assert.Equal(t, 1, queue.SearchedRepositories)
+	})
+
+	t.Run("should list pull requests of other repositories when a repository fails", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		setupTokenProvider(t, deps, "")
+		me := bitbucket.Account{UUID: "{" + faker.UUIDHyphenated() + "}"}
+		other := bitbucket.PullRequestAuthor{UUID: "{" + faker.UUIDHyphenated() + "}"}
+		listErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, mock.Anything).
+			Return(&bitbucket.CurrentUser{User: me}, nil)
+		mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(p bitbucket.ListPullRequestsParams) bool { return p.RepoSlug == "api" },
+		)).Return(nil, listErr)
+		toReview := bitbucket.PullRequest{ID: 1, Author: &other}
+		mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+			func(p bitbucket.ListPullRequestsParams) bool { return p.RepoSlug == "web" },
+		)).Return([]bitbucket.PullRequest{toReview}, nil)
+		service := NewBitbucketService(deps)
+
+		queue, err := service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{
+			Workspace:    "acme",
+			Repositories: []string{"api", "web"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []ReviewQueueItem{{
+			Repository:  "acme/web",
+			Role:        ReviewQueueRoleReviewer,
+			Reasons:     []string{"awaiting your approval"},
+			PullRequest: toReview,
+		}}, queue.Items)
+		assert.Equal(t, 2, queue.SearchedRepositories)
+		assert.Equal(t, []FailedRepository{{
+			Repository: "acme/api",
+			Error:      "failed to list pull requests: " + listErr.Error(),
+		}}, queue.FailedRepositories)
+	})
+
+	t.Run("should return error when all repositories fail", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		setupTokenProvider(t, deps, "")
+		listErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, mock.Anything).
+			Return(&bitbucket.CurrentUser{User: bitbucket.Account{UUID: faker.UUIDHyphenated()}}, nil)
+		mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.Anything).Return(nil, listErr)
+		service := NewBitbucketService(deps)
+
+		queue, err := service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{
+			Workspace:    "acme",
+			Repositories: []string{"api"},
+		})
+
+		require.ErrorIs(t, err, listErr)
+		require.ErrorContains(t, err, "acme/api")
+		assert.Nil(t, queue)
+	})
+
+	t.Run("should require a scope", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		setupTokenProvider(t, deps, "")
+
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, mock.Anything).
+			Return(&bitbucket.CurrentUser{User: bitbucket.Account{UUID: faker.UUIDHyphenated()}}, nil)
+		service := NewBitbucketService(deps)
+
+		queue, err := service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{Repositories: []string{"api"}})
+
+		require.ErrorContains(t, err, "invalid repository")
+		assert.Nil(t, queue)
+
+		_, err = service.GetReviewQueue(t.Context(), BitbucketReviewQueueParams{})
+		require.ErrorContains(t, err, "workspace or repositories are required")
+	})
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+const (
+	codeSearchDefaultLimit = 20
+	codeSearchMaxLimit     = 100
+
+	// codeSearchMaxPageLen is the largest page of results Bitbucket returns.
+	codeSearchMaxPageLen = 100
+
+	// codeSearchFields adds repositories of matched files that search results omit by default.
+	codeSearchFields = "+values.file.commit.repository"
+)
+
+// BitbucketSearchCodeParams contains parameters for searching code across repositories of a workspace.
+type BitbucketSearchCodeParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Workspace to search in
+	Workspace string `json:"workspace"`
+
+	// Search query, supports Bitbucket code search syntax such as "repo:", "lang:" and "ext:"
+	Query string `json:"query"`
+
+	// Maximum number of files to return (optional, defaults to 20, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// CodeSearchResults lists files matching a code search query.
+type CodeSearchResults struct {
+	Workspace string `json:"workspace"`
+	Query     string `json:"query"`
+
+	// Files matching the query, most relevant first.
+	Files []CodeSearchFile `json:"files"`
+
+	// Truncated is true when more files match than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// CodeSearchFile is a file matching a code search query.
+type CodeSearchFile struct {
+	// Repository full name, e.g. "workspace/repo"
+	Repository string `json:"repository,omitempty"`
+	Path       string `json:"path"`
+
+	// PathMatched is true when the query matched the path of the file.
+	PathMatched bool `json:"path_matched,omitempty"`
+
+	// MatchCount is the number of matches in the content of the file.
+	MatchCount int `json:"match_count"`
+
+	// Snippets are groups of consecutive lines around matches in the content of the file.
+	Snippets [][]CodeSearchLine `json:"snippets,omitempty"`
+}
+
+// CodeSearchLine is a line of a snippet of a matched file.
+type CodeSearchLine struct {
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// SearchCode searches code across repositories of a workspace.
+func (s *BitbucketService) SearchCode(
+	ctx context.Context,
+	params BitbucketSearchCodeParams,
+) (*CodeSearchResults, error) {
+	s.logger.InfoContext(ctx, "Searching code",
+		slog.String("workspace", params.Workspace),
+		slog.String("query", params.Query))
+
+	if params.Workspace == "" {
+		return nil, errors.New("workspace is required")
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, errors.New("search query is required")
+	}
+	limit := min(params.Limit, codeSearchMaxLimit)
+	if limit <= 0 {
+		limit = codeSearchDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	results, err := s.client.SearchCode(ctx, tokenProvider, bitbucket.SearchCodeParams{
+		Workspace: params.Workspace,
+		Query:     params.Query,
+		Fields:    codeSearchFields,
+		PageLen:   min(limit+1, codeSearchMaxPageLen),
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	files := lo.Map(results[:min(len(results), limit)], func(result bitbucket.CodeSearchResult, _ int) CodeSearchFile {
+		return newCodeSearchFile(result)
+	})
+	return &CodeSearchResults{
+		Workspace: params.Workspace,
+		Query:     params.Query,
+		Files:     files,
+		Truncated: len(results) > limit,
+	}, nil
+}
+
+// newCodeSearchFile flattens highlighted segments of a search result into plain lines.
+func newCodeSearchFile(result bitbucket.CodeSearchResult) CodeSearchFile {
+	file := CodeSearchFile{
+		MatchCount: result.ContentMatchCount,
+		PathMatched: lo.SomeBy(result.PathMatches, func(segment bitbucket.CodeSearchSegment) bool {
+			return segment.Match
+		}),
+	}
+	if result.File != nil {
+		file.Path = result.File.Path
+		if result.File.Commit != nil && result.File.Commit.Repository != nil {
+			file.Repository = result.File.Commit.Repository.FullName
+		}
+	}
+	for _, match := range result.ContentMatches {
+		snippet := make([]CodeSearchLine, 0, len(match.Lines))
+		for _, line := range match.Lines {
+			var text strings.Builder
+			matched := false
+			for _, segment := range line.Segments {
+				text.WriteString(segment.Text)
+				matched = matched || segment.Match
+			}
+			snippet = append(snippet, CodeSearchLine{Line: line.Line, Text: text.String(), Match: matched})
+		}
+		file.Snippets = append(file.Snippets, snippet)
+	}
+	return file
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_SearchCode(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeParams := func() BitbucketSearchCodeParams {
+		return BitbucketSearchCodeParams{
+			AccountName: "account-" + faker.Username(),
+			Workspace:   "ws-" + faker.Username(),
+			Query:       "parseDiff lang:go",
+		}
+	}
+
+	t.Run("should flatten matched lines and report truncation", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Limit = 1
+		tokenProvider := setupTokenProvider(t, deps, params.AccountName)
+
+		mockClient.EXPECT().SearchCode(mock.Anything, tokenProvider, bitbucket.SearchCodeParams{
+			Workspace: params.Workspace,
+			Query:     params.Query,
+			Fields:    codeSearchFields,
+			PageLen:   2,
+			Limit:     2,
+		}).Return([]bitbucket.CodeSearchResult{
+			{
+				ContentMatchCount: 1,
+				ContentMatches: []bitbucket.CodeSearchMatch{{Lines: []bitbucket.CodeSearchLine{
+					{Line: 2, Segments: nil},
+					{Line: 3, Segments: []bitbucket.CodeSearchSegment{
+						{Text: "func "}, {Text: "parseDiff", Match: true}, {Text: "() {"},
+					}},
+				}}},
+				PathMatches: []bitbucket.CodeSearchSegment{{Text: "diff.go"}},
+				File: &bitbucket.CodeSearchFile{Path: "diff.go", Commit: &bitbucket.CodeSearchCommit{
+					Repository: &bitbucket.PullRequestRepository{FullName: params.Workspace + "/demo"},
+				}},
+			},
+			{File: &bitbucket.CodeSearchFile{Path: "other.go"}},
+		}, nil)
+		service := NewBitbucketService(deps)
+
+		results, err := service.SearchCode(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &CodeSearchResults{
+			Workspace: params.Workspace,
+			Query:     params.Query,
+			Files: []CodeSearchFile{{
+				Repository: params.Workspace + "/demo",
+				Path:       "diff.go",
+				MatchCount: 1,
+				Snippets: [][]CodeSearchLine{{
+					{Line: 2, Text: ""},
+					{Line: 3, Text: "func parseDiff() {", Match: true},
+				}},
+			}},
+			Truncated: true,
+		}, results)
+	})
+
+	t.Run("should report files matched by path", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		tokenProvider := setupTokenProvider(t, deps, params.AccountName)
+
+		mockClient.EXPECT().SearchCode(mock.Anything, tokenProvider, mock.MatchedBy(
+			func(p bitbucket.SearchCodeParams) bool {
+				return p.Limit == codeSearchDefaultLimit+1
+			},
+		)).Return([]bitbucket.CodeSearchResult{{
+			PathMatches: []bitbucket.CodeSearchSegment{{Text: "parseDiff", Match: true}, {Text: ".md"}},
+			File:        &bitbucket.CodeSearchFile{Path: "parseDiff.md"},
+		}}, nil)
+		service := NewBitbucketService(deps)
+
+		results, err := service.SearchCode(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, []CodeSearchFile{{Path: "parseDiff.md", PathMatched: true}}, results.Files)
+		assert.False(t, results.Truncated)
+	})
+
+	t.Run("should return error when search fails", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		setupTokenProvider(t, deps, params.AccountName)
+		searchErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().SearchCode(mock.Anything, mock.Anything, mock.Anything).Return(nil, searchErr)
+		service := NewBitbucketService(deps)
+
+		results, err := service.SearchCode(t.Context(), params)
+
+		require.ErrorIs(t, err, searchErr)
+		assert.Nil(t, results)
+	})
+
+	t.Run("should validate parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		noWorkspace := makeParams()
+		noWorkspace.Workspace = ""
+		_, err := service.SearchCode(t.Context(), noWorkspace)
+		require.ErrorContains(t, err, "workspace is required")
+
+		noQuery := makeParams()
+		noQuery.Query = " "
+		_, err = service.SearchCode(t.Context(), noQuery)
+		require.ErrorContains(t, err, "search query is required")
+	})
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+const (
+	// directoryDefaultDepth lists only direct children of a directory.
+	directoryDefaultDepth = 1
+
+	// directoryMaxDepth limits recursion so that listings of large repositories stay small.
+	directoryMaxDepth = 10
+
+	directoryDefaultLimit = 200
+	directoryMaxLimit     = 1000
+
+	// directoryPageLen is the page size used when listing directories.
+	directoryPageLen = 100
+)
+
+// BitbucketListDirectoryParams contains parameters for listing a directory of a repository.
+type BitbucketListDirectoryParams struct {
+	BitbucketRepositoryParams
+
+	// Commit hash or branch name to list the directory at
+	Ref string `json:"ref"`
+
+	// Directory path (optional, defaults to the repository root)
+	Path string `json:"path,omitempty"`
+
+	// Depth of nested directories to list (optional, defaults to 1, max 10)
+	Depth int `json:"depth,omitempty"`
+
+	// Maximum number of entries to return (optional, defaults to 200, max 1000)
+	Limit int `json:"limit,omitempty"`
+}
+
+// DirectoryListing is the content of a repository directory.
+type DirectoryListing struct {
+	Ref  string `json:"ref"`
+	Path string `json:"path"`
+
+	// Entries are files and directories of the directory. When the path points to a file,
+	// the only entry is the file itself.
+	Entries []bitbucket.TreeEntry `json:"entries"`
+
+	// Truncated is true when there are more entries than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ListDirectory lists files and directories of a repository directory, optionally recursing into
+// nested directories. Paths of files are resolved to their metadata.
+func (s *BitbucketService) ListDirectory(
+	ctx context.Context,
+	params BitbucketListDirectoryParams,
+) (*DirectoryListing, error) {
+	path := strings.Trim(params.Path, "/")
+	s.logger.InfoContext(ctx, "Listing directory",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("ref", params.Ref),
+		slog.String("path", path),
+		slog.Int("depth", params.Depth))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Ref == "" {
+		return nil, errors.New("ref is required")
+	}
+	depth := min(max(params.Depth, directoryDefaultDepth), directoryMaxDepth)
+	limit := min(params.Limit, directoryMaxLimit)
+	if limit <= 0 {
+		limit = directoryDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	listing := &DirectoryListing{Ref: params.Ref, Path: path}
+
+	if path != "" {
+		meta, err := s.client.GetFileMeta(ctx, tokenProvider, bitbucket.GetFileMetaParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Ref,
+			Path:      path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata of %s: %w", path, err)
+		}
+		if !meta.IsDirectory() {
+			listing.Entries = []bitbucket.TreeEntry{*meta}
+			return listing, nil
+		}
+	}
+
+	entries, err := s.client.ListDirectory(ctx, tokenProvider, bitbucket.ListDirectoryParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Commit:    params.Ref,
+		Path:      path,
+		MaxDepth:  depth,
+		PageLen:   directoryPageLen,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+
+	listing.Truncated = len(entries) > limit
+	listing.Entries = entries[:min(len(entries), limit)]
+	if listing.Entries == nil {
+		listing.Entries = []bitbucket.TreeEntry{}
+	}
+	return listing, nil
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_ListDirectory(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeParams := func() BitbucketListDirectoryParams {
+		return BitbucketListDirectoryParams{
+			BitbucketRepositoryParams: BitbucketRepositoryParams{
+				AccountName: "account-" + faker.Username(),
+				RepoOwner:   "owner-" + faker.Username(),
+				RepoName:    "repo-" + faker.Username(),
+			},
+			Ref: faker.UUIDDigit(),
+		}
+	}
+
+	newFiles := func(count int) []bitbucket.TreeEntry {
+		return lo.Times(count, func(_ int) bitbucket.TreeEntry {
+			return bitbucket.TreeEntry{Type: bitbucket.TreeEntryTypeFile, Path: faker.Word() + ".go"}
+		})
+	}
+
+	t.Run("should list repository root with defaults", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		entries := newFiles(2)
+
+		mockClient.EXPECT().ListDirectory(mock.Anything, tokenProvider, bitbucket.ListDirectoryParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Ref,
+			MaxDepth:  directoryDefaultDepth,
+			PageLen:   directoryPageLen,
+			Limit:     directoryDefaultLimit + 1,
+		}).Return(entries, nil)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DirectoryListing{Ref: params.Ref, Entries: entries}, listing)
+	})
+
+	t.Run("should list nested directory recursively and truncate", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Path = "/internal/app/"
+		params.Depth = directoryMaxDepth + 1
+		params.Limit = 2
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		entries := newFiles(3)
+
+		mockClient.EXPECT().GetFileMeta(mock.Anything, tokenProvider, bitbucket.GetFileMetaParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Ref,
+			Path:      "internal/app",
+		}).Return(&bitbucket.TreeEntry{Type: bitbucket.TreeEntryTypeDirectory, Path: "internal/app"}, nil)
+		mockClient.EXPECT().ListDirectory(mock.Anything, tokenProvider, bitbucket.ListDirectoryParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    params.Ref,
+			Path:      "internal/app",
+			MaxDepth:  directoryMaxDepth,
+			PageLen:   directoryPageLen,
+			Limit:     3,
+		}).Return(entries, nil)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DirectoryListing{
+			Ref:       params.Ref,
+			Path:      "internal/app",
+			Entries:   entries[:2],
+			Truncated: true,
+		}, listing)
+	})
+
+	t.Run("should return metadata of a file path", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Path = "go.mod"
+		tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		meta := &bitbucket.TreeEntry{Type: bitbucket.TreeEntryTypeFile, Path: params.Path, Size: 512}
+
+		mockClient.EXPECT().GetFileMeta(mock.Anything, tokenProvider, mock.Anything).Return(meta, nil)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, []bitbucket.TreeEntry{*meta}, listing.Entries)
+	})
+
+	t.Run("should return empty entries of empty directory", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+
+		mockClient.EXPECT().ListDirectory(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, []bitbucket.TreeEntry{}, listing.Entries)
+	})
+
+	t.Run("should fail when metadata can not be read", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		params.Path = "missing/" + faker.Word()
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		expectedErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().GetFileMeta(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, listing)
+	})
+
+	t.Run("should fail when directory can not be listed", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		params := makeParams()
+		setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+		expectedErr := errors.New(faker.Sentence())
+
+		mockClient.EXPECT().ListDirectory(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+		service := NewBitbucketService(deps)
+
+		listing, err := service.ListDirectory(t.Context(), params)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, listing)
+	})
+
+	t.Run("should validate required parameters", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+
+		noOwner := makeParams()
+		noOwner.RepoOwner = ""
+		_, err := service.ListDirectory(t.Context(), noOwner)
+		require.ErrorContains(t, err, "repository owner is required")
+
+		noRef := makeParams()
+		noRef.Ref = ""
+		_, err = service.ListDirectory(t.Context(), noRef)
+		require.ErrorContains(t, err, "ref is required")
+	})
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// squashMessageCommitsPageLen is the page size used when listing commits of a pull request.
+const squashMessageCommitsPageLen = 50
+
+// defaultSquashMessageTemplate is used when the repository does not provide its own template.
+const defaultSquashMessageTemplate = `{{.Title}} (pull request #{{.PullRequestID}})
+{{- if .JiraKeys}}
+
+{{join .JiraKeys " "}}
+{{- end}}
+{{- if .CommitSubjects}}
+{{range .CommitSubjects}}
+* {{.}}
+{{- end}}
+{{- end}}
+{{- if .CoAuthors}}
+{{range .CoAuthors}}
+Co-authored-by: {{.}}
+{{- end}}
+{{- end}}`
+
+var (
+	jiraKeyPattern         = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[1-9][0-9]*\b`)
+	coAuthorTrailerPattern = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.+?)\s*$`)
+)
+
+// SquashMessageData is the data available to squash message templates.
+type SquashMessageData struct {
+	PullRequestID     int
+	Title             string
+	SourceBranch      string
+	DestinationBranch string
+
+	// CommitSubjects are the first lines of the pull request commits, oldest first. Merge commits are skipped.
+	CommitSubjects []string
+
+	// JiraKeys are the Jira issue keys found in the source branch, title and commit messages.
+	JiraKeys []string
+
+	// CoAuthors are the commit authors other than the pull request author, e.g. "Jane Doe <jane@example.com>".
+	CoAuthors []string
+}
+
+// composeMergeMessage generates the merge commit message of a pull request from its title, commits
+// and linked Jira keys. The template is read from the destination branch of the repository when present.
+func (s *BitbucketService) composeMergeMessage(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketMergePRParams,
+) (string, error) {
+	pr, err := s.client.GetPR(ctx, tokenProvider, bitbucket.GetPRParams{
+		Username:      params.RepoOwner,
+		RepoSlug:      params.RepoName,
+		PullRequestID: params.PullRequestID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	commits, err := s.client.ListPRCommits(ctx, tokenProvider, bitbucket.ListPRCommitsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		PullReqID: params.PullRequestID,
+		PageLen:   squashMessageCommitsPageLen,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	data := newSquashMessageData(pr, commits)
+
+	templateText, err := s.loadSquashMessageTemplate(ctx, tokenProvider, params, data.DestinationBranch)
+	if err != nil {
+		return "", err
+	}
+
+	return renderSquashMessage(templateText, data)
+}
+
+// loadSquashMessageTemplate reads the repository specific template from the destination branch
+// and falls back to the default template when the repository does not have one.
+func (s *BitbucketService) loadSquashMessageTemplate(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketMergePRParams,
+	destinationBranch string,
+) (string, error) {
+	if s.squashMessageTemplatePath == "" || destinationBranch == "" {
+		return defaultSquashMessageTemplate, nil
+	}
+
+	content, err := s.client.GetFileContent(ctx, tokenProvider, bitbucket.GetFileContentParams{
+		RepoOwner:  params.RepoOwner,
+		RepoName:   params.RepoName,
+		CommitHash: destinationBranch,
+		FilePath:   s.squashMessageTemplatePath,
+	})
+	if err != nil {
+		var httpErr *middleware.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			s.logger.DebugContext(ctx, "Repository has no squash message template, using default",
+				slog.String("path", s.squashMessageTemplatePath))
+			return defaultSquashMessageTemplate, nil
+		}
+		return "", fmt.Errorf("failed to get squash message template: %w", err)
+	}
+
+	return content.Content, nil
+}
+
+func newSquashMessageData(pr *bitbucket.PullRequest, commits []bitbucket.Commit) SquashMessageData {
+	data := SquashMessageData{
+		PullRequestID: pr.ID,
+		Title:         pr.Title,
+		SourceBranch:  pr.Source.Branch.Name,
+	}
+	if pr.Destination != nil {
+		data.DestinationBranch = pr.Destination.Branch.Name
+	}
+
+	jiraKeys := newOrderedSet()
+	jiraKeys.add(jiraKeyPattern.FindAllString(data.SourceBranch, -1)...)
+	jiraKeys.add(jiraKeyPattern.FindAllString(data.Title, -1)...)
+	coAuthors := newOrderedSet()
+	authorEmails := pullRequestAuthorEmails(pr.Author, commits)
+
+	// Commits are listed newest first
+	for _, commit := range slices.Backward(commits) {
+		jiraKeys.add(jiraKeyPattern.FindAllString(commit.Message, -1)...)
+		for _, match := range coAuthorTrailerPattern.FindAllStringSubmatch(commit.Message, -1) {
+			coAuthors.add(match[1])
+		}
+		// Merge commits only bring in changes of others, e.g. of the destination branch
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		if commit.Author != nil && commit.Author.Raw != "" &&
+			!isPullRequestAuthor(pr.Author, commit.Author, authorEmails) {
+			coAuthors.add(commit.Author.Raw)
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		if subject = strings.TrimSpace(subject); subject != "" {
+			data.CommitSubjects = append(data.CommitSubjects, subject)
+		}
+	}
+
+	data.JiraKeys = jiraKeys.values
+	data.CoAuthors = coAuthors.values
+	return data
+}
+
+// isPullRequestAuthor reports whether the commit author is the account that opened the pull request.
+// Authors not linked to an account are matched by emails the account committed with or by name.
+func isPullRequestAuthor(
+	prAuthor *bitbucket.PullRequestAuthor,
+	commitAuthor *bitbucket.CommitAuthor,
+	authorEmails map[string]bool,
+) bool {
+	if prAuthor == nil {
+		return false
+	}
+	if commitAuthor.User != nil {
+		return isPullRequestAuthorAccount(prAuthor, commitAuthor.User)
+	}
+	name, email := parseRawCommitAuthor(commitAuthor.Raw)
+	return (email != "" && authorEmails[email]) ||
+		(prAuthor.DisplayName != "" && strings.EqualFold(name, prAuthor.DisplayName))
+}
+
+func isPullRequestAuthorAccount(prAuthor *bitbucket.PullRequestAuthor, account *bitbucket.Account) bool {
+	return (prAuthor.UUID != "" && prAuthor.UUID == account.UUID) ||
+		(prAuthor.AccountID != "" && prAuthor.AccountID == account.AccountID)
+}
+
+// pullRequestAuthorEmails returns emails of commits authored by the pull request author's account.
+func pullRequestAuthorEmails(prAuthor *bitbucket.PullRequestAuthor, commits []bitbucket.Commit) map[string]bool {
+	emails := map[string]bool{}
+	if prAuthor == nil {
+		return emails
+	}
+	for _, commit := range commits {
+		if commit.Author == nil || commit.Author.User == nil ||
+			!isPullRequestAuthorAccount(prAuthor, commit.Author.User) {
+			continue
+		}
+		if _, email := parseRawCommitAuthor(commit.Author.Raw); email != "" {
+			emails[email] = true
+		}
+	}
+	return emails
+}
+
+// parseRawCommitAuthor splits a raw commit author "Jane Doe <jane@example.com>" into the name and
+// the lower cased email.
+func parseRawCommitAuthor(raw string) (string, string) {
+	name, rest, found := strings.Cut(raw, "<")
+	if !found {
+		return strings.TrimSpace(raw), ""
+	}
+	email, _, _ := strings.Cut(rest, ">")
+	return strings.TrimSpace(name), strings.ToLower(strings.TrimSpace(email))
+}
+
+func renderSquashMessage(templateText string, data SquashMessageData) (string, error) {
+	tmpl, err := template.New("squash-message").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("invalid squash message template: %w", err)
+	}
+
+	var sb strings.Builder
+	if err = tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render squash message: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// orderedSet keeps unique values in insertion order, comparing them case-insensitively.
+type orderedSet struct {
+	seen   map[string]struct{}
+	values []string
+}
+
+func newOrderedSet() *orderedSet {
+	return &orderedSet{seen: map[string]struct{}{}}
+}
+
+func (s *orderedSet) add(values ...string) {
+	for _, value := range values {
+		key := strings.ToLower(value)
+		if _, ok := s.seen[key]; ok {
+			continue
+		}
+		s.seen[key] = struct{}{}
+		s.values = append(s.values, value)
+	}
+}
@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_ComposeMergeMessage(t *testing.T) {
+	templatePath := ".atlacp/" + faker.Word() + ".tmpl"
+
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:                    NewMockbitbucketClient(t),
+			AuthFactory:               NewMockbitbucketAuthFactory(t),
+			RootLogger:                diag.RootTestLogger().With("test", t.Name()),
+			SquashMessageTemplatePath: templatePath,
+		}
+	}
+
+	makeParams := func(pr *bitbucket.PullRequest) BitbucketMergePRParams {
+		return BitbucketMergePRParams{
+			RepoOwner:      "owner-" + faker.Username(),
+			RepoName:       "repo-" + faker.Username(),
+			PullRequestID:  pr.ID,
+			MergeStrategy:  "squash",
+			ComposeMessage: true,
+		}
+	}
+
+	// setupMocks sets up expectations for a merge with composed message and returns the sent message.
+	setupMocks := func(
+		t *testing.T,
+		deps BitbucketServiceDeps,
+		params BitbucketMergePRParams,
+		pr *bitbucket.PullRequest,
+		commits []bitbucket.Commit,
+		template *bitbucket.FileContent,
+		templateErr error,
+		expectMerge bool,
+	) *string {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+			Username:      params.RepoOwner,
+			RepoSlug:      params.RepoName,
+			PullRequestID: params.PullRequestID,
+		}).Return(pr, nil)
+		mockClient.EXPECT().ListPRCommits(mock.Anything, tokenProvider, bitbucket.ListPRCommitsParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			PullReqID: params.PullRequestID,
+			PageLen:   squashMessageCommitsPageLen,
+		}).Return(commits, nil)
+		mockClient.EXPECT().GetFileContent(mock.Anything, tokenProvider, bitbucket.GetFileContentParams{
+			RepoOwner:  params.RepoOwner,
+			RepoName:   params.RepoName,
+			CommitHash: pr.Destination.Branch.Name,
+			FilePath:   templatePath,
+		}).Return(template, templateErr)
+
+		var sentMessage string
+		if expectMerge {
+			mockClient.EXPECT().
+				MergePR(mock.Anything, tokenProvider, mock.Anything).
+				RunAndReturn(func(
+					_ context.Context,
+					_ bitbucket.TokenProvider,
+					mergeParams bitbucket.MergePRParams,
+				) (*bitbucket.MergePRResult, error) {
+					sentMessage = mergeParams.MergeParameters.Message
+					return &bitbucket.MergePRResult{PullRequest: pr}, nil
+				})
+		}
+		return &sentMessage
+	}
+
+	notFoundErr := fmt.Errorf("get file content failed: %w", &middleware.HTTPError{StatusCode: http.StatusNotFound})
+
+	t.Run("should compose message with default template", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		pr := bitbucket.NewRandomPullRequest(func(pr *bitbucket.PullRequest) {
+			pr.Title = "Add login form"
+			pr.Source.Branch.Name = "feature/AUTH-12-login"
+		})
+		params := makeParams(pr)
+		commits := []bitbucket.Commit{
+			// Newest first
+			{
+				Message: "Fix review comments for WEB-7\n\nCo-authored-by: Pair Programmer <pair@example.com>",
+				Author: &bitbucket.CommitAuthor{
+					Raw:  "PR Author <author@example.com>",
+					User: &bitbucket.Account{UUID: pr.Author.UUID},
+				},
+			},
+			{
+				Message: "Merge branch 'main' into feature/AUTH-12-login",
+				Parents: []*bitbucket.Commit{{}, {}},
+				Author:  &bitbucket.CommitAuthor{Raw: "Other Dev <other@example.com>"},
+			},
+			{
+				Message: "Add form layout AUTH-12\n\nLonger description",
+				Author:  &bitbucket.CommitAuthor{Raw: "Other Dev <other@example.com>"},
+			},
+		}
+		sentMessage := setupMocks(t, deps, params, pr, commits, nil, notFoundErr, true)
+		service := NewBitbucketService(deps)
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(`Add login form (pull request #%d)
+
+AUTH-12 WEB-7
+
+* Add form layout AUTH-12
+* Fix review comments for WEB-7
+
+Co-authored-by: Other Dev <other@example.com>
+Co-authored-by: Pair Programmer <pair@example.com>`, pr.ID), *sentMessage)
+	})
+
+	t.Run("should not add pull request author or merge commit authors as co-authors", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		pr := bitbucket.NewRandomPullRequest(func(pr *bitbucket.PullRequest) {
+			pr.Title = "Add login form"
+			pr.Author.DisplayName = "PR Author"
+		})
+		params := makeParams(pr)
+		commits := []bitbucket.Commit{
+			// Newest first
+			{
+				Message: "Fix typo",
+				Author:  &bitbucket.CommitAuthor{Raw: "pr author <author@home.example.com>"},
+			},
+			{
+				Message: "Merge branch 'main' into feature",
+				Parents: []*bitbucket.Commit{{}, {}},
+				Author:  &bitbucket.CommitAuthor{Raw: "Release Bot <bot@example.com>"},
+			},
+			{
+				Message: "Add tests",
+				Author:  &bitbucket.CommitAuthor{Raw: "Author Laptop <Author@Example.com>"},
+			},
+			{
+				Message: "Add form",
+				Author: &bitbucket.CommitAuthor{
+					Raw:  "Author Work <author@example.com>",
+					User: &bitbucket.Account{AccountID: pr.Author.AccountID},
+				},
+			},
+		}
+		sentMessage := setupMocks(t, deps, params, pr, commits, nil, notFoundErr, true)
+		service := NewBitbucketService(deps)
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(`Add login form (pull request #%d)
+
+* Add form
+* Add tests
+* Fix typo`, pr.ID), *sentMessage)
+	})
+
+	t.Run("should compose message with repository template", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		pr := bitbucket.NewRandomPullRequest(func(pr *bitbucket.PullRequest) {
+			pr.Title = "PROJ-1 Improve " + faker.Word()
+		})
+		params := makeParams(pr)
+		commits := []bitbucket.Commit{{Message: "first"}, {Message: "second"}}
+		template := &bitbucket.FileContent{
+			Content: `[{{join .JiraKeys ","}}] {{.Title}} into {{.DestinationBranch}}: {{join .CommitSubjects "; "}}` + "\n",
+		}
+		sentMessage := setupMocks(t, deps, params, pr, commits, template, nil, true)
+		service := NewBitbucketService(deps)
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t,
+			fmt.Sprintf("[PROJ-1] %s into %s: second; first", pr.Title, pr.Destination.Branch.Name),
+			*sentMessage,
+		)
+	})
+
+	t.Run("should fail when repository template is invalid", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		pr := bitbucket.NewRandomPullRequest()
+		params := makeParams(pr)
+		setupMocks(t, deps, params, pr, nil, &bitbucket.FileContent{Content: "{{.Title"}, nil, false)
+		service := NewBitbucketService(deps)
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.ErrorContains(t, err, "invalid squash message template")
+	})
+
+	t.Run("should fail when template can not be read", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		pr := bitbucket.NewRandomPullRequest()
+		params := makeParams(pr)
+		templateErr := errors.New(faker.Sentence())
+		setupMocks(t, deps, params, pr, nil, nil, templateErr, false)
+		service := NewBitbucketService(deps)
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.ErrorIs(t, err, templateErr)
+	})
+
+	t.Run("should not allow message together with composed message", func(t *testing.T) {
+		service := NewBitbucketService(makeMockDeps(t))
+		params := makeParams(bitbucket.NewRandomPullRequest())
+		params.Message = faker.Sentence()
+
+		_, err := service.MergePR(t.Context(), params)
+
+		require.ErrorContains(t, err, "message can not be provided when composing the merge message")
+	})
+}
@@ -0,0 +1,436 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+const (
+	// staleDefaultIdleDays is used when neither open nor idle days are given.
+	staleDefaultIdleDays = 7
+
+	stalePRsDefaultLimit = 50
+	stalePRsMaxLimit     = 100
+
+	// staleRepositoryLimit limits stale pull requests fetched from a single repository.
+	staleRepositoryLimit = 50
+
+	// staleConcurrency limits repositories looked at the same time.
+	staleConcurrency = 8
+
+	// staleReminderDefaultIntervalDays is the minimum time between reminders when idle days are not given.
+	staleReminderDefaultIntervalDays = 7
+
+	// staleReminderCommentsPageLen is the number of recent comments checked for previous reminders.
+	staleReminderCommentsPageLen = 50
+
+	// staleReminderMarker is a markdown comment appended to reminders to recognize them later.
+	staleReminderMarker = "[//]: # (atlacp-stale-reminder)"
+
+	// bbqlTimeLayout formats times in BBQL filters.
+	bbqlTimeLayout = "2006-01-02T15:04:05-07:00"
+
+	day = 24 * time.Hour
+)
+
+// defaultStaleReminderTemplate is used when no reminder message is given.
+const defaultStaleReminderTemplate = `This pull request has been open for {{.OpenDays}} days ` +
+	`and had no activity for {{.IdleDays}} days.
+{{- if .Mentions}} {{join .Mentions " "}}, could you please take a look?{{end}}`
+
+// Statuses of stale pull request reminders.
+const (
+	StaleNudgeStatusNudged  = "nudged"
+	StaleNudgeStatusSkipped = "skipped"
+	StaleNudgeStatusFailed  = "failed"
+)
+
+// BitbucketStalePRsParams contains parameters for finding stale pull requests.
+type BitbucketStalePRsParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Workspace to look in (optional if repositories are given or watched repositories are configured)
+	Workspace string `json:"workspace,omitempty"`
+
+	// Repositories to look in as full names (workspace/repo_slug) or slugs of the workspace (optional)
+	Repositories []string `json:"repositories,omitempty"`
+
+	// OpenDays finds pull requests open for at least this many days (optional)
+	OpenDays int `json:"open_days,omitempty"`
+
+	// IdleDays finds pull requests without activity for at least this many days
+	// (optional, defaults to 7 when open days are not given either)
+	IdleDays int `json:"idle_days,omitempty"`
+
+	// Maximum number of pull requests to return (optional, defaults to 50, max 100)
+	Limit int `json:"limit,omitempty"`
+}
+
+// StalePullRequests lists stale open pull requests grouped by author and pending reviewer.
+type StalePullRequests struct {
+	AsOf     time.Time `json:"as_of"`
+	OpenDays int       `json:"open_days,omitempty"`
+	IdleDays int       `json:"idle_days,omitempty"`
+
+	// PullRequests are stale pull requests, least recently updated first.
+	PullRequests []StalePullRequest `json:"pull_requests"`
+
+	// ByAuthor groups the listed pull requests by their author.
+	ByAuthor []StalePRGroup `json:"by_author"`
+
+	// ByReviewer groups the listed pull requests by reviewers that have not approved them yet.
+	ByReviewer []StalePRGroup `json:"by_reviewer"`
+
+	// Truncated is true when more pull requests are stale than listed.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// SearchedRepositories is the number of repositories looked at.
+	SearchedRepositories int `json:"searched_repositories"`
+
+	// RepositoriesTruncated is true when the workspace has more repositories than looked at.
+	RepositoriesTruncated bool `json:"repositories_truncated,omitempty"`
+
+	// FailedRepositories could not be looked at, stale pull requests of other repositories are listed regardless.
+	FailedRepositories []FailedRepository `json:"failed_repositories,omitempty"`
+}
+
+// StalePullRequest is an open pull request that is open for too long or has no recent activity.
+type StalePullRequest struct {
+	// Repository full name (workspace/repo_slug)
+	Repository string `json:"repository"`
+
+	OpenDays int      `json:"open_days"`
+	IdleDays int      `json:"idle_days"`
+	Reasons  []string `json:"reasons"`
+
+	// PendingReviewers are reviewers that have not approved the pull request yet.
+	PendingReviewers []bitbucket.PullRequestAuthor `json:"pending_reviewers,omitempty"`
+
+	PullRequest bitbucket.PullRequest `json:"pull_request"`
+}
+
+// StalePRGroup lists stale pull requests of a user, e.g. "acme/api#7".
+type StalePRGroup struct {
+	User         bitbucket.PullRequestAuthor `json:"user"`
+	PullRequests []string                    `json:"pull_requests"`
+}
+
+// BitbucketNudgeStalePRsParams contains parameters for reminding about stale pull requests.
+type BitbucketNudgeStalePRsParams struct {
+	BitbucketStalePRsParams
+
+	// Message is a Go template of the reminder comment (optional). The available fields are
+	// .PullRequestID, .Title, .Author, .OpenDays, .IdleDays and .Mentions, and a join function.
+	Message string `json:"message,omitempty"`
+
+	// TagReviewers mentions reviewers that have not approved the pull request yet.
+	TagReviewers bool `json:"tag_reviewers,omitempty"`
+}
+
+// StaleReminderData is the data available to reminder message templates.
+type StaleReminderData struct {
+	PullRequestID int
+	Title         string
+	Author        string
+	OpenDays      int
+	IdleDays      int
+
+	// Mentions of pending reviewers, only when reviewers are tagged.
+	Mentions []string
+}
+
+// StalePRNudges contains stale pull requests and the outcome of reminding about each of them.
+type StalePRNudges struct {
+	Stale  *StalePullRequests `json:"stale"`
+	Nudges []StalePRNudge     `json:"nudges"`
+}
+
+// StalePRNudge is the outcome of reminding about a stale pull request.
+type StalePRNudge struct {
+	Repository    string `json:"repository"`
+	PullRequestID int    `json:"pull_request_id"`
+
+	// Status is nudged, skipped or failed.
+	Status    string `json:"status"`
+	CommentID int64  `json:"comment_id,omitempty"`
+	Details   string `json:"details,omitempty"`
+}
+
+// FindStalePullRequests finds open pull requests that are open longer than the given number of days
+// or have no activity for the given number of days. Repositories are looked at concurrently,
+// repositories that fail are reported and stale pull requests of the others are returned.
+func (s *BitbucketService) FindStalePullRequests(
+	ctx context.Context,
+	params BitbucketStalePRsParams,
+) (*StalePullRequests, error) {
+	s.logger.InfoContext(ctx, "Finding stale pull requests",
+		slog.String("workspace", params.Workspace),
+		slog.Int("open_days", params.OpenDays),
+		slog.Int("idle_days", params.IdleDays))
+
+	if params.OpenDays < 0 || params.IdleDays < 0 {
+		return nil, errors.New("open days and idle days must not be negative")
+	}
+	if params.OpenDays == 0 && params.IdleDays == 0 {
+		params.IdleDays = staleDefaultIdleDays
+	}
+	limit := min(params.Limit, stalePRsMaxLimit)
+	if limit <= 0 {
+		limit = stalePRsDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	repositories, repositoriesTruncated, err := s.resolveRepositoryScope(
+		ctx, tokenProvider, params.Workspace, params.Repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	items, failedRepositories, err := collectPerRepository(ctx, s.logger, repositories, staleConcurrency,
+		func(ctx context.Context, repository repositoryRef) ([]StalePullRequest, error) {
+			return s.findRepositoryStalePullRequests(ctx, tokenProvider, repository, params, now)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale pull requests: %w", err)
+	}
+
+	slices.SortStableFunc(items, func(a, b StalePullRequest) int {
+		return cmp.Compare(pullRequestUpdatedOn(a.PullRequest), pullRequestUpdatedOn(b.PullRequest))
+	})
+	listed := items[:min(len(items), limit)]
+	byAuthor, byReviewer := groupStalePullRequests(listed)
+	return &StalePullRequests{
+		AsOf:                  now,
+		OpenDays:              params.OpenDays,
+		IdleDays:              params.IdleDays,
+		PullRequests:          listed,
+		ByAuthor:              byAuthor,
+		ByReviewer:            byReviewer,
+		Truncated:             len(items) > limit,
+		SearchedRepositories:  len(repositories),
+		RepositoriesTruncated: repositoriesTruncated,
+		FailedRepositories:    failedRepositories,
+	}, nil
+}
+
+// findRepositoryStalePullRequests returns stale open pull requests of a repository.
+func (s *BitbucketService) findRepositoryStalePullRequests(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	repository repositoryRef,
+	params BitbucketStalePRsParams,
+	now time.Time,
+) ([]StalePullRequest, error) {
+	var conditions []string
+	if params.OpenDays > 0 {
+		openedBefore := now.Add(-time.Duration(params.OpenDays) * day)
+		conditions = append(conditions, "created_on <= "+openedBefore.UTC().Format(bbqlTimeLayout))
+	}
+	if params.IdleDays > 0 {
+		updatedBefore := now.Add(-time.Duration(params.IdleDays) * day)
+		conditions = append(conditions, "updated_on <= "+updatedBefore.UTC().Format(bbqlTimeLayout))
+	}
+	pullRequests, err := s.client.ListPullRequests(ctx, tokenProvider, bitbucket.ListPullRequestsParams{
+		Workspace: repository.Workspace,
+		RepoSlug:  repository.Slug,
+		States:    []string{"OPEN"},
+		Query:     strings.Join(conditions, " OR "),
+		Sort:      "updated_on",
+		Fields:    reviewQueueFields,
+		PageLen:   staleRepositoryLimit,
+		Limit:     staleRepositoryLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	var items []StalePullRequest
+	for _, pr := range pullRequests {
+		item := StalePullRequest{
+			Repository:       repository.fullName(),
+			PendingReviewers: pendingReviewers(pr),
+			PullRequest:      pr,
+		}
+		if pr.CreatedOn != nil {
+			item.OpenDays = int(now.Sub(*pr.CreatedOn) / day)
+		}
+		if pr.UpdatedOn != nil {
+			item.IdleDays = int(now.Sub(*pr.UpdatedOn) / day)
+		}
+		if params.OpenDays > 0 && item.OpenDays >= params.OpenDays {
+			item.Reasons = append(item.Reasons, fmt.Sprintf("open for %d days", item.OpenDays))
+		}
+		if params.IdleDays > 0 && item.IdleDays >= params.IdleDays {
+			item.Reasons = append(item.Reasons, fmt.Sprintf("no activity for %d days", item.IdleDays))
+		}
+		if len(item.Reasons) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// pendingReviewers returns reviewers of a pull request that have not approved it yet.
+func pendingReviewers(pr bitbucket.PullRequest) []bitbucket.PullRequestAuthor {
+	return lo.Filter(pr.Reviewers, func(reviewer bitbucket.PullRequestAuthor, _ int) bool {
+		return !lo.ContainsBy(pr.Participants, func(p bitbucket.Participant) bool {
+			return p.Approved && userKey(p.User) == userKey(reviewer)
+		})
+	})
+}
+
+// groupStalePullRequests groups stale pull requests by author and by pending reviewer in order of appearance.
+func groupStalePullRequests(items []StalePullRequest) ([]StalePRGroup, []StalePRGroup) {
+	byAuthor := []StalePRGroup{}
+	byReviewer := []StalePRGroup{}
+	addTo := func(groups *[]StalePRGroup, user bitbucket.PullRequestAuthor, ref string) {
+		index := slices.IndexFunc(*groups, func(group StalePRGroup) bool {
+			return userKey(group.User) == userKey(user)
+		})
+		if index < 0 {
+			*groups = append(*groups, StalePRGroup{User: user})
+			index = len(*groups) - 1
+		}
+		(*groups)[index].PullRequests = append((*groups)[index].PullRequests, ref)
+	}
+	for _, item := range items {
+		ref := fmt.Sprintf("%s#%d", item.Repository, item.PullRequest.ID)
+		if item.PullRequest.Author != nil {
+			addTo(&byAuthor, *item.PullRequest.Author, ref)
+		}
+		for _, reviewer := range item.PendingReviewers {
+			addTo(&byReviewer, reviewer, ref)
+		}
+	}
+	return byAuthor, byReviewer
+}
+
+// userKey identifies a user by UUID, account ID or display name, whichever is known.
+func userKey(user bitbucket.PullRequestAuthor) string {
+	return lo.CoalesceOrEmpty(user.UUID, user.AccountID, user.DisplayName)
+}
+
+// NudgeStalePullRequests finds stale pull requests and posts a reminder comment on each of them.
+// Pull requests reminded about recently, within idle days or a week by default, are skipped.
+// Failures are reported per pull request.
+func (s *BitbucketService) NudgeStalePullRequests(
+	ctx context.Context,
+	params BitbucketNudgeStalePRsParams,
+) (*StalePRNudges, error) {
+	messageTemplate, err := parseStaleReminderTemplate(lo.CoalesceOrEmpty(params.Message, defaultStaleReminderTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	stale, err := s.FindStalePullRequests(ctx, params.BitbucketStalePRsParams)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Reminding about stale pull requests",
+		slog.Int("pull_requests", len(stale.PullRequests)),
+		slog.Bool("tag_reviewers", params.TagReviewers))
+
+	intervalDays := lo.Ternary(stale.IdleDays > 0, stale.IdleDays, staleReminderDefaultIntervalDays)
+	remindedSince := stale.AsOf.Add(-time.Duration(intervalDays) * day)
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	nudges := make([]StalePRNudge, 0, len(stale.PullRequests))
+	for _, item := range stale.PullRequests {
+		nudge := s.nudgeStalePullRequest(ctx, tokenProvider, item, messageTemplate, params.TagReviewers, remindedSince)
+		nudges = append(nudges, nudge)
+	}
+	return &StalePRNudges{Stale: stale, Nudges: nudges}, nil
+}
+
+func parseStaleReminderTemplate(templateText string) (*template.Template, error) {
+	tmpl, err := template.New("stale-reminder").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(templateText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reminder message template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// nudgeStalePullRequest posts a reminder comment on a stale pull request unless it was reminded about
+// since the given time.
+func (s *BitbucketService) nudgeStalePullRequest(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	item StalePullRequest,
+	messageTemplate *template.Template,
+	tagReviewers bool,
+	remindedSince time.Time,
+) StalePRNudge {
+	pr := item.PullRequest
+	workspace, repoSlug, _ := strings.Cut(item.Repository, "/")
+	nudge := StalePRNudge{Repository: item.Repository, PullRequestID: pr.ID, Status: StaleNudgeStatusFailed}
+
+	recent, err := s.client.ListPRComments(ctx, tokenProvider, bitbucket.ListPRCommentsParams{
+		Workspace: workspace,
+		RepoSlug:  repoSlug,
+		PRID:      int64(pr.ID),
+		PageLen:   staleReminderCommentsPageLen,
+		Query:     "created_on >= " + remindedSince.UTC().Format(bbqlTimeLayout),
+		Sort:      "-created_on",
+	})
+	if err != nil {
+		nudge.Details = "failed to list comments: " + err.Error()
+		return nudge
+	}
+	for _, comment := range recent.Values {
+		if strings.Contains(comment.Content.Raw, staleReminderMarker) {
+			nudge.Status = StaleNudgeStatusSkipped
+			nudge.Details = "already reminded on " + comment.CreatedOn.Format(time.DateOnly)
+			return nudge
+		}
+	}
+
+	data := StaleReminderData{
+		PullRequestID: pr.ID,
+		Title:         pr.Title,
+		OpenDays:      item.OpenDays,
+		IdleDays:      item.IdleDays,
+	}
+	if pr.Author != nil {
+		data.Author = pr.Author.DisplayName
+	}
+	if tagReviewers {
+		for _, reviewer := range item.PendingReviewers {
+			if reviewer.AccountID != "" {
+				data.Mentions = append(data.Mentions, "@{"+reviewer.AccountID+"}")
+			}
+		}
+	}
+	var message strings.Builder
+	if err = messageTemplate.Execute(&message, data); err != nil {
+		nudge.Details = "failed to render reminder: " + err.Error()
+		return nudge
+	}
+
+	commentID, _, err := s.client.AddPRComment(ctx, tokenProvider, bitbucket.AddPRCommentParams{
+		Workspace:   workspace,
+		RepoSlug:    repoSlug,
+		PullReqID:   pr.ID,
+		CommentText: strings.TrimSpace(message.String()) + "\n\n" + staleReminderMarker,
+	})
+	if err != nil {
+		nudge.Details = "failed to add comment: " + err.Error()
+		return nudge
+	}
+	nudge.Status = StaleNudgeStatusNudged
+	nudge.CommentID = commentID
+	return nudge
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_StalePullRequests(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		value := now.Add(-time.Duration(days) * day)
+		return &value
+	}
+
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+			Now:         func() time.Time { return now },
+		}
+	}
+
+	jane := bitbucket.PullRequestAuthor{UUID: "{jane}", AccountID: "jane-id", DisplayName: "Jane"}
+	john := bitbucket.PullRequestAuthor{UUID: "{john}", AccountID: "john-id", DisplayName: "John"}
+	ann := bitbucket.PullRequestAuthor{UUID: "{ann}", DisplayName: "Ann"}
+
+	t.Run("FindStalePullRequests", func(t *testing.T) {
+		t.Run("should find and group stale pull requests", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			accountName := "account-" + faker.Username()
+			tokenProvider := setupTokenProvider(t, deps, accountName)
+
+			idle := bitbucket.PullRequest{
+				ID:           1,
+				Author:       &jane,
+				Reviewers:    []bitbucket.PullRequestAuthor{john, ann},
+				Participants: []bitbucket.Participant{{User: ann, Approved: true}},
+				CreatedOn:    daysAgo(20),
+				UpdatedOn:    daysAgo(10),
+			}
+			old := bitbucket.PullRequest{
+				ID:        2,
+				Author:    &john,
+				Reviewers: []bitbucket.PullRequestAuthor{jane},
+				CreatedOn: daysAgo(31),
+				UpdatedOn: daysAgo(1),
+			}
+			fresh := bitbucket.PullRequest{ID: 3, Author: &jane, CreatedOn: daysAgo(2), UpdatedOn: daysAgo(1)}
+
+			expectList := func(slug string, result []bitbucket.PullRequest) {
+				mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, bitbucket.ListPullRequestsParams{
+					Workspace: "acme",
+					RepoSlug:  slug,
+					States:    []string{"OPEN"},
+					Query:     "created_on <= 2026-02-18T12:00:00+00:00 OR updated_on <= 2026-03-13T12:00:00+00:00",
+					Sort:      "updated_on",
+					Fields:    reviewQueueFields,
+					PageLen:   staleRepositoryLimit,
+					Limit:     staleRepositoryLimit,
+				}).Return(result, nil)
+			}
+			expectList("api", []bitbucket.PullRequest{old, fresh})
+			expectList("web", []bitbucket.PullRequest{idle})
+			service := NewBitbucketService(deps)
+
+			stale, err := service.FindStalePullRequests(t.Context(), BitbucketStalePRsParams{
+				AccountName:  accountName,
+				Workspace:    "acme",
+				Repositories: []string{"api", "web"},
+				OpenDays:     30,
+				IdleDays:     7,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &StalePullRequests{
+				AsOf:     now,
+				OpenDays: 30,
+				IdleDays: 7,
+				PullRequests: []StalePullRequest{
+					{
+						Repository:       "acme/web",
+						OpenDays:         20,
+						IdleDays:         10,
+						Reasons:          []string{"no activity for 10 days"},
+						PendingReviewers: []bitbucket.PullRequestAuthor{john},
+						PullRequest:      idle,
+					},
+					{
+						Repository:       "acme/api",
+						OpenDays:         31,
+						IdleDays:         1,
+						Reasons:          []string{"open for 31 days"},
+						PendingReviewers: []bitbucket.PullRequestAuthor{jane},
+						PullRequest:      old,
+					},
+				},
+				ByAuthor: []StalePRGroup{
+					{User: jane, PullRequests: []string{"acme/web#1"}},
+					{User: john, PullRequests: []string{"acme/api#2"}},
+				},
+				ByReviewer: []StalePRGroup{
+					{User: john, PullRequests: []string{"acme/web#1"}},
+					{User: jane, PullRequests: []string{"acme/api#2"}},
+				},
+				SearchedRepositories: 2,
+			}, stale)
+		})
+
+		t.Run("should default to a week without activity", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			setupTokenProvider(t, deps, "")
+
+			mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+				func(p bitbucket.ListPullRequestsParams) bool {
+					return p.Query == "updated_on <= 2026-03-13T12:00:00+00:00"
+				},
+			)).Return([]bitbucket.PullRequest{}, nil)
+			service := NewBitbucketService(deps)
+
+			stale, err := service.FindStalePullRequests(t.Context(), BitbucketStalePRsParams{
+				Repositories: []string{"acme/api"},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, staleDefaultIdleDays, stale.IdleDays)
+			assert.Empty(t, stale.PullRequests)
+		})
+
+		t.Run("should find stale pull requests of other repositories when a repository fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			setupTokenProvider(t, deps, "")
+			listErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+				func(p bitbucket.ListPullRequestsParams) bool { return p.RepoSlug == "api" },
+			)).Return(nil, listErr)
+			idle := bitbucket.PullRequest{ID: 3, Author: &jane, CreatedOn: daysAgo(9), UpdatedOn: daysAgo(8)}
+			mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.MatchedBy(
+				func(p bitbucket.ListPullRequestsParams) bool { return p.RepoSlug == "web" },
+			)).Return([]bitbucket.PullRequest{idle}, nil)
+			service := NewBitbucketService(deps)
+
+			stale, err := service.FindStalePullRequests(t.Context(), BitbucketStalePRsParams{
+				Repositories: []string{"acme/api", "acme/web"},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, stale.PullRequests, 1)
+			assert.Equal(t, "acme/web", stale.PullRequests[0].Repository)
+			assert.Equal(t, 2, stale.SearchedRepositories)
+			assert.Equal(t, []FailedRepository{{
+				Repository: "acme/api",
+				Error:      "failed to list pull requests: " + listErr.Error(),
+			}}, stale.FailedRepositories)
+		})
+
+		t.Run("should return error when all repositories fail", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			setupTokenProvider(t, deps, "")
+			listErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListPullRequests(mock.Anything, mock.Anything, mock.Anything).Return(nil, listErr)
+			service := NewBitbucketService(deps)
+
+			stale, err := service.FindStalePullRequests(t.Context(), BitbucketStalePRsParams{
+				Repositories: []string{"acme/api"},
+			})
+
+			require.ErrorIs(t, err, listErr)
+			assert.Nil(t, stale)
+		})
+
+		t.Run("should reject negative days", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.FindStalePullRequests(t.Context(), BitbucketStalePRsParams{IdleDays: -1})
+
+			require.ErrorContains(t, err, "must not be negative")
+		})
+	})
+
+	t.Run("NudgeStalePullRequests", func(t *testing.T) {
+		t.Run("should remind about stale pull requests not reminded about recently", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+
+			reminded := bitbucket.PullRequest{ID: 1, Author: &jane, UpdatedOn: daysAgo(9)}
+			toRemind := bitbucket.PullRequest{
+				ID:        2,
+				Title:     "Add refunds",
+				Author:    &jane,
+				Reviewers: []bitbucket.PullRequestAuthor{john, ann},
+				UpdatedOn: daysAgo(8),
+			}
+			failing := bitbucket.PullRequest{ID: 3, Author: &jane, UpdatedOn: daysAgo(7)}
+			mockClient.EXPECT().ListPullRequests(mock.Anything, tokenProvider, mock.Anything).
+				Return([]bitbucket.PullRequest{toRemind, failing, reminded}, nil)
+
+			expectComments := func(prID int64, comments []bitbucket.PRComment, err error) {
+				mockClient.EXPECT().ListPRComments(mock.Anything, tokenProvider, bitbucket.ListPRCommentsParams{
+					Workspace: "acme",
+					RepoSlug:  "api",
+					PRID:      prID,
+					PageLen:   staleReminderCommentsPageLen,
+					Query:     "created_on >= 2026-03-13T12:00:00+00:00",
+					Sort:      "-created_on",
+				}).Return(&bitbucket.ListPRCommentsResponse{Values: comments}, err)
+			}
+			previous := bitbucket.PRComment{CreatedOn: *daysAgo(3)}
+			previous.Content.Raw = "Ping\n\n" + staleReminderMarker
+			expectComments(1, []bitbucket.PRComment{previous}, nil)
+			expectComments(2, []bitbucket.PRComment{}, nil)
+			commentsErr := errors.New(faker.Sentence())
+			expectComments(3, nil, commentsErr)
+
+			mockClient.EXPECT().AddPRComment(mock.Anything, tokenProvider, bitbucket.AddPRCommentParams{
+				Workspace:   "acme",
+				RepoSlug:    "api",
+				PullReqID:   2,
+				CommentText: "#2 Add refunds by Jane idle 8 days @{john-id}\n\n" + staleReminderMarker,
+			}).Return(int64(42), "", nil)
+			service := NewBitbucketService(deps)
+
+			nudges, err := service.NudgeStalePullRequests(t.Context(), BitbucketNudgeStalePRsParams{
+				BitbucketStalePRsParams: BitbucketStalePRsParams{Repositories: []string{"acme/api"}},
+				Message: "#{{.PullRequestID}} {{.Title}} by {{.Author}} idle {{.IdleDays}} days " +
+					`{{join .Mentions " "}}`,
+				TagReviewers: true,
+			})
+
+			require.NoError(t, err)
+			require.Len(t, nudges.Stale.PullRequests, 3)
+			assert.Equal(t, []StalePRNudge{
+				{Repository: "acme/api", PullRequestID: 1, Status: StaleNudgeStatusSkipped,
+					Details: "already reminded on 2026-03-17"},
+				{Repository: "acme/api", PullRequestID: 2, Status: StaleNudgeStatusNudged, CommentID: 42},
+				{Repository: "acme/api", PullRequestID: 3, Status: StaleNudgeStatusFailed,
+					Details: "failed to list comments: " + commentsErr.Error()},
+			}, nudges.Nudges)
+		})
+
+		t.Run("should render default reminder", func(t *testing.T) {
+			tmpl, err := parseStaleReminderTemplate(defaultStaleReminderTemplate)
+			require.NoError(t, err)
+
+			var rendered strings.Builder
+			require.NoError(t, tmpl.Execute(&rendered, StaleReminderData{
+				OpenDays: 12,
+				IdleDays: 8,
+				Mentions: []string{"@{john-id}", "@{ann-id}"},
+			}))
+			assert.Equal(t, "This pull request has been open for 12 days and had no activity for 8 days. "+
+				"@{john-id} @{ann-id}, could you please take a look?", rendered.String())
+		})
+
+		t.Run("should reject invalid message template", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			nudges, err := service.NudgeStalePullRequests(t.Context(), BitbucketNudgeStalePRsParams{
+				Message: "{{.Unclosed",
+			})
+
+			require.ErrorContains(t, err, "invalid reminder message template")
+			assert.Nil(t, nudges)
+		})
+	})
+}
@@ -0,0 +1,416 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// tagsDefaultLimit is the number of tags listed when no limit is given.
+	tagsDefaultLimit = 50
+
+	// tagsMaxLimit is the maximum page size accepted by the tags API.
+	tagsMaxLimit = 100
+
+	// tagsSortNewestFirst lists tags of the most recent commits first.
+	tagsSortNewestFirst = "-target.date"
+
+	// referencedPullRequestsConcurrency limits pull requests of commits fetched at the same time.
+	referencedPullRequestsConcurrency = 8
+
+	// tagsSearchMaxPages limits pages of tags looked through for the tag preceding a given one.
+	tagsSearchMaxPages = 20
+
+	// tagChangesMaxCommits limits the number of commits listed between two tags.
+	tagChangesMaxCommits = 1000
+)
+
+// pullRequestReferencePattern matches the reference Bitbucket adds to merge and squash commit messages.
+var pullRequestReferencePattern = regexp.MustCompile(`\(pull request #(\d+)\)`)
+
+// BitbucketListTagsParams contains parameters for listing tags of a repository.
+type BitbucketListTagsParams struct {
+	BitbucketRepositoryParams
+
+	// Only list tags whose name contains this text (optional)
+	Filter string `json:"filter,omitempty"`
+
+	// Sort field, prefix with - for descending order (optional, defaults to -target.date, newest first)
+	Sort string `json:"sort,omitempty"`
+
+	// Maximum number of tags to return (optional)
+	Limit int `json:"limit,omitempty"`
+}
+
+// BitbucketTagParams identifies a tag of a repository.
+type BitbucketTagParams struct {
+	BitbucketRepositoryParams
+
+	// Tag name
+	Name string `json:"name"`
+}
+
+// BitbucketCreateTagParams contains parameters for creating a tag.
+type BitbucketCreateTagParams struct {
+	BitbucketRepositoryParams
+
+	// Tag name
+	Name string `json:"name"`
+
+	// Branch name or commit hash to tag
+	Target string `json:"target"`
+
+	// Tag message (optional)
+	Message string `json:"message,omitempty"`
+}
+
+// BitbucketListTagChangesParams contains parameters for listing changes between two tags.
+type BitbucketListTagChangesParams struct {
+	BitbucketRepositoryParams
+
+	// Tag, branch or commit the changes are listed up to
+	To string `json:"to"`
+
+	// Tag the changes are listed from (optional, defaults to the tag preceding To)
+	From string `json:"from,omitempty"`
+}
+
+// TagChanges lists commits and merged pull requests between two tags.
+type TagChanges struct {
+	// From is the tag the changes are listed from, empty when there is no previous tag.
+	From string `json:"from,omitempty"`
+
+	To string `json:"to"`
+
+	// Commits reachable from To but not from From, newest first.
+	Commits []bitbucket.Commit `json:"commits"`
+
+	// PullRequests merged between the tags, found by commits or by references in commit messages.
+	PullRequests []bitbucket.PullRequest `json:"pull_requests"`
+
+	// Truncated is true when there are more commits than listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ListTags lists tags of a repository, newest first by default.
+func (s *BitbucketService) ListTags(ctx context.Context, params BitbucketListTagsParams) ([]bitbucket.Tag, error) {
+	s.logger.InfoContext(ctx, "Listing tags",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("filter", params.Filter))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = tagsDefaultLimit
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	return s.listTags(ctx, tokenProvider, params.BitbucketRepositoryParams, params.Filter,
+		lo.CoalesceOrEmpty(params.Sort, tagsSortNewestFirst), min(limit, tagsMaxLimit))
+}
+
+// CreateTag tags a branch or a commit.
+func (s *BitbucketService) CreateTag(ctx context.Context, params BitbucketCreateTagParams) (*bitbucket.Tag, error) {
+	s.logger.InfoContext(ctx, "Creating tag",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("tag", params.Name),
+		slog.String("target", params.Target))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Name == "" {
+		return nil, errors.New("tag name is required")
+	}
+	if params.Target == "" {
+		return nil, errors.New("target branch or commit is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	tag, err := s.client.CreateTag(ctx, tokenProvider, bitbucket.CreateTagParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Name:      params.Name,
+		Target:    params.Target,
+		Message:   params.Message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag of a repository.
+func (s *BitbucketService) DeleteTag(ctx context.Context, params BitbucketTagParams) error {
+	s.logger.InfoContext(ctx, "Deleting tag",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("tag", params.Name))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return err
+	}
+	if params.Name == "" {
+		return errors.New("tag name is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	if err := s.client.DeleteTag(ctx, tokenProvider, bitbucket.DeleteTagParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Name:      params.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+// ListTagChanges lists commits and merged pull requests between two tags.
+// When From is not provided the tag preceding To is used, e.g. the previous release.
+func (s *BitbucketService) ListTagChanges(
+	ctx context.Context,
+	params BitbucketListTagChangesParams,
+) (*TagChanges, error) {
+	s.logger.InfoContext(ctx, "Listing changes between tags",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("from", params.From),
+		slog.String("to", params.To))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.To == "" {
+		return nil, errors.New("target tag is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	from := params.From
+	if from == "" {
+		previous, err := s.findPreviousTag(ctx, tokenProvider, params.BitbucketRepositoryParams, params.To)
+		if err != nil {
+			return nil, err
+		}
+		from = previous
+	}
+
+	listParams := bitbucket.ListCommitsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Include:   []string{params.To},
+		PageLen:   commitsPageLen,
+		Limit:     tagChangesMaxCommits + 1,
+	}
+	if from != "" {
+		listParams.Exclude = []string{from}
+	}
+	commits, err := s.client.ListCommits(ctx, tokenProvider, listParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	changes := &TagChanges{
+		From:      from,
+		To:        params.To,
+		Commits:   lo.Ternary(commits == nil, []bitbucket.Commit{}, commits),
+		Truncated: len(commits) > tagChangesMaxCommits,
+	}
+	if changes.Truncated {
+		changes.Commits = commits[:tagChangesMaxCommits]
+	}
+
+	changes.PullRequests, err = s.getMergedPullRequests(
+		ctx, tokenProvider, params.BitbucketRepositoryParams, changes.Commits)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (s *BitbucketService) listTags(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	filter string,
+	sort string,
+	limit int,
+) ([]bitbucket.Tag, error) {
+	listParams := bitbucket.ListTagsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Sort:      sort,
+		PageLen:   limit,
+	}
+	if filter != "" {
+		listParams.Query = "name ~ " + strconv.Quote(filter)
+	}
+
+	page, err := s.client.ListTags(ctx, tokenProvider, listParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return page.Values, nil
+}
+
+// findPreviousTag returns the most recent tag preceding the given tag, or the most recent tag
+// when to is not a tag, e.g. a branch. Tags are paged through newest first until the tag is found.
+// An empty name is returned when there is no such tag.
+func (s *BitbucketService) findPreviousTag(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	to string,
+) (string, error) {
+	target, err := s.client.ListTags(ctx, tokenProvider, bitbucket.ListTagsParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Query:     "name = " + strconv.Quote(to),
+		PageLen:   1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get tag %s: %w", to, err)
+	}
+	isTag := len(target.Values) > 0
+	toHash := ""
+	if isTag {
+		toHash = tagHash(target.Values[0])
+	}
+
+	foundTo := !isTag
+	for page := 1; page <= tagsSearchMaxPages; page++ {
+		tags, listErr := s.client.ListTags(ctx, tokenProvider, bitbucket.ListTagsParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Sort:      tagsSortNewestFirst,
+			Page:      page,
+			PageLen:   tagsMaxLimit,
+		})
+		if listErr != nil {
+			return "", fmt.Errorf("failed to list tags: %w", listErr)
+		}
+		for _, tag := range tags.Values {
+			if !foundTo {
+				foundTo = tag.Name == to
+				continue
+			}
+			// Tags of the same commit as the target tag are skipped, e.g. v1.2.0 and latest.
+			if toHash == "" || tagHash(tag) != toHash {
+				return tag.Name, nil
+			}
+		}
+		if tags.Next == "" {
+			break
+		}
+	}
+	if !foundTo {
+		return "", fmt.Errorf("tag %s is not among the %d most recent tags, pass the previous tag explicitly",
+			to, tagsSearchMaxPages*tagsMaxLimit)
+	}
+	return "", nil
+}
+
+// getMergedPullRequests returns merged pull requests of commits, newest first. Pull requests are
+// looked up per commit, falling back to references in commit messages when commit links are not indexed.
+func (s *BitbucketService) getMergedPullRequests(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	commits []bitbucket.Commit,
+) ([]bitbucket.PullRequest, error) {
+	found := make([][]bitbucket.PullRequest, len(commits))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(referencedPullRequestsConcurrency)
+	for i, commit := range commits {
+		group.Go(func() error {
+			var err error
+			found[i], err = s.getCommitPullRequests(groupCtx, tokenProvider, params, commit)
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	pullRequests := lo.UniqBy(lo.Flatten(found), func(pr bitbucket.PullRequest) int { return pr.ID })
+	return lo.Filter(pullRequests, func(pr bitbucket.PullRequest, _ int) bool {
+		return pr.State == "MERGED"
+	}), nil
+}
+
+// getReferencedPullRequests returns pull requests referenced by commit messages, newest first.
+// References to pull requests that do not exist, e.g. of other repositories, are ignored.
+// Pull requests are fetched concurrently.
+func (s *BitbucketService) getReferencedPullRequests(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	commits []bitbucket.Commit,
+) ([]bitbucket.PullRequest, error) {
+	var prIDs []int
+	seen := make(map[int]bool)
+	for _, commit := range commits {
+		for _, match := range pullRequestReferencePattern.FindAllStringSubmatch(commit.Message, -1) {
+			prID, err := strconv.Atoi(match[1])
+			if err != nil || seen[prID] {
+				continue
+			}
+			seen[prID] = true
+			prIDs = append(prIDs, prID)
+		}
+	}
+
+	found := make([]*bitbucket.PullRequest, len(prIDs))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(referencedPullRequestsConcurrency)
+	for i, prID := range prIDs {
+		group.Go(func() error {
+			pr, err := s.client.GetPR(groupCtx, tokenProvider, bitbucket.GetPRParams{
+				Username:      params.RepoOwner,
+				RepoSlug:      params.RepoName,
+				PullRequestID: prID,
+			})
+			if err != nil {
+				var httpErr *middleware.HTTPError
+				if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+					return nil
+				}
+				return fmt.Errorf("failed to get pull request %d: %w", prID, err)
+			}
+			found[i] = pr
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	pullRequests := []bitbucket.PullRequest{}
+	for _, pr := range found {
+		if pr != nil {
+			pullRequests = append(pullRequests, *pr)
+		}
+	}
+	return pullRequests, nil
+}
+
+func tagHash(tag bitbucket.Tag) string {
+	if tag.Target == nil {
+		return ""
+	}
+	return tag.Target.Hash
+}
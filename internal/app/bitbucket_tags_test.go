@@ -0,0 +1,468 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Tags(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newTag := func(name string) bitbucket.Tag {
+		return bitbucket.Tag{Type: "tag", Name: name, Target: &bitbucket.Commit{Hash: faker.UUIDDigit()}}
+	}
+
+	t.Run("ListTags", func(t *testing.T) {
+		t.Run("should list newest tags first by default", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketListTagsParams{BitbucketRepositoryParams: makeRepoParams(), Filter: "v1."}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			tags := []bitbucket.Tag{newTag("v1.1.0"), newTag("v1.0.0")}
+
+			mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, bitbucket.ListTagsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Query:     `name ~ "v1."`,
+				Sort:      tagsSortNewestFirst,
+				PageLen:   tagsDefaultLimit,
+			}).Return(&bitbucket.Paginated[bitbucket.Tag]{Values: tags}, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTags(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, tags, got)
+		})
+
+		t.Run("should use given sort and cap limit", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketListTagsParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Sort:                      "name",
+				Limit:                     tagsMaxLimit + 1,
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			expectedErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, bitbucket.ListTagsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Sort:      "name",
+				PageLen:   tagsMaxLimit,
+			}).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListTags(t.Context(), params)
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+	})
+
+	t.Run("CreateTag", func(t *testing.T) {
+		t.Run("should create tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketCreateTagParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "v" + faker.Word(),
+				Target:                    "main",
+				Message:                   faker.Sentence(),
+			}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+			tag := newTag(params.Name)
+
+			mockClient.EXPECT().CreateTag(mock.Anything, tokenProvider, bitbucket.CreateTagParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Name:      params.Name,
+				Target:    params.Target,
+				Message:   params.Message,
+			}).Return(&tag, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.CreateTag(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, &tag, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.CreateTag(t.Context(), BitbucketCreateTagParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Target:                    "main",
+			})
+			require.ErrorContains(t, err, "tag name is required")
+
+			_, err = service.CreateTag(t.Context(), BitbucketCreateTagParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Name:                      "v1",
+			})
+			require.ErrorContains(t, err, "target branch or commit is required")
+		})
+	})
+
+	t.Run("DeleteTag", func(t *testing.T) {
+		t.Run("should delete tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			params := BitbucketTagParams{BitbucketRepositoryParams: makeRepoParams(), Name: "v" + faker.Word()}
+			tokenProvider := setupTokenProvider(t, deps, params.BitbucketRepositoryParams.AccountName)
+
+			mockClient.EXPECT().DeleteTag(mock.Anything, tokenProvider, bitbucket.DeleteTagParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Name:      params.Name,
+			}).Return(nil)
+			service := NewBitbucketService(deps)
+
+			require.NoError(t, service.DeleteTag(t.Context(), params))
+		})
+
+		t.Run("should require tag name", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			err := service.DeleteTag(t.Context(), BitbucketTagParams{BitbucketRepositoryParams: makeRepoParams()})
+
+			require.ErrorContains(t, err, "tag name is required")
+		})
+	})
+
+	t.Run("ListTagChanges", func(t *testing.T) {
+		expectPreviousTagLookup := func(
+			t *testing.T,
+			deps BitbucketServiceDeps,
+			tokenProvider bitbucket.TokenProvider,
+			params BitbucketRepositoryParams,
+			to string,
+			pages ...[]bitbucket.Tag,
+		) {
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			target := lo.Filter(lo.Flatten(pages), func(tag bitbucket.Tag, _ int) bool { return tag.Name == to })
+			mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, bitbucket.ListTagsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Query:     "name = " + strconv.Quote(to),
+				PageLen:   1,
+			}).Return(&bitbucket.Paginated[bitbucket.Tag]{Values: lo.Subset(target, 0, 1)}, nil)
+			for i, tags := range pages {
+				mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, bitbucket.ListTagsParams{
+					Workspace: params.RepoOwner,
+					RepoSlug:  params.RepoName,
+					Sort:      tagsSortNewestFirst,
+					Page:      i + 1,
+					PageLen:   tagsMaxLimit,
+				}).Return(&bitbucket.Paginated[bitbucket.Tag]{
+					Values: tags,
+					Next:   lo.Ternary(i < len(pages)-1, "https://api.bitbucket.org/next", ""),
+				}, nil)
+			}
+		}
+
+		expectCommits := func(
+			t *testing.T,
+			deps BitbucketServiceDeps,
+			tokenProvider bitbucket.TokenProvider,
+			params BitbucketRepositoryParams,
+			to string,
+			from string,
+			commits []bitbucket.Commit,
+		) {
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			listParams := bitbucket.ListCommitsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Include:   []string{to},
+				PageLen:   commitsPageLen,
+				Limit:     tagChangesMaxCommits + 1,
+			}
+			if from != "" {
+				listParams.Exclude = []string{from}
+			}
+			mockClient.EXPECT().ListCommits(mock.Anything, tokenProvider, listParams).Return(commits, nil)
+		}
+
+		expectCommitPullRequests := func(
+			t *testing.T,
+			deps BitbucketServiceDeps,
+			tokenProvider bitbucket.TokenProvider,
+			params BitbucketRepositoryParams,
+			commit bitbucket.Commit,
+			pullRequests []bitbucket.PullRequest,
+			err error,
+		) {
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockClient.EXPECT().ListCommitPullRequests(mock.Anything, tokenProvider, bitbucket.ListCommitPullRequestsParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Commit:    commit.Hash,
+				PageLen:   blamePullRequestsLimit,
+				Limit:     blamePullRequestsLimit,
+			}).Return(pullRequests, err)
+		}
+
+		t.Run("should list commits and merged pull requests of commits since previous tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			previous := newTag("v1.1.0")
+			current := newTag("v1.2.0")
+			expectPreviousTagLookup(t, deps, tokenProvider, repoParams, current.Name, []bitbucket.Tag{current, previous})
+
+			commits := []bitbucket.Commit{
+				{Hash: faker.UUIDDigit(), Message: "Merged in feature"},
+				{Hash: faker.UUIDDigit(), Message: "Add feature"},
+				{Hash: faker.UUIDDigit(), Message: "Fix typo"},
+			}
+			expectCommits(t, deps, tokenProvider, repoParams, current.Name, previous.Name, commits)
+
+			merged := bitbucket.PullRequest{ID: 12, Title: faker.Sentence(), State: "MERGED"}
+			declined := bitbucket.PullRequest{ID: 9, Title: faker.Sentence(), State: "DECLINED"}
+			expectCommitPullRequests(t, deps, tokenProvider, repoParams, commits[0], []bitbucket.PullRequest{merged}, nil)
+			expectCommitPullRequests(t, deps, tokenProvider, repoParams, commits[1],
+				[]bitbucket.PullRequest{declined, merged}, nil)
+			expectCommitPullRequests(t, deps, tokenProvider, repoParams, commits[2], nil, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        current.Name,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &TagChanges{
+				From:         previous.Name,
+				To:           current.Name,
+				Commits:      commits,
+				PullRequests: []bitbucket.PullRequest{merged},
+			}, got)
+		})
+
+		t.Run("should list pull requests referenced by commit messages when commits are not indexed", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			current := newTag("v1.2.0")
+			alias := current
+			alias.Name = "latest"
+			previous := newTag("v1.1.0")
+			expectPreviousTagLookup(t, deps, tokenProvider, repoParams, current.Name,
+				[]bitbucket.Tag{newTag("v1.3.0"), current, alias, previous, newTag("v1.0.0")})
+
+			commits := []bitbucket.Commit{
+				{Hash: faker.UUIDDigit(), Message: "Add feature (pull request #12)\n\nDetails"},
+				{Hash: faker.UUIDDigit(), Message: "Fix typo"},
+				{Hash: faker.UUIDDigit(), Message: "Merged in fix (pull request #7)\n\nFix (pull request #12)"},
+				{Hash: faker.UUIDDigit(), Message: "Port change (pull request #3)"},
+			}
+			expectCommits(t, deps, tokenProvider, repoParams, current.Name, previous.Name, commits)
+			for _, commit := range commits {
+				expectCommitPullRequests(t, deps, tokenProvider, repoParams, commit,
+					nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			}
+
+			// Each commit falls back to its own references, so #12 referenced by two commits is fetched twice.
+			pr12 := bitbucket.PullRequest{ID: 12, Title: faker.Sentence(), State: "MERGED"}
+			pr7 := bitbucket.PullRequest{ID: 7, Title: faker.Sentence(), State: "MERGED"}
+			for _, pr := range []bitbucket.PullRequest{pr12, pr7} {
+				mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+					Username:      repoParams.RepoOwner,
+					RepoSlug:      repoParams.RepoName,
+					PullRequestID: pr.ID,
+				}).Return(&pr, nil).Times(lo.Ternary(pr.ID == pr12.ID, 2, 1))
+			}
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, mock.MatchedBy(func(p bitbucket.GetPRParams) bool {
+				return p.PullRequestID == 3
+			})).Return(nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        current.Name,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &TagChanges{
+				From:         previous.Name,
+				To:           current.Name,
+				Commits:      commits,
+				PullRequests: []bitbucket.PullRequest{pr12, pr7},
+			}, got)
+		})
+
+		t.Run("should use most recent tag when target is not a tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			latest := newTag("v2.0.0")
+			expectPreviousTagLookup(t, deps, tokenProvider, repoParams, "main", []bitbucket.Tag{latest, newTag("v1.0.0")})
+			expectCommits(t, deps, tokenProvider, repoParams, "main", latest.Name, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        "main",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &TagChanges{
+				From:         latest.Name,
+				To:           "main",
+				Commits:      []bitbucket.Commit{},
+				PullRequests: []bitbucket.PullRequest{},
+			}, got)
+		})
+
+		t.Run("should page through tags to find the previous tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			current := newTag("v1.0.1")
+			previous := newTag("v1.0.0")
+			newer := lo.Times(tagsMaxLimit, func(i int) bitbucket.Tag { return newTag("v2.0." + strconv.Itoa(i)) })
+			expectPreviousTagLookup(t, deps, tokenProvider, repoParams, current.Name,
+				newer[:tagsMaxLimit-1], []bitbucket.Tag{newer[tagsMaxLimit-1], current}, []bitbucket.Tag{previous})
+			expectCommits(t, deps, tokenProvider, repoParams, current.Name, previous.Name, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        current.Name,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, previous.Name, got.From)
+		})
+
+		t.Run("should fail when target tag is not among the most recent tags", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, mock.MatchedBy(func(p bitbucket.ListTagsParams) bool {
+				return p.Query != ""
+			})).Return(&bitbucket.Paginated[bitbucket.Tag]{Values: []bitbucket.Tag{newTag("v0.0.1")}}, nil)
+			mockClient.EXPECT().ListTags(mock.Anything, tokenProvider, mock.MatchedBy(func(p bitbucket.ListTagsParams) bool {
+				return p.Query == ""
+			})).Return(&bitbucket.Paginated[bitbucket.Tag]{
+				Values: []bitbucket.Tag{newTag("v2.0.0")},
+				Next:   "https://api.bitbucket.org/next",
+			}, nil).Times(tagsSearchMaxPages)
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        "v0.0.1",
+			})
+
+			require.ErrorContains(t, err, "tag v0.0.1 is not among the 2000 most recent tags")
+		})
+
+		t.Run("should truncate commits of given range", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			commits := lo.Times(tagChangesMaxCommits+1, func(_ int) bitbucket.Commit {
+				return bitbucket.Commit{Hash: faker.UUIDDigit()}
+			})
+			expectCommits(t, deps, tokenProvider, repoParams, "v2.0.0", "v1.0.0", commits)
+			mocks.GetMock[*MockbitbucketClient](t, deps.Client).EXPECT().
+				ListCommitPullRequests(mock.Anything, tokenProvider, mock.Anything).
+				Return(nil, nil).Times(tagChangesMaxCommits)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				From:                      "v1.0.0",
+				To:                        "v2.0.0",
+			})
+
+			require.NoError(t, err)
+			assert.True(t, got.Truncated)
+			assert.Len(t, got.Commits, tagChangesMaxCommits)
+		})
+
+		t.Run("should list all commits when there is no previous tag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			first := newTag("v0.1.0")
+			expectPreviousTagLookup(t, deps, tokenProvider, repoParams, first.Name, []bitbucket.Tag{first})
+			commits := []bitbucket.Commit{{Hash: faker.UUIDDigit(), Message: "Initial commit"}}
+			expectCommits(t, deps, tokenProvider, repoParams, first.Name, "", commits)
+			expectCommitPullRequests(t, deps, tokenProvider, repoParams, commits[0], nil, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				To:                        first.Name,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, got.From)
+			assert.Equal(t, commits, got.Commits)
+		})
+
+		t.Run("should fail when pull request can not be fetched", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			tokenProvider := setupTokenProvider(t, deps, repoParams.AccountName)
+			commit := bitbucket.Commit{Hash: faker.UUIDDigit(), Message: "Change (pull request #1)"}
+			expectCommits(t, deps, tokenProvider, repoParams, "v2", "v1", []bitbucket.Commit{commit})
+			expectCommitPullRequests(t, deps, tokenProvider, repoParams, commit,
+				nil, &middleware.HTTPError{StatusCode: http.StatusNotFound})
+			expectedErr := errors.New(faker.Sentence())
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, mock.Anything).Return(nil, expectedErr)
+			service := NewBitbucketService(deps)
+
+			_, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: repoParams,
+				From:                      "v1",
+				To:                        "v2",
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+
+		t.Run("should require target tag", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListTagChanges(t.Context(), BitbucketListTagChangesParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "target tag is required")
+		})
+	})
+}
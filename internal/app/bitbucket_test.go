@@ -3,6 +3,7 @@ package app
 import (
 	"encoding/json"
 	"errors"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -1051,9 +1052,10 @@ func TestBitbucketService(t *testing.T) {
 					assert.Equal(t, message, params.MergeParameters.Message)
 					assert.Equal(t, mergeStrategy, params.MergeParameters.MergeStrategy)
 					assert.Equal(t, closeSourceBranch, params.MergeParameters.CloseSourceBranch)
+					assert.True(t, params.Async)
 					return true
 				})).
-				Return(expectedPR, nil)
+				Return(&bitbucket.MergePRResult{PullRequest: expectedPR}, nil)
 
 			// Act
 			result, err := service.MergePR(t.Context(), BitbucketMergePRParams{
@@ -1107,7 +1109,7 @@ func TestBitbucketService(t *testing.T) {
 					assert.Equal(t, mergeStrategy, params.MergeParameters.MergeStrategy)
 					return true
 				})).
-				Return(expectedPR, nil)
+				Return(&bitbucket.MergePRResult{PullRequest: expectedPR}, nil)
 
 			// Act
 			result, err := service.MergePR(t.Context(), BitbucketMergePRParams{
@@ -1154,7 +1156,7 @@ func TestBitbucketService(t *testing.T) {
 					assert.Equal(t, mergeStrategy, params.MergeParameters.MergeStrategy)
 					return true
 				})).
-				Return(expectedPR, nil)
+				Return(&bitbucket.MergePRResult{PullRequest: expectedPR}, nil)
 
 			// Act
 			result, err := service.MergePR(t.Context(), BitbucketMergePRParams{
@@ -1207,6 +1209,146 @@ func TestBitbucketService(t *testing.T) {
 			assert.ErrorIs(t, err, clientErr)
 		})
 
+		t.Run("polls merge task until merge completes", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.MergePollInterval = time.Millisecond
+			deps.MergeTimeout = time.Second
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			service := NewBitbucketService(deps)
+
+			params := BitbucketMergePRParams{
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(10000),
+			}
+			taskID := faker.UUIDHyphenated()
+			tokenProvider := newStaticTokenProvider("token-" + faker.UUIDHyphenated())
+			mergedPR := bitbucket.NewRandomPullRequest(bitbucket.WithPullRequestState("MERGED"))
+			taskParams := bitbucket.GetMergeTaskStatusParams{
+				Username:      params.RepoOwner,
+				RepoSlug:      params.RepoName,
+				PullRequestID: params.PullRequestID,
+				TaskID:        taskID,
+			}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+			mockClient.EXPECT().
+				MergePR(mock.Anything, tokenProvider, mock.Anything).
+				Return(&bitbucket.MergePRResult{TaskID: taskID}, nil)
+			mockClient.EXPECT().
+				GetMergeTaskStatus(mock.Anything, tokenProvider, taskParams).
+				Return(&bitbucket.MergeTaskStatus{TaskStatus: bitbucket.MergeTaskStatusPending}, nil).
+				Times(2)
+			mockClient.EXPECT().
+				GetMergeTaskStatus(mock.Anything, tokenProvider, taskParams).
+				Return(&bitbucket.MergeTaskStatus{
+					TaskStatus:  bitbucket.MergeTaskStatusSuccess,
+					MergeResult: mergedPR,
+				}, nil).
+				Once()
+
+			result, err := service.MergePR(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, mergedPR, result)
+		})
+
+		t.Run("fetches pull request when merge task has no merge result", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.MergeTimeout = time.Second
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			service := NewBitbucketService(deps)
+
+			params := BitbucketMergePRParams{
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(10000),
+			}
+			tokenProvider := newStaticTokenProvider("token-" + faker.UUIDHyphenated())
+			mergedPR := bitbucket.NewRandomPullRequest(bitbucket.WithPullRequestState("MERGED"))
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").Return(tokenProvider)
+			mockClient.EXPECT().
+				MergePR(mock.Anything, tokenProvider, mock.Anything).
+				Return(&bitbucket.MergePRResult{TaskID: faker.UUIDHyphenated()}, nil)
+			mockClient.EXPECT().
+				GetMergeTaskStatus(mock.Anything, tokenProvider, mock.Anything).
+				Return(&bitbucket.MergeTaskStatus{TaskStatus: bitbucket.MergeTaskStatusSuccess}, nil)
+			mockClient.EXPECT().
+				GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+					Username:      params.RepoOwner,
+					RepoSlug:      params.RepoName,
+					PullRequestID: params.PullRequestID,
+				}).
+				Return(mergedPR, nil)
+
+			result, err := service.MergePR(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, mergedPR, result)
+		})
+
+		t.Run("fails with timeout merge error when merge task does not complete", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.MergePollInterval = time.Millisecond
+			deps.MergeTimeout = 10 * time.Millisecond
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			service := NewBitbucketService(deps)
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").
+				Return(newStaticTokenProvider("token-" + faker.UUIDHyphenated()))
+			mockClient.EXPECT().
+				MergePR(mock.Anything, mock.Anything, mock.Anything).
+				Return(&bitbucket.MergePRResult{TaskID: faker.UUIDHyphenated()}, nil)
+			mockClient.EXPECT().
+				GetMergeTaskStatus(mock.Anything, mock.Anything, mock.Anything).
+				Return(&bitbucket.MergeTaskStatus{TaskStatus: bitbucket.MergeTaskStatusPending}, nil)
+
+			result, err := service.MergePR(t.Context(), BitbucketMergePRParams{
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(10000),
+			})
+
+			assert.Nil(t, result)
+			var mergeErr *bitbucket.MergeError
+			require.ErrorAs(t, err, &mergeErr)
+			assert.Equal(t, bitbucket.MergeErrorReasonTimeout, mergeErr.Reason)
+		})
+
+		t.Run("fails when merge task fails", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.MergeTimeout = time.Second
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			service := NewBitbucketService(deps)
+
+			taskErr := &bitbucket.MergeError{
+				Reason:  bitbucket.MergeErrorReasonConflict,
+				Message: faker.Sentence(),
+			}
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, "").
+				Return(newStaticTokenProvider("token-" + faker.UUIDHyphenated()))
+			mockClient.EXPECT().
+				MergePR(mock.Anything, mock.Anything, mock.Anything).
+				Return(&bitbucket.MergePRResult{TaskID: faker.UUIDHyphenated()}, nil)
+			mockClient.EXPECT().
+				GetMergeTaskStatus(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, taskErr)
+
+			result, err := service.MergePR(t.Context(), BitbucketMergePRParams{
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(10000),
+			})
+
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, taskErr)
+		})
+
 		t.Run("fails when missing required parameters", func(t *testing.T) {
 			// Arrange
 			deps := makeMockDeps(t)
@@ -2289,9 +2431,13 @@ func TestBitbucketService(t *testing.T) {
 			filePath := "src/" + faker.Word() + ".go"
 			expectedContent := "package main\n\nfunc main() {}\n"
 			expectedMeta := bitbucket.FileContentMeta{
-				Size:     29,
-				Type:     "file",
-				Encoding: "utf-8",
+				Size:       29,
+				Type:       "file",
+				Encoding:   "utf-8",
+				MimeType:   "text/x-go",
+				TotalLines: 3,
+				FromLine:   1,
+				ToLine:     3,
 			}
 			expectedResult := &bitbucket.FileContentResult{
 				Content: expectedContent,
@@ -2305,6 +2451,20 @@ func TestBitbucketService(t *testing.T) {
 				getTokenProvider(mock.Anything, accountName).
 				Return(tokenProvider)
 
+			mockClient.EXPECT().
+				GetFileMeta(mock.Anything, tokenProvider, bitbucket.GetFileMetaParams{
+					Workspace: repoOwner,
+					RepoSlug:  repoName,
+					Commit:    commitHash,
+					Path:      filePath,
+				}).
+				Return(&bitbucket.TreeEntry{
+					Type:     bitbucket.TreeEntryTypeFile,
+					Path:     filePath,
+					Size:     29,
+					MimeType: "text/x-go",
+				}, nil)
+
 			// Mock the client to return expected file content
 			mockClient.EXPECT().
 				GetFileContent(
@@ -2889,6 +3049,14 @@ func TestBitbucketService(t *testing.T) {
 }
 
 // Helper for creating random tasks.
+// setupTokenProvider expects a token provider of the given account to be requested and returns it.
+func setupTokenProvider(t *testing.T, deps BitbucketServiceDeps, accountName string) bitbucket.TokenProvider {
+	mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+	tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+	mockAuth.EXPECT().getTokenProvider(mock.Anything, accountName).Return(tokenProvider)
+	return tokenProvider
+}
+
 func createRandomPaginatedTasks(count int) *bitbucket.PaginatedTasks {
 	tasks := make([]bitbucket.PullRequestCommentTask, count)
 	for i := range count {
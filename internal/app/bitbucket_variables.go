@@ -0,0 +1,265 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+// securedVariableMask replaces values of secured variables in results.
+const securedVariableMask = "********"
+
+// BitbucketRepositoryParams identifies a repository.
+type BitbucketRepositoryParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+}
+
+// BitbucketCreateRepositoryVariableParams contains parameters for creating a repository variable.
+type BitbucketCreateRepositoryVariableParams struct {
+	BitbucketRepositoryParams
+
+	// Variable name
+	Key string `json:"key"`
+
+	// Variable value
+	Value string `json:"value"`
+
+	// Secured variables are never exposed in logs or the API
+	Secured bool `json:"secured,omitempty"`
+}
+
+// BitbucketUpdateRepositoryVariableParams contains parameters for updating a repository variable.
+// The variable is looked up by VariableUUID when provided, otherwise by Key.
+type BitbucketUpdateRepositoryVariableParams struct {
+	BitbucketRepositoryParams
+
+	// Variable UUID (optional)
+	VariableUUID string `json:"variable_uuid,omitempty"`
+
+	// Variable name. Renames the variable when VariableUUID is provided.
+	Key string `json:"key"`
+
+	// New variable value
+	Value string `json:"value"`
+
+	// Changes the secured flag when provided (optional, keeps the current flag by default)
+	Secured *bool `json:"secured,omitempty"`
+}
+
+// BitbucketDeleteRepositoryVariableParams contains parameters for deleting a repository variable.
+// The variable is looked up by VariableUUID when provided, otherwise by Key.
+type BitbucketDeleteRepositoryVariableParams struct {
+	BitbucketRepositoryParams
+
+	// Variable UUID (optional)
+	VariableUUID string `json:"variable_uuid,omitempty"`
+
+	// Variable name (optional)
+	Key string `json:"key,omitempty"`
+}
+
+// ListRepositoryVariables lists pipelines variables of a repository. Values of secured variables are masked.
+func (s *BitbucketService) ListRepositoryVariables(
+	ctx context.Context,
+	params BitbucketRepositoryParams,
+) ([]bitbucket.PipelineVariable, error) {
+	s.logger.InfoContext(ctx, "Listing repository variables",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName))
+
+	if err := validateRepositoryParams(params); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	variables, err := s.listRepositoryVariables(ctx, tokenProvider, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(variables, func(v bitbucket.PipelineVariable, _ int) bitbucket.PipelineVariable {
+		return maskRepositoryVariable(v)
+	}), nil
+}
+
+// CreateRepositoryVariable creates a pipelines variable of a repository.
+func (s *BitbucketService) CreateRepositoryVariable(
+	ctx context.Context,
+	params BitbucketCreateRepositoryVariableParams,
+) (*bitbucket.PipelineVariable, error) {
+	s.logger.InfoContext(ctx, "Creating repository variable",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("key", params.Key),
+		slog.Bool("secured", params.Secured))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Key == "" {
+		return nil, errors.New("variable key is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	variable, err := s.client.CreateRepositoryVariable(ctx, tokenProvider, bitbucket.CreateRepositoryVariableParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+		Variable: bitbucket.PipelineVariable{
+			Key:     params.Key,
+			Value:   params.Value,
+			Secured: params.Secured,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository variable: %w", err)
+	}
+
+	return lo.ToPtr(maskRepositoryVariable(*variable)), nil
+}
+
+// UpdateRepositoryVariable updates the value, key or secured flag of a repository variable.
+func (s *BitbucketService) UpdateRepositoryVariable(
+	ctx context.Context,
+	params BitbucketUpdateRepositoryVariableParams,
+) (*bitbucket.PipelineVariable, error) {
+	s.logger.InfoContext(ctx, "Updating repository variable",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("variable_uuid", params.VariableUUID),
+		slog.String("key", params.Key))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return nil, err
+	}
+	if params.Key == "" {
+		return nil, errors.New("variable key is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	existing, err := s.findRepositoryVariable(
+		ctx, tokenProvider, params.BitbucketRepositoryParams, params.VariableUUID, params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := s.client.UpdateRepositoryVariable(ctx, tokenProvider, bitbucket.UpdateRepositoryVariableParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		VariableUUID: existing.UUID,
+		Variable: bitbucket.PipelineVariable{
+			UUID:    existing.UUID,
+			Key:     params.Key,
+			Value:   params.Value,
+			Secured: lo.FromPtrOr(params.Secured, existing.Secured),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repository variable: %w", err)
+	}
+
+	return lo.ToPtr(maskRepositoryVariable(*variable)), nil
+}
+
+// DeleteRepositoryVariable deletes a repository variable.
+func (s *BitbucketService) DeleteRepositoryVariable(
+	ctx context.Context,
+	params BitbucketDeleteRepositoryVariableParams,
+) error {
+	s.logger.InfoContext(ctx, "Deleting repository variable",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("variable_uuid", params.VariableUUID),
+		slog.String("key", params.Key))
+
+	if err := validateRepositoryParams(params.BitbucketRepositoryParams); err != nil {
+		return err
+	}
+	if params.VariableUUID == "" && params.Key == "" {
+		return errors.New("either variable UUID or key is required")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	variableUUID := params.VariableUUID
+	if variableUUID == "" {
+		existing, err := s.findRepositoryVariable(
+			ctx, tokenProvider, params.BitbucketRepositoryParams, "", params.Key)
+		if err != nil {
+			return err
+		}
+		variableUUID = existing.UUID
+	}
+
+	err := s.client.DeleteRepositoryVariable(ctx, tokenProvider, bitbucket.DeleteRepositoryVariableParams{
+		Workspace:    params.RepoOwner,
+		RepoSlug:     params.RepoName,
+		VariableUUID: variableUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete repository variable: %w", err)
+	}
+
+	return nil
+}
+
+func (s *BitbucketService) listRepositoryVariables(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+) ([]bitbucket.PipelineVariable, error) {
+	variables, err := s.client.ListRepositoryVariables(ctx, tokenProvider, bitbucket.ListRepositoryVariablesParams{
+		Workspace: params.RepoOwner,
+		RepoSlug:  params.RepoName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository variables: %w", err)
+	}
+	return variables, nil
+}
+
+// findRepositoryVariable looks up a variable by UUID, or by key when the UUID is empty.
+func (s *BitbucketService) findRepositoryVariable(
+	ctx context.Context,
+	tokenProvider bitbucket.TokenProvider,
+	params BitbucketRepositoryParams,
+	variableUUID string,
+	key string,
+) (*bitbucket.PipelineVariable, error) {
+	variables, err := s.listRepositoryVariables(ctx, tokenProvider, params)
+	if err != nil {
+		return nil, err
+	}
+	variable, found := lo.Find(variables, func(v bitbucket.PipelineVariable) bool {
+		if variableUUID != "" {
+			return v.UUID == normalizeUUID(variableUUID)
+		}
+		return v.Key == key
+	})
+	if !found {
+		return nil, fmt.Errorf("repository variable %s not found", lo.CoalesceOrEmpty(variableUUID, key))
+	}
+	return &variable, nil
+}
+
+func maskRepositoryVariable(variable bitbucket.PipelineVariable) bitbucket.PipelineVariable {
+	if variable.Secured {
+		variable.Value = securedVariableMask
+	}
+	return variable
+}
+
+func validateRepositoryParams(params BitbucketRepositoryParams) error {
+	if params.RepoOwner == "" {
+		return errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return errors.New("repository name is required")
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_RepositoryVariables(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:      NewMockbitbucketClient(t),
+			AuthFactory: NewMockbitbucketAuthFactory(t),
+			RootLogger:  diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	makeRepoParams := func() BitbucketRepositoryParams {
+		return BitbucketRepositoryParams{
+			AccountName: "account-" + faker.Username(),
+			RepoOwner:   "owner-" + faker.Username(),
+			RepoName:    "repo-" + faker.Username(),
+		}
+	}
+
+	newVariable := func(secured bool) bitbucket.PipelineVariable {
+		variable := bitbucket.PipelineVariable{
+			UUID:    "{" + faker.UUIDHyphenated() + "}",
+			Key:     "KEY_" + faker.Word() + "_" + faker.UUIDDigit(),
+			Secured: secured,
+		}
+		if !secured {
+			variable.Value = faker.Word()
+		}
+		return variable
+	}
+
+	setupList := func(
+		t *testing.T,
+		deps BitbucketServiceDeps,
+		params BitbucketRepositoryParams,
+		variables []bitbucket.PipelineVariable,
+	) bitbucket.TokenProvider {
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+		tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+		mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+		mockClient.EXPECT().ListRepositoryVariables(mock.Anything, tokenProvider, bitbucket.ListRepositoryVariablesParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+		}).Return(variables, nil)
+		return tokenProvider
+	}
+
+	t.Run("ListRepositoryVariables", func(t *testing.T) {
+		t.Run("should mask secured values", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			params := makeRepoParams()
+			plain := newVariable(false)
+			secured := newVariable(true)
+			setupList(t, deps, params, []bitbucket.PipelineVariable{plain, secured})
+			service := NewBitbucketService(deps)
+
+			got, err := service.ListRepositoryVariables(t.Context(), params)
+
+			require.NoError(t, err)
+			secured.Value = securedVariableMask
+			assert.Equal(t, []bitbucket.PipelineVariable{plain, secured}, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.ListRepositoryVariables(t.Context(), BitbucketRepositoryParams{RepoName: faker.Word()})
+			require.ErrorContains(t, err, "repository owner is required")
+
+			_, err = service.ListRepositoryVariables(t.Context(), BitbucketRepositoryParams{RepoOwner: faker.Word()})
+			require.ErrorContains(t, err, "repository name is required")
+		})
+	})
+
+	t.Run("CreateRepositoryVariable", func(t *testing.T) {
+		t.Run("should create variable and mask its value", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketCreateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				Key:                       "KEY_" + faker.Word(),
+				Value:                     faker.Password(),
+				Secured:                   true,
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			created := bitbucket.PipelineVariable{
+				UUID:    "{" + faker.UUIDHyphenated() + "}",
+				Key:     params.Key,
+				Secured: true,
+			}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().CreateRepositoryVariable(mock.Anything, tokenProvider,
+				bitbucket.CreateRepositoryVariableParams{
+					Workspace: params.RepoOwner,
+					RepoSlug:  params.RepoName,
+					Variable:  bitbucket.PipelineVariable{Key: params.Key, Value: params.Value, Secured: true},
+				}).Return(&created, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.CreateRepositoryVariable(t.Context(), params)
+
+			require.NoError(t, err)
+			created.Value = securedVariableMask
+			assert.Equal(t, &created, got)
+		})
+
+		t.Run("should require key", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			_, err := service.CreateRepositoryVariable(t.Context(), BitbucketCreateRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "variable key is required")
+		})
+	})
+
+	t.Run("UpdateRepositoryVariable", func(t *testing.T) {
+		t.Run("should update variable found by key keeping secured flag", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			existing := newVariable(true)
+			tokenProvider := setupList(t, deps, repoParams, []bitbucket.PipelineVariable{newVariable(false), existing})
+			params := BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: repoParams,
+				Key:                       existing.Key,
+				Value:                     faker.Password(),
+			}
+			updated := bitbucket.PipelineVariable{UUID: existing.UUID, Key: existing.Key, Secured: true}
+
+			mockClient.EXPECT().UpdateRepositoryVariable(mock.Anything, tokenProvider,
+				bitbucket.UpdateRepositoryVariableParams{
+					Workspace:    repoParams.RepoOwner,
+					RepoSlug:     repoParams.RepoName,
+					VariableUUID: existing.UUID,
+					Variable: bitbucket.PipelineVariable{
+						UUID:    existing.UUID,
+						Key:     existing.Key,
+						Value:   params.Value,
+						Secured: true,
+					},
+				}).Return(&updated, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.UpdateRepositoryVariable(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, securedVariableMask, got.Value)
+		})
+
+		t.Run("should rename variable found by UUID", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			existing := newVariable(false)
+			tokenProvider := setupList(t, deps, repoParams, []bitbucket.PipelineVariable{existing})
+			params := BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: repoParams,
+				VariableUUID:              existing.UUID[1 : len(existing.UUID)-1],
+				Key:                       "RENAMED_" + faker.Word(),
+				Value:                     faker.Word(),
+				Secured:                   lo.ToPtr(true),
+			}
+			updated := bitbucket.PipelineVariable{UUID: existing.UUID, Key: params.Key, Secured: true}
+
+			mockClient.EXPECT().UpdateRepositoryVariable(mock.Anything, tokenProvider,
+				bitbucket.UpdateRepositoryVariableParams{
+					Workspace:    repoParams.RepoOwner,
+					RepoSlug:     repoParams.RepoName,
+					VariableUUID: existing.UUID,
+					Variable: bitbucket.PipelineVariable{
+						UUID:    existing.UUID,
+						Key:     params.Key,
+						Value:   params.Value,
+						Secured: true,
+					},
+				}).Return(&updated, nil)
+			service := NewBitbucketService(deps)
+
+			got, err := service.UpdateRepositoryVariable(t.Context(), params)
+
+			require.NoError(t, err)
+			assert.Equal(t, params.Key, got.Key)
+		})
+
+		t.Run("should fail when variable is not found", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			repoParams := makeRepoParams()
+			setupList(t, deps, repoParams, []bitbucket.PipelineVariable{newVariable(false)})
+			service := NewBitbucketService(deps)
+
+			_, err := service.UpdateRepositoryVariable(t.Context(), BitbucketUpdateRepositoryVariableParams{
+				BitbucketRepositoryParams: repoParams,
+				Key:                       "MISSING_" + faker.Word(),
+			})
+
+			require.ErrorContains(t, err, "not found")
+		})
+	})
+
+	t.Run("DeleteRepositoryVariable", func(t *testing.T) {
+		t.Run("should delete variable by UUID", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := BitbucketDeleteRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+				VariableUUID:              "{" + faker.UUIDHyphenated() + "}",
+			}
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().DeleteRepositoryVariable(mock.Anything, tokenProvider,
+				bitbucket.DeleteRepositoryVariableParams{
+					Workspace:    params.RepoOwner,
+					RepoSlug:     params.RepoName,
+					VariableUUID: params.VariableUUID,
+				}).Return(nil)
+			service := NewBitbucketService(deps)
+
+			require.NoError(t, service.DeleteRepositoryVariable(t.Context(), params))
+		})
+
+		t.Run("should delete variable by key", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			repoParams := makeRepoParams()
+			existing := newVariable(false)
+			tokenProvider := setupList(t, deps, repoParams, []bitbucket.PipelineVariable{existing})
+			expectedErr := errors.New(faker.Sentence())
+
+			mockClient.EXPECT().DeleteRepositoryVariable(mock.Anything, tokenProvider,
+				bitbucket.DeleteRepositoryVariableParams{
+					Workspace:    repoParams.RepoOwner,
+					RepoSlug:     repoParams.RepoName,
+					VariableUUID: existing.UUID,
+				}).Return(expectedErr)
+			service := NewBitbucketService(deps)
+
+			err := service.DeleteRepositoryVariable(t.Context(), BitbucketDeleteRepositoryVariableParams{
+				BitbucketRepositoryParams: repoParams,
+				Key:                       existing.Key,
+			})
+
+			require.ErrorIs(t, err, expectedErr)
+		})
+
+		t.Run("should require variable UUID or key", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+
+			err := service.DeleteRepositoryVariable(t.Context(), BitbucketDeleteRepositoryVariableParams{
+				BitbucketRepositoryParams: makeRepoParams(),
+			})
+
+			require.ErrorContains(t, err, "either variable UUID or key is required")
+		})
+	})
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/samber/lo"
+)
+
+// WaitProgress describes the state observed by a single check of a wait operation.
+type WaitProgress struct {
+	// Attempt is the number of the check, starting at 1
+	Attempt int
+
+	// Message is a human readable description of the observed state
+	Message string
+}
+
+// WaitProgressFunc is notified after each check of a wait operation.
+type WaitProgressFunc func(ctx context.Context, progress WaitProgress)
+
+// BitbucketWaitForPipelineParams contains parameters for waiting for a pipeline run to complete.
+type BitbucketWaitForPipelineParams struct {
+	BitbucketPipelineParams
+
+	// Timeout is the maximum time to wait (optional, uses the configured timeout if zero)
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// BitbucketWaitForBuildStatusesParams contains parameters for waiting for build statuses of
+// a pull request head commit to complete.
+type BitbucketWaitForBuildStatusesParams struct {
+	// Account name to use for authentication (optional, uses default if empty)
+	AccountName string `json:"account_name,omitempty"`
+
+	// Repository owner (username/workspace)
+	RepoOwner string `json:"repo_owner"`
+
+	// Repository name (slug)
+	RepoName string `json:"repo_name"`
+
+	// Pull request ID
+	PullRequestID int `json:"pull_request_id"`
+
+	// Timeout is the maximum time to wait (optional, uses the configured timeout if zero)
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// PipelineWaitResult is the last observed state of a pipeline run.
+type PipelineWaitResult struct {
+	PipelineRun
+
+	// TimedOut is true when the pipeline did not complete within the timeout
+	TimedOut bool `json:"timed_out"`
+}
+
+// BuildStatusesWaitResult is the last observed state of build statuses of a commit.
+type BuildStatusesWaitResult struct {
+	Commit   string                   `json:"commit"`
+	Statuses []bitbucket.CommitStatus `json:"statuses"`
+
+	// TimedOut is true when some builds did not complete within the timeout
+	TimedOut bool `json:"timed_out"`
+}
+
+// WaitForPipeline polls a pipeline run until it completes or the timeout is reached.
+func (s *BitbucketService) WaitForPipeline(
+	ctx context.Context,
+	params BitbucketWaitForPipelineParams,
+	onProgress WaitProgressFunc,
+) (*PipelineWaitResult, error) {
+	s.logger.InfoContext(ctx, "Waiting for pipeline",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.String("pipeline_uuid", params.PipelineUUID))
+
+	if err := validatePipelineParams(params.BitbucketPipelineParams); err != nil {
+		return nil, err
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	result := &PipelineWaitResult{}
+	timedOut, err := s.pollWithBackoff(ctx, params.Timeout, func(attempt int) (bool, error) {
+		run, err := s.getPipelineRun(ctx, tokenProvider, params.BitbucketPipelineParams)
+		if err != nil {
+			return false, err
+		}
+		result.PipelineRun = *run
+		onProgress(ctx, WaitProgress{Attempt: attempt, Message: describePipelineProgress(run)})
+		return run.Pipeline.State != nil && run.Pipeline.State.Name == bitbucket.PipelineStateCompleted, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for pipeline: %w", err)
+	}
+	result.TimedOut = timedOut
+
+	return result, nil
+}
+
+// WaitForBuildStatuses polls build statuses of the pull request head commit until all of them
+// complete or the timeout is reached. The wait continues while no builds are reported yet.
+func (s *BitbucketService) WaitForBuildStatuses(
+	ctx context.Context,
+	params BitbucketWaitForBuildStatusesParams,
+	onProgress WaitProgressFunc,
+) (*BuildStatusesWaitResult, error) {
+	s.logger.InfoContext(ctx, "Waiting for build statuses",
+		slog.String("repo", params.RepoOwner+"/"+params.RepoName),
+		slog.Int("pr_id", params.PullRequestID))
+
+	if params.RepoOwner == "" {
+		return nil, errors.New("repository owner is required")
+	}
+	if params.RepoName == "" {
+		return nil, errors.New("repository name is required")
+	}
+	if params.PullRequestID <= 0 {
+		return nil, errors.New("pull request ID must be positive")
+	}
+
+	tokenProvider := s.authFactory.getTokenProvider(ctx, params.AccountName)
+	pr, err := s.client.GetPR(ctx, tokenProvider, bitbucket.GetPRParams{
+		Username:      params.RepoOwner,
+		RepoSlug:      params.RepoName,
+		PullRequestID: params.PullRequestID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+	if pr.Source.Commit == nil || pr.Source.Commit.Hash == "" {
+		return nil, errors.New("pull request head commit is not available")
+	}
+
+	result := &BuildStatusesWaitResult{Commit: pr.Source.Commit.Hash}
+	timedOut, err := s.pollWithBackoff(ctx, params.Timeout, func(attempt int) (bool, error) {
+		statuses, err := s.client.ListCommitStatuses(ctx, tokenProvider, bitbucket.ListCommitStatusesParams{
+			Workspace: params.RepoOwner,
+			RepoSlug:  params.RepoName,
+			Commit:    result.Commit,
+		})
+		if err != nil {
+			return false, err
+		}
+		result.Statuses = statuses
+		inProgress := lo.Filter(statuses, func(status bitbucket.CommitStatus, _ int) bool {
+			return status.State == bitbucket.CommitStatusStateInProgress
+		})
+		onProgress(ctx, WaitProgress{
+			Attempt: attempt,
+			Message: describeBuildStatusesProgress(statuses, inProgress),
+		})
+		return len(statuses) > 0 && len(inProgress) == 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for build statuses: %w", err)
+	}
+	result.TimedOut = timedOut
+
+	return result, nil
+}
+
+// pollWithBackoff calls check until it reports completion, returns an error or the timeout is reached.
+// The delay between checks starts at the poll interval and doubles up to the maximum poll interval.
+// The timeout is capped by the configured wait timeout.
+func (s *BitbucketService) pollWithBackoff(
+	ctx context.Context,
+	timeout time.Duration,
+	check func(attempt int) (bool, error),
+) (bool, error) {
+	if timeout <= 0 || timeout > s.waitTimeout {
+		timeout = s.waitTimeout
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	interval := s.waitPollInterval
+	for attempt := 1; ; attempt++ {
+		done, err := check(attempt)
+		if err != nil {
+			return false, err
+		}
+		if done {
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-deadline.C:
+			return true, nil
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, s.waitMaxPollInterval)
+	}
+}
+
+func describePipelineProgress(run *PipelineRun) string {
+	state := "UNKNOWN"
+	if run.Pipeline.State != nil {
+		state = run.Pipeline.State.Name
+		if run.Pipeline.State.Result != nil {
+			state += "/" + run.Pipeline.State.Result.Name
+		} else if run.Pipeline.State.Stage != nil {
+			state += "/" + run.Pipeline.State.Stage.Name
+		}
+	}
+	completed := lo.CountBy(run.Steps, func(step bitbucket.PipelineStep) bool {
+		return step.State != nil && step.State.Name == bitbucket.PipelineStateCompleted
+	})
+	return fmt.Sprintf("Pipeline #%d is %s, %d of %d steps completed",
+		run.Pipeline.BuildNumber, state, completed, len(run.Steps))
+}
+
+func describeBuildStatusesProgress(statuses, inProgress []bitbucket.CommitStatus) string {
+	if len(statuses) == 0 {
+		return "No builds reported yet"
+	}
+	message := fmt.Sprintf("%d of %d builds completed", len(statuses)-len(inProgress), len(statuses))
+	if len(inProgress) > 0 {
+		names := lo.Map(inProgress, func(status bitbucket.CommitStatus, _ int) string {
+			return lo.CoalesceOrEmpty(status.Name, status.Key)
+		})
+		message += ", in progress: " + strings.Join(names, ", ")
+	}
+	return message
+}
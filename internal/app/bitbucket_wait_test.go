@@ -0,0 +1,252 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_Wait(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:              NewMockbitbucketClient(t),
+			AuthFactory:         NewMockbitbucketAuthFactory(t),
+			RootLogger:          diag.RootTestLogger().With("test", t.Name()),
+			WaitPollInterval:    time.Millisecond,
+			WaitMaxPollInterval: 2 * time.Millisecond,
+			WaitTimeout:         time.Second,
+		}
+	}
+
+	recordProgress := func() (*[]WaitProgress, WaitProgressFunc) {
+		var progress []WaitProgress
+		return &progress, func(_ context.Context, p WaitProgress) {
+			progress = append(progress, p)
+		}
+	}
+
+	t.Run("WaitForPipeline", func(t *testing.T) {
+		makeParams := func() BitbucketWaitForPipelineParams {
+			return BitbucketWaitForPipelineParams{
+				BitbucketPipelineParams: BitbucketPipelineParams{
+					AccountName:  "account-" + faker.Username(),
+					RepoOwner:    "owner-" + faker.Username(),
+					RepoName:     "repo-" + faker.Username(),
+					PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+				},
+			}
+		}
+
+		t.Run("should poll until pipeline completes", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			buildNumber := 1 + rand.IntN(1000)
+			running := &bitbucket.Pipeline{
+				BuildNumber: buildNumber,
+				State: &bitbucket.PipelineState{
+					Name:  bitbucket.PipelineStateInProgress,
+					Stage: &bitbucket.PipelineStateResult{Name: "RUNNING"},
+				},
+			}
+			completed := &bitbucket.Pipeline{
+				BuildNumber: buildNumber,
+				State: &bitbucket.PipelineState{
+					Name:   bitbucket.PipelineStateCompleted,
+					Result: &bitbucket.PipelineStateResult{Name: bitbucket.PipelineResultSuccessful},
+				},
+			}
+			steps := []bitbucket.PipelineStep{
+				{UUID: faker.UUIDHyphenated(), State: &bitbucket.PipelineState{Name: bitbucket.PipelineStateCompleted}},
+			}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().GetPipeline(mock.Anything, tokenProvider, bitbucket.GetPipelineParams{
+				Workspace:    params.RepoOwner,
+				RepoSlug:     params.RepoName,
+				PipelineUUID: params.PipelineUUID,
+			}).Return(running, nil).Times(2)
+			mockClient.EXPECT().GetPipeline(mock.Anything, tokenProvider, mock.Anything).Return(completed, nil).Once()
+			mockClient.EXPECT().ListPipelineSteps(mock.Anything, tokenProvider, mock.Anything).Return(steps, nil).Times(3)
+			progress, onProgress := recordProgress()
+
+			got, err := NewBitbucketService(deps).WaitForPipeline(t.Context(), params, onProgress)
+
+			require.NoError(t, err)
+			assert.Equal(t, &PipelineWaitResult{PipelineRun: PipelineRun{Pipeline: completed, Steps: steps}}, got)
+			require.Len(t, *progress, 3)
+			assert.Equal(t, WaitProgress{
+				Attempt: 1,
+				Message: fmt.Sprintf("Pipeline #%d is IN_PROGRESS/RUNNING, 1 of 1 steps completed", buildNumber),
+			}, (*progress)[0])
+			assert.Equal(t, 3, (*progress)[2].Attempt)
+		})
+
+		t.Run("should report timeout", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.WaitTimeout = 10 * time.Millisecond
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			params.Timeout = time.Hour // capped by the configured timeout
+			running := &bitbucket.Pipeline{State: &bitbucket.PipelineState{Name: bitbucket.PipelineStatePending}}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, mock.Anything).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().GetPipeline(mock.Anything, mock.Anything, mock.Anything).Return(running, nil)
+			mockClient.EXPECT().ListPipelineSteps(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			_, onProgress := recordProgress()
+
+			got, err := NewBitbucketService(deps).WaitForPipeline(t.Context(), params, onProgress)
+
+			require.NoError(t, err)
+			assert.True(t, got.TimedOut)
+			assert.Equal(t, running, got.Pipeline)
+		})
+
+		t.Run("should stop when context is cancelled", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			deps.WaitPollInterval = time.Hour
+			deps.WaitMaxPollInterval = time.Hour
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			ctx, cancel := context.WithCancel(t.Context())
+			running := &bitbucket.Pipeline{State: &bitbucket.PipelineState{Name: bitbucket.PipelineStatePending}}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, mock.Anything).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().GetPipeline(mock.Anything, mock.Anything, mock.Anything).Return(running, nil)
+			mockClient.EXPECT().ListPipelineSteps(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			got, err := NewBitbucketService(deps).WaitForPipeline(ctx, makeParams(), func(context.Context, WaitProgress) {
+				cancel()
+			})
+
+			require.ErrorIs(t, err, context.Canceled)
+			assert.Nil(t, got)
+		})
+
+		t.Run("should fail when pipeline can not be loaded", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			expectedErr := errors.New(faker.Sentence())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, mock.Anything).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().GetPipeline(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+			_, onProgress := recordProgress()
+
+			got, err := NewBitbucketService(deps).WaitForPipeline(t.Context(), makeParams(), onProgress)
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, got)
+		})
+	})
+
+	t.Run("WaitForBuildStatuses", func(t *testing.T) {
+		makeParams := func() BitbucketWaitForBuildStatusesParams {
+			return BitbucketWaitForBuildStatusesParams{
+				AccountName:   "account-" + faker.Username(),
+				RepoOwner:     "owner-" + faker.Username(),
+				RepoName:      "repo-" + faker.Username(),
+				PullRequestID: 1 + rand.IntN(1000),
+			}
+		}
+
+		t.Run("should poll until all builds of head commit complete", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			params := makeParams()
+			tokenProvider := newStaticTokenProvider(faker.UUIDHyphenated())
+			pr := bitbucket.NewRandomPullRequest()
+			headCommit := faker.UUIDDigit()
+			pr.Source.Commit = &bitbucket.PullRequestCommit{Hash: headCommit}
+			buildKey := "build-" + faker.Word()
+			inProgress := []bitbucket.CommitStatus{
+				{Key: buildKey, State: bitbucket.CommitStatusStateInProgress},
+				{Key: "lint", Name: "Lint", State: bitbucket.CommitStatusStateSuccessful},
+			}
+			completed := []bitbucket.CommitStatus{
+				{Key: buildKey, State: bitbucket.CommitStatusStateFailed},
+				{Key: "lint", Name: "Lint", State: bitbucket.CommitStatusStateSuccessful},
+			}
+
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, params.AccountName).Return(tokenProvider)
+			mockClient.EXPECT().GetPR(mock.Anything, tokenProvider, bitbucket.GetPRParams{
+				Username:      params.RepoOwner,
+				RepoSlug:      params.RepoName,
+				PullRequestID: params.PullRequestID,
+			}).Return(pr, nil)
+			statusesParams := bitbucket.ListCommitStatusesParams{
+				Workspace: params.RepoOwner,
+				RepoSlug:  params.RepoName,
+				Commit:    headCommit,
+			}
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, statusesParams).Return(nil, nil).Once()
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, statusesParams).Return(inProgress, nil).Once()
+			mockClient.EXPECT().ListCommitStatuses(mock.Anything, tokenProvider, statusesParams).Return(completed, nil).Once()
+			progress, onProgress := recordProgress()
+
+			got, err := NewBitbucketService(deps).WaitForBuildStatuses(t.Context(), params, onProgress)
+
+			require.NoError(t, err)
+			assert.Equal(t, &BuildStatusesWaitResult{Commit: headCommit, Statuses: completed}, got)
+			assert.Equal(t, []WaitProgress{
+				{Attempt: 1, Message: "No builds reported yet"},
+				{Attempt: 2, Message: "1 of 2 builds completed, in progress: " + buildKey},
+				{Attempt: 3, Message: "2 of 2 builds completed"},
+			}, *progress)
+		})
+
+		t.Run("should fail when pull request can not be loaded", func(t *testing.T) {
+			deps := makeMockDeps(t)
+			mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+			mockAuth := mocks.GetMock[*MockbitbucketAuthFactory](t, deps.AuthFactory)
+			expectedErr := errors.New(faker.Sentence())
+			mockAuth.EXPECT().getTokenProvider(mock.Anything, mock.Anything).
+				Return(newStaticTokenProvider(faker.UUIDHyphenated()))
+			mockClient.EXPECT().GetPR(mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+			_, onProgress := recordProgress()
+
+			got, err := NewBitbucketService(deps).WaitForBuildStatuses(t.Context(), makeParams(), onProgress)
+
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, got)
+		})
+
+		t.Run("should validate required parameters", func(t *testing.T) {
+			service := NewBitbucketService(makeMockDeps(t))
+			validParams := makeParams()
+			_, onProgress := recordProgress()
+
+			noOwner := validParams
+			noOwner.RepoOwner = ""
+			_, err := service.WaitForBuildStatuses(t.Context(), noOwner, onProgress)
+			require.ErrorContains(t, err, "repository owner is required")
+
+			noName := validParams
+			noName.RepoName = ""
+			_, err = service.WaitForBuildStatuses(t.Context(), noName, onProgress)
+			require.ErrorContains(t, err, "repository name is required")
+
+			noID := validParams
+			noID.PullRequestID = 0
+			_, err = service.WaitForBuildStatuses(t.Context(), noID, onProgress)
+			require.ErrorContains(t, err, "pull request ID must be positive")
+		})
+	})
+}
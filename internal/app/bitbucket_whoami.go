@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+)
+
+// BitbucketIdentities lists the Bitbucket identities of configured accounts.
+type BitbucketIdentities struct {
+	// Accounts with Bitbucket credentials in the order of the configuration.
+	Accounts []BitbucketIdentity `json:"accounts"`
+}
+
+// BitbucketIdentity describes who a configured account authenticates as and what it can access.
+type BitbucketIdentity struct {
+	// Account name to pass as the account parameter of other tools
+	Account string `json:"account"`
+	Default bool   `json:"default,omitempty"`
+
+	// User the token authenticates as
+	User *bitbucket.Account `json:"user,omitempty"`
+
+	// Scopes granted to the token, only known for OAuth and access tokens
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Workspaces the user is a member of with the role of the user
+	Workspaces          []bitbucket.WorkspaceMembership `json:"workspaces,omitempty"`
+	WorkspacesTruncated bool                            `json:"workspaces_truncated,omitempty"`
+
+	// Error describes why the identity could not be resolved, e.g. an expired token.
+	// Access tokens of a repository or a workspace have no user and can not list workspaces.
+	Error string `json:"error,omitempty"`
+}
+
+// WhoAmI resolves the user, token scopes and workspaces of every account with Bitbucket credentials.
+// Failures are reported per account, so that one broken token does not hide the others.
+func (s *BitbucketService) WhoAmI(ctx context.Context) (*BitbucketIdentities, error) {
+	s.logger.InfoContext(ctx, "Resolving Bitbucket identities")
+
+	accounts, err := s.accountsRepo.ListAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	identities := &BitbucketIdentities{Accounts: []BitbucketIdentity{}}
+	for _, account := range accounts {
+		if account.Bitbucket == nil {
+			continue
+		}
+		identities.Accounts = append(identities.Accounts, s.resolveIdentity(ctx, account))
+	}
+	return identities, nil
+}
+
+// resolveIdentity looks up the user and workspaces of an account.
+func (s *BitbucketService) resolveIdentity(ctx context.Context, account AtlassianAccount) BitbucketIdentity {
+	identity := BitbucketIdentity{Account: account.Name, Default: account.Default}
+	tokenProvider := s.authFactory.getTokenProvider(ctx, account.Name)
+
+	var failures []string
+	user, err := s.client.GetCurrentUser(ctx, tokenProvider)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to resolve user of account",
+			slog.String("account", account.Name),
+			slog.Any("error", err))
+		failures = append(failures, "failed to get user: "+err.Error())
+	} else {
+		identity.User = &user.User
+		identity.Scopes = user.Scopes
+	}
+
+	memberships, err := s.client.ListWorkspaces(ctx, tokenProvider, bitbucket.ListWorkspacesParams{
+		Sort:    "workspace.slug",
+		PageLen: min(workspacesMaxLimit+1, discoveryMaxPageLen),
+		Limit:   workspacesMaxLimit + 1,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to list workspaces of account",
+			slog.String("account", account.Name),
+			slog.Any("error", err))
+		failures = append(failures, "failed to list workspaces: "+err.Error())
+	} else {
+		identity.Workspaces = memberships[:min(len(memberships), workspacesMaxLimit)]
+		identity.WorkspacesTruncated = len(memberships) > workspacesMaxLimit
+	}
+
+	identity.Error = strings.Join(failures, "; ")
+	return identity
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemyago/atlacp/internal/diag"
+	"github.com/gemyago/atlacp/internal/services/bitbucket"
+	"github.com/gemyago/atlacp/internal/testing/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketService_WhoAmI(t *testing.T) {
+	makeMockDeps := func(t *testing.T) BitbucketServiceDeps {
+		return BitbucketServiceDeps{
+			Client:       NewMockbitbucketClient(t),
+			AuthFactory:  NewMockbitbucketAuthFactory(t),
+			AccountsRepo: NewMockAtlassianAccountsRepository(t),
+			RootLogger:   diag.RootTestLogger().With("test", t.Name()),
+		}
+	}
+
+	t.Run("should resolve identities of bitbucket accounts", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockClient := mocks.GetMock[*MockbitbucketClient](t, deps.Client)
+		mockRepo := mocks.GetMock[*MockAtlassianAccountsRepository](t, deps.AccountsRepo)
+		defaultAccount := NewRandomAtlassianAccount(WithAtlassianAccountDefault(true))
+		jiraAccount := NewRandomAtlassianAccount()
+		jiraAccount.Bitbucket = nil
+		botAccount := NewRandomAtlassianAccount()
+		mockRepo.EXPECT().ListAccounts(mock.Anything).
+			Return([]AtlassianAccount{defaultAccount, jiraAccount, botAccount}, nil)
+
+		defaultTokenProvider := setupTokenProvider(t, deps, defaultAccount.Name)
+		user := &bitbucket.CurrentUser{
+			User:   bitbucket.Account{DisplayName: faker.Name(), UUID: faker.UUIDHyphenated()},
+			Scopes: []string{"pullrequest:write"},
+		}
+		memberships := []bitbucket.WorkspaceMembership{
+			{Permission: "member", Workspace: &bitbucket.Workspace{Slug: "acme"}},
+		}
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, defaultTokenProvider).Return(user, nil)
+		mockClient.EXPECT().ListWorkspaces(mock.Anything, defaultTokenProvider, bitbucket.ListWorkspacesParams{
+			Sort:    "workspace.slug",
+			PageLen: discoveryMaxPageLen,
+			Limit:   workspacesMaxLimit + 1,
+		}).Return(memberships, nil)
+
+		botTokenProvider := setupTokenProvider(t, deps, botAccount.Name)
+		userErr := errors.New(faker.Sentence())
+		workspacesErr := errors.New(faker.Sentence())
+		mockClient.EXPECT().GetCurrentUser(mock.Anything, botTokenProvider).Return(nil, userErr)
+		mockClient.EXPECT().ListWorkspaces(mock.Anything, botTokenProvider, mock.Anything).Return(nil, workspacesErr)
+		service := NewBitbucketService(deps)
+
+		identities, err := service.WhoAmI(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, &BitbucketIdentities{Accounts: []BitbucketIdentity{
+			{
+				Account:    defaultAccount.Name,
+				Default:    true,
+				User:       &user.User,
+				Scopes:     user.Scopes,
+				Workspaces: memberships,
+			},
+			{
+				Account: botAccount.Name,
+				Error: "failed to get user: " + userErr.Error() +
+					"; failed to list workspaces: " + workspacesErr.Error(),
+			},
+		}}, identities)
+	})
+
+	t.Run("should return error when accounts can not be listed", func(t *testing.T) {
+		deps := makeMockDeps(t)
+		mockRepo := mocks.GetMock[*MockAtlassianAccountsRepository](t, deps.AccountsRepo)
+		listErr := errors.New(faker.Sentence())
+		mockRepo.EXPECT().ListAccounts(mock.Anything).Return(nil, listErr)
+		service := NewBitbucketService(deps)
+
+		identities, err := service.WhoAmI(t.Context())
+
+		require.ErrorIs(t, err, listErr)
+		assert.Nil(t, identities)
+	})
+}
@@ -140,6 +140,64 @@ func (_c *MockAtlassianAccountsRepository_GetDefaultAccount_Call) RunAndReturn(r
 	return _c
 }
 
+// ListAccounts provides a mock function with given fields: ctx
+func (_m *MockAtlassianAccountsRepository) ListAccounts(ctx context.Context) ([]AtlassianAccount, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccounts")
+	}
+
+	var r0 []AtlassianAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]AtlassianAccount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []AtlassianAccount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AtlassianAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAtlassianAccountsRepository_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
+type MockAtlassianAccountsRepository_ListAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAtlassianAccountsRepository_Expecter) ListAccounts(ctx interface{}) *MockAtlassianAccountsRepository_ListAccounts_Call {
+	return &MockAtlassianAccountsRepository_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx)}
+}
+
+func (_c *MockAtlassianAccountsRepository_ListAccounts_Call) Run(run func(ctx context.Context)) *MockAtlassianAccountsRepository_ListAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAtlassianAccountsRepository_ListAccounts_Call) Return(_a0 []AtlassianAccount, _a1 error) *MockAtlassianAccountsRepository_ListAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAtlassianAccountsRepository_ListAccounts_Call) RunAndReturn(run func(context.Context) ([]AtlassianAccount, error)) *MockAtlassianAccountsRepository_ListAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAtlassianAccountsRepository creates a new instance of MockAtlassianAccountsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAtlassianAccountsRepository(t interface {
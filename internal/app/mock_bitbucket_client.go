@@ -150,28 +150,28 @@ func (_c *MockbitbucketClient_ApprovePR_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// CreatePR provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) CreatePR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePRParams) (*bitbucket.PullRequest, error) {
+// CommitFiles provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CommitFiles(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CommitFilesParams) (*bitbucket.Commit, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePR")
+		panic("no return value specified for CommitFiles")
 	}
 
-	var r0 *bitbucket.PullRequest
+	var r0 *bitbucket.Commit
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) (*bitbucket.PullRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CommitFilesParams) (*bitbucket.Commit, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) *bitbucket.PullRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CommitFilesParams) *bitbucket.Commit); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequest)
+			r0 = ret.Get(0).(*bitbucket.Commit)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CommitFilesParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -180,58 +180,58 @@ func (_m *MockbitbucketClient) CreatePR(ctx context.Context, tokenProvider bitbu
 	return r0, r1
 }
 
-// MockbitbucketClient_CreatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePR'
-type MockbitbucketClient_CreatePR_Call struct {
+// MockbitbucketClient_CommitFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitFiles'
+type MockbitbucketClient_CommitFiles_Call struct {
 	*mock.Call
 }
 
-// CreatePR is a helper method to define mock.On call
+// CommitFiles is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.CreatePRParams
-func (_e *MockbitbucketClient_Expecter) CreatePR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreatePR_Call {
-	return &MockbitbucketClient_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, tokenProvider, params)}
+//   - params bitbucket.CommitFilesParams
+func (_e *MockbitbucketClient_Expecter) CommitFiles(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CommitFiles_Call {
+	return &MockbitbucketClient_CommitFiles_Call{Call: _e.mock.On("CommitFiles", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_CreatePR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePRParams)) *MockbitbucketClient_CreatePR_Call {
+func (_c *MockbitbucketClient_CommitFiles_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CommitFilesParams)) *MockbitbucketClient_CommitFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreatePRParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CommitFilesParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_CreatePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketClient_CreatePR_Call {
+func (_c *MockbitbucketClient_CommitFiles_Call) Return(_a0 *bitbucket.Commit, _a1 error) *MockbitbucketClient_CommitFiles_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_CreatePR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketClient_CreatePR_Call {
+func (_c *MockbitbucketClient_CommitFiles_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CommitFilesParams) (*bitbucket.Commit, error)) *MockbitbucketClient_CommitFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePullRequestTask provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) CreatePullRequestTask(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error) {
+// CreateBranch provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreateBranch(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateBranchParams) (*bitbucket.Branch, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePullRequestTask")
+		panic("no return value specified for CreateBranch")
 	}
 
-	var r0 *bitbucket.PullRequestCommentTask
+	var r0 *bitbucket.Branch
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateBranchParams) (*bitbucket.Branch, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) *bitbucket.PullRequestCommentTask); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateBranchParams) *bitbucket.Branch); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequestCommentTask)
+			r0 = ret.Get(0).(*bitbucket.Branch)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateBranchParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -240,58 +240,58 @@ func (_m *MockbitbucketClient) CreatePullRequestTask(ctx context.Context, tokenP
 	return r0, r1
 }
 
-// MockbitbucketClient_CreatePullRequestTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePullRequestTask'
-type MockbitbucketClient_CreatePullRequestTask_Call struct {
+// MockbitbucketClient_CreateBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBranch'
+type MockbitbucketClient_CreateBranch_Call struct {
 	*mock.Call
 }
 
-// CreatePullRequestTask is a helper method to define mock.On call
+// CreateBranch is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.CreatePullRequestTaskParams
-func (_e *MockbitbucketClient_Expecter) CreatePullRequestTask(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreatePullRequestTask_Call {
-	return &MockbitbucketClient_CreatePullRequestTask_Call{Call: _e.mock.On("CreatePullRequestTask", ctx, tokenProvider, params)}
+//   - params bitbucket.CreateBranchParams
+func (_e *MockbitbucketClient_Expecter) CreateBranch(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreateBranch_Call {
+	return &MockbitbucketClient_CreateBranch_Call{Call: _e.mock.On("CreateBranch", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_CreatePullRequestTask_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePullRequestTaskParams)) *MockbitbucketClient_CreatePullRequestTask_Call {
+func (_c *MockbitbucketClient_CreateBranch_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateBranchParams)) *MockbitbucketClient_CreateBranch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreatePullRequestTaskParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreateBranchParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_CreatePullRequestTask_Call) Return(_a0 *bitbucket.PullRequestCommentTask, _a1 error) *MockbitbucketClient_CreatePullRequestTask_Call {
+func (_c *MockbitbucketClient_CreateBranch_Call) Return(_a0 *bitbucket.Branch, _a1 error) *MockbitbucketClient_CreateBranch_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_CreatePullRequestTask_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error)) *MockbitbucketClient_CreatePullRequestTask_Call {
+func (_c *MockbitbucketClient_CreateBranch_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreateBranchParams) (*bitbucket.Branch, error)) *MockbitbucketClient_CreateBranch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileContent provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) GetFileContent(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileContentParams) (*bitbucket.FileContent, error) {
+// CreateCommitStatus provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreateCommitStatus(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateCommitStatusParams) (*bitbucket.CommitStatus, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileContent")
+		panic("no return value specified for CreateCommitStatus")
 	}
 
-	var r0 *bitbucket.FileContent
+	var r0 *bitbucket.CommitStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) (*bitbucket.FileContent, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateCommitStatusParams) (*bitbucket.CommitStatus, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) *bitbucket.FileContent); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateCommitStatusParams) *bitbucket.CommitStatus); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.FileContent)
+			r0 = ret.Get(0).(*bitbucket.CommitStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateCommitStatusParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -300,50 +300,50 @@ func (_m *MockbitbucketClient) GetFileContent(ctx context.Context, tokenProvider
 	return r0, r1
 }
 
-// MockbitbucketClient_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
-type MockbitbucketClient_GetFileContent_Call struct {
+// MockbitbucketClient_CreateCommitStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCommitStatus'
+type MockbitbucketClient_CreateCommitStatus_Call struct {
 	*mock.Call
 }
 
-// GetFileContent is a helper method to define mock.On call
+// CreateCommitStatus is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.GetFileContentParams
-func (_e *MockbitbucketClient_Expecter) GetFileContent(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetFileContent_Call {
-	return &MockbitbucketClient_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, tokenProvider, params)}
+//   - params bitbucket.CreateCommitStatusParams
+func (_e *MockbitbucketClient_Expecter) CreateCommitStatus(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreateCommitStatus_Call {
+	return &MockbitbucketClient_CreateCommitStatus_Call{Call: _e.mock.On("CreateCommitStatus", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_GetFileContent_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileContentParams)) *MockbitbucketClient_GetFileContent_Call {
+func (_c *MockbitbucketClient_CreateCommitStatus_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateCommitStatusParams)) *MockbitbucketClient_CreateCommitStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetFileContentParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreateCommitStatusParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetFileContent_Call) Return(_a0 *bitbucket.FileContent, _a1 error) *MockbitbucketClient_GetFileContent_Call {
+func (_c *MockbitbucketClient_CreateCommitStatus_Call) Return(_a0 *bitbucket.CommitStatus, _a1 error) *MockbitbucketClient_CreateCommitStatus_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetFileContent_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) (*bitbucket.FileContent, error)) *MockbitbucketClient_GetFileContent_Call {
+func (_c *MockbitbucketClient_CreateCommitStatus_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreateCommitStatusParams) (*bitbucket.CommitStatus, error)) *MockbitbucketClient_CreateCommitStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPR provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) GetPR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRParams) (*bitbucket.PullRequest, error) {
+// CreatePR provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreatePR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePRParams) (*bitbucket.PullRequest, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPR")
+		panic("no return value specified for CreatePR")
 	}
 
 	var r0 *bitbucket.PullRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) (*bitbucket.PullRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) (*bitbucket.PullRequest, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) *bitbucket.PullRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) *bitbucket.PullRequest); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
@@ -351,7 +351,7 @@ func (_m *MockbitbucketClient) GetPR(ctx context.Context, tokenProvider bitbucke
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -360,56 +360,58 @@ func (_m *MockbitbucketClient) GetPR(ctx context.Context, tokenProvider bitbucke
 	return r0, r1
 }
 
-// MockbitbucketClient_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
-type MockbitbucketClient_GetPR_Call struct {
+// MockbitbucketClient_CreatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePR'
+type MockbitbucketClient_CreatePR_Call struct {
 	*mock.Call
 }
 
-// GetPR is a helper method to define mock.On call
+// CreatePR is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.GetPRParams
-func (_e *MockbitbucketClient_Expecter) GetPR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPR_Call {
-	return &MockbitbucketClient_GetPR_Call{Call: _e.mock.On("GetPR", ctx, tokenProvider, params)}
+//   - params bitbucket.CreatePRParams
+func (_e *MockbitbucketClient_Expecter) CreatePR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreatePR_Call {
+	return &MockbitbucketClient_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_GetPR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRParams)) *MockbitbucketClient_GetPR_Call {
+func (_c *MockbitbucketClient_CreatePR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePRParams)) *MockbitbucketClient_CreatePR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreatePRParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketClient_GetPR_Call {
+func (_c *MockbitbucketClient_CreatePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketClient_CreatePR_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) (*bitbucket.PullRequest, error)) *MockbitbucketClient_GetPR_Call {
+func (_c *MockbitbucketClient_CreatePR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketClient_CreatePR_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPRDiff provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) GetPRDiff(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffParams) (string, error) {
+// CreatePullRequestTask provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreatePullRequestTask(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPRDiff")
+		panic("no return value specified for CreatePullRequestTask")
 	}
 
-	var r0 string
+	var r0 *bitbucket.PullRequestCommentTask
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) *bitbucket.PullRequestCommentTask); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PullRequestCommentTask)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -418,83 +420,58 @@ func (_m *MockbitbucketClient) GetPRDiff(ctx context.Context, tokenProvider bitb
 	return r0, r1
 }
 
-// MockbitbucketClient_GetPRDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiff'
-type MockbitbucketClient_GetPRDiff_Call struct {
+// MockbitbucketClient_CreatePullRequestTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePullRequestTask'
+type MockbitbucketClient_CreatePullRequestTask_Call struct {
 	*mock.Call
 }
 
-// GetPRDiff is a helper method to define mock.On call
+// CreatePullRequestTask is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.GetPRDiffParams
-func (_e *MockbitbucketClient_Expecter) GetPRDiff(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPRDiff_Call {
-	return &MockbitbucketClient_GetPRDiff_Call{Call: _e.mock.On("GetPRDiff", ctx, tokenProvider, params)}
+//   - params bitbucket.CreatePullRequestTaskParams
+func (_e *MockbitbucketClient_Expecter) CreatePullRequestTask(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreatePullRequestTask_Call {
+	return &MockbitbucketClient_CreatePullRequestTask_Call{Call: _e.mock.On("CreatePullRequestTask", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_GetPRDiff_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffParams)) *MockbitbucketClient_GetPRDiff_Call {
+func (_c *MockbitbucketClient_CreatePullRequestTask_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreatePullRequestTaskParams)) *MockbitbucketClient_CreatePullRequestTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRDiffParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreatePullRequestTaskParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPRDiff_Call) Return(_a0 string, _a1 error) *MockbitbucketClient_GetPRDiff_Call {
+func (_c *MockbitbucketClient_CreatePullRequestTask_Call) Return(_a0 *bitbucket.PullRequestCommentTask, _a1 error) *MockbitbucketClient_CreatePullRequestTask_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPRDiff_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) (string, error)) *MockbitbucketClient_GetPRDiff_Call {
+func (_c *MockbitbucketClient_CreatePullRequestTask_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreatePullRequestTaskParams) (*bitbucket.PullRequestCommentTask, error)) *MockbitbucketClient_CreatePullRequestTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPRDiffStat provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) GetPRDiffStat(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffStatParams) (*struct {
-	Size    int                  `json:"size,omitempty"`
-	Page    int                  `json:"page,omitempty"`
-	PageLen int                  `json:"pagelen,omitempty"`
-	Values  []bitbucket.DiffStat `json:"values"`
-}, error) {
+// CreateRepositoryVariable provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreateRepositoryVariable(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPRDiffStat")
+		panic("no return value specified for CreateRepositoryVariable")
 	}
 
-	var r0 *struct {
-		Size    int                  `json:"size,omitempty"`
-		Page    int                  `json:"page,omitempty"`
-		PageLen int                  `json:"pagelen,omitempty"`
-		Values  []bitbucket.DiffStat `json:"values"`
-	}
+	var r0 *bitbucket.PipelineVariable
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) (*struct {
-		Size    int                  `json:"size,omitempty"`
-		Page    int                  `json:"page,omitempty"`
-		PageLen int                  `json:"pagelen,omitempty"`
-		Values  []bitbucket.DiffStat `json:"values"`
-	}, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) *struct {
-		Size    int                  `json:"size,omitempty"`
-		Page    int                  `json:"page,omitempty"`
-		PageLen int                  `json:"pagelen,omitempty"`
-		Values  []bitbucket.DiffStat `json:"values"`
-	}); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateRepositoryVariableParams) *bitbucket.PipelineVariable); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*struct {
-				Size    int                  `json:"size,omitempty"`
-				Page    int                  `json:"page,omitempty"`
-				PageLen int                  `json:"pagelen,omitempty"`
-				Values  []bitbucket.DiffStat `json:"values"`
-			})
+			r0 = ret.Get(0).(*bitbucket.PipelineVariable)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateRepositoryVariableParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -503,68 +480,58 @@ func (_m *MockbitbucketClient) GetPRDiffStat(ctx context.Context, tokenProvider
 	return r0, r1
 }
 
-// MockbitbucketClient_GetPRDiffStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiffStat'
-type MockbitbucketClient_GetPRDiffStat_Call struct {
+// MockbitbucketClient_CreateRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRepositoryVariable'
+type MockbitbucketClient_CreateRepositoryVariable_Call struct {
 	*mock.Call
 }
 
-// GetPRDiffStat is a helper method to define mock.On call
+// CreateRepositoryVariable is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.GetPRDiffStatParams
-func (_e *MockbitbucketClient_Expecter) GetPRDiffStat(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPRDiffStat_Call {
-	return &MockbitbucketClient_GetPRDiffStat_Call{Call: _e.mock.On("GetPRDiffStat", ctx, tokenProvider, params)}
+//   - params bitbucket.CreateRepositoryVariableParams
+func (_e *MockbitbucketClient_Expecter) CreateRepositoryVariable(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreateRepositoryVariable_Call {
+	return &MockbitbucketClient_CreateRepositoryVariable_Call{Call: _e.mock.On("CreateRepositoryVariable", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_GetPRDiffStat_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffStatParams)) *MockbitbucketClient_GetPRDiffStat_Call {
+func (_c *MockbitbucketClient_CreateRepositoryVariable_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateRepositoryVariableParams)) *MockbitbucketClient_CreateRepositoryVariable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRDiffStatParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreateRepositoryVariableParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPRDiffStat_Call) Return(_a0 *struct {
-	Size    int                  `json:"size,omitempty"`
-	Page    int                  `json:"page,omitempty"`
-	PageLen int                  `json:"pagelen,omitempty"`
-	Values  []bitbucket.DiffStat `json:"values"`
-}, _a1 error) *MockbitbucketClient_GetPRDiffStat_Call {
+func (_c *MockbitbucketClient_CreateRepositoryVariable_Call) Return(_a0 *bitbucket.PipelineVariable, _a1 error) *MockbitbucketClient_CreateRepositoryVariable_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_GetPRDiffStat_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) (*struct {
-	Size    int                  `json:"size,omitempty"`
-	Page    int                  `json:"page,omitempty"`
-	PageLen int                  `json:"pagelen,omitempty"`
-	Values  []bitbucket.DiffStat `json:"values"`
-}, error)) *MockbitbucketClient_GetPRDiffStat_Call {
+func (_c *MockbitbucketClient_CreateRepositoryVariable_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)) *MockbitbucketClient_CreateRepositoryVariable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPRComments provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) ListPRComments(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error) {
+// CreateTag provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) CreateTag(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateTagParams) (*bitbucket.Tag, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPRComments")
+		panic("no return value specified for CreateTag")
 	}
 
-	var r0 *bitbucket.ListPRCommentsResponse
+	var r0 *bitbucket.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateTagParams) (*bitbucket.Tag, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) *bitbucket.ListPRCommentsResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateTagParams) *bitbucket.Tag); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.ListPRCommentsResponse)
+			r0 = ret.Get(0).(*bitbucket.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.CreateTagParams) error); ok {
 		r1 = rf(ctx, tokenProvider, params)
 	} else {
 		r1 = ret.Error(1)
@@ -573,167 +540,2203 @@ func (_m *MockbitbucketClient) ListPRComments(ctx context.Context, tokenProvider
 	return r0, r1
 }
 
-// MockbitbucketClient_ListPRComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRComments'
-type MockbitbucketClient_ListPRComments_Call struct {
+// MockbitbucketClient_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type MockbitbucketClient_CreateTag_Call struct {
 	*mock.Call
 }
 
-// ListPRComments is a helper method to define mock.On call
+// CreateTag is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.ListPRCommentsParams
-func (_e *MockbitbucketClient_Expecter) ListPRComments(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPRComments_Call {
-	return &MockbitbucketClient_ListPRComments_Call{Call: _e.mock.On("ListPRComments", ctx, tokenProvider, params)}
+//   - params bitbucket.CreateTagParams
+func (_e *MockbitbucketClient_Expecter) CreateTag(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_CreateTag_Call {
+	return &MockbitbucketClient_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_ListPRComments_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommentsParams)) *MockbitbucketClient_ListPRComments_Call {
+func (_c *MockbitbucketClient_CreateTag_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.CreateTagParams)) *MockbitbucketClient_CreateTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPRCommentsParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.CreateTagParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_ListPRComments_Call) Return(_a0 *bitbucket.ListPRCommentsResponse, _a1 error) *MockbitbucketClient_ListPRComments_Call {
+func (_c *MockbitbucketClient_CreateTag_Call) Return(_a0 *bitbucket.Tag, _a1 error) *MockbitbucketClient_CreateTag_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockbitbucketClient_ListPRComments_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error)) *MockbitbucketClient_ListPRComments_Call {
+func (_c *MockbitbucketClient_CreateTag_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.CreateTagParams) (*bitbucket.Tag, error)) *MockbitbucketClient_CreateTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPullRequestTasks provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) ListPullRequestTasks(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error) {
+// DeleteBranch provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) DeleteBranch(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteBranchParams) error {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPullRequestTasks")
+		panic("no return value specified for DeleteBranch")
 	}
 
-	var r0 *bitbucket.PaginatedTasks
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error)); ok {
-		return rf(ctx, tokenProvider, params)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) *bitbucket.PaginatedTasks); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteBranchParams) error); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PaginatedTasks)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) error); ok {
-		r1 = rf(ctx, tokenProvider, params)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockbitbucketClient_ListPullRequestTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequestTasks'
-type MockbitbucketClient_ListPullRequestTasks_Call struct {
+// MockbitbucketClient_DeleteBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBranch'
+type MockbitbucketClient_DeleteBranch_Call struct {
 	*mock.Call
 }
 
-// ListPullRequestTasks is a helper method to define mock.On call
+// DeleteBranch is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.ListPullRequestTasksParams
-func (_e *MockbitbucketClient_Expecter) ListPullRequestTasks(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPullRequestTasks_Call {
-	return &MockbitbucketClient_ListPullRequestTasks_Call{Call: _e.mock.On("ListPullRequestTasks", ctx, tokenProvider, params)}
+//   - params bitbucket.DeleteBranchParams
+func (_e *MockbitbucketClient_Expecter) DeleteBranch(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_DeleteBranch_Call {
+	return &MockbitbucketClient_DeleteBranch_Call{Call: _e.mock.On("DeleteBranch", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_ListPullRequestTasks_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestTasksParams)) *MockbitbucketClient_ListPullRequestTasks_Call {
+func (_c *MockbitbucketClient_DeleteBranch_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteBranchParams)) *MockbitbucketClient_DeleteBranch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPullRequestTasksParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.DeleteBranchParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_ListPullRequestTasks_Call) Return(_a0 *bitbucket.PaginatedTasks, _a1 error) *MockbitbucketClient_ListPullRequestTasks_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockbitbucketClient_DeleteBranch_Call) Return(_a0 error) *MockbitbucketClient_DeleteBranch_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockbitbucketClient_ListPullRequestTasks_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error)) *MockbitbucketClient_ListPullRequestTasks_Call {
+func (_c *MockbitbucketClient_DeleteBranch_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteBranchParams) error) *MockbitbucketClient_DeleteBranch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MergePR provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) MergePR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.MergePRParams) (*bitbucket.PullRequest, error) {
+// DeleteRepositoryVariable provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) DeleteRepositoryVariable(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteRepositoryVariableParams) error {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MergePR")
+		panic("no return value specified for DeleteRepositoryVariable")
 	}
 
-	var r0 *bitbucket.PullRequest
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) (*bitbucket.PullRequest, error)); ok {
-		return rf(ctx, tokenProvider, params)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) *bitbucket.PullRequest); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteRepositoryVariableParams) error); ok {
 		r0 = rf(ctx, tokenProvider, params)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bitbucket.PullRequest)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) error); ok {
-		r1 = rf(ctx, tokenProvider, params)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockbitbucketClient_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
-type MockbitbucketClient_MergePR_Call struct {
+// MockbitbucketClient_DeleteRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRepositoryVariable'
+type MockbitbucketClient_DeleteRepositoryVariable_Call struct {
 	*mock.Call
 }
 
-// MergePR is a helper method to define mock.On call
+// DeleteRepositoryVariable is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tokenProvider bitbucket.TokenProvider
-//   - params bitbucket.MergePRParams
-func (_e *MockbitbucketClient_Expecter) MergePR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_MergePR_Call {
-	return &MockbitbucketClient_MergePR_Call{Call: _e.mock.On("MergePR", ctx, tokenProvider, params)}
+//   - params bitbucket.DeleteRepositoryVariableParams
+func (_e *MockbitbucketClient_Expecter) DeleteRepositoryVariable(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_DeleteRepositoryVariable_Call {
+	return &MockbitbucketClient_DeleteRepositoryVariable_Call{Call: _e.mock.On("DeleteRepositoryVariable", ctx, tokenProvider, params)}
 }
 
-func (_c *MockbitbucketClient_MergePR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.MergePRParams)) *MockbitbucketClient_MergePR_Call {
+func (_c *MockbitbucketClient_DeleteRepositoryVariable_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteRepositoryVariableParams)) *MockbitbucketClient_DeleteRepositoryVariable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.MergePRParams))
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.DeleteRepositoryVariableParams))
 	})
 	return _c
 }
 
-func (_c *MockbitbucketClient_MergePR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketClient_MergePR_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockbitbucketClient_DeleteRepositoryVariable_Call) Return(_a0 error) *MockbitbucketClient_DeleteRepositoryVariable_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockbitbucketClient_MergePR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) (*bitbucket.PullRequest, error)) *MockbitbucketClient_MergePR_Call {
+func (_c *MockbitbucketClient_DeleteRepositoryVariable_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteRepositoryVariableParams) error) *MockbitbucketClient_DeleteRepositoryVariable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RequestPRChanges provides a mock function with given fields: ctx, tokenProvider, params
-func (_m *MockbitbucketClient) RequestPRChanges(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.RequestPRChangesParams) (string, time.Time, error) {
+// DeleteTag provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) DeleteTag(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteTagParams) error {
 	ret := _m.Called(ctx, tokenProvider, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RequestPRChanges")
+		panic("no return value specified for DeleteTag")
 	}
 
-	var r0 string
-	var r1 time.Time
-	var r2 error
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteTagParams) error); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockbitbucketClient_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type MockbitbucketClient_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.DeleteTagParams
+func (_e *MockbitbucketClient_Expecter) DeleteTag(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_DeleteTag_Call {
+	return &MockbitbucketClient_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_DeleteTag_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.DeleteTagParams)) *MockbitbucketClient_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.DeleteTagParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_DeleteTag_Call) Return(_a0 error) *MockbitbucketClient_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockbitbucketClient_DeleteTag_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.DeleteTagParams) error) *MockbitbucketClient_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranch provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetBranch(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetBranchParams) (*bitbucket.Branch, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranch")
+	}
+
+	var r0 *bitbucket.Branch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetBranchParams) (*bitbucket.Branch, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetBranchParams) *bitbucket.Branch); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Branch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetBranchParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranch'
+type MockbitbucketClient_GetBranch_Call struct {
+	*mock.Call
+}
+
+// GetBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetBranchParams
+func (_e *MockbitbucketClient_Expecter) GetBranch(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetBranch_Call {
+	return &MockbitbucketClient_GetBranch_Call{Call: _e.mock.On("GetBranch", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetBranch_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetBranchParams)) *MockbitbucketClient_GetBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetBranchParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetBranch_Call) Return(_a0 *bitbucket.Branch, _a1 error) *MockbitbucketClient_GetBranch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetBranch_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetBranchParams) (*bitbucket.Branch, error)) *MockbitbucketClient_GetBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommit provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetCommit(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetCommitParams) (*bitbucket.Commit, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommit")
+	}
+
+	var r0 *bitbucket.Commit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetCommitParams) (*bitbucket.Commit, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetCommitParams) *bitbucket.Commit); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Commit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetCommitParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetCommit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommit'
+type MockbitbucketClient_GetCommit_Call struct {
+	*mock.Call
+}
+
+// GetCommit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetCommitParams
+func (_e *MockbitbucketClient_Expecter) GetCommit(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetCommit_Call {
+	return &MockbitbucketClient_GetCommit_Call{Call: _e.mock.On("GetCommit", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetCommit_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetCommitParams)) *MockbitbucketClient_GetCommit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetCommitParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetCommit_Call) Return(_a0 *bitbucket.Commit, _a1 error) *MockbitbucketClient_GetCommit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetCommit_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetCommitParams) (*bitbucket.Commit, error)) *MockbitbucketClient_GetCommit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrentUser provides a mock function with given fields: ctx, tokenProvider
+func (_m *MockbitbucketClient) GetCurrentUser(ctx context.Context, tokenProvider bitbucket.TokenProvider) (*bitbucket.CurrentUser, error) {
+	ret := _m.Called(ctx, tokenProvider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentUser")
+	}
+
+	var r0 *bitbucket.CurrentUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider) (*bitbucket.CurrentUser, error)); ok {
+		return rf(ctx, tokenProvider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider) *bitbucket.CurrentUser); ok {
+		r0 = rf(ctx, tokenProvider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.CurrentUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider) error); ok {
+		r1 = rf(ctx, tokenProvider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetCurrentUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentUser'
+type MockbitbucketClient_GetCurrentUser_Call struct {
+	*mock.Call
+}
+
+// GetCurrentUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+func (_e *MockbitbucketClient_Expecter) GetCurrentUser(ctx interface{}, tokenProvider interface{}) *MockbitbucketClient_GetCurrentUser_Call {
+	return &MockbitbucketClient_GetCurrentUser_Call{Call: _e.mock.On("GetCurrentUser", ctx, tokenProvider)}
+}
+
+func (_c *MockbitbucketClient_GetCurrentUser_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider)) *MockbitbucketClient_GetCurrentUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetCurrentUser_Call) Return(_a0 *bitbucket.CurrentUser, _a1 error) *MockbitbucketClient_GetCurrentUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetCurrentUser_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider) (*bitbucket.CurrentUser, error)) *MockbitbucketClient_GetCurrentUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileContent provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetFileContent(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileContentParams) (*bitbucket.FileContent, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileContent")
+	}
+
+	var r0 *bitbucket.FileContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) (*bitbucket.FileContent, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) *bitbucket.FileContent); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.FileContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type MockbitbucketClient_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetFileContentParams
+func (_e *MockbitbucketClient_Expecter) GetFileContent(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetFileContent_Call {
+	return &MockbitbucketClient_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetFileContent_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileContentParams)) *MockbitbucketClient_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetFileContentParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetFileContent_Call) Return(_a0 *bitbucket.FileContent, _a1 error) *MockbitbucketClient_GetFileContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetFileContent_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileContentParams) (*bitbucket.FileContent, error)) *MockbitbucketClient_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileMeta provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetFileMeta(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileMetaParams) (*bitbucket.TreeEntry, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileMeta")
+	}
+
+	var r0 *bitbucket.TreeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileMetaParams) (*bitbucket.TreeEntry, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileMetaParams) *bitbucket.TreeEntry); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.TreeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileMetaParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetFileMeta_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileMeta'
+type MockbitbucketClient_GetFileMeta_Call struct {
+	*mock.Call
+}
+
+// GetFileMeta is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetFileMetaParams
+func (_e *MockbitbucketClient_Expecter) GetFileMeta(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetFileMeta_Call {
+	return &MockbitbucketClient_GetFileMeta_Call{Call: _e.mock.On("GetFileMeta", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetFileMeta_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetFileMetaParams)) *MockbitbucketClient_GetFileMeta_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetFileMetaParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetFileMeta_Call) Return(_a0 *bitbucket.TreeEntry, _a1 error) *MockbitbucketClient_GetFileMeta_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetFileMeta_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetFileMetaParams) (*bitbucket.TreeEntry, error)) *MockbitbucketClient_GetFileMeta_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMergeTaskStatus provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetMergeTaskStatus(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetMergeTaskStatusParams) (*bitbucket.MergeTaskStatus, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMergeTaskStatus")
+	}
+
+	var r0 *bitbucket.MergeTaskStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetMergeTaskStatusParams) (*bitbucket.MergeTaskStatus, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetMergeTaskStatusParams) *bitbucket.MergeTaskStatus); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.MergeTaskStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetMergeTaskStatusParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetMergeTaskStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMergeTaskStatus'
+type MockbitbucketClient_GetMergeTaskStatus_Call struct {
+	*mock.Call
+}
+
+// GetMergeTaskStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetMergeTaskStatusParams
+func (_e *MockbitbucketClient_Expecter) GetMergeTaskStatus(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetMergeTaskStatus_Call {
+	return &MockbitbucketClient_GetMergeTaskStatus_Call{Call: _e.mock.On("GetMergeTaskStatus", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetMergeTaskStatus_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetMergeTaskStatusParams)) *MockbitbucketClient_GetMergeTaskStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetMergeTaskStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetMergeTaskStatus_Call) Return(_a0 *bitbucket.MergeTaskStatus, _a1 error) *MockbitbucketClient_GetMergeTaskStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetMergeTaskStatus_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetMergeTaskStatusParams) (*bitbucket.MergeTaskStatus, error)) *MockbitbucketClient_GetMergeTaskStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPR provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetPR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRParams) (*bitbucket.PullRequest, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPR")
+	}
+
+	var r0 *bitbucket.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) (*bitbucket.PullRequest, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) *bitbucket.PullRequest); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
+type MockbitbucketClient_GetPR_Call struct {
+	*mock.Call
+}
+
+// GetPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetPRParams
+func (_e *MockbitbucketClient_Expecter) GetPR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPR_Call {
+	return &MockbitbucketClient_GetPR_Call{Call: _e.mock.On("GetPR", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetPR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRParams)) *MockbitbucketClient_GetPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPR_Call) Return(_a0 *bitbucket.PullRequest, _a1 error) *MockbitbucketClient_GetPR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRParams) (*bitbucket.PullRequest, error)) *MockbitbucketClient_GetPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRDiff provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetPRDiff(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffParams) (string, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRDiff")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) (string, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) string); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetPRDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiff'
+type MockbitbucketClient_GetPRDiff_Call struct {
+	*mock.Call
+}
+
+// GetPRDiff is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetPRDiffParams
+func (_e *MockbitbucketClient_Expecter) GetPRDiff(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPRDiff_Call {
+	return &MockbitbucketClient_GetPRDiff_Call{Call: _e.mock.On("GetPRDiff", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetPRDiff_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffParams)) *MockbitbucketClient_GetPRDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRDiffParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPRDiff_Call) Return(_a0 string, _a1 error) *MockbitbucketClient_GetPRDiff_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPRDiff_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffParams) (string, error)) *MockbitbucketClient_GetPRDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPRDiffStat provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetPRDiffStat(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffStatParams) (*struct {
+	Size    int                  `json:"size,omitempty"`
+	Page    int                  `json:"page,omitempty"`
+	PageLen int                  `json:"pagelen,omitempty"`
+	Values  []bitbucket.DiffStat `json:"values"`
+}, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPRDiffStat")
+	}
+
+	var r0 *struct {
+		Size    int                  `json:"size,omitempty"`
+		Page    int                  `json:"page,omitempty"`
+		PageLen int                  `json:"pagelen,omitempty"`
+		Values  []bitbucket.DiffStat `json:"values"`
+	}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) (*struct {
+		Size    int                  `json:"size,omitempty"`
+		Page    int                  `json:"page,omitempty"`
+		PageLen int                  `json:"pagelen,omitempty"`
+		Values  []bitbucket.DiffStat `json:"values"`
+	}, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) *struct {
+		Size    int                  `json:"size,omitempty"`
+		Page    int                  `json:"page,omitempty"`
+		PageLen int                  `json:"pagelen,omitempty"`
+		Values  []bitbucket.DiffStat `json:"values"`
+	}); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*struct {
+				Size    int                  `json:"size,omitempty"`
+				Page    int                  `json:"page,omitempty"`
+				PageLen int                  `json:"pagelen,omitempty"`
+				Values  []bitbucket.DiffStat `json:"values"`
+			})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetPRDiffStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRDiffStat'
+type MockbitbucketClient_GetPRDiffStat_Call struct {
+	*mock.Call
+}
+
+// GetPRDiffStat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetPRDiffStatParams
+func (_e *MockbitbucketClient_Expecter) GetPRDiffStat(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPRDiffStat_Call {
+	return &MockbitbucketClient_GetPRDiffStat_Call{Call: _e.mock.On("GetPRDiffStat", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetPRDiffStat_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPRDiffStatParams)) *MockbitbucketClient_GetPRDiffStat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPRDiffStatParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPRDiffStat_Call) Return(_a0 *struct {
+	Size    int                  `json:"size,omitempty"`
+	Page    int                  `json:"page,omitempty"`
+	PageLen int                  `json:"pagelen,omitempty"`
+	Values  []bitbucket.DiffStat `json:"values"`
+}, _a1 error) *MockbitbucketClient_GetPRDiffStat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPRDiffStat_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPRDiffStatParams) (*struct {
+	Size    int                  `json:"size,omitempty"`
+	Page    int                  `json:"page,omitempty"`
+	PageLen int                  `json:"pagelen,omitempty"`
+	Values  []bitbucket.DiffStat `json:"values"`
+}, error)) *MockbitbucketClient_GetPRDiffStat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPipeline provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetPipeline(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPipelineParams) (*bitbucket.Pipeline, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipeline")
+	}
+
+	var r0 *bitbucket.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineParams) (*bitbucket.Pipeline, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineParams) *bitbucket.Pipeline); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPipeline'
+type MockbitbucketClient_GetPipeline_Call struct {
+	*mock.Call
+}
+
+// GetPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetPipelineParams
+func (_e *MockbitbucketClient_Expecter) GetPipeline(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPipeline_Call {
+	return &MockbitbucketClient_GetPipeline_Call{Call: _e.mock.On("GetPipeline", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetPipeline_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPipelineParams)) *MockbitbucketClient_GetPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPipeline_Call) Return(_a0 *bitbucket.Pipeline, _a1 error) *MockbitbucketClient_GetPipeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPipeline_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineParams) (*bitbucket.Pipeline, error)) *MockbitbucketClient_GetPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPipelineStepLog provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetPipelineStepLog(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPipelineStepLogParams) (*bitbucket.PipelineStepLog, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipelineStepLog")
+	}
+
+	var r0 *bitbucket.PipelineStepLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineStepLogParams) (*bitbucket.PipelineStepLog, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineStepLogParams) *bitbucket.PipelineStepLog); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PipelineStepLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineStepLogParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetPipelineStepLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPipelineStepLog'
+type MockbitbucketClient_GetPipelineStepLog_Call struct {
+	*mock.Call
+}
+
+// GetPipelineStepLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetPipelineStepLogParams
+func (_e *MockbitbucketClient_Expecter) GetPipelineStepLog(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetPipelineStepLog_Call {
+	return &MockbitbucketClient_GetPipelineStepLog_Call{Call: _e.mock.On("GetPipelineStepLog", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetPipelineStepLog_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetPipelineStepLogParams)) *MockbitbucketClient_GetPipelineStepLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetPipelineStepLogParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPipelineStepLog_Call) Return(_a0 *bitbucket.PipelineStepLog, _a1 error) *MockbitbucketClient_GetPipelineStepLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetPipelineStepLog_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetPipelineStepLogParams) (*bitbucket.PipelineStepLog, error)) *MockbitbucketClient_GetPipelineStepLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepository provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) GetRepository(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetRepositoryParams) (*bitbucket.Repository, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepository")
+	}
+
+	var r0 *bitbucket.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetRepositoryParams) (*bitbucket.Repository, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetRepositoryParams) *bitbucket.Repository); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.GetRepositoryParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_GetRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepository'
+type MockbitbucketClient_GetRepository_Call struct {
+	*mock.Call
+}
+
+// GetRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.GetRepositoryParams
+func (_e *MockbitbucketClient_Expecter) GetRepository(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_GetRepository_Call {
+	return &MockbitbucketClient_GetRepository_Call{Call: _e.mock.On("GetRepository", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_GetRepository_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.GetRepositoryParams)) *MockbitbucketClient_GetRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.GetRepositoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetRepository_Call) Return(_a0 *bitbucket.Repository, _a1 error) *MockbitbucketClient_GetRepository_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_GetRepository_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.GetRepositoryParams) (*bitbucket.Repository, error)) *MockbitbucketClient_GetRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBranchRestrictions provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListBranchRestrictions(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListBranchRestrictionsParams) ([]bitbucket.BranchRestriction, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBranchRestrictions")
+	}
+
+	var r0 []bitbucket.BranchRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchRestrictionsParams) ([]bitbucket.BranchRestriction, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchRestrictionsParams) []bitbucket.BranchRestriction); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.BranchRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchRestrictionsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListBranchRestrictions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBranchRestrictions'
+type MockbitbucketClient_ListBranchRestrictions_Call struct {
+	*mock.Call
+}
+
+// ListBranchRestrictions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListBranchRestrictionsParams
+func (_e *MockbitbucketClient_Expecter) ListBranchRestrictions(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListBranchRestrictions_Call {
+	return &MockbitbucketClient_ListBranchRestrictions_Call{Call: _e.mock.On("ListBranchRestrictions", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListBranchRestrictions_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListBranchRestrictionsParams)) *MockbitbucketClient_ListBranchRestrictions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListBranchRestrictionsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListBranchRestrictions_Call) Return(_a0 []bitbucket.BranchRestriction, _a1 error) *MockbitbucketClient_ListBranchRestrictions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListBranchRestrictions_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchRestrictionsParams) ([]bitbucket.BranchRestriction, error)) *MockbitbucketClient_ListBranchRestrictions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBranches provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListBranches(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListBranchesParams) (*bitbucket.Paginated[bitbucket.Branch], error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBranches")
+	}
+
+	var r0 *bitbucket.Paginated[bitbucket.Branch]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchesParams) (*bitbucket.Paginated[bitbucket.Branch], error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchesParams) *bitbucket.Paginated[bitbucket.Branch]); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Paginated[bitbucket.Branch])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListBranches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBranches'
+type MockbitbucketClient_ListBranches_Call struct {
+	*mock.Call
+}
+
+// ListBranches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListBranchesParams
+func (_e *MockbitbucketClient_Expecter) ListBranches(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListBranches_Call {
+	return &MockbitbucketClient_ListBranches_Call{Call: _e.mock.On("ListBranches", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListBranches_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListBranchesParams)) *MockbitbucketClient_ListBranches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListBranchesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListBranches_Call) Return(_a0 *bitbucket.Paginated[bitbucket.Branch], _a1 error) *MockbitbucketClient_ListBranches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListBranches_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListBranchesParams) (*bitbucket.Paginated[bitbucket.Branch], error)) *MockbitbucketClient_ListBranches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCommitPullRequests provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListCommitPullRequests(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitPullRequestsParams) ([]bitbucket.PullRequest, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommitPullRequests")
+	}
+
+	var r0 []bitbucket.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitPullRequestsParams) ([]bitbucket.PullRequest, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitPullRequestsParams) []bitbucket.PullRequest); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitPullRequestsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListCommitPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommitPullRequests'
+type MockbitbucketClient_ListCommitPullRequests_Call struct {
+	*mock.Call
+}
+
+// ListCommitPullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListCommitPullRequestsParams
+func (_e *MockbitbucketClient_Expecter) ListCommitPullRequests(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListCommitPullRequests_Call {
+	return &MockbitbucketClient_ListCommitPullRequests_Call{Call: _e.mock.On("ListCommitPullRequests", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListCommitPullRequests_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitPullRequestsParams)) *MockbitbucketClient_ListCommitPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListCommitPullRequestsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommitPullRequests_Call) Return(_a0 []bitbucket.PullRequest, _a1 error) *MockbitbucketClient_ListCommitPullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommitPullRequests_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitPullRequestsParams) ([]bitbucket.PullRequest, error)) *MockbitbucketClient_ListCommitPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCommitStatuses provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListCommitStatuses(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitStatusesParams) ([]bitbucket.CommitStatus, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommitStatuses")
+	}
+
+	var r0 []bitbucket.CommitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitStatusesParams) ([]bitbucket.CommitStatus, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitStatusesParams) []bitbucket.CommitStatus); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.CommitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitStatusesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListCommitStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommitStatuses'
+type MockbitbucketClient_ListCommitStatuses_Call struct {
+	*mock.Call
+}
+
+// ListCommitStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListCommitStatusesParams
+func (_e *MockbitbucketClient_Expecter) ListCommitStatuses(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListCommitStatuses_Call {
+	return &MockbitbucketClient_ListCommitStatuses_Call{Call: _e.mock.On("ListCommitStatuses", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListCommitStatuses_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitStatusesParams)) *MockbitbucketClient_ListCommitStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListCommitStatusesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommitStatuses_Call) Return(_a0 []bitbucket.CommitStatus, _a1 error) *MockbitbucketClient_ListCommitStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommitStatuses_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitStatusesParams) ([]bitbucket.CommitStatus, error)) *MockbitbucketClient_ListCommitStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCommits provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListCommits(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitsParams) ([]bitbucket.Commit, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommits")
+	}
+
+	var r0 []bitbucket.Commit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitsParams) ([]bitbucket.Commit, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitsParams) []bitbucket.Commit); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Commit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommits'
+type MockbitbucketClient_ListCommits_Call struct {
+	*mock.Call
+}
+
+// ListCommits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListCommitsParams
+func (_e *MockbitbucketClient_Expecter) ListCommits(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListCommits_Call {
+	return &MockbitbucketClient_ListCommits_Call{Call: _e.mock.On("ListCommits", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListCommits_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListCommitsParams)) *MockbitbucketClient_ListCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListCommitsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommits_Call) Return(_a0 []bitbucket.Commit, _a1 error) *MockbitbucketClient_ListCommits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListCommits_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListCommitsParams) ([]bitbucket.Commit, error)) *MockbitbucketClient_ListCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeployments provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListDeployments(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListDeploymentsParams) (*bitbucket.Paginated[bitbucket.Deployment], error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeployments")
+	}
+
+	var r0 *bitbucket.Paginated[bitbucket.Deployment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDeploymentsParams) (*bitbucket.Paginated[bitbucket.Deployment], error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDeploymentsParams) *bitbucket.Paginated[bitbucket.Deployment]); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Paginated[bitbucket.Deployment])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDeploymentsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListDeployments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeployments'
+type MockbitbucketClient_ListDeployments_Call struct {
+	*mock.Call
+}
+
+// ListDeployments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListDeploymentsParams
+func (_e *MockbitbucketClient_Expecter) ListDeployments(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListDeployments_Call {
+	return &MockbitbucketClient_ListDeployments_Call{Call: _e.mock.On("ListDeployments", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListDeployments_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListDeploymentsParams)) *MockbitbucketClient_ListDeployments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListDeploymentsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListDeployments_Call) Return(_a0 *bitbucket.Paginated[bitbucket.Deployment], _a1 error) *MockbitbucketClient_ListDeployments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListDeployments_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListDeploymentsParams) (*bitbucket.Paginated[bitbucket.Deployment], error)) *MockbitbucketClient_ListDeployments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDirectory provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListDirectory(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListDirectoryParams) ([]bitbucket.TreeEntry, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDirectory")
+	}
+
+	var r0 []bitbucket.TreeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDirectoryParams) ([]bitbucket.TreeEntry, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDirectoryParams) []bitbucket.TreeEntry); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.TreeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListDirectoryParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDirectory'
+type MockbitbucketClient_ListDirectory_Call struct {
+	*mock.Call
+}
+
+// ListDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListDirectoryParams
+func (_e *MockbitbucketClient_Expecter) ListDirectory(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListDirectory_Call {
+	return &MockbitbucketClient_ListDirectory_Call{Call: _e.mock.On("ListDirectory", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListDirectory_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListDirectoryParams)) *MockbitbucketClient_ListDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListDirectoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListDirectory_Call) Return(_a0 []bitbucket.TreeEntry, _a1 error) *MockbitbucketClient_ListDirectory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListDirectory_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListDirectoryParams) ([]bitbucket.TreeEntry, error)) *MockbitbucketClient_ListDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEnvironments provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListEnvironments(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListEnvironmentsParams) ([]bitbucket.DeploymentEnvironment, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEnvironments")
+	}
+
+	var r0 []bitbucket.DeploymentEnvironment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListEnvironmentsParams) ([]bitbucket.DeploymentEnvironment, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListEnvironmentsParams) []bitbucket.DeploymentEnvironment); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.DeploymentEnvironment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListEnvironmentsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListEnvironments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEnvironments'
+type MockbitbucketClient_ListEnvironments_Call struct {
+	*mock.Call
+}
+
+// ListEnvironments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListEnvironmentsParams
+func (_e *MockbitbucketClient_Expecter) ListEnvironments(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListEnvironments_Call {
+	return &MockbitbucketClient_ListEnvironments_Call{Call: _e.mock.On("ListEnvironments", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListEnvironments_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListEnvironmentsParams)) *MockbitbucketClient_ListEnvironments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListEnvironmentsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListEnvironments_Call) Return(_a0 []bitbucket.DeploymentEnvironment, _a1 error) *MockbitbucketClient_ListEnvironments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListEnvironments_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListEnvironmentsParams) ([]bitbucket.DeploymentEnvironment, error)) *MockbitbucketClient_ListEnvironments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFileHistory provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListFileHistory(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListFileHistoryParams) ([]bitbucket.TreeEntry, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFileHistory")
+	}
+
+	var r0 []bitbucket.TreeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListFileHistoryParams) ([]bitbucket.TreeEntry, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListFileHistoryParams) []bitbucket.TreeEntry); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.TreeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListFileHistoryParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListFileHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFileHistory'
+type MockbitbucketClient_ListFileHistory_Call struct {
+	*mock.Call
+}
+
+// ListFileHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListFileHistoryParams
+func (_e *MockbitbucketClient_Expecter) ListFileHistory(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListFileHistory_Call {
+	return &MockbitbucketClient_ListFileHistory_Call{Call: _e.mock.On("ListFileHistory", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListFileHistory_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListFileHistoryParams)) *MockbitbucketClient_ListFileHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListFileHistoryParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListFileHistory_Call) Return(_a0 []bitbucket.TreeEntry, _a1 error) *MockbitbucketClient_ListFileHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListFileHistory_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListFileHistoryParams) ([]bitbucket.TreeEntry, error)) *MockbitbucketClient_ListFileHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPRComments provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPRComments(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPRComments")
+	}
+
+	var r0 *bitbucket.ListPRCommentsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) *bitbucket.ListPRCommentsResponse); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.ListPRCommentsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPRComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRComments'
+type MockbitbucketClient_ListPRComments_Call struct {
+	*mock.Call
+}
+
+// ListPRComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPRCommentsParams
+func (_e *MockbitbucketClient_Expecter) ListPRComments(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPRComments_Call {
+	return &MockbitbucketClient_ListPRComments_Call{Call: _e.mock.On("ListPRComments", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPRComments_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommentsParams)) *MockbitbucketClient_ListPRComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPRCommentsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRComments_Call) Return(_a0 *bitbucket.ListPRCommentsResponse, _a1 error) *MockbitbucketClient_ListPRComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRComments_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommentsParams) (*bitbucket.ListPRCommentsResponse, error)) *MockbitbucketClient_ListPRComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPRCommits provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPRCommits(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommitsParams) ([]bitbucket.Commit, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPRCommits")
+	}
+
+	var r0 []bitbucket.Commit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommitsParams) ([]bitbucket.Commit, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommitsParams) []bitbucket.Commit); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Commit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommitsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPRCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRCommits'
+type MockbitbucketClient_ListPRCommits_Call struct {
+	*mock.Call
+}
+
+// ListPRCommits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPRCommitsParams
+func (_e *MockbitbucketClient_Expecter) ListPRCommits(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPRCommits_Call {
+	return &MockbitbucketClient_ListPRCommits_Call{Call: _e.mock.On("ListPRCommits", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPRCommits_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRCommitsParams)) *MockbitbucketClient_ListPRCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPRCommitsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRCommits_Call) Return(_a0 []bitbucket.Commit, _a1 error) *MockbitbucketClient_ListPRCommits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRCommits_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRCommitsParams) ([]bitbucket.Commit, error)) *MockbitbucketClient_ListPRCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPRStatuses provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPRStatuses(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRStatusesParams) ([]bitbucket.CommitStatus, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPRStatuses")
+	}
+
+	var r0 []bitbucket.CommitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRStatusesParams) ([]bitbucket.CommitStatus, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRStatusesParams) []bitbucket.CommitStatus); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.CommitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRStatusesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPRStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRStatuses'
+type MockbitbucketClient_ListPRStatuses_Call struct {
+	*mock.Call
+}
+
+// ListPRStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPRStatusesParams
+func (_e *MockbitbucketClient_Expecter) ListPRStatuses(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPRStatuses_Call {
+	return &MockbitbucketClient_ListPRStatuses_Call{Call: _e.mock.On("ListPRStatuses", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPRStatuses_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPRStatusesParams)) *MockbitbucketClient_ListPRStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPRStatusesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRStatuses_Call) Return(_a0 []bitbucket.CommitStatus, _a1 error) *MockbitbucketClient_ListPRStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPRStatuses_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPRStatusesParams) ([]bitbucket.CommitStatus, error)) *MockbitbucketClient_ListPRStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPipelineSteps provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPipelineSteps(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPipelineStepsParams) ([]bitbucket.PipelineStep, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPipelineSteps")
+	}
+
+	var r0 []bitbucket.PipelineStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelineStepsParams) ([]bitbucket.PipelineStep, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelineStepsParams) []bitbucket.PipelineStep); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.PipelineStep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelineStepsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPipelineSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPipelineSteps'
+type MockbitbucketClient_ListPipelineSteps_Call struct {
+	*mock.Call
+}
+
+// ListPipelineSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPipelineStepsParams
+func (_e *MockbitbucketClient_Expecter) ListPipelineSteps(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPipelineSteps_Call {
+	return &MockbitbucketClient_ListPipelineSteps_Call{Call: _e.mock.On("ListPipelineSteps", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPipelineSteps_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPipelineStepsParams)) *MockbitbucketClient_ListPipelineSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPipelineStepsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPipelineSteps_Call) Return(_a0 []bitbucket.PipelineStep, _a1 error) *MockbitbucketClient_ListPipelineSteps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPipelineSteps_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelineStepsParams) ([]bitbucket.PipelineStep, error)) *MockbitbucketClient_ListPipelineSteps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPipelines provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPipelines(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPipelinesParams) (*bitbucket.Paginated[bitbucket.Pipeline], error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPipelines")
+	}
+
+	var r0 *bitbucket.Paginated[bitbucket.Pipeline]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelinesParams) (*bitbucket.Paginated[bitbucket.Pipeline], error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelinesParams) *bitbucket.Paginated[bitbucket.Pipeline]); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Paginated[bitbucket.Pipeline])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelinesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPipelines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPipelines'
+type MockbitbucketClient_ListPipelines_Call struct {
+	*mock.Call
+}
+
+// ListPipelines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPipelinesParams
+func (_e *MockbitbucketClient_Expecter) ListPipelines(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPipelines_Call {
+	return &MockbitbucketClient_ListPipelines_Call{Call: _e.mock.On("ListPipelines", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPipelines_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPipelinesParams)) *MockbitbucketClient_ListPipelines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPipelinesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPipelines_Call) Return(_a0 *bitbucket.Paginated[bitbucket.Pipeline], _a1 error) *MockbitbucketClient_ListPipelines_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPipelines_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPipelinesParams) (*bitbucket.Paginated[bitbucket.Pipeline], error)) *MockbitbucketClient_ListPipelines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPullRequestTasks provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPullRequestTasks(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequestTasks")
+	}
+
+	var r0 *bitbucket.PaginatedTasks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) *bitbucket.PaginatedTasks); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PaginatedTasks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPullRequestTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequestTasks'
+type MockbitbucketClient_ListPullRequestTasks_Call struct {
+	*mock.Call
+}
+
+// ListPullRequestTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPullRequestTasksParams
+func (_e *MockbitbucketClient_Expecter) ListPullRequestTasks(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPullRequestTasks_Call {
+	return &MockbitbucketClient_ListPullRequestTasks_Call{Call: _e.mock.On("ListPullRequestTasks", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPullRequestTasks_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestTasksParams)) *MockbitbucketClient_ListPullRequestTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPullRequestTasksParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPullRequestTasks_Call) Return(_a0 *bitbucket.PaginatedTasks, _a1 error) *MockbitbucketClient_ListPullRequestTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPullRequestTasks_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestTasksParams) (*bitbucket.PaginatedTasks, error)) *MockbitbucketClient_ListPullRequestTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPullRequests provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListPullRequests(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestsParams) ([]bitbucket.PullRequest, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequests")
+	}
+
+	var r0 []bitbucket.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestsParams) ([]bitbucket.PullRequest, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestsParams) []bitbucket.PullRequest); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequests'
+type MockbitbucketClient_ListPullRequests_Call struct {
+	*mock.Call
+}
+
+// ListPullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListPullRequestsParams
+func (_e *MockbitbucketClient_Expecter) ListPullRequests(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListPullRequests_Call {
+	return &MockbitbucketClient_ListPullRequests_Call{Call: _e.mock.On("ListPullRequests", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListPullRequests_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListPullRequestsParams)) *MockbitbucketClient_ListPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListPullRequestsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPullRequests_Call) Return(_a0 []bitbucket.PullRequest, _a1 error) *MockbitbucketClient_ListPullRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListPullRequests_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListPullRequestsParams) ([]bitbucket.PullRequest, error)) *MockbitbucketClient_ListPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRepositories provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListRepositories(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListRepositoriesParams) ([]bitbucket.Repository, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositories")
+	}
+
+	var r0 []bitbucket.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoriesParams) ([]bitbucket.Repository, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoriesParams) []bitbucket.Repository); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoriesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListRepositories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRepositories'
+type MockbitbucketClient_ListRepositories_Call struct {
+	*mock.Call
+}
+
+// ListRepositories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListRepositoriesParams
+func (_e *MockbitbucketClient_Expecter) ListRepositories(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListRepositories_Call {
+	return &MockbitbucketClient_ListRepositories_Call{Call: _e.mock.On("ListRepositories", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListRepositories_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListRepositoriesParams)) *MockbitbucketClient_ListRepositories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListRepositoriesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListRepositories_Call) Return(_a0 []bitbucket.Repository, _a1 error) *MockbitbucketClient_ListRepositories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListRepositories_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoriesParams) ([]bitbucket.Repository, error)) *MockbitbucketClient_ListRepositories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRepositoryVariables provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListRepositoryVariables(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListRepositoryVariablesParams) ([]bitbucket.PipelineVariable, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositoryVariables")
+	}
+
+	var r0 []bitbucket.PipelineVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoryVariablesParams) ([]bitbucket.PipelineVariable, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoryVariablesParams) []bitbucket.PipelineVariable); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.PipelineVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoryVariablesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListRepositoryVariables_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRepositoryVariables'
+type MockbitbucketClient_ListRepositoryVariables_Call struct {
+	*mock.Call
+}
+
+// ListRepositoryVariables is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListRepositoryVariablesParams
+func (_e *MockbitbucketClient_Expecter) ListRepositoryVariables(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListRepositoryVariables_Call {
+	return &MockbitbucketClient_ListRepositoryVariables_Call{Call: _e.mock.On("ListRepositoryVariables", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListRepositoryVariables_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListRepositoryVariablesParams)) *MockbitbucketClient_ListRepositoryVariables_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListRepositoryVariablesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListRepositoryVariables_Call) Return(_a0 []bitbucket.PipelineVariable, _a1 error) *MockbitbucketClient_ListRepositoryVariables_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListRepositoryVariables_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListRepositoryVariablesParams) ([]bitbucket.PipelineVariable, error)) *MockbitbucketClient_ListRepositoryVariables_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListTags(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListTagsParams) (*bitbucket.Paginated[bitbucket.Tag], error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 *bitbucket.Paginated[bitbucket.Tag]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListTagsParams) (*bitbucket.Paginated[bitbucket.Tag], error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListTagsParams) *bitbucket.Paginated[bitbucket.Tag]); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Paginated[bitbucket.Tag])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListTagsParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockbitbucketClient_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListTagsParams
+func (_e *MockbitbucketClient_Expecter) ListTags(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListTags_Call {
+	return &MockbitbucketClient_ListTags_Call{Call: _e.mock.On("ListTags", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListTags_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListTagsParams)) *MockbitbucketClient_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListTagsParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListTags_Call) Return(_a0 *bitbucket.Paginated[bitbucket.Tag], _a1 error) *MockbitbucketClient_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListTags_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListTagsParams) (*bitbucket.Paginated[bitbucket.Tag], error)) *MockbitbucketClient_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWorkspaces provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) ListWorkspaces(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListWorkspacesParams) ([]bitbucket.WorkspaceMembership, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkspaces")
+	}
+
+	var r0 []bitbucket.WorkspaceMembership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListWorkspacesParams) ([]bitbucket.WorkspaceMembership, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListWorkspacesParams) []bitbucket.WorkspaceMembership); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.WorkspaceMembership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.ListWorkspacesParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_ListWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkspaces'
+type MockbitbucketClient_ListWorkspaces_Call struct {
+	*mock.Call
+}
+
+// ListWorkspaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.ListWorkspacesParams
+func (_e *MockbitbucketClient_Expecter) ListWorkspaces(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_ListWorkspaces_Call {
+	return &MockbitbucketClient_ListWorkspaces_Call{Call: _e.mock.On("ListWorkspaces", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_ListWorkspaces_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.ListWorkspacesParams)) *MockbitbucketClient_ListWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.ListWorkspacesParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListWorkspaces_Call) Return(_a0 []bitbucket.WorkspaceMembership, _a1 error) *MockbitbucketClient_ListWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_ListWorkspaces_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.ListWorkspacesParams) ([]bitbucket.WorkspaceMembership, error)) *MockbitbucketClient_ListWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePR provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) MergePR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.MergePRParams) (*bitbucket.MergePRResult, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergePR")
+	}
+
+	var r0 *bitbucket.MergePRResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) (*bitbucket.MergePRResult, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) *bitbucket.MergePRResult); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.MergePRResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
+type MockbitbucketClient_MergePR_Call struct {
+	*mock.Call
+}
+
+// MergePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.MergePRParams
+func (_e *MockbitbucketClient_Expecter) MergePR(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_MergePR_Call {
+	return &MockbitbucketClient_MergePR_Call{Call: _e.mock.On("MergePR", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_MergePR_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.MergePRParams)) *MockbitbucketClient_MergePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.MergePRParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_MergePR_Call) Return(_a0 *bitbucket.MergePRResult, _a1 error) *MockbitbucketClient_MergePR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_MergePR_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.MergePRParams) (*bitbucket.MergePRResult, error)) *MockbitbucketClient_MergePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestPRChanges provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) RequestPRChanges(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.RequestPRChangesParams) (string, time.Time, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPRChanges")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
 	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.RequestPRChangesParams) (string, time.Time, error)); ok {
 		return rf(ctx, tokenProvider, params)
 	}
@@ -848,6 +2851,234 @@ func (_c *MockbitbucketClient_ResolvePRComment_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// SearchCode provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) SearchCode(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.SearchCodeParams) ([]bitbucket.CodeSearchResult, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchCode")
+	}
+
+	var r0 []bitbucket.CodeSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.SearchCodeParams) ([]bitbucket.CodeSearchResult, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.SearchCodeParams) []bitbucket.CodeSearchResult); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitbucket.CodeSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.SearchCodeParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_SearchCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchCode'
+type MockbitbucketClient_SearchCode_Call struct {
+	*mock.Call
+}
+
+// SearchCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.SearchCodeParams
+func (_e *MockbitbucketClient_Expecter) SearchCode(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_SearchCode_Call {
+	return &MockbitbucketClient_SearchCode_Call{Call: _e.mock.On("SearchCode", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_SearchCode_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.SearchCodeParams)) *MockbitbucketClient_SearchCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.SearchCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_SearchCode_Call) Return(_a0 []bitbucket.CodeSearchResult, _a1 error) *MockbitbucketClient_SearchCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_SearchCode_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.SearchCodeParams) ([]bitbucket.CodeSearchResult, error)) *MockbitbucketClient_SearchCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopPipeline provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) StopPipeline(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.StopPipelineParams) error {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopPipeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.StopPipelineParams) error); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockbitbucketClient_StopPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopPipeline'
+type MockbitbucketClient_StopPipeline_Call struct {
+	*mock.Call
+}
+
+// StopPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.StopPipelineParams
+func (_e *MockbitbucketClient_Expecter) StopPipeline(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_StopPipeline_Call {
+	return &MockbitbucketClient_StopPipeline_Call{Call: _e.mock.On("StopPipeline", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_StopPipeline_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.StopPipelineParams)) *MockbitbucketClient_StopPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.StopPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_StopPipeline_Call) Return(_a0 error) *MockbitbucketClient_StopPipeline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockbitbucketClient_StopPipeline_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.StopPipelineParams) error) *MockbitbucketClient_StopPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TriggerPipeline provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) TriggerPipeline(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.TriggerPipelineParams) (*bitbucket.Pipeline, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerPipeline")
+	}
+
+	var r0 *bitbucket.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.TriggerPipelineParams) (*bitbucket.Pipeline, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.TriggerPipelineParams) *bitbucket.Pipeline); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.TriggerPipelineParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_TriggerPipeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TriggerPipeline'
+type MockbitbucketClient_TriggerPipeline_Call struct {
+	*mock.Call
+}
+
+// TriggerPipeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.TriggerPipelineParams
+func (_e *MockbitbucketClient_Expecter) TriggerPipeline(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_TriggerPipeline_Call {
+	return &MockbitbucketClient_TriggerPipeline_Call{Call: _e.mock.On("TriggerPipeline", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_TriggerPipeline_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.TriggerPipelineParams)) *MockbitbucketClient_TriggerPipeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.TriggerPipelineParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_TriggerPipeline_Call) Return(_a0 *bitbucket.Pipeline, _a1 error) *MockbitbucketClient_TriggerPipeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_TriggerPipeline_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.TriggerPipelineParams) (*bitbucket.Pipeline, error)) *MockbitbucketClient_TriggerPipeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCommitStatus provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) UpdateCommitStatus(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdateCommitStatusParams) (*bitbucket.CommitStatus, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommitStatus")
+	}
+
+	var r0 *bitbucket.CommitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateCommitStatusParams) (*bitbucket.CommitStatus, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateCommitStatusParams) *bitbucket.CommitStatus); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.CommitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateCommitStatusParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_UpdateCommitStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommitStatus'
+type MockbitbucketClient_UpdateCommitStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCommitStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.UpdateCommitStatusParams
+func (_e *MockbitbucketClient_Expecter) UpdateCommitStatus(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_UpdateCommitStatus_Call {
+	return &MockbitbucketClient_UpdateCommitStatus_Call{Call: _e.mock.On("UpdateCommitStatus", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_UpdateCommitStatus_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdateCommitStatusParams)) *MockbitbucketClient_UpdateCommitStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.UpdateCommitStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_UpdateCommitStatus_Call) Return(_a0 *bitbucket.CommitStatus, _a1 error) *MockbitbucketClient_UpdateCommitStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_UpdateCommitStatus_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateCommitStatusParams) (*bitbucket.CommitStatus, error)) *MockbitbucketClient_UpdateCommitStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePR provides a mock function with given fields: ctx, tokenProvider, params
 func (_m *MockbitbucketClient) UpdatePR(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdatePRParams) (*bitbucket.PullRequest, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
@@ -908,6 +3139,66 @@ func (_c *MockbitbucketClient_UpdatePR_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpdateRepositoryVariable provides a mock function with given fields: ctx, tokenProvider, params
+func (_m *MockbitbucketClient) UpdateRepositoryVariable(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error) {
+	ret := _m.Called(ctx, tokenProvider, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRepositoryVariable")
+	}
+
+	var r0 *bitbucket.PipelineVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)); ok {
+		return rf(ctx, tokenProvider, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateRepositoryVariableParams) *bitbucket.PipelineVariable); ok {
+		r0 = rf(ctx, tokenProvider, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bitbucket.PipelineVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateRepositoryVariableParams) error); ok {
+		r1 = rf(ctx, tokenProvider, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockbitbucketClient_UpdateRepositoryVariable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRepositoryVariable'
+type MockbitbucketClient_UpdateRepositoryVariable_Call struct {
+	*mock.Call
+}
+
+// UpdateRepositoryVariable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenProvider bitbucket.TokenProvider
+//   - params bitbucket.UpdateRepositoryVariableParams
+func (_e *MockbitbucketClient_Expecter) UpdateRepositoryVariable(ctx interface{}, tokenProvider interface{}, params interface{}) *MockbitbucketClient_UpdateRepositoryVariable_Call {
+	return &MockbitbucketClient_UpdateRepositoryVariable_Call{Call: _e.mock.On("UpdateRepositoryVariable", ctx, tokenProvider, params)}
+}
+
+func (_c *MockbitbucketClient_UpdateRepositoryVariable_Call) Run(run func(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdateRepositoryVariableParams)) *MockbitbucketClient_UpdateRepositoryVariable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bitbucket.TokenProvider), args[2].(bitbucket.UpdateRepositoryVariableParams))
+	})
+	return _c
+}
+
+func (_c *MockbitbucketClient_UpdateRepositoryVariable_Call) Return(_a0 *bitbucket.PipelineVariable, _a1 error) *MockbitbucketClient_UpdateRepositoryVariable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockbitbucketClient_UpdateRepositoryVariable_Call) RunAndReturn(run func(context.Context, bitbucket.TokenProvider, bitbucket.UpdateRepositoryVariableParams) (*bitbucket.PipelineVariable, error)) *MockbitbucketClient_UpdateRepositoryVariable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTask provides a mock function with given fields: ctx, tokenProvider, params
 func (_m *MockbitbucketClient) UpdateTask(ctx context.Context, tokenProvider bitbucket.TokenProvider, params bitbucket.UpdateTaskParams) (*bitbucket.PullRequestCommentTask, error) {
 	ret := _m.Called(ctx, tokenProvider, params)
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// pipelineLogTailLines is the number of last log lines included in a failure excerpt.
+	pipelineLogTailLines = 40
+
+	// pipelineLogMaxErrorLines is the maximum number of error lines included in a failure excerpt.
+	pipelineLogMaxErrorLines = 50
+
+	// pipelineLogMaxLineLength is the length log lines are cut to in a failure excerpt.
+	pipelineLogMaxLineLength = 500
+)
+
+var (
+	// pipelineLogErrorPattern matches common Go, JavaScript/TypeScript and Java
+	// test failure and compiler error lines.
+	pipelineLogErrorPattern = regexp.MustCompile(strings.Join([]string{
+		// Go
+		`^\s*--- FAIL: `,
+		`^\s*FAIL\b`,
+		`^panic: `,
+		`\.go:\d+(:\d+)?: `,
+		`^\s*Error( Trace)?:\s`,
+		// JavaScript / TypeScript
+		`^\s*[●✕×] `,
+		`\b(AssertionError|TypeError|ReferenceError|SyntaxError)\b`,
+		`error TS\d+:`,
+		`^npm ERR!`,
+		`^\s*\d+ failing$`,
+		// Java
+		`^\[ERROR\]`,
+		`BUILD (FAILURE|FAILED)`,
+		`Tests run: \d+, Failures: (0*[1-9]\d*|0+, Errors: 0*[1-9])`,
+		`\.java:(\[\d+,\d+\]|\d+: error:)`,
+		`^(Exception in thread|[\w.$]+(Exception|Error)(: |$))`,
+		`^> Task \S+ FAILED`,
+		// Generic
+		`(?i)^error: `,
+	}, "|"))
+
+	ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+)
+
+// PipelineLogExcerpt is the failing section of a pipeline step log.
+type PipelineLogExcerpt struct {
+	// ErrorLines are lines matching known test failure and compiler error patterns
+	// that precede the tail, in log order.
+	ErrorLines []string `json:"error_lines,omitempty"`
+
+	// Tail is the end of the log.
+	Tail []string `json:"tail"`
+}
+
+// extractPipelineLogFailure pulls the failing section out of a step log.
+// The first line is skipped for partial logs since it may be cut.
+func extractPipelineLogFailure(content string, partial bool) PipelineLogExcerpt {
+	content = ansiEscapePattern.ReplaceAllString(content, "")
+	lines := strings.Split(strings.TrimRight(content, "\r\n"), "\n")
+	if partial && len(lines) > 1 {
+		lines = lines[1:]
+	}
+	for i, line := range lines {
+		lines[i] = truncateLogLine(strings.TrimRight(line, "\r"))
+	}
+
+	tailStart := max(0, len(lines)-pipelineLogTailLines)
+	var errorLines []string
+	for _, line := range lines[:tailStart] {
+		if pipelineLogErrorPattern.MatchString(line) {
+			errorLines = append(errorLines, line)
+		}
+	}
+	// Failures at the end of the log are usually the most relevant ones
+	if len(errorLines) > pipelineLogMaxErrorLines {
+		errorLines = errorLines[len(errorLines)-pipelineLogMaxErrorLines:]
+	}
+
+	tail := lines[tailStart:]
+	if len(tail) == 1 && tail[0] == "" {
+		tail = nil
+	}
+	return PipelineLogExcerpt{ErrorLines: errorLines, Tail: tail}
+}
+
+func truncateLogLine(line string) string {
+	if len(line) <= pipelineLogMaxLineLength {
+		return line
+	}
+	return strings.ToValidUTF8(line[:pipelineLogMaxLineLength], "") + "..."
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractPipelineLogFailure(t *testing.T) {
+	makeNoise := func(count int) []string {
+		lines := make([]string, count)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("step %d: %s", i, strings.ToLower(faker.Word()))
+		}
+		return lines
+	}
+
+	t.Run("should return whole short log as tail", func(t *testing.T) {
+		lines := makeNoise(pipelineLogTailLines - 1)
+
+		got := extractPipelineLogFailure(strings.Join(lines, "\n")+"\n", false)
+
+		assert.Equal(t, PipelineLogExcerpt{Tail: lines}, got)
+	})
+
+	t.Run("should extract error lines preceding the tail", func(t *testing.T) {
+		errorLines := []string{
+			"--- FAIL: TestSomething (0.01s)",
+			"    service_test.go:42: expected 1, got 2",
+			"FAIL\tgithub.com/acme/service\t0.123s",
+			"panic: runtime error: invalid memory address",
+			"internal/app/service.go:10:2: undefined: foo",
+			"  ● Button › renders label",
+			"TypeError: Cannot read properties of undefined (reading 'id')",
+			"src/index.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.",
+			"npm ERR! Test failed.  See above for more details.",
+			"[ERROR] Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin",
+			"[INFO] BUILD FAILURE",
+			"Tests run: 12, Failures: 1, Errors: 0, Skipped: 0",
+			"java.lang.IllegalStateException: boom",
+			"> Task :app:test FAILED",
+		}
+		var lines []string
+		for _, errorLine := range errorLines {
+			lines = append(lines, makeNoise(3)...)
+			lines = append(lines, errorLine)
+		}
+		lines = append(lines, "Tests run: 12, Failures: 0, Errors: 0, Skipped: 0")
+		tail := makeNoise(pipelineLogTailLines)
+		lines = append(lines, tail...)
+
+		got := extractPipelineLogFailure(strings.Join(lines, "\n"), false)
+
+		assert.Equal(t, errorLines, got.ErrorLines)
+		assert.Equal(t, tail, got.Tail)
+	})
+
+	t.Run("should keep last error lines when there are too many", func(t *testing.T) {
+		var lines []string
+		for i := range pipelineLogMaxErrorLines + 10 {
+			lines = append(lines, fmt.Sprintf("--- FAIL: Test%d (0.00s)", i))
+		}
+		lines = append(lines, makeNoise(pipelineLogTailLines)...)
+
+		got := extractPipelineLogFailure(strings.Join(lines, "\n"), false)
+
+		assert.Len(t, got.ErrorLines, pipelineLogMaxErrorLines)
+		assert.Equal(t, "--- FAIL: Test10 (0.00s)", got.ErrorLines[0])
+	})
+
+	t.Run("should strip colors, carriage returns and cut long lines", func(t *testing.T) {
+		longLine := strings.Repeat("x", pipelineLogMaxLineLength+10)
+
+		got := extractPipelineLogFailure("\x1b[31mFAIL\x1b[0m pkg\r\n"+longLine, false)
+
+		assert.Equal(t, []string{"FAIL pkg", strings.Repeat("x", pipelineLogMaxLineLength) + "..."}, got.Tail)
+	})
+
+	t.Run("should skip first line of partial log", func(t *testing.T) {
+		lines := makeNoise(3)
+
+		got := extractPipelineLogFailure("tial line\n"+strings.Join(lines, "\n"), true)
+
+		assert.Equal(t, lines, got.Tail)
+	})
+
+	t.Run("should handle empty log", func(t *testing.T) {
+		got := extractPipelineLogFailure("", false)
+
+		assert.Equal(t, PipelineLogExcerpt{}, got)
+	})
+}
@@ -0,0 +1,553 @@
+package app
+
+import (
+	"bytes"
+	_ "embed" // embeds the pipelines configuration schema
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// Severities of pipelines configuration diagnostics.
+const (
+	PipelinesConfigSeverityError   = "error"
+	PipelinesConfigSeverityWarning = "warning"
+)
+
+const (
+	// pipelinesSchemaURL is the location the embedded schema is registered at.
+	pipelinesSchemaURL = "bitbucket-pipelines.schema.json"
+
+	// pipelinesMaxStepServices is the maximum number of services a single step can use.
+	pipelinesMaxStepServices = 5
+
+	// pipelinesDockerService is the name of the built-in docker service.
+	pipelinesDockerService = "docker"
+
+	// yamlMergeTag is the tag of the "<<" merge key.
+	yamlMergeTag = "!!merge"
+)
+
+//go:embed bitbucket_pipelines_schema.json
+var pipelinesSchemaJSON []byte
+
+var (
+	yamlErrorLinePattern     = regexp.MustCompile(`^line (\d+): (.+)$`)
+	yamlUnknownAnchorPattern = regexp.MustCompile(`unknown anchor '([^']+)' referenced`)
+)
+
+// PipelinesConfigDiagnostic is a problem found in a bitbucket-pipelines.yml file.
+type PipelinesConfigDiagnostic struct {
+	// Line is the 1-based line of the problem, 0 if not known.
+	Line int `json:"line,omitempty"`
+
+	// Column is the 1-based column of the problem, 0 if not known.
+	Column int `json:"column,omitempty"`
+
+	Severity string `json:"severity"`
+
+	// Path is the location of the problematic value, e.g. pipelines.default[0].step.caches[1].
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// isPredefinedPipelinesCache reports whether the cache is provided by Bitbucket Pipelines
+// and does not need to be declared in definitions.caches.
+func isPredefinedPipelinesCache(name string) bool {
+	switch name {
+	case "composer", "dotnetcore", "gradle", "ivy2", "maven", "node", "pip", "sbt", pipelinesDockerService:
+		return true
+	}
+	return false
+}
+
+// validatePipelinesConfig checks a bitbucket-pipelines.yml file against the embedded schema
+// and runs semantic checks the schema can not express. Diagnostics are ordered by line.
+func validatePipelinesConfig(content string) ([]PipelinesConfigDiagnostic, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return yamlErrorDiagnostics(content, err), nil
+	}
+	if len(root.Content) == 0 {
+		return []PipelinesConfigDiagnostic{
+			{Line: 1, Severity: PipelinesConfigSeverityError, Message: "pipelines configuration is empty"},
+		}, nil
+	}
+
+	validator := &pipelinesConfigValidator{document: root.Content[0]}
+	if err := validator.checkSchema(); err != nil {
+		return nil, err
+	}
+	validator.checkNodes()
+	validator.checkSteps()
+
+	sort.SliceStable(validator.diagnostics, func(i, j int) bool {
+		return validator.diagnostics[i].Line < validator.diagnostics[j].Line
+	})
+	return validator.diagnostics, nil
+}
+
+// yamlErrorDiagnostics converts YAML parser errors to diagnostics.
+func yamlErrorDiagnostics(content string, err error) []PipelinesConfigDiagnostic {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	diagnostics := make([]PipelinesConfigDiagnostic, 0, len(messages))
+	for _, msg := range messages {
+		msg = strings.TrimPrefix(msg, "yaml: ")
+		if match := yamlUnknownAnchorPattern.FindStringSubmatch(msg); match != nil {
+			diagnostics = append(diagnostics, unknownAnchorDiagnostic(content, match[1]))
+			continue
+		}
+		diagnostic := PipelinesConfigDiagnostic{Severity: PipelinesConfigSeverityError, Message: msg}
+		if match := yamlErrorLinePattern.FindStringSubmatch(msg); match != nil {
+			diagnostic.Line, _ = strconv.Atoi(match[1])
+			diagnostic.Message = match[2]
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// unknownAnchorDiagnostic reports an alias that refers to an anchor that is not defined
+// before it. The YAML parser does not report the position, so the alias is looked up in the content.
+func unknownAnchorDiagnostic(content, anchor string) PipelinesConfigDiagnostic {
+	aliasPattern := regexp.MustCompile(`(^|[\s\[{,:-])\*` + regexp.QuoteMeta(anchor) + `($|[\s\]},])`)
+	stepPattern := regexp.MustCompile(`^\s*(-\s*)?step:\s*\*` + regexp.QuoteMeta(anchor) + `\s*$`)
+	diagnostic := PipelinesConfigDiagnostic{
+		Severity: PipelinesConfigSeverityError,
+		Message:  fmt.Sprintf("alias *%s refers to undefined anchor &%s", anchor, anchor),
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		loc := aliasPattern.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		diagnostic.Line = i + 1
+		diagnostic.Column = strings.Index(line[loc[0]:], "*") + loc[0] + 1
+		if stepPattern.MatchString(line) {
+			diagnostic.Message = fmt.Sprintf("step refers to undefined anchor &%s", anchor)
+		}
+		if strings.Contains(strings.Join(lines[i:], "\n"), "&"+anchor) {
+			diagnostic.Message += ", anchors must be defined before they are referenced"
+		}
+		break
+	}
+	return diagnostic
+}
+
+type pipelinesConfigValidator struct {
+	document    *yaml.Node
+	diagnostics []PipelinesConfigDiagnostic
+}
+
+func (v *pipelinesConfigValidator) add(severity string, node *yaml.Node, path, msg string) {
+	diagnostic := PipelinesConfigDiagnostic{Severity: severity, Path: path, Message: msg}
+	if node != nil {
+		diagnostic.Line = node.Line
+		diagnostic.Column = node.Column
+	}
+	v.diagnostics = append(v.diagnostics, diagnostic)
+}
+
+// pipelinesSchema compiles the embedded JSON schema once, the compiled schema is safe for concurrent use.
+var pipelinesSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(pipelinesSchemaJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pipelines schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err = compiler.AddResource(pipelinesSchemaURL, schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to load pipelines schema: %w", err)
+	}
+	schema, err := compiler.Compile(pipelinesSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pipelines schema: %w", err)
+	}
+	return schema, nil
+})
+
+// checkSchema validates the configuration against the embedded JSON schema.
+func (v *pipelinesConfigValidator) checkSchema() error {
+	schema, err := pipelinesSchema()
+	if err != nil {
+		return err
+	}
+
+	err = schema.Validate(yamlNodeValue(v.document))
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	printer := message.NewPrinter(language.English)
+	for _, leaf := range schemaErrorLeaves(validationErr) {
+		if additional, ok := leaf.ErrorKind.(*kind.AdditionalProperties); ok {
+			for _, property := range additional.Properties {
+				node, path := v.locate(slices.Concat(leaf.InstanceLocation, []string{property}))
+				v.add(PipelinesConfigSeverityError, node, path, fmt.Sprintf("unknown property %q", property))
+			}
+			continue
+		}
+		node, path := v.locate(leaf.InstanceLocation)
+		v.add(PipelinesConfigSeverityError, node, path, leaf.ErrorKind.LocalizedString(printer))
+	}
+	return nil
+}
+
+func schemaErrorLeaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, schemaErrorLeaves(cause)...)
+	}
+	return leaves
+}
+
+// locate finds the node at the schema instance location. Keys are reported at the
+// key node so the diagnostic points to the line the property is declared at.
+func (v *pipelinesConfigValidator) locate(location []string) (*yaml.Node, string) {
+	node := v.document
+	position := node
+	var path strings.Builder
+	for _, token := range location {
+		node = resolveYAMLAlias(node)
+		switch node.Kind {
+		case yaml.MappingNode:
+			key, value := yamlMappingEntry(node, token)
+			if key == nil {
+				return position, path.String()
+			}
+			if path.Len() > 0 {
+				path.WriteByte('.')
+			}
+			path.WriteString(token)
+			position, node = key, value
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return position, path.String()
+			}
+			path.WriteString("[" + token + "]")
+			node = node.Content[index]
+			position = node
+		case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+			return position, path.String()
+		}
+	}
+	return position, path.String()
+}
+
+// checkNodes reports duplicate keys, invalid merge keys, redefined and unused anchors.
+func (v *pipelinesConfigValidator) checkNodes() {
+	anchors := map[string]*yaml.Node{}
+	var anchorOrder []*yaml.Node
+	used := map[*yaml.Node]bool{}
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Anchor != "" {
+			if previous, found := anchors[node.Anchor]; found {
+				v.add(PipelinesConfigSeverityWarning, node, "", fmt.Sprintf(
+					"anchor &%s redefines the anchor at line %d", node.Anchor, previous.Line))
+			}
+			anchors[node.Anchor] = node
+			anchorOrder = append(anchorOrder, node)
+		}
+		if node.Kind == yaml.AliasNode {
+			used[node.Alias] = true
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			v.checkMappingKeys(node)
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(v.document)
+
+	for _, node := range anchorOrder {
+		if !used[node] {
+			v.add(PipelinesConfigSeverityWarning, node, "", fmt.Sprintf("anchor &%s is never referenced", node.Anchor))
+		}
+	}
+}
+
+func (v *pipelinesConfigValidator) checkMappingKeys(node *yaml.Node) {
+	seen := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Tag == yamlMergeTag {
+			if !isYAMLMergeValue(value) {
+				v.add(PipelinesConfigSeverityError, key, "", "merge key value must be an alias of a mapping")
+			}
+			continue
+		}
+		if previous, found := seen[key.Value]; found {
+			v.add(PipelinesConfigSeverityError, key, "", fmt.Sprintf(
+				"mapping key %q already defined at line %d", key.Value, previous.Line))
+			continue
+		}
+		seen[key.Value] = key
+	}
+}
+
+func isYAMLMergeValue(value *yaml.Node) bool {
+	if value.Kind == yaml.SequenceNode {
+		for _, item := range value.Content {
+			if resolveYAMLAlias(item).Kind != yaml.MappingNode {
+				return false
+			}
+		}
+		return true
+	}
+	return resolveYAMLAlias(value).Kind == yaml.MappingNode
+}
+
+// checkSteps reports caches and services that steps use but that are not defined.
+func (v *pipelinesConfigValidator) checkSteps() {
+	_, definitions := yamlMappingEntry(v.document, "definitions")
+	_, cachesNode := yamlMappingEntry(definitions, "caches")
+	_, servicesNode := yamlMappingEntry(definitions, "services")
+	definedCaches := yamlMappingKeys(cachesNode)
+	definedServices := yamlMappingKeys(servicesNode)
+
+	for name, key := range definedServices {
+		_, service := yamlMappingEntry(servicesNode, name)
+		_, image := yamlMappingEntry(service, "image")
+		_, serviceType := yamlMappingEntry(service, "type")
+		isDocker := name == pipelinesDockerService || (serviceType != nil && serviceType.Value == pipelinesDockerService)
+		if image == nil && !isDocker {
+			v.add(PipelinesConfigSeverityError, key, "definitions.services."+name,
+				fmt.Sprintf("service %q must define an image", name))
+		}
+	}
+
+	checked := map[*yaml.Node]bool{}
+	_, pipelines := yamlMappingEntry(v.document, "pipelines")
+	forEachPipelinesStep(pipelines, func(step *yaml.Node) {
+		if checked[step] {
+			return
+		}
+		checked[step] = true
+
+		_, caches := yamlMappingEntry(step, "caches")
+		for _, cache := range yamlSequenceItems(caches) {
+			if _, defined := definedCaches[cache.Value]; !defined && !isPredefinedPipelinesCache(cache.Value) {
+				v.add(PipelinesConfigSeverityError, cache, "",
+					fmt.Sprintf("cache %q is not predefined and is not declared in definitions.caches", cache.Value))
+			}
+		}
+
+		servicesKey, services := yamlMappingEntry(step, "services")
+		serviceItems := yamlSequenceItems(services)
+		if len(serviceItems) > pipelinesMaxStepServices {
+			v.add(PipelinesConfigSeverityError, servicesKey, "", fmt.Sprintf(
+				"step uses %d services, at most %d are allowed", len(serviceItems), pipelinesMaxStepServices))
+		}
+		for _, service := range serviceItems {
+			if _, defined := definedServices[service.Value]; !defined && service.Value != pipelinesDockerService {
+				v.add(PipelinesConfigSeverityError, service, "",
+					fmt.Sprintf("service %q is not declared in definitions.services", service.Value))
+			}
+		}
+	})
+}
+
+// forEachPipelinesStep calls fn with every step of all pipelines, including steps of
+// parallel groups and stages.
+func forEachPipelinesStep(pipelines *yaml.Node, fn func(step *yaml.Node)) {
+	var walkItems func(items *yaml.Node)
+	walkItems = func(items *yaml.Node) {
+		for _, item := range yamlSequenceItems(items) {
+			if _, step := yamlMappingEntry(item, "step"); step != nil {
+				fn(resolveYAMLAlias(step))
+			}
+			if _, parallel := yamlMappingEntry(item, "parallel"); parallel != nil {
+				if _, steps := yamlMappingEntry(parallel, "steps"); steps != nil {
+					parallel = steps
+				}
+				walkItems(parallel)
+			}
+			if _, stage := yamlMappingEntry(item, "stage"); stage != nil {
+				_, steps := yamlMappingEntry(stage, "steps")
+				walkItems(steps)
+			}
+		}
+	}
+
+	pipelines = resolveYAMLAlias(pipelines)
+	if pipelines == nil || pipelines.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(pipelines.Content); i += 2 {
+		section := pipelines.Content[i+1]
+		if pipelines.Content[i].Value == "default" {
+			walkItems(section)
+			continue
+		}
+		for _, pipeline := range yamlMappingKeys(section) {
+			_, items := yamlMappingEntry(section, pipeline.Value)
+			walkItems(items)
+		}
+	}
+}
+
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// yamlMappingEntry returns the key and value nodes of the mapping entry, including
+// entries merged with "<<". Returns nils if the node is not a mapping or has no such key.
+func yamlMappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	node = resolveYAMLAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Tag == yamlMergeTag {
+			merges = append(merges, valueNode)
+			continue
+		}
+		if keyNode.Value == key {
+			return keyNode, valueNode
+		}
+	}
+	for _, merge := range merges {
+		sources := []*yaml.Node{merge}
+		if merge.Kind == yaml.SequenceNode {
+			sources = merge.Content
+		}
+		for _, source := range sources {
+			if keyNode, valueNode := yamlMappingEntry(source, key); keyNode != nil {
+				return keyNode, valueNode
+			}
+		}
+	}
+	return nil, nil
+}
+
+// yamlMappingKeys returns the key nodes of the mapping by key value.
+func yamlMappingKeys(node *yaml.Node) map[string]*yaml.Node {
+	keys := map[string]*yaml.Node{}
+	node = resolveYAMLAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Tag != yamlMergeTag {
+			keys[node.Content[i].Value] = node.Content[i]
+		}
+	}
+	return keys
+}
+
+// yamlSequenceItems returns the scalar or collection items of the sequence with aliases resolved.
+func yamlSequenceItems(node *yaml.Node) []*yaml.Node {
+	node = resolveYAMLAlias(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	items := make([]*yaml.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		items = append(items, resolveYAMLAlias(item))
+	}
+	return items
+}
+
+// yamlNodeValue converts the node to the generic representation the schema validator
+// works with. Mapping keys are always strings, timestamps are kept as strings.
+func yamlNodeValue(node *yaml.Node) any {
+	node = resolveYAMLAlias(node)
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return yamlNodeValue(node.Content[0])
+	case yaml.SequenceNode:
+		items := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			items = append(items, yamlNodeValue(item))
+		}
+		return items
+	case yaml.MappingNode:
+		values := map[string]any{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Tag == yamlMergeTag {
+				continue
+			}
+			values[node.Content[i].Value] = yamlNodeValue(node.Content[i+1])
+		}
+		for key := range yamlMergedKeys(node) {
+			if _, found := values[key]; !found {
+				_, value := yamlMappingEntry(node, key)
+				values[key] = yamlNodeValue(value)
+			}
+		}
+		return values
+	case yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!bool", "!!int", "!!float":
+		var value any
+		if err := node.Decode(&value); err == nil {
+			return value
+		}
+	}
+	return node.Value
+}
+
+// yamlMergedKeys returns keys the mapping gets from "<<" merges.
+func yamlMergedKeys(node *yaml.Node) map[string]*yaml.Node {
+	keys := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Tag != yamlMergeTag {
+			continue
+		}
+		sources := []*yaml.Node{node.Content[i+1]}
+		if node.Content[i+1].Kind == yaml.SequenceNode {
+			sources = node.Content[i+1].Content
+		}
+		for _, source := range sources {
+			source = resolveYAMLAlias(source)
+			if source.Kind != yaml.MappingNode {
+				continue
+			}
+			for key, keyNode := range yamlMappingKeys(source) {
+				keys[key] = keyNode
+			}
+			for key, keyNode := range yamlMergedKeys(source) {
+				keys[key] = keyNode
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePipelinesConfig(t *testing.T) {
+	findDiagnostic := func(
+		t *testing.T,
+		diagnostics []PipelinesConfigDiagnostic,
+		line int,
+	) PipelinesConfigDiagnostic {
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Line == line {
+				return diagnostic
+			}
+		}
+		require.Failf(t, "diagnostic not found", "line %d, got %+v", line, diagnostics)
+		return PipelinesConfigDiagnostic{}
+	}
+
+	t.Run("should accept valid configuration", func(t *testing.T) {
+		content := `image: golang:1.24
+
+definitions:
+  caches:
+    gomod: ~/go/pkg/mod
+  services:
+    postgres:
+      image: postgres:16
+      variables:
+        POSTGRES_PASSWORD: secret
+    docker:
+      memory: 2048
+  steps:
+    - step: &test
+        name: Test
+        caches: [gomod, docker]
+        services: [postgres, docker]
+        script:
+          - go test ./...
+          - pipe: atlassian/slack-notify:2.0.0
+            variables:
+              WEBHOOK_URL: $WEBHOOK_URL
+
+pipelines:
+  default:
+    - step: *test
+  branches:
+    main:
+      - parallel:
+          - step: *test
+          - step:
+              <<: *test
+              name: Lint
+              script: [make lint]
+      - stage:
+          name: Deploy
+          deployment: production
+          steps:
+            - step:
+                trigger: manual
+                script: [make deploy]
+  custom:
+    release:
+      - variables:
+          - name: VERSION
+      - step:
+          max-time: 30
+          size: 2x
+          script: [make release]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("should accept concurrency groups, output variables and imported pipelines", func(t *testing.T) {
+		content := `pipelines:
+  branches:
+    main:
+      - step:
+          name: Build
+          output-variables:
+            - VERSION
+          script:
+            - echo "VERSION=1.0.0" >> $BITBUCKET_PIPELINES_VARIABLES_PATH
+      - step:
+          name: Deploy
+          deployment: production
+          concurrency-group: production-deploy
+          script: [make deploy]
+  custom:
+    shared-release:
+      import: shared-pipelines:main:release
+    shared-steps:
+      - import: shared-pipelines:main:build
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("should report schema violations with lines", func(t *testing.T) {
+		content := `pipelines:
+  default:
+    - step:
+        name: Build
+        script-typo: [make]
+    - step:
+        size: 3x
+        script: [make]
+  branch:
+    main:
+      - step:
+          script: [make]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		missingScript := findDiagnostic(t, diagnostics, 3)
+		assert.Equal(t, PipelinesConfigSeverityError, missingScript.Severity)
+		assert.Equal(t, "pipelines.default[0].step", missingScript.Path)
+		assert.Contains(t, missingScript.Message, "script")
+		assert.Equal(t, PipelinesConfigDiagnostic{
+			Line:     5,
+			Column:   9,
+			Severity: PipelinesConfigSeverityError,
+			Path:     "pipelines.default[0].step.script-typo",
+			Message:  `unknown property "script-typo"`,
+		}, findDiagnostic(t, diagnostics, 5))
+		assert.Equal(t, "pipelines.default[1].step.size", findDiagnostic(t, diagnostics, 7).Path)
+		assert.Equal(t, `unknown property "branch"`, findDiagnostic(t, diagnostics, 9).Message)
+	})
+
+	t.Run("should report undefined caches and services", func(t *testing.T) {
+		content := `definitions:
+  caches:
+    gomod: ~/go/pkg/mod
+  services:
+    redis:
+      memory: 512
+pipelines:
+  default:
+    - step:
+        caches:
+          - gomod
+          - node
+          - golang
+        services:
+          - redis
+          - mysql
+        script: [make]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		require.Len(t, diagnostics, 3)
+		assert.Equal(t, `service "redis" must define an image`, findDiagnostic(t, diagnostics, 5).Message)
+		assert.Equal(t, PipelinesConfigDiagnostic{
+			Line:     13,
+			Column:   13,
+			Severity: PipelinesConfigSeverityError,
+			Message:  `cache "golang" is not predefined and is not declared in definitions.caches`,
+		}, findDiagnostic(t, diagnostics, 13))
+		assert.Equal(t,
+			`service "mysql" is not declared in definitions.services`,
+			findDiagnostic(t, diagnostics, 16).Message,
+		)
+	})
+
+	t.Run("should report too many services of a step", func(t *testing.T) {
+		content := `pipelines:
+  default:
+    - step:
+        services: [docker, docker, docker, docker, docker, docker]
+        script: [make]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "step uses 6 services, at most 5 are allowed", diagnostics[0].Message)
+		assert.Equal(t, 4, diagnostics[0].Line)
+	})
+
+	t.Run("should report unknown step references", func(t *testing.T) {
+		content := `pipelines:
+  default:
+    - step: *build
+definitions:
+  steps:
+    - step: &build
+        script: [make]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		assert.Equal(t, []PipelinesConfigDiagnostic{
+			{
+				Line:     3,
+				Column:   13,
+				Severity: PipelinesConfigSeverityError,
+				Message:  "step refers to undefined anchor &build, anchors must be defined before they are referenced",
+			},
+		}, diagnostics)
+	})
+
+	t.Run("should report anchor problems", func(t *testing.T) {
+		content := `definitions:
+  steps:
+    - step: &build
+        script: [make build]
+    - step: &build
+        script: [make test]
+    - step: &unused
+        script: [make lint]
+pipelines:
+  default:
+    - step: *build
+    - step:
+        <<: [make]
+        script: [make]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		assert.Equal(t, PipelinesConfigSeverityWarning, findDiagnostic(t, diagnostics, 3).Severity)
+		assert.Equal(t, "anchor &build is never referenced", findDiagnostic(t, diagnostics, 3).Message)
+		assert.Equal(t, "anchor &build redefines the anchor at line 3", findDiagnostic(t, diagnostics, 5).Message)
+		assert.Equal(t, "anchor &unused is never referenced", findDiagnostic(t, diagnostics, 7).Message)
+		assert.Equal(t, "merge key value must be an alias of a mapping", findDiagnostic(t, diagnostics, 13).Message)
+	})
+
+	t.Run("should report duplicate keys", func(t *testing.T) {
+		content := `pipelines:
+  default:
+    - step:
+        script: [make]
+        script: [make test]
+`
+
+		diagnostics, err := validatePipelinesConfig(content)
+
+		require.NoError(t, err)
+		assert.Equal(t, `mapping key "script" already defined at line 4`, findDiagnostic(t, diagnostics, 5).Message)
+	})
+
+	t.Run("should report YAML syntax errors", func(t *testing.T) {
+		diagnostics, err := validatePipelinesConfig("pipelines:\n  default: [\n")
+
+		require.NoError(t, err)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, PipelinesConfigSeverityError, diagnostics[0].Severity)
+		assert.Equal(t, 2, diagnostics[0].Line)
+	})
+
+	t.Run("should report empty configuration", func(t *testing.T) {
+		diagnostics, err := validatePipelinesConfig("")
+
+		require.NoError(t, err)
+		assert.Equal(t, "pipelines configuration is empty", diagnostics[0].Message)
+	})
+
+	t.Run("should compile schema once", func(t *testing.T) {
+		first, err := pipelinesSchema()
+		require.NoError(t, err)
+		second, err := pipelinesSchema()
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+	})
+}
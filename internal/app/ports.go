@@ -19,6 +19,9 @@ type AtlassianAccountsRepository interface {
 	// GetAccountByName returns an account with the specified name.
 	// Returns an error if no account with the name is found.
 	GetAccountByName(ctx context.Context, name string) (*AtlassianAccount, error)
+
+	// ListAccounts returns all configured accounts in the order of the configuration.
+	ListAccounts(ctx context.Context) ([]AtlassianAccount, error)
 }
 
 // TokenProvider provides authentication tokens for API requests.
@@ -63,7 +66,42 @@ type bitbucketClient interface {
 		ctx context.Context,
 		tokenProvider bitbucket.TokenProvider,
 		params bitbucket.MergePRParams,
-	) (*bitbucket.PullRequest, error)
+	) (*bitbucket.MergePRResult, error)
+
+	// ListPRCommits returns all commits of a pull request.
+	ListPRCommits(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListPRCommitsParams,
+	) ([]bitbucket.Commit, error)
+
+	// ListCommitStatuses returns all build statuses of a commit.
+	ListCommitStatuses(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListCommitStatusesParams,
+	) ([]bitbucket.CommitStatus, error)
+
+	// CreateCommitStatus creates or overwrites a build status of a commit.
+	CreateCommitStatus(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.CreateCommitStatusParams,
+	) (*bitbucket.CommitStatus, error)
+
+	// UpdateCommitStatus updates an existing build status of a commit.
+	UpdateCommitStatus(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.UpdateCommitStatusParams,
+	) (*bitbucket.CommitStatus, error)
+
+	// GetMergeTaskStatus returns the status of an asynchronous merge.
+	GetMergeTaskStatus(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetMergeTaskStatusParams,
+	) (*bitbucket.MergeTaskStatus, error)
 
 	// ListPullRequestTasks returns a paginated list of tasks on a pull request.
 	ListPullRequestTasks(
@@ -138,6 +176,240 @@ type bitbucketClient interface {
 		tokenProvider bitbucket.TokenProvider,
 		params bitbucket.ListPRCommentsParams,
 	) (*bitbucket.ListPRCommentsResponse, error)
+
+	// ListPRStatuses returns all build statuses reported for the commits of a pull request.
+	ListPRStatuses(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListPRStatusesParams,
+	) ([]bitbucket.CommitStatus, error)
+
+	// ListBranchRestrictions returns all branch restrictions configured for a repository.
+	ListBranchRestrictions(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListBranchRestrictionsParams,
+	) ([]bitbucket.BranchRestriction, error)
+
+	// ListPipelines returns a page of pipelines of a repository.
+	ListPipelines(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListPipelinesParams,
+	) (*bitbucket.Paginated[bitbucket.Pipeline], error)
+
+	// GetPipeline retrieves a single pipeline.
+	GetPipeline(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetPipelineParams,
+	) (*bitbucket.Pipeline, error)
+
+	// TriggerPipeline starts a new pipeline run.
+	TriggerPipeline(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.TriggerPipelineParams,
+	) (*bitbucket.Pipeline, error)
+
+	// StopPipeline stops a running pipeline.
+	StopPipeline(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.StopPipelineParams,
+	) error
+
+	// ListPipelineSteps returns all steps of a pipeline.
+	ListPipelineSteps(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListPipelineStepsParams,
+	) ([]bitbucket.PipelineStep, error)
+
+	// GetPipelineStepLog retrieves the log of a pipeline step.
+	GetPipelineStepLog(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetPipelineStepLogParams,
+	) (*bitbucket.PipelineStepLog, error)
+
+	// ListRepositoryVariables returns all pipelines variables of a repository.
+	ListRepositoryVariables(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListRepositoryVariablesParams,
+	) ([]bitbucket.PipelineVariable, error)
+
+	// CreateRepositoryVariable creates a pipelines variable of a repository.
+	CreateRepositoryVariable(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.CreateRepositoryVariableParams,
+	) (*bitbucket.PipelineVariable, error)
+
+	// UpdateRepositoryVariable updates a pipelines variable of a repository.
+	UpdateRepositoryVariable(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.UpdateRepositoryVariableParams,
+	) (*bitbucket.PipelineVariable, error)
+
+	// DeleteRepositoryVariable deletes a pipelines variable of a repository.
+	DeleteRepositoryVariable(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.DeleteRepositoryVariableParams,
+	) error
+
+	// ListEnvironments returns all deployment environments of a repository.
+	ListEnvironments(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListEnvironmentsParams,
+	) ([]bitbucket.DeploymentEnvironment, error)
+
+	// ListDeployments returns a single page of deployments of a repository.
+	ListDeployments(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListDeploymentsParams,
+	) (*bitbucket.Paginated[bitbucket.Deployment], error)
+
+	// ListBranches returns a single page of open branches of a repository.
+	ListBranches(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListBranchesParams,
+	) (*bitbucket.Paginated[bitbucket.Branch], error)
+
+	// GetBranch returns a branch of a repository together with its head commit.
+	GetBranch(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetBranchParams,
+	) (*bitbucket.Branch, error)
+
+	// CreateBranch creates a branch pointing at the given target.
+	CreateBranch(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.CreateBranchParams,
+	) (*bitbucket.Branch, error)
+
+	// DeleteBranch deletes a branch of a repository.
+	DeleteBranch(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.DeleteBranchParams,
+	) error
+
+	// ListCommits returns commits reachable from Include but not from Exclude, newest first.
+	ListCommits(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListCommitsParams,
+	) ([]bitbucket.Commit, error)
+
+	// ListTags returns a single page of tags of a repository.
+	ListTags(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListTagsParams,
+	) (*bitbucket.Paginated[bitbucket.Tag], error)
+
+	// CreateTag creates a tag pointing at the given target.
+	CreateTag(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.CreateTagParams,
+	) (*bitbucket.Tag, error)
+
+	// DeleteTag deletes a tag of a repository.
+	DeleteTag(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.DeleteTagParams,
+	) error
+
+	// ListDirectory lists files and directories of a directory at a commit.
+	ListDirectory(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListDirectoryParams,
+	) ([]bitbucket.TreeEntry, error)
+
+	// GetFileMeta retrieves metadata of a file or a directory at a commit.
+	GetFileMeta(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetFileMetaParams,
+	) (*bitbucket.TreeEntry, error)
+
+	// ListFileHistory lists versions of a file at commits that modified it, newest first.
+	ListFileHistory(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListFileHistoryParams,
+	) ([]bitbucket.TreeEntry, error)
+
+	// ListCommitPullRequests lists pull requests that contain a commit.
+	ListCommitPullRequests(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListCommitPullRequestsParams,
+	) ([]bitbucket.PullRequest, error)
+
+	// CommitFiles creates a commit with added, modified and deleted files.
+	CommitFiles(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.CommitFilesParams,
+	) (*bitbucket.Commit, error)
+
+	// SearchCode searches code in repositories of a workspace.
+	SearchCode(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.SearchCodeParams,
+	) ([]bitbucket.CodeSearchResult, error)
+
+	// ListWorkspaces lists workspaces the user is a member of.
+	ListWorkspaces(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListWorkspacesParams,
+	) ([]bitbucket.WorkspaceMembership, error)
+
+	// ListRepositories lists repositories of a workspace.
+	ListRepositories(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListRepositoriesParams,
+	) ([]bitbucket.Repository, error)
+
+	// GetRepository returns a repository.
+	GetRepository(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetRepositoryParams,
+	) (*bitbucket.Repository, error)
+
+	// GetCurrentUser returns the account the token authenticates as and the scopes of the token.
+	GetCurrentUser(ctx context.Context, tokenProvider bitbucket.TokenProvider) (*bitbucket.CurrentUser, error)
+
+	// GetCommit returns a commit.
+	GetCommit(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.GetCommitParams,
+	) (*bitbucket.Commit, error)
+
+	// ListPullRequests lists pull requests of a repository.
+	ListPullRequests(
+		ctx context.Context,
+		tokenProvider bitbucket.TokenProvider,
+		params bitbucket.ListPullRequestsParams,
+	) ([]bitbucket.PullRequest, error)
 }
 
 // Error types for account-related operations.
@@ -41,6 +41,10 @@ func (p configValueProvider) asDuration() di.ConstructorWithOpts {
 	return di.ProvideValue(p.cfg.GetDuration(p.configPath), dig.Name(p.diPath))
 }
 
+func (p configValueProvider) asStringSlice() di.ConstructorWithOpts {
+	return di.ProvideValue(p.cfg.GetStringSlice(p.configPath), dig.Name(p.diPath))
+}
+
 func Provide(container *dig.Container, cfg *viper.Viper) error {
 	return di.ProvideAll(container,
 		provideConfigValue(cfg, "gracefulShutdownTimeout").asDuration(),
@@ -62,6 +66,14 @@ func Provide(container *dig.Container, cfg *viper.Viper) error {
 
 		// atlassian config
 		provideConfigValue(cfg, "atlassian.bitbucket.baseUrl").asString(),
+		provideConfigValue(cfg, "atlassian.bitbucket.mergePollInterval").asDuration(),
+		provideConfigValue(cfg, "atlassian.bitbucket.mergeTimeout").asDuration(),
+		provideConfigValue(cfg, "atlassian.bitbucket.squashMessageTemplatePath").asString(),
+		provideConfigValue(cfg, "atlassian.bitbucket.waitPollInterval").asDuration(),
+		provideConfigValue(cfg, "atlassian.bitbucket.waitMaxPollInterval").asDuration(),
+		provideConfigValue(cfg, "atlassian.bitbucket.waitTimeout").asDuration(),
+		provideConfigValue(cfg, "atlassian.bitbucket.watchedRepositories").asStringSlice(),
+		provideConfigValue(cfg, "atlassian.bitbucket.diffSkipPatterns").asStringSlice(),
 		provideConfigValue(cfg, "atlassian.jira.baseUrl").asString(),
 		provideConfigValue(cfg, "atlassian.accountsFilePath").asString(),
 	)
@@ -87,6 +87,21 @@ func Test_provideConfigValue(t *testing.T) {
 		}))
 	})
 
+	t.Run("should provide config value as string slice", func(t *testing.T) {
+		cfg := viper.New()
+		configKey := "string-slice-cfg"
+		cfg.Set(configKey, []string{faker.Word(), faker.Word()})
+		type configReceiver struct {
+			dig.In
+			Value []string `name:"config.string-slice-cfg"`
+		}
+		container := dig.New()
+		require.NoError(t, di.ProvideAll(container, provideConfigValue(cfg, configKey).asStringSlice()))
+		require.NoError(t, container.Invoke(func(receiver configReceiver) {
+			require.Equal(t, cfg.GetStringSlice(configKey), receiver.Value)
+		}))
+	})
+
 	t.Run("should panic if config key is not found", func(t *testing.T) {
 		cfg := viper.New()
 		configKey := "int-cfg-key"
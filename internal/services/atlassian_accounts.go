@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 
 	"github.com/gemyago/atlacp/internal/app"
 	"go.uber.org/dig"
@@ -89,6 +90,11 @@ func (r *atlassianAccountsRepository) GetAccountByName(_ context.Context, name s
 	return nil, fmt.Errorf("%w: %s", app.ErrAccountNotFound, name)
 }
 
+// ListAccounts returns all configured accounts in the order of the configuration.
+func (r *atlassianAccountsRepository) ListAccounts(_ context.Context) ([]app.AtlassianAccount, error) {
+	return slices.Clone(r.config.Accounts), nil
+}
+
 // validateAccountsConfig validates the accounts configuration.
 func validateAccountsConfig(config *atlassianAccountsConfig) error {
 	if len(config.Accounts) == 0 {
@@ -179,6 +179,28 @@ func TestAtlassianAccountsRepository(t *testing.T) {
 		})
 	})
 
+	t.Run("ListAccounts", func(t *testing.T) {
+		t.Run("should return all accounts in configuration order", func(t *testing.T) {
+			// Arrange
+			accounts := []app.AtlassianAccount{
+				app.NewRandomAtlassianAccount(),
+				app.NewRandomAtlassianAccount(app.WithAtlassianAccountDefault(true)),
+				app.NewRandomAtlassianAccount(),
+			}
+
+			configPath := createTempAccountsFile(t, accounts)
+			repository, err := NewAtlassianAccountsRepository(makeMockDeps(configPath))
+			require.NoError(t, err, "Failed to create repository")
+
+			// Act
+			result, err := repository.ListAccounts(t.Context())
+
+			// Assert
+			require.NoError(t, err, "ListAccounts should not return an error")
+			assert.Equal(t, accounts, result, "Should return all accounts")
+		})
+	})
+
 	t.Run("NewAtlassianAccountsRepository", func(t *testing.T) {
 		t.Run("should fail when file read fails", func(t *testing.T) {
 			// Create a directory instead of a file to cause read failure
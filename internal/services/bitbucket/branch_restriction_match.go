@@ -0,0 +1,29 @@
+package bitbucket
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MatchesBranch reports whether the restriction applies to the given branch name.
+// Glob patterns support the "*" wildcard. Branching model restrictions are matched by the
+// conventional branch type prefix (e.g. "feature/"); development and production types
+// depend on the repository branching model and are not matched.
+func (r BranchRestriction) MatchesBranch(branch string) bool {
+	switch r.BranchMatchKind {
+	case BranchMatchKindBranchingModel:
+		switch r.BranchType {
+		case "feature", "bugfix", "release", "hotfix":
+			return strings.HasPrefix(branch, r.BranchType+"/")
+		default:
+			return false
+		}
+	default:
+		if r.Pattern == "" {
+			return false
+		}
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(r.Pattern), `\*`, ".*") + "$"
+		matched, err := regexp.MatchString(expr, branch)
+		return err == nil && matched
+	}
+}
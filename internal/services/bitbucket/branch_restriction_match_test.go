@@ -0,0 +1,40 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchRestriction_MatchesBranch(t *testing.T) {
+	t.Run("glob patterns", func(t *testing.T) {
+		name := faker.Word()
+		restriction := BranchRestriction{BranchMatchKind: BranchMatchKindGlob, Pattern: name}
+		assert.True(t, restriction.MatchesBranch(name))
+		assert.False(t, restriction.MatchesBranch(name+"-other"))
+
+		wildcard := BranchRestriction{BranchMatchKind: BranchMatchKindGlob, Pattern: "release/*"}
+		assert.True(t, wildcard.MatchesBranch("release/"+name))
+		assert.True(t, wildcard.MatchesBranch("release/"+name+"/hotfix"))
+		assert.False(t, wildcard.MatchesBranch("feature/"+name))
+
+		// Dots must be treated literally and not as regexp wildcards
+		literal := BranchRestriction{BranchMatchKind: BranchMatchKindGlob, Pattern: "v1.0"}
+		assert.False(t, literal.MatchesBranch("v1x0"))
+	})
+
+	t.Run("empty pattern never matches", func(t *testing.T) {
+		assert.False(t, BranchRestriction{}.MatchesBranch(faker.Word()))
+	})
+
+	t.Run("branching model types", func(t *testing.T) {
+		name := faker.Word()
+		feature := BranchRestriction{BranchMatchKind: BranchMatchKindBranchingModel, BranchType: "feature"}
+		assert.True(t, feature.MatchesBranch("feature/"+name))
+		assert.False(t, feature.MatchesBranch("bugfix/"+name))
+
+		production := BranchRestriction{BranchMatchKind: BranchMatchKindBranchingModel, BranchType: "production"}
+		assert.False(t, production.MatchesBranch(name))
+	})
+}
@@ -0,0 +1,145 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// FileChange is a file added or modified by a commit.
+type FileChange struct {
+	Path    string
+	Content string
+}
+
+// CommitFilesParams contains parameters for creating a commit from file changes.
+type CommitFilesParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Branch the commit is created on, the main branch when empty.
+	Branch string
+
+	Message string
+
+	// Author in "Name <email>" format, the authenticated user when empty.
+	Author string
+
+	// Parents of the commit, the head of the branch when empty. When the branch exists,
+	// the commit is rejected with 409 unless its head is the parent.
+	Parents []string
+
+	// Files added or modified by the commit.
+	Files []FileChange
+
+	// DeletedPaths are paths of files deleted by the commit.
+	DeletedPaths []string
+}
+
+// CommitFiles creates a commit with added, modified and deleted files without a clone.
+// POST /repositories/{workspace}/{repo_slug}/src.
+func (c *Client) CommitFiles(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params CommitFilesParams,
+) (*Commit, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	body, contentType, err := newCommitFilesForm(params)
+	if err != nil {
+		return nil, fmt.Errorf("commit files failed: %w", err)
+	}
+
+	fullURL := c.baseURL + fmt.Sprintf("/repositories/%s/%s/src",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+	req, err := http.NewRequestWithContext(ctxWithAuth, http.MethodPost, fullURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("commit files failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The created commit is only referenced by the Location header
+	hash, err := parseCommitLocation(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, fmt.Errorf("commit files failed: %w", err)
+	}
+	return &Commit{Hash: hash}, nil
+}
+
+// newCommitFilesForm builds the multipart form of the src endpoint. Paths of files are prefixed
+// with a slash so that they never collide with fields like message or branch.
+func newCommitFilesForm(params CommitFilesParams) (*bytes.Buffer, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fields := [][2]string{
+		{"message", params.Message},
+		{"author", params.Author},
+		{"branch", params.Branch},
+	}
+	for _, parent := range params.Parents {
+		fields = append(fields, [2]string{"parents", parent})
+	}
+	for _, deletedPath := range params.DeletedPaths {
+		fields = append(fields, [2]string{"files", "/" + deletedPath})
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, "", fmt.Errorf("failed to write %s field: %w", field[0], err)
+		}
+	}
+
+	for _, file := range params.Files {
+		part, err := writer.CreateFormFile("/"+file.Path, path.Base(file.Path))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create form file %s: %w", file.Path, err)
+		}
+		if _, err = part.Write([]byte(file.Content)); err != nil {
+			return nil, "", fmt.Errorf("failed to write form file %s: %w", file.Path, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to close form: %w", err)
+	}
+	return &body, writer.FormDataContentType(), nil
+}
+
+// parseCommitLocation extracts the commit hash from the commit link,
+// e.g. .../repositories/{workspace}/{repo_slug}/commit/{commit}.
+func parseCommitLocation(location string) (string, error) {
+	if location == "" {
+		return "", errors.New("commit created without location")
+	}
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse commit location: %w", err)
+	}
+	hash := path.Base(locationURL.Path)
+	if path.Base(path.Dir(locationURL.Path)) != "commit" || hash == "" {
+		return "", fmt.Errorf("unexpected commit location: %s", location)
+	}
+	return hash, nil
+}
@@ -0,0 +1,121 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CommitFiles(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		hash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/src", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			assert.NoError(t, r.ParseMultipartForm(1024*1024))
+			assert.Equal(t, []string{"Fix typo"}, r.MultipartForm.Value["message"])
+			assert.Equal(t, []string{"Jane <jane@example.com>"}, r.MultipartForm.Value["author"])
+			assert.Equal(t, []string{"feature/typo"}, r.MultipartForm.Value["branch"])
+			assert.Equal(t, []string{"abc123"}, r.MultipartForm.Value["parents"])
+			assert.Equal(t, []string{"/docs/old.md", "/message"}, r.MultipartForm.Value["files"])
+
+			files := map[string]string{}
+			for name, headers := range r.MultipartForm.File {
+				file, err := headers[0].Open()
+				if !assert.NoError(t, err) {
+					return
+				}
+				content, err := io.ReadAll(file)
+				assert.NoError(t, err)
+				files[name] = string(content)
+			}
+			assert.Equal(t, map[string]string{
+				"/README.md":     "# Title\n",
+				"/docs/guide.md": "",
+			}, files)
+
+			w.Header().Set("Location",
+				fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/commit/%s", workspace, repoSlug, hash))
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CommitFiles(t.Context(), mockTokenProvider, CommitFilesParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Branch:    "feature/typo",
+			Message:   "Fix typo",
+			Author:    "Jane <jane@example.com>",
+			Parents:   []string{"abc123"},
+			Files: []FileChange{
+				{Path: "README.md", Content: "# Title\n"},
+				{Path: "docs/guide.md", Content: ""},
+			},
+			DeletedPaths: []string{"docs/old.md", "message"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Commit{Hash: hash}, got)
+	})
+
+	t.Run("fails without commit location", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CommitFiles(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CommitFilesParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Message: faker.Sentence()})
+
+		require.ErrorContains(t, err, "commit created without location")
+		assert.Nil(t, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CommitFiles(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CommitFilesParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Message: faker.Sentence()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "commit files failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.CommitFiles(t.Context(), &MockTokenProvider{Err: tokenErr},
+			CommitFilesParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Message: faker.Sentence()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CreateBranchParams contains parameters for creating a branch.
+type CreateBranchParams struct {
+	Workspace string
+	RepoSlug  string
+	Name      string
+
+	// Target is a commit hash or a branch name the new branch starts from.
+	Target string
+}
+
+// refTarget is the commit a created branch or tag points at.
+type refTarget struct {
+	Hash string `json:"hash"`
+}
+
+type createBranchRequest struct {
+	Name   string    `json:"name"`
+	Target refTarget `json:"target"`
+}
+
+// CreateBranch creates a branch pointing at the given target.
+// POST /repositories/{workspace}/{repo_slug}/refs/branches.
+func (c *Client) CreateBranch(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params CreateBranchParams,
+) (*Branch, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/branches",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	var branch Branch
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[createBranchRequest, Branch]{
+			Method: "POST",
+			URL:    c.baseURL + path,
+			Body: &createBranchRequest{
+				Name:   params.Name,
+				Target: refTarget{Hash: params.Target},
+			},
+			Target: &branch,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create branch failed: %w", err)
+	}
+
+	return &branch, nil
+}
@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateBranch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		branchName := "feature/" + faker.Word()
+		target := "main"
+		commitHash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/refs/branches", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"name":   branchName,
+				"target": map[string]any{"hash": target},
+			}, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"type": "branch", "name": %q, "target": {"hash": %q}}`, branchName, commitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateBranch(t.Context(), mockTokenProvider, CreateBranchParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Name:      branchName,
+			Target:    target,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Branch{Type: "branch", Name: branchName, Target: &Commit{Hash: commitHash}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateBranch(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CreateBranchParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "create branch failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.CreateBranch(t.Context(), &MockTokenProvider{Err: tokenErr},
+			CreateBranchParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,64 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CommitStatusPayload is the request body for creating or updating a build status.
+type CommitStatusPayload struct {
+	Key         string `json:"key,omitempty"`
+	State       string `json:"state,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	RefName     string `json:"refname,omitempty"`
+}
+
+// CreateCommitStatusParams contains parameters for creating a build status of a commit.
+type CreateCommitStatusParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+
+	// Key, State and URL are required by Bitbucket.
+	Status CommitStatusPayload
+}
+
+// CreateCommitStatus creates a build status for a commit.
+// An existing status with the same key is overwritten.
+// POST /repositories/{workspace}/{repo_slug}/commit/{commit}/statuses/build.
+func (c *Client) CreateCommitStatus(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params CreateCommitStatusParams,
+) (*CommitStatus, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Commit),
+	)
+
+	var status CommitStatus
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[CommitStatusPayload, CommitStatus]{
+		Method: "POST",
+		URL:    c.baseURL + path,
+		Body:   &params.Status,
+		Target: &status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create commit status failed: %w", err)
+	}
+
+	return &status, nil
+}
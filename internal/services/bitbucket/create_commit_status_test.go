@@ -0,0 +1,92 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateCommitStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+		payload := CommitStatusPayload{
+			Key:         "build-" + faker.Word(),
+			State:       CommitStatusStateInProgress,
+			URL:         faker.URL(),
+			Name:        faker.Sentence(),
+			Description: faker.Sentence(),
+			RefName:     "feature/" + faker.Word(),
+		}
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build", workspace, repoSlug, commit),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body CommitStatusPayload
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, payload, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"type": "build", "key": %q, "state": %q, "url": %q}`, body.Key, body.State, body.URL)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateCommitStatus(t.Context(), mockTokenProvider, CreateCommitStatusParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			Status:    payload,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &CommitStatus{Type: "build", Key: payload.Key, State: payload.State, URL: payload.URL}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateCommitStatus(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CreateCommitStatusParams{
+				Workspace: faker.Username(),
+				RepoSlug:  faker.Username(),
+				Commit:    faker.UUIDDigit(),
+			})
+
+		require.ErrorContains(t, err, "create commit status failed")
+		assert.Nil(t, got)
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.CreateCommitStatus(t.Context(), &MockTokenProvider{Err: tokenErr}, CreateCommitStatusParams{})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
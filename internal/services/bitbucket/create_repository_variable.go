@@ -0,0 +1,53 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CreateRepositoryVariableParams contains parameters for creating a pipelines variable of a repository.
+type CreateRepositoryVariableParams struct {
+	Workspace string
+	RepoSlug  string
+	Variable  PipelineVariable
+}
+
+// CreateRepositoryVariable creates a pipelines variable of a repository.
+// POST /repositories/{workspace}/{repo_slug}/pipelines_config/variables.
+func (c *Client) CreateRepositoryVariable(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params CreateRepositoryVariableParams,
+) (*PipelineVariable, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	var variable PipelineVariable
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[PipelineVariable, PipelineVariable]{
+			Method: "POST",
+			URL:    c.baseURL + path,
+			Body:   &params.Variable,
+			Target: &variable,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create repository variable failed: %w", err)
+	}
+
+	return &variable, nil
+}
@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateRepositoryVariable(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		variable := PipelineVariable{Key: "KEY_" + faker.Word(), Value: faker.Password(), Secured: true}
+		variableUUID := "{" + faker.UUIDHyphenated() + "}"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables", workspace, repoSlug),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body PipelineVariable
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, variable, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"uuid": %q, "key": %q, "secured": true}`, variableUUID, variable.Key)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateRepositoryVariable(t.Context(), mockTokenProvider, CreateRepositoryVariableParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Variable:  variable,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineVariable{UUID: variableUUID, Key: variable.Key, Secured: true}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateRepositoryVariable(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CreateRepositoryVariableParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "create repository variable failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.CreateRepositoryVariable(t.Context(), &MockTokenProvider{Err: tokenErr},
+			CreateRepositoryVariableParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
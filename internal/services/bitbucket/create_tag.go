@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CreateTagParams contains parameters for creating a tag.
+type CreateTagParams struct {
+	Workspace string
+	RepoSlug  string
+	Name      string
+
+	// Target is a commit hash or a branch name to tag.
+	Target string
+
+	// Optional message, creates an annotated tag when provided
+	Message string
+}
+
+type createTagRequest struct {
+	Name    string    `json:"name"`
+	Target  refTarget `json:"target"`
+	Message string    `json:"message,omitempty"`
+}
+
+// CreateTag creates a tag pointing at the given target.
+// POST /repositories/{workspace}/{repo_slug}/refs/tags.
+func (c *Client) CreateTag(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params CreateTagParams,
+) (*Tag, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/tags",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	var tag Tag
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[createTagRequest, Tag]{
+			Method: "POST",
+			URL:    c.baseURL + path,
+			Body: &createTagRequest{
+				Name:    params.Name,
+				Target:  refTarget{Hash: params.Target},
+				Message: params.Message,
+			},
+			Target: &tag,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create tag failed: %w", err)
+	}
+
+	return &tag, nil
+}
@@ -0,0 +1,88 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateTag(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		tagName := "v" + faker.Word()
+		target := "main"
+		commitHash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/refs/tags", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"name":    tagName,
+				"target":  map[string]any{"hash": target},
+				"message": "Release " + tagName,
+			}, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"type": "tag", "name": %q, "target": {"hash": %q}}`, tagName, commitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateTag(t.Context(), mockTokenProvider, CreateTagParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Name:      tagName,
+			Target:    target,
+			Message:   "Release " + tagName,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Tag{Type: "tag", Name: tagName, Target: &Commit{Hash: commitHash}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.CreateTag(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			CreateTagParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "create tag failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.CreateTag(t.Context(), &MockTokenProvider{Err: tokenErr},
+			CreateTagParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
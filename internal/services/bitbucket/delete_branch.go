@@ -0,0 +1,47 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// DeleteBranchParams contains parameters for deleting a branch.
+type DeleteBranchParams struct {
+	Workspace string
+	RepoSlug  string
+	Name      string
+}
+
+// DeleteBranch deletes a branch of a repository.
+// DELETE /repositories/{workspace}/{repo_slug}/refs/branches/{name}.
+func (c *Client) DeleteBranch(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params DeleteBranchParams,
+) error {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/branches/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Name),
+	)
+
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, interface{}]{
+		Method: "DELETE",
+		URL:    c.baseURL + path,
+	})
+	if err != nil {
+		return fmt.Errorf("delete branch failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// DeleteRepositoryVariableParams contains parameters for deleting a pipelines variable of a repository.
+type DeleteRepositoryVariableParams struct {
+	Workspace    string
+	RepoSlug     string
+	VariableUUID string
+}
+
+// DeleteRepositoryVariable deletes a pipelines variable of a repository.
+// DELETE /repositories/{workspace}/{repo_slug}/pipelines_config/variables/{variable_uuid}.
+func (c *Client) DeleteRepositoryVariable(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params DeleteRepositoryVariableParams,
+) error {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.VariableUUID),
+	)
+
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, interface{}]{
+		Method: "DELETE",
+		URL:    c.baseURL + path,
+	})
+	if err != nil {
+		return fmt.Errorf("delete repository variable failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DeleteRepositoryVariable(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		variableUUID := "{" + faker.UUIDHyphenated() + "}"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables/%s", workspace, repoSlug, variableUUID),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		err := client.DeleteRepositoryVariable(t.Context(), mockTokenProvider, DeleteRepositoryVariableParams{
+			Workspace:    workspace,
+			RepoSlug:     repoSlug,
+			VariableUUID: variableUUID,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		err := client.DeleteRepositoryVariable(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			DeleteRepositoryVariableParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				VariableUUID: faker.UUIDHyphenated(),
+			})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delete repository variable failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		err := client.DeleteRepositoryVariable(t.Context(), &MockTokenProvider{Err: tokenErr},
+			DeleteRepositoryVariableParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				VariableUUID: faker.UUIDHyphenated(),
+			})
+
+		require.ErrorIs(t, err, tokenErr)
+	})
+}
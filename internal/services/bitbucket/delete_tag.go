@@ -0,0 +1,47 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// DeleteTagParams contains parameters for deleting a tag.
+type DeleteTagParams struct {
+	Workspace string
+	RepoSlug  string
+	Name      string
+}
+
+// DeleteTag deletes a tag of a repository.
+// DELETE /repositories/{workspace}/{repo_slug}/refs/tags/{name}.
+func (c *Client) DeleteTag(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params DeleteTagParams,
+) error {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/tags/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Name),
+	)
+
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, interface{}]{
+		Method: "DELETE",
+		URL:    c.baseURL + path,
+	})
+	if err != nil {
+		return fmt.Errorf("delete tag failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DeleteTag(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		tagName := "release/" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/refs/tags/%s", workspace, repoSlug, url.PathEscape(tagName)),
+				r.URL.EscapedPath())
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		err := client.DeleteTag(t.Context(), mockTokenProvider, DeleteTagParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Name:      tagName,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		err := client.DeleteTag(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			DeleteTagParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delete tag failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		err := client.DeleteTag(t.Context(), &MockTokenProvider{Err: tokenErr},
+			DeleteTagParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+	})
+}
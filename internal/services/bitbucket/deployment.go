@@ -0,0 +1,85 @@
+package bitbucket
+
+import "time"
+
+// Deployment state names.
+const (
+	DeploymentStateUndeployed = "UNDEPLOYED"
+	DeploymentStateInProgress = "IN_PROGRESS"
+	DeploymentStateCompleted  = "COMPLETED"
+)
+
+// Deployment status names of a completed deployment.
+const (
+	DeploymentStatusSuccessful = "SUCCESSFUL"
+	DeploymentStatusFailed     = "FAILED"
+	DeploymentStatusStopped    = "STOPPED"
+)
+
+// DeploymentEnvironmentType is the category of an environment, e.g. Test, Staging or Production.
+type DeploymentEnvironmentType struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+	Rank int    `json:"rank,omitempty"`
+}
+
+// DeploymentEnvironment matches the Bitbucket OpenAPI "deployment_environment" definition.
+type DeploymentEnvironment struct {
+	UUID            string                     `json:"uuid"`
+	Name            string                     `json:"name,omitempty"`
+	Slug            string                     `json:"slug,omitempty"`
+	Rank            int                        `json:"rank,omitempty"`
+	Hidden          bool                       `json:"hidden,omitempty"`
+	EnvironmentType *DeploymentEnvironmentType `json:"environment_type,omitempty"`
+}
+
+// DeploymentStateStatus is the outcome of a completed deployment.
+type DeploymentStateStatus struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+}
+
+// DeploymentState matches the Bitbucket OpenAPI "deployment_state" definitions.
+type DeploymentState struct {
+	Type           string                 `json:"type,omitempty"`
+	Name           string                 `json:"name"`
+	URL            string                 `json:"url,omitempty"`
+	Status         *DeploymentStateStatus `json:"status,omitempty"`
+	StartDate      *time.Time             `json:"start_date,omitempty"`
+	CompletionDate *time.Time             `json:"completion_date,omitempty"`
+}
+
+// DeploymentRelease matches the Bitbucket OpenAPI "deployment_release" definition.
+type DeploymentRelease struct {
+	UUID      string          `json:"uuid,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	URL       string          `json:"url,omitempty"`
+	Commit    *PipelineCommit `json:"commit,omitempty"`
+	CreatedOn *time.Time      `json:"created_on,omitempty"`
+}
+
+// DeploymentPipeline identifies the pipeline that produced a deployable.
+type DeploymentPipeline struct {
+	Type string `json:"type,omitempty"`
+	UUID string `json:"uuid"`
+}
+
+// Deployable is the build artifact of a pipeline that gets deployed.
+type Deployable struct {
+	Type      string              `json:"type,omitempty"`
+	UUID      string              `json:"uuid,omitempty"`
+	Name      string              `json:"name,omitempty"`
+	URL       string              `json:"url,omitempty"`
+	Pipeline  *DeploymentPipeline `json:"pipeline,omitempty"`
+	Commit    *PipelineCommit     `json:"commit,omitempty"`
+	CreatedOn *time.Time          `json:"created_on,omitempty"`
+}
+
+// Deployment matches the Bitbucket OpenAPI "deployment" definition.
+type Deployment struct {
+	UUID        string                 `json:"uuid"`
+	State       *DeploymentState       `json:"state,omitempty"`
+	Environment *DeploymentEnvironment `json:"environment,omitempty"`
+	Release     *DeploymentRelease     `json:"release,omitempty"`
+	Deployable  *Deployable            `json:"deployable,omitempty"`
+}
@@ -0,0 +1,49 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetBranchParams contains parameters for getting a branch of a repository.
+type GetBranchParams struct {
+	Workspace string
+	RepoSlug  string
+	Name      string
+}
+
+// GetBranch returns a branch of a repository together with its head commit.
+// GET /repositories/{workspace}/{repo_slug}/refs/branches/{name}.
+func (c *Client) GetBranch(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetBranchParams,
+) (*Branch, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/branches/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Name),
+	)
+
+	var branch Branch
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, Branch]{
+		Method: "GET",
+		URL:    c.baseURL + path,
+		Target: &branch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get branch failed: %w", err)
+	}
+
+	return &branch, nil
+}
@@ -0,0 +1,78 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetBranch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		branchName := "feature/" + faker.Word()
+		commitHash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/refs/branches/%s", workspace, repoSlug, url.PathEscape(branchName)),
+				r.URL.EscapedPath())
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"type": "branch", "name": %q, "target": {"hash": %q}}`, branchName, commitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetBranch(t.Context(), mockTokenProvider, GetBranchParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Name:      branchName,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Branch{Type: "branch", Name: branchName, Target: &Commit{Hash: commitHash}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetBranch(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			GetBranchParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get branch failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetBranch(t.Context(), &MockTokenProvider{Err: tokenErr},
+			GetBranchParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Name: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,51 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetCommitParams contains parameters for getting a commit.
+type GetCommitParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Commit is a full or abbreviated commit hash.
+	Commit string
+}
+
+// GetCommit returns a commit with its message, author and parents.
+// GET /repositories/{workspace}/{repo_slug}/commit/{commit}.
+func (c *Client) GetCommit(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetCommitParams,
+) (*Commit, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Commit),
+	)
+
+	var commit Commit
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, Commit]{
+		Method: "GET",
+		URL:    c.baseURL + path,
+		Target: &commit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get commit failed: %w", err)
+	}
+
+	return &commit, nil
+}
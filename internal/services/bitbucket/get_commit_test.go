@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCommit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		hash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/commit/%s", workspace, repoSlug, hash), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"hash": %q, "message": "Fix rounding (pull request #12)",
+				"author": {"raw": "Jane <jane@example.com>"}}`, hash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetCommit(t.Context(), mockTokenProvider, GetCommitParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    hash,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Commit{
+			Hash:    hash,
+			Message: "Fix rounding (pull request #12)",
+			Author:  &CommitAuthor{Raw: "Jane <jane@example.com>"},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetCommit(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			GetCommitParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get commit failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetCommit(t.Context(), &MockTokenProvider{Err: tokenErr},
+			GetCommitParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CurrentUser is the account the token authenticates as.
+type CurrentUser struct {
+	User Account `json:"user"`
+
+	// Scopes granted to the token. Only reported for OAuth and access tokens, empty otherwise.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// GetCurrentUser returns the account the token authenticates as and the scopes of the token.
+// GET /user.
+func (c *Client) GetCurrentUser(ctx context.Context, tokenProvider TokenProvider) (*CurrentUser, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	req, err := http.NewRequestWithContext(ctxWithAuth, http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get current user failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var user CurrentUser
+	if err = json.NewDecoder(resp.Body).Decode(&user.User); err != nil {
+		return nil, fmt.Errorf("get current user failed: %w", err)
+	}
+	user.Scopes = parseOAuthScopes(resp.Header.Get("X-Oauth-Scopes"))
+
+	return &user, nil
+}
+
+// parseOAuthScopes splits the comma separated list of scopes from the X-OAuth-Scopes header.
+func parseOAuthScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
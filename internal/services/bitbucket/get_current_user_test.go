@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCurrentUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		uuid := "{" + faker.UUIDHyphenated() + "}"
+		nickname := faker.Username()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/user", r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-OAuth-Scopes", "pullrequest:write, repository,  account")
+			fmt.Fprintf(w, `{"type": "user", "display_name": "Jane Doe", "uuid": %q, "nickname": %q}`, uuid, nickname)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetCurrentUser(t.Context(), mockTokenProvider)
+
+		require.NoError(t, err)
+		assert.Equal(t, &CurrentUser{
+			User:   Account{Type: "user", DisplayName: "Jane Doe", UUID: uuid, Nickname: nickname},
+			Scopes: []string{"pullrequest:write", "repository", "account"},
+		}, got)
+	})
+
+	t.Run("success without scopes header", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"type": "user", "display_name": "Jane Doe"}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetCurrentUser(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()})
+
+		require.NoError(t, err)
+		assert.Equal(t, &CurrentUser{User: Account{Type: "user", DisplayName: "Jane Doe"}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetCurrentUser(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get current user failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetCurrentUser(t.Context(), &MockTokenProvider{Err: tokenErr})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
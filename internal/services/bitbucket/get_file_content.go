@@ -17,9 +17,10 @@ type GetFileContentParams struct {
 	CommitHash string
 	FilePath   string
 	Account    *string // optional
-}
 
-// FileContent represents the raw file content.
+	// MaxBytes limits how much of the file is read, the whole file is read when not positive.
+	MaxBytes int64
+}
 
 // GetFileContent retrieves the content of a file at a specific commit.
 func (c *Client) GetFileContent(
@@ -66,7 +67,12 @@ func (c *Client) GetFileContent(
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var reader io.Reader = resp.Body
+	if params.MaxBytes > 0 {
+		// One extra byte tells if the file is longer than the limit
+		reader = io.LimitReader(resp.Body, params.MaxBytes+1)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file content response: %w", err)
 	}
@@ -76,9 +82,16 @@ func (c *Client) GetFileContent(
 		return nil, fmt.Errorf("get file content failed: status %d, body: %s", resp.StatusCode, string(body))
 	}
 
+	truncated := params.MaxBytes > 0 && int64(len(body)) > params.MaxBytes
+	if truncated {
+		body = body[:params.MaxBytes]
+	}
+
 	return &FileContent{
-		Path:    params.FilePath,
-		Commit:  params.CommitHash,
-		Content: string(body),
+		Path:        params.FilePath,
+		Commit:      params.CommitHash,
+		Content:     string(body),
+		ContentType: resp.Header.Get("Content-Type"),
+		Truncated:   truncated,
 	}, nil
 }
@@ -94,6 +94,46 @@ func TestClient_GetFileContent(t *testing.T) {
 		assert.Equal(t, expectedContent, result.Content)
 	})
 
+	t.Run("limits content to max bytes", func(t *testing.T) {
+		commit := faker.UUIDHyphenated()
+		filePath := "img/" + faker.Word() + ".png"
+		data := []byte("\x89PNG\r\n\x1a\n" + faker.Sentence())
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(data)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+		params := GetFileContentParams{
+			RepoOwner:  "test-user-" + faker.Word(),
+			RepoName:   "test-repo-" + faker.Word(),
+			CommitHash: commit,
+			FilePath:   filePath,
+			MaxBytes:   8,
+		}
+
+		result, err := client.GetFileContent(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &FileContent{
+			Path:        filePath,
+			Commit:      commit,
+			Content:     string(data[:8]),
+			ContentType: "image/png",
+			Truncated:   true,
+		}, result)
+
+		params.MaxBytes = int64(len(data))
+		result, err = client.GetFileContent(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(data), result.Content)
+		assert.False(t, result.Truncated)
+	})
+
 	t.Run("handles API error", func(t *testing.T) {
 		username := "test-user-" + faker.Word()
 		repoSlug := "test-repo-" + faker.Word()
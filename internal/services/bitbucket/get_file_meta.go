@@ -0,0 +1,43 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetFileMetaParams contains parameters for getting metadata of a file or a directory.
+type GetFileMetaParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+	Path      string
+}
+
+// GetFileMeta returns metadata of a file or a directory at a commit without its content.
+// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}?format=meta.
+func (c *Client) GetFileMeta(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetFileMetaParams,
+) (*TreeEntry, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	var entry TreeEntry
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, TreeEntry]{
+		Method: "GET",
+		URL:    c.baseURL + sourcePath(params.Workspace, params.RepoSlug, params.Commit, params.Path) + "?format=meta",
+		Target: &entry,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get file meta failed: %w", err)
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetFileMeta(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+		filePath := "src/" + faker.Word() + "/main.go"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/src/%s/%s", workspace, repoSlug, commit, filePath), r.URL.Path)
+			assert.Equal(t, "meta", r.URL.Query().Get("format"))
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"type": "commit_file", "path": %q, "size": 2048, "mimetype": "text/x-go",
+				"attributes": ["binary"], "commit": {"hash": %q}}`, filePath, commit)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetFileMeta(t.Context(), mockTokenProvider, GetFileMetaParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			Path:      filePath,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &TreeEntry{
+			Type:       TreeEntryTypeFile,
+			Path:       filePath,
+			Size:       2048,
+			MimeType:   "text/x-go",
+			Attributes: []string{"binary"},
+			Commit:     &Commit{Hash: commit},
+		}, got)
+		assert.False(t, got.IsDirectory())
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetFileMeta(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			GetFileMetaParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Path: faker.Word()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get file meta failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetFileMeta(t.Context(), &MockTokenProvider{Err: tokenErr},
+			GetFileMetaParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Path: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
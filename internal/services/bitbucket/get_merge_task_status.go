@@ -0,0 +1,72 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// Merge task statuses.
+const (
+	MergeTaskStatusPending = "PENDING"
+	MergeTaskStatusSuccess = "SUCCESS"
+)
+
+// GetMergeTaskStatusParams contains parameters for getting the status of a merge task.
+type GetMergeTaskStatusParams struct {
+	Username      string
+	RepoSlug      string
+	PullRequestID int
+	TaskID        string
+}
+
+// MergeTaskStatus represents the status of an asynchronous merge.
+type MergeTaskStatus struct {
+	TaskStatus string `json:"task_status"`
+
+	// MergeResult is the merged pull request. It is only set when the task succeeded.
+	MergeResult *PullRequest `json:"merge_result,omitempty"`
+}
+
+// GetMergeTaskStatus retrieves the status of an asynchronous merge.
+// Failed merges are reported as MergeError.
+// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/merge/task-status/{task_id}.
+func (c *Client) GetMergeTaskStatus(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetMergeTaskStatusParams,
+) (*MergeTaskStatus, error) {
+	if params.TaskID == "" {
+		return nil, errors.New("task ID is required")
+	}
+
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf(
+		"/repositories/%s/%s/pullrequests/%d/merge/task-status/%s",
+		params.Username,
+		params.RepoSlug,
+		params.PullRequestID,
+		url.PathEscape(params.TaskID),
+	)
+
+	var status MergeTaskStatus
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, MergeTaskStatus]{
+		Method: "GET",
+		URL:    c.baseURL + path,
+		Target: &status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get merge task status failed: %w", newMergeErrorFromHTTPError(err, MergeErrorReasonFailed))
+	}
+
+	return &status, nil
+}
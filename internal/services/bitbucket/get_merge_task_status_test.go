@@ -0,0 +1,132 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetMergeTaskStatus(t *testing.T) {
+	makeParams := func() GetMergeTaskStatusParams {
+		return GetMergeTaskStatusParams{
+			Username:      "workspace-" + faker.Word(),
+			RepoSlug:      "repo-" + faker.Word(),
+			PullRequestID: rand.IntN(1000) + 1,
+			TaskID:        faker.UUIDHyphenated(),
+		}
+	}
+
+	t.Run("returns pending status", func(t *testing.T) {
+		params := makeParams()
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge/task-status/%s",
+				params.Username, params.RepoSlug, params.PullRequestID, params.TaskID), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"task_status": "PENDING", "links": {}}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.GetMergeTaskStatus(t.Context(), mockTokenProvider, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &MergeTaskStatus{TaskStatus: MergeTaskStatusPending}, result)
+	})
+
+	t.Run("returns merged pull request on success", func(t *testing.T) {
+		params := makeParams()
+		mergeCommitHash := faker.UUIDDigit()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{
+				"task_status": "SUCCESS",
+				"merge_result": {"id": %d, "state": "MERGED", "merge_commit": {"hash": "%s"}}
+			}`, params.PullRequestID, mergeCommitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.GetMergeTaskStatus(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, MergeTaskStatusSuccess, result.TaskStatus)
+		require.NotNil(t, result.MergeResult)
+		assert.Equal(t, params.PullRequestID, result.MergeResult.ID)
+		assert.Equal(t, mergeCommitHash, result.MergeResult.MergeCommit.Hash)
+	})
+
+	t.Run("returns merge error when task failed", func(t *testing.T) {
+		params := makeParams()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "Merge failed due to merge conflicts"}}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.GetMergeTaskStatus(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.ErrorContains(t, err, "get merge task status failed")
+		assert.Nil(t, result)
+		var mergeErr *MergeError
+		require.ErrorAs(t, err, &mergeErr)
+		assert.Equal(t, MergeErrorReasonConflict, mergeErr.Reason)
+		assert.Equal(t, "Merge failed due to merge conflicts", mergeErr.Message)
+	})
+
+	t.Run("returns merge error with failed reason for other task errors", func(t *testing.T) {
+		params := makeParams()
+		message := faker.Sentence()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"type": "error", "error": {"message": %q}}`, message)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		_, err := client.GetMergeTaskStatus(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		var mergeErr *MergeError
+		require.ErrorAs(t, err, &mergeErr)
+		assert.Equal(t, &MergeError{Reason: MergeErrorReasonFailed, Message: message, Err: mergeErr.Err}, mergeErr)
+	})
+
+	t.Run("requires task ID", func(t *testing.T) {
+		params := makeParams()
+		params.TaskID = ""
+		client := NewClient(makeMockDepsWithTestName(t, "http://example.com"))
+
+		_, err := client.GetMergeTaskStatus(t.Context(), &MockTokenProvider{}, params)
+
+		require.ErrorContains(t, err, "task ID is required")
+	})
+
+	t.Run("handles token provider error", func(t *testing.T) {
+		mockTokenProvider := &MockTokenProvider{Err: errors.New(faker.Sentence())}
+		client := NewClient(makeMockDepsWithTestName(t, "http://example.com"))
+
+		_, err := client.GetMergeTaskStatus(t.Context(), mockTokenProvider, makeParams())
+
+		require.ErrorIs(t, err, mockTokenProvider.Err)
+	})
+}
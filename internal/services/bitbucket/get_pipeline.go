@@ -0,0 +1,49 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetPipelineParams contains parameters for getting a pipeline.
+type GetPipelineParams struct {
+	Workspace    string
+	RepoSlug     string
+	PipelineUUID string
+}
+
+// GetPipeline retrieves a single pipeline.
+// GET /repositories/{workspace}/{repo_slug}/pipelines/{pipeline_uuid}.
+func (c *Client) GetPipeline(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetPipelineParams,
+) (*Pipeline, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.PipelineUUID),
+	)
+
+	var pipeline Pipeline
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, Pipeline]{
+		Method: "GET",
+		URL:    c.baseURL + path,
+		Target: &pipeline,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get pipeline failed: %w", err)
+	}
+
+	return &pipeline, nil
+}
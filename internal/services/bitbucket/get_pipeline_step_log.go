@@ -0,0 +1,140 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetPipelineStepLogParams contains parameters for getting the log of a pipeline step.
+type GetPipelineStepLogParams struct {
+	Workspace    string
+	RepoSlug     string
+	PipelineUUID string
+	StepUUID     string
+
+	// TailBytes limits the log to its last TailBytes bytes using a range request.
+	// The whole log is returned when zero.
+	TailBytes int64
+}
+
+// PipelineStepLog is the (possibly partial) log of a pipeline step.
+type PipelineStepLog struct {
+	Content string `json:"content"`
+
+	// TotalSize is the size of the whole log in bytes. It is only known for partial logs.
+	TotalSize int64 `json:"total_size,omitempty"`
+
+	// Truncated is true when Content does not include the beginning of the log.
+	Truncated bool `json:"truncated"`
+}
+
+// GetPipelineStepLog retrieves the log of a pipeline step.
+// The log may be served by a redirect to a storage location, which is followed
+// without the Bitbucket credentials.
+// GET /repositories/{workspace}/{repo_slug}/pipelines/{pipeline_uuid}/steps/{step_uuid}/log.
+func (c *Client) GetPipelineStepLog(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetPipelineStepLogParams,
+) (*PipelineStepLog, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps/%s/log",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.PipelineUUID),
+		url.PathEscape(params.StepUUID),
+	)
+
+	// Redirects are followed manually so the token is not sent to the storage location
+	noRedirectClient := *c.httpClient
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := sendStepLogRequest(ctxWithAuth, &noRedirectClient, c.baseURL+path, params.TailBytes)
+	if err != nil {
+		return handleStepLogError(err)
+	}
+	defer resp.Body.Close()
+
+	if location := resp.Header.Get("Location"); isRedirectStatus(resp.StatusCode) && location != "" {
+		redirectURL, parseErr := resp.Request.URL.Parse(location)
+		if parseErr != nil {
+			return nil, fmt.Errorf("get pipeline step log failed: invalid redirect location: %w", parseErr)
+		}
+		resp, err = sendStepLogRequest(ctx, &noRedirectClient, redirectURL.String(), params.TailBytes)
+		if err != nil {
+			return handleStepLogError(err)
+		}
+		defer resp.Body.Close()
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("get pipeline step log failed: failed to read response: %w", err)
+	}
+
+	log := &PipelineStepLog{Content: string(body)}
+	if resp.StatusCode == http.StatusPartialContent {
+		start, total := parseContentRange(resp.Header.Get("Content-Range"))
+		log.TotalSize = total
+		log.Truncated = start > 0
+	}
+	return log, nil
+}
+
+func sendStepLogRequest(
+	ctx context.Context,
+	httpClient *http.Client,
+	requestURL string,
+	tailBytes int64,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if tailBytes > 0 {
+		req.Header.Set("Range", "bytes=-"+strconv.FormatInt(tailBytes, 10))
+	}
+	return httpClient.Do(req)
+}
+
+// handleStepLogError treats an unsatisfiable range as an empty log. Bitbucket
+// responds with 416 when the step did not produce any output.
+func handleStepLogError(err error) (*PipelineStepLog, error) {
+	var httpErr *middleware.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return &PipelineStepLog{}, nil
+	}
+	return nil, fmt.Errorf("get pipeline step log failed: %w", err)
+}
+
+func isRedirectStatus(statusCode int) bool {
+	return statusCode >= http.StatusMultipleChoices && statusCode < http.StatusBadRequest
+}
+
+// parseContentRange parses the start offset and the total size of
+// a "bytes start-end/total" Content-Range header. Zeros are returned when unknown.
+func parseContentRange(contentRange string) (int64, int64) {
+	byteRange, total, found := strings.Cut(strings.TrimPrefix(contentRange, "bytes "), "/")
+	if !found {
+		return 0, 0
+	}
+	startValue, _, _ := strings.Cut(byteRange, "-")
+	start, _ := strconv.ParseInt(startValue, 10, 64)
+	totalSize, _ := strconv.ParseInt(total, 10, 64)
+	return start, totalSize
+}
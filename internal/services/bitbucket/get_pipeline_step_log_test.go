@@ -0,0 +1,143 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPipelineStepLog(t *testing.T) {
+	makeParams := func() GetPipelineStepLogParams {
+		return GetPipelineStepLogParams{
+			Workspace:    "ws-" + faker.Word(),
+			RepoSlug:     "repo-" + faker.Word(),
+			PipelineUUID: "{" + faker.UUIDHyphenated() + "}",
+			StepUUID:     "{" + faker.UUIDHyphenated() + "}",
+		}
+	}
+
+	t.Run("success returns whole log", func(t *testing.T) {
+		params := makeParams()
+		content := faker.Paragraph()
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps/%s/log",
+				params.Workspace, params.RepoSlug, params.PipelineUUID, params.StepUUID), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			assert.Empty(t, r.Header.Get("Range"))
+			fmt.Fprint(w, content)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipelineStepLog(t.Context(), mockTokenProvider, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineStepLog{Content: content}, got)
+	})
+
+	t.Run("requests log tail with range", func(t *testing.T) {
+		params := makeParams()
+		params.TailBytes = 10
+		content := "0123456789"
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "bytes=-10", r.Header.Get("Range"))
+			w.Header().Set("Content-Range", "bytes 90-99/100")
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, content)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipelineStepLog(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineStepLog{Content: content, TotalSize: 100, Truncated: true}, got)
+	})
+
+	t.Run("follows redirect without credentials", func(t *testing.T) {
+		params := makeParams()
+		params.TailBytes = 100
+		content := faker.Paragraph()
+
+		storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Authorization"))
+			assert.Equal(t, "bytes=-100", r.Header.Get("Range"))
+			assert.Equal(t, "/logs/step.txt", r.URL.Path)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, content)
+		}))
+		defer storage.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NotEmpty(t, r.Header.Get("Authorization"))
+			http.Redirect(w, r, storage.URL+"/logs/step.txt", http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipelineStepLog(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineStepLog{Content: content, TotalSize: int64(len(content))}, got)
+	})
+
+	t.Run("returns empty log when range is not satisfiable", func(t *testing.T) {
+		params := makeParams()
+		params.TailBytes = 100
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipelineStepLog(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineStepLog{}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipelineStepLog(t.Context(),
+			&MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, makeParams())
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get pipeline step log failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetPipelineStepLog(t.Context(), &MockTokenProvider{Err: tokenErr}, makeParams())
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,84 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPipeline(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		pipelineUUID := "{" + faker.UUIDHyphenated() + "}"
+		buildNumber := 1 + rand.IntN(1000)
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pipelines/%s", workspace, repoSlug, pipelineUUID), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"uuid": %q, "build_number": %d, "state": {"name": "IN_PROGRESS", "stage": {"name": "RUNNING"}}}`,
+				pipelineUUID, buildNumber)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipeline(t.Context(), mockTokenProvider, GetPipelineParams{
+			Workspace:    workspace,
+			RepoSlug:     repoSlug,
+			PipelineUUID: pipelineUUID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Pipeline{
+			UUID:        pipelineUUID,
+			BuildNumber: buildNumber,
+			State: &PipelineState{
+				Name:  PipelineStateInProgress,
+				Stage: &PipelineStateResult{Name: "RUNNING"},
+			},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetPipeline(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			GetPipelineParams{Workspace: faker.Username(), RepoSlug: faker.Username(), PipelineUUID: faker.UUIDHyphenated()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get pipeline failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetPipeline(t.Context(), &MockTokenProvider{Err: tokenErr},
+			GetPipelineParams{Workspace: faker.Username(), RepoSlug: faker.Username(), PipelineUUID: faker.UUIDHyphenated()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,47 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// GetRepositoryParams contains parameters for getting a repository.
+type GetRepositoryParams struct {
+	Workspace string
+	RepoSlug  string
+}
+
+// GetRepository returns a repository with its main branch, project and language.
+// GET /repositories/{workspace}/{repo_slug}.
+func (c *Client) GetRepository(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params GetRepositoryParams,
+) (*Repository, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	var repository Repository
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, Repository]{
+		Method: "GET",
+		URL:    c.baseURL + path,
+		Target: &repository,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get repository failed: %w", err)
+	}
+
+	return &repository, nil
+}
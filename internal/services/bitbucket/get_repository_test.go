@@ -0,0 +1,94 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetRepository(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{
+				"type": "repository",
+				"name": "Payments Service",
+				"slug": %[2]q,
+				"full_name": "%[1]s/%[2]s",
+				"is_private": true,
+				"language": "go",
+				"size": 123456,
+				"mainbranch": {"type": "branch", "name": "main"},
+				"project": {"type": "project", "key": "PAY", "name": "Payments"},
+				"workspace": {"type": "workspace", "slug": %[1]q}
+			}`, workspace, repoSlug)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetRepository(t.Context(), mockTokenProvider, GetRepositoryParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Repository{
+			Type:       "repository",
+			Name:       "Payments Service",
+			Slug:       repoSlug,
+			FullName:   workspace + "/" + repoSlug,
+			IsPrivate:  true,
+			Language:   "go",
+			Size:       123456,
+			MainBranch: &Branch{Type: "branch", Name: "main"},
+			Project:    &Project{Type: "project", Key: "PAY", Name: "Payments"},
+			Workspace:  &Workspace{Type: "workspace", Slug: workspace},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.GetRepository(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			GetRepositoryParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "get repository failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.GetRepository(t.Context(), &MockTokenProvider{Err: tokenErr},
+			GetRepositoryParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,63 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListBranchRestrictionsParams contains parameters for listing branch restrictions of a repository.
+type ListBranchRestrictionsParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Optional query parameters
+	Kind    string // restrict results to a specific restriction kind
+	Pattern string // restrict results to a specific branch pattern
+	PageLen int
+}
+
+// ListBranchRestrictions returns all branch restrictions configured for a repository.
+// GET /repositories/{workspace}/{repo_slug}/branch-restrictions.
+func (c *Client) ListBranchRestrictions(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListBranchRestrictionsParams,
+) ([]BranchRestriction, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/branch-restrictions",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	if params.Kind != "" {
+		query.Add("kind", params.Kind)
+	}
+	if params.Pattern != "" {
+		query.Add("pattern", params.Pattern)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	restrictions, err := fetchAllPages[BranchRestriction](ctxWithAuth, c.httpClient, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("list branch restrictions failed: %w", err)
+	}
+
+	return restrictions, nil
+}
@@ -0,0 +1,100 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListBranchRestrictions(t *testing.T) {
+	t.Run("success with filters", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		pattern := "release/" + faker.Word()
+		restrictionID := 1 + rand.IntN(1000)
+		requiredApprovals := 1 + rand.IntN(5)
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/branch-restrictions", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, BranchRestrictionRequireApprovals, r.URL.Query().Get("kind"))
+			assert.Equal(t, pattern, r.URL.Query().Get("pattern"))
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"values": [{
+				"id": %d,
+				"kind": %q,
+				"branch_match_kind": "glob",
+				"pattern": %q,
+				"value": %d
+			}]}`, restrictionID, BranchRestrictionRequireApprovals, pattern, requiredApprovals)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListBranchRestrictions(t.Context(), mockTokenProvider, ListBranchRestrictionsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Kind:      BranchRestrictionRequireApprovals,
+			Pattern:   pattern,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []BranchRestriction{{
+			ID:              restrictionID,
+			Kind:            BranchRestrictionRequireApprovals,
+			BranchMatchKind: BranchMatchKindGlob,
+			Pattern:         pattern,
+			Value:           lo.ToPtr(requiredApprovals),
+		}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListBranchRestrictions(t.Context(), &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}, ListBranchRestrictionsParams{
+			Workspace: faker.Username(),
+			RepoSlug:  faker.Username(),
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list branch restrictions failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListBranchRestrictions(t.Context(), &MockTokenProvider{Err: tokenErr}, ListBranchRestrictionsParams{
+			Workspace: faker.Username(),
+			RepoSlug:  faker.Username(),
+		})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,57 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListCommitPullRequestsParams contains parameters for listing pull requests that contain a commit.
+type ListCommitPullRequestsParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+
+	// Optional query parameters
+	PageLen int
+
+	// Limit stops paging once at least this many pull requests are collected.
+	// All pull requests are listed when not positive.
+	Limit int
+}
+
+// ListCommitPullRequests returns pull requests as part of which the commit was reviewed.
+// Bitbucket responds with 404 until pull request commit links of the repository are indexed.
+// GET /repositories/{workspace}/{repo_slug}/commit/{commit}/pullrequests.
+func (c *Client) ListCommitPullRequests(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListCommitPullRequestsParams,
+) ([]PullRequest, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/pullrequests",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Commit),
+	)
+
+	requestURL := c.baseURL + path
+	if params.PageLen > 0 {
+		requestURL += "?" + url.Values{"pagelen": {strconv.Itoa(params.PageLen)}}.Encode()
+	}
+
+	pullRequests, err := fetchPages[PullRequest](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list commit pull requests failed: %w", err)
+	}
+
+	return pullRequests, nil
+}
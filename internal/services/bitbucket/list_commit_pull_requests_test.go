@@ -0,0 +1,77 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListCommitPullRequests(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/commit/%s/pullrequests", workspace, repoSlug, commit),
+				r.URL.Path)
+			assert.Equal(t, "50", r.URL.Query().Get("pagelen"))
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"values": [{"id": 7, "title": "Add blame", "state": "MERGED"}]}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommitPullRequests(t.Context(), mockTokenProvider, ListCommitPullRequestsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			PageLen:   50,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []PullRequest{{ID: 7, Title: "Add blame", State: "MERGED"}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommitPullRequests(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListCommitPullRequestsParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list commit pull requests failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListCommitPullRequests(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListCommitPullRequestsParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
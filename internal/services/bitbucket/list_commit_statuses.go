@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListCommitStatusesParams contains parameters for listing build statuses of a commit.
+type ListCommitStatusesParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+
+	// Optional query parameters
+	Query   string
+	Sort    string
+	PageLen int
+}
+
+// ListCommitStatuses returns all build statuses reported for a commit.
+// GET /repositories/{workspace}/{repo_slug}/commit/{commit}/statuses.
+func (c *Client) ListCommitStatuses(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListCommitStatusesParams,
+) ([]CommitStatus, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Commit),
+	)
+
+	query := url.Values{}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	statuses, err := fetchAllPages[CommitStatus](ctxWithAuth, c.httpClient, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("list commit statuses failed: %w", err)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,102 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListCommitStatuses(t *testing.T) {
+	t.Run("success follows all pages", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+		pageLen := 1 + rand.IntN(50)
+		firstKey := "build-" + faker.Word()
+		secondKey := "build-" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var serverURL string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			expectedPath := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses", workspace, repoSlug, commit)
+			assert.Equal(t, expectedPath, r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `{"values": [{"key": %q, "state": "FAILED"}]}`, secondKey)
+				return
+			}
+			assert.Equal(t, fmt.Sprint(pageLen), r.URL.Query().Get("pagelen"))
+			fmt.Fprintf(w, `{"values": [{"key": %q, "state": "SUCCESSFUL"}], "next": "%s%s?page=2"}`,
+				firstKey, serverURL, expectedPath)
+		}))
+		defer server.Close()
+		serverURL = server.URL
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommitStatuses(t.Context(), mockTokenProvider, ListCommitStatusesParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			PageLen:   pageLen,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []CommitStatus{
+			{Key: firstKey, State: CommitStatusStateSuccessful},
+			{Key: secondKey, State: CommitStatusStateFailed},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommitStatuses(t.Context(), mockTokenProvider, ListCommitStatusesParams{
+			Workspace: faker.Username(),
+			RepoSlug:  faker.Username(),
+			Commit:    faker.UUIDDigit(),
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list commit statuses failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListCommitStatuses(t.Context(), &MockTokenProvider{Err: tokenErr}, ListCommitStatusesParams{
+			Workspace: faker.Username(),
+			RepoSlug:  faker.Username(),
+			Commit:    faker.UUIDDigit(),
+		})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
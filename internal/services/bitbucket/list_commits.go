@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListCommitsParams contains parameters for listing commits of a repository.
+type ListCommitsParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Include lists refs or commits whose history is listed.
+	Include []string
+
+	// Exclude lists refs or commits whose history is omitted.
+	Exclude []string
+
+	// Optional query parameters
+	PageLen int
+
+	// Limit stops paging once at least this many commits are collected. All commits are listed when not positive.
+	Limit int
+}
+
+// ListCommits returns commits reachable from Include but not from Exclude, newest first.
+// It is the equivalent of git log include ^exclude.
+// GET /repositories/{workspace}/{repo_slug}/commits.
+func (c *Client) ListCommits(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListCommitsParams,
+) ([]Commit, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commits",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	for _, include := range params.Include {
+		query.Add("include", include)
+	}
+	for _, exclude := range params.Exclude {
+		query.Add("exclude", exclude)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	commits, err := fetchPages[Commit](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list commits failed: %w", err)
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,93 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListCommits(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		hashes := []string{faker.UUIDDigit(), faker.UUIDDigit(), faker.UUIDDigit()}
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/commits", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, []string{"feature/x"}, r.URL.Query()["include"])
+				assert.Equal(t, []string{"main", "develop"}, r.URL.Query()["exclude"])
+				assert.Equal(t, "2", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [{"hash": %q}, {"hash": %q}], "next": "%s%s?page=2"}`,
+					hashes[0], hashes[1], server.URL, r.URL.Path)
+			case "2":
+				fmt.Fprintf(w, `{"values": [{"hash": %q}], "next": "%s%s?page=3"}`,
+					hashes[2], server.URL, r.URL.Path)
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommits(t.Context(), mockTokenProvider, ListCommitsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Include:   []string{"feature/x"},
+			Exclude:   []string{"main", "develop"},
+			PageLen:   2,
+			Limit:     3,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Commit{{Hash: hashes[0]}, {Hash: hashes[1]}, {Hash: hashes[2]}}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListCommits(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListCommitsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list commits failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListCommits(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListCommitsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
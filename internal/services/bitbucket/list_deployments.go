@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListDeploymentsParams contains parameters for listing deployments of a repository.
+type ListDeploymentsParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Optional environment UUID to list deployments of
+	Environment string
+
+	// Optional query parameters
+	Sort    string
+	Page    int
+	PageLen int
+}
+
+// ListDeployments returns a single page of deployments of a repository.
+// GET /repositories/{workspace}/{repo_slug}/deployments.
+func (c *Client) ListDeployments(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListDeploymentsParams,
+) (*Paginated[Deployment], error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/deployments",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	if params.Environment != "" {
+		query.Add("environment", params.Environment)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.Page > 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var result Paginated[Deployment]
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[interface{}, Paginated[Deployment]]{
+			Method: "GET",
+			URL:    requestURL,
+			Target: &result,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list deployments failed: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,103 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListDeployments(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		environmentUUID := "{" + faker.UUIDHyphenated() + "}"
+		deploymentUUID := "{" + faker.UUIDHyphenated() + "}"
+		commitHash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/deployments", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			assert.Equal(t, environmentUUID, r.URL.Query().Get("environment"))
+			assert.Equal(t, "-state.started_on", r.URL.Query().Get("sort"))
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			assert.Equal(t, "10", r.URL.Query().Get("pagelen"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"page": 2, "values": [{"uuid": %q, "environment": {"uuid": %q}, `+
+				`"state": {"name": "COMPLETED", "status": {"name": "SUCCESSFUL"}, `+
+				`"completion_date": "2025-01-02T03:04:05Z"}, `+
+				`"release": {"name": "#42", "commit": {"hash": %q}}}]}`,
+				deploymentUUID, environmentUUID, commitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListDeployments(t.Context(), mockTokenProvider, ListDeploymentsParams{
+			Workspace:   workspace,
+			RepoSlug:    repoSlug,
+			Environment: environmentUUID,
+			Sort:        "-state.started_on",
+			Page:        2,
+			PageLen:     10,
+		})
+
+		require.NoError(t, err)
+		completedOn := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		assert.Equal(t, &Paginated[Deployment]{
+			Page: 2,
+			Values: []Deployment{
+				{
+					UUID:        deploymentUUID,
+					Environment: &DeploymentEnvironment{UUID: environmentUUID},
+					State: &DeploymentState{
+						Name:           DeploymentStateCompleted,
+						Status:         &DeploymentStateStatus{Name: DeploymentStatusSuccessful},
+						CompletionDate: &completedOn,
+					},
+					Release: &DeploymentRelease{Name: "#42", Commit: &PipelineCommit{Hash: commitHash}},
+				},
+			},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListDeployments(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListDeploymentsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list deployments failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListDeployments(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListDeploymentsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
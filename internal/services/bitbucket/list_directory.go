@@ -0,0 +1,72 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListDirectoryParams contains parameters for listing a directory of the repository source.
+type ListDirectoryParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+
+	// Path of the directory, the repository root when empty.
+	Path string
+
+	// Optional query parameters
+	MaxDepth int    // list nested directories recursively up to this depth
+	Query    string // filter entries, e.g. type="commit_file"
+	PageLen  int
+
+	// Limit stops paging once at least this many entries are collected. All entries are listed when not positive.
+	Limit int
+}
+
+// ListDirectory returns files and directories of a directory at a commit.
+// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}.
+func (c *Client) ListDirectory(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListDirectoryParams,
+) ([]TreeEntry, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	// Trailing slash makes Bitbucket list the directory instead of returning a file
+	path := sourcePath(params.Workspace, params.RepoSlug, params.Commit, params.Path)
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	query := url.Values{}
+	if params.MaxDepth > 0 {
+		query.Add("max_depth", strconv.Itoa(params.MaxDepth))
+	}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	entries, err := fetchPages[TreeEntry](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list directory failed: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListDirectory(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/src/%s/docs/my%%20notes/", workspace, repoSlug, commit),
+				r.URL.EscapedPath())
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, "2", r.URL.Query().Get("max_depth"))
+				assert.Equal(t, `type="commit_file"`, r.URL.Query().Get("q"))
+				assert.Equal(t, "2", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [
+					{"type": "commit_directory", "path": "docs/my notes/img"},
+					{"type": "commit_file", "path": "docs/my notes/a.md", "size": 12, "mimetype": "text/markdown"}
+				], "next": "%s%s?page=2"}`, server.URL, r.URL.EscapedPath())
+			case "2":
+				fmt.Fprintf(w, `{"values": [{"type": "commit_file", "path": "docs/my notes/img/b.png"}],
+					"next": "%s%s?page=3"}`, server.URL, r.URL.EscapedPath())
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListDirectory(t.Context(), mockTokenProvider, ListDirectoryParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			Path:      "/docs/my notes/",
+			MaxDepth:  2,
+			Query:     `type="commit_file"`,
+			PageLen:   2,
+			Limit:     3,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TreeEntry{
+			{Type: TreeEntryTypeDirectory, Path: "docs/my notes/img"},
+			{Type: TreeEntryTypeFile, Path: "docs/my notes/a.md", Size: 12, MimeType: "text/markdown"},
+			{Type: TreeEntryTypeFile, Path: "docs/my notes/img/b.png"},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("success lists repository root", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/src/%s/", workspace, repoSlug, commit), r.URL.Path)
+			assert.Empty(t, r.URL.RawQuery)
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"values": [{"type": "commit_file", "path": "README.md"}]}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListDirectory(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListDirectoryParams{Workspace: workspace, RepoSlug: repoSlug, Commit: commit})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TreeEntry{{Type: TreeEntryTypeFile, Path: "README.md"}}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListDirectory(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListDirectoryParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list directory failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListDirectory(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListDirectoryParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: faker.UUIDDigit()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
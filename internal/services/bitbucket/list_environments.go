@@ -0,0 +1,41 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListEnvironmentsParams contains parameters for listing deployment environments of a repository.
+type ListEnvironmentsParams struct {
+	Workspace string
+	RepoSlug  string
+}
+
+// ListEnvironments returns all deployment environments of a repository.
+// GET /repositories/{workspace}/{repo_slug}/environments.
+func (c *Client) ListEnvironments(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListEnvironmentsParams,
+) ([]DeploymentEnvironment, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/environments",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	environments, err := fetchAllPages[DeploymentEnvironment](ctxWithAuth, c.httpClient, c.baseURL+path)
+	if err != nil {
+		return nil, fmt.Errorf("list environments failed: %w", err)
+	}
+
+	return environments, nil
+}
@@ -0,0 +1,82 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListEnvironments(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		environmentUUID := "{" + faker.UUIDHyphenated() + "}"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/environments", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"values": [{"uuid": %q, "name": "Production", "slug": "production", "rank": 2, `+
+				`"environment_type": {"name": "Production", "rank": 2}}]}`, environmentUUID)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListEnvironments(t.Context(), mockTokenProvider, ListEnvironmentsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []DeploymentEnvironment{
+			{
+				UUID:            environmentUUID,
+				Name:            "Production",
+				Slug:            "production",
+				Rank:            2,
+				EnvironmentType: &DeploymentEnvironmentType{Name: "Production", Rank: 2},
+			},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListEnvironments(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListEnvironmentsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list environments failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListEnvironments(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListEnvironmentsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
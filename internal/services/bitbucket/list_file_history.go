@@ -0,0 +1,68 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListFileHistoryParams contains parameters for listing commits that modified a file.
+type ListFileHistoryParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+	Path      string
+
+	// DisableRenames stops following renames of the file.
+	DisableRenames bool
+
+	// Optional query parameters
+	Fields  string // partial response fields, e.g. +values.commit.author
+	PageLen int
+
+	// Limit stops paging once at least this many entries are collected. All entries are listed when not positive.
+	Limit int
+}
+
+// ListFileHistory returns versions of a file at commits that modified it, newest first.
+// Renames are followed, so paths of older entries may differ.
+// GET /repositories/{workspace}/{repo_slug}/filehistory/{commit}/{path}.
+func (c *Client) ListFileHistory(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListFileHistoryParams,
+) ([]TreeEntry, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := commitFilePath(params.Workspace, params.RepoSlug, "filehistory", params.Commit, params.Path)
+
+	query := url.Values{}
+	if params.DisableRenames {
+		query.Add("renames", "false")
+	}
+	if params.Fields != "" {
+		query.Add("fields", params.Fields)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	entries, err := fetchPages[TreeEntry](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list file history failed: %w", err)
+	}
+
+	return entries, nil
+}
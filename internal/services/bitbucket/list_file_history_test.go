@@ -0,0 +1,103 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListFileHistory(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/filehistory/%s/docs/my%%20notes.md", workspace, repoSlug, commit),
+				r.URL.EscapedPath())
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, "false", r.URL.Query().Get("renames"))
+				assert.Equal(t, "+values.commit.author", r.URL.Query().Get("fields"))
+				assert.Equal(t, "2", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [
+					{"type": "commit_file", "path": "docs/my notes.md", "commit": {"hash": "c3"}},
+					{"type": "commit_file", "path": "docs/my notes.md", "commit": {"hash": "c2"}}
+				], "next": "%s%s?page=2"}`, server.URL, r.URL.EscapedPath())
+			case "2":
+				fmt.Fprintf(w, `{"values": [{"type": "commit_file", "path": "notes.md", "commit": {"hash": "c1"}}],
+					"next": "%s%s?page=3"}`, server.URL, r.URL.EscapedPath())
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListFileHistory(t.Context(), mockTokenProvider, ListFileHistoryParams{
+			Workspace:      workspace,
+			RepoSlug:       repoSlug,
+			Commit:         commit,
+			Path:           "docs/my notes.md",
+			DisableRenames: true,
+			Fields:         "+values.commit.author",
+			PageLen:        2,
+			Limit:          3,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []TreeEntry{
+			{Type: TreeEntryTypeFile, Path: "docs/my notes.md", Commit: &Commit{Hash: "c3"}},
+			{Type: TreeEntryTypeFile, Path: "docs/my notes.md", Commit: &Commit{Hash: "c2"}},
+			{Type: TreeEntryTypeFile, Path: "notes.md", Commit: &Commit{Hash: "c1"}},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListFileHistory(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListFileHistoryParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: "main", Path: "a.go"})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list file history failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListFileHistory(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListFileHistoryParams{Workspace: faker.Username(), RepoSlug: faker.Username(), Commit: "main", Path: "a.go"})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,43 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListPipelineStepsParams contains parameters for listing steps of a pipeline.
+type ListPipelineStepsParams struct {
+	Workspace    string
+	RepoSlug     string
+	PipelineUUID string
+}
+
+// ListPipelineSteps returns all steps of a pipeline.
+// GET /repositories/{workspace}/{repo_slug}/pipelines/{pipeline_uuid}/steps.
+func (c *Client) ListPipelineSteps(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListPipelineStepsParams,
+) ([]PipelineStep, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.PipelineUUID),
+	)
+
+	steps, err := fetchAllPages[PipelineStep](ctxWithAuth, c.httpClient, c.baseURL+path)
+	if err != nil {
+		return nil, fmt.Errorf("list pipeline steps failed: %w", err)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,103 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPipelineSteps(t *testing.T) {
+	t.Run("success follows all pages", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		pipelineUUID := "{" + faker.UUIDHyphenated() + "}"
+		firstStep := "{" + faker.UUIDHyphenated() + "}"
+		secondStep := "{" + faker.UUIDHyphenated() + "}"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var serverURL string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			expectedPath := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps", workspace, repoSlug, pipelineUUID)
+			assert.Equal(t, expectedPath, r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `{"values": [{"uuid": %q, "name": "deploy", "state": {"name": "PENDING"}}]}`, secondStep)
+				return
+			}
+			fmt.Fprintf(w, `{"values": [{"uuid": %q, "name": "build", "state": {"name": "COMPLETED", `+
+				`"result": {"name": "SUCCESSFUL"}}}], "next": "%s%s?page=2"}`,
+				firstStep, serverURL, r.URL.EscapedPath())
+		}))
+		defer server.Close()
+		serverURL = server.URL
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPipelineSteps(t.Context(), mockTokenProvider, ListPipelineStepsParams{
+			Workspace:    workspace,
+			RepoSlug:     repoSlug,
+			PipelineUUID: pipelineUUID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []PipelineStep{
+			{
+				UUID: firstStep,
+				Name: "build",
+				State: &PipelineState{
+					Name:   PipelineStateCompleted,
+					Result: &PipelineStateResult{Name: PipelineResultSuccessful},
+				},
+			},
+			{UUID: secondStep, Name: "deploy", State: &PipelineState{Name: PipelineStatePending}},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPipelineSteps(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListPipelineStepsParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				PipelineUUID: faker.UUIDHyphenated(),
+			})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list pipeline steps failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListPipelineSteps(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListPipelineStepsParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				PipelineUUID: faker.UUIDHyphenated(),
+			})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
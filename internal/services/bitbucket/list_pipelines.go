@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListPipelinesParams contains parameters for listing pipelines of a repository.
+type ListPipelinesParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Optional filters
+	Branch string
+	Status string
+
+	// Optional query parameters
+	Sort    string
+	Page    int
+	PageLen int
+}
+
+// ListPipelines returns a single page of pipelines of a repository.
+// GET /repositories/{workspace}/{repo_slug}/pipelines.
+func (c *Client) ListPipelines(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListPipelinesParams,
+) (*Paginated[Pipeline], error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	if params.Branch != "" {
+		query.Add("target.branch", params.Branch)
+	}
+	if params.Status != "" {
+		query.Add("status", params.Status)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.Page > 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var result Paginated[Pipeline]
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[interface{}, Paginated[Pipeline]]{
+			Method: "GET",
+			URL:    requestURL,
+			Target: &result,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list pipelines failed: %w", err)
+	}
+
+	return &result, nil
+}
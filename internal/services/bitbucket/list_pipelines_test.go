@@ -0,0 +1,107 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPipelines(t *testing.T) {
+	t.Run("success with filters", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		branch := "feature/" + faker.Word()
+		pageLen := 1 + rand.IntN(50)
+		pipelineUUID := "{" + faker.UUIDHyphenated() + "}"
+		buildNumber := 1 + rand.IntN(1000)
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pipelines", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			assert.Equal(t, branch, r.URL.Query().Get("target.branch"))
+			assert.Equal(t, "FAILED", r.URL.Query().Get("status"))
+			assert.Equal(t, "-created_on", r.URL.Query().Get("sort"))
+			assert.Equal(t, fmt.Sprint(pageLen), r.URL.Query().Get("pagelen"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"size": 1, "values": [{
+				"uuid": %q,
+				"build_number": %d,
+				"state": {"name": "COMPLETED", "result": {"name": "FAILED"}},
+				"target": {"type": "pipeline_ref_target", "ref_type": "branch", "ref_name": %q}
+			}]}`, pipelineUUID, buildNumber, branch)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPipelines(t.Context(), mockTokenProvider, ListPipelinesParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Branch:    branch,
+			Status:    "FAILED",
+			Sort:      "-created_on",
+			PageLen:   pageLen,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Paginated[Pipeline]{
+			Size: 1,
+			Values: []Pipeline{
+				{
+					UUID:        pipelineUUID,
+					BuildNumber: buildNumber,
+					State: &PipelineState{
+						Name:   PipelineStateCompleted,
+						Result: &PipelineStateResult{Name: PipelineResultFailed},
+					},
+					Target: &PipelineTarget{
+						Type:    PipelineTargetTypeRef,
+						RefType: PipelineRefTypeBranch,
+						RefName: branch,
+					},
+				},
+			},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPipelines(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListPipelinesParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list pipelines failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListPipelines(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListPipelinesParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
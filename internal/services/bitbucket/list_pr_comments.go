@@ -37,6 +37,12 @@ func (c *Client) ListPRComments(
 	if params.PageLen > 0 {
 		query.Add("pagelen", strconv.Itoa(params.PageLen))
 	}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
 
 	requestURL := c.baseURL + path
 	if len(query) > 0 {
@@ -249,6 +249,33 @@ func TestClient_ListPRComments(t *testing.T) {
 		assert.Equal(t, "https://api.bitbucket.org/2.0/prev-page", result.Previous)
 	})
 
+	t.Run("sends filter and sort query params when specified", func(t *testing.T) {
+		query := fmt.Sprintf("created_on >= %d-01-01", rand.Intn(10)+2020)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, query, r.URL.Query().Get("q"))
+			assert.Equal(t, "-created_on", r.URL.Query().Get("sort"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"values": []}`)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.ListPRComments(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListPRCommentsParams{
+				Workspace: faker.Username(),
+				RepoSlug:  faker.Username(),
+				PRID:      int64(rand.Intn(1000) + 1),
+				Query:     query,
+				Sort:      "-created_on",
+			})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Values)
+	})
+
 	t.Run("no query params sent when pagination fields are zero-valued", func(t *testing.T) {
 		// Arrange
 		workspace := "test-workspace-" + faker.Word()
@@ -0,0 +1,52 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListPRCommitsParams contains parameters for listing commits of a pull request.
+type ListPRCommitsParams struct {
+	Workspace string
+	RepoSlug  string
+	PullReqID int
+
+	// Optional query parameters
+	PageLen int
+}
+
+// ListPRCommits returns all commits of a pull request, newest first.
+// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/commits.
+func (c *Client) ListPRCommits(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListPRCommitsParams,
+) ([]Commit, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/commits",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		params.PullReqID,
+	)
+
+	requestURL := c.baseURL + path
+	if params.PageLen > 0 {
+		requestURL += "?" + url.Values{"pagelen": []string{strconv.Itoa(params.PageLen)}}.Encode()
+	}
+
+	commits, err := fetchAllPages[Commit](ctxWithAuth, c.httpClient, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request commits failed: %w", err)
+	}
+
+	return commits, nil
+}
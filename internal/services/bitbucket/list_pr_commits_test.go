@@ -0,0 +1,114 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPRCommits(t *testing.T) {
+	t.Run("success follows all pages", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		prID := 100 + rand.IntN(9000)
+		pageLen := 1 + rand.IntN(50)
+		firstHash := faker.UUIDDigit()
+		secondHash := faker.UUIDDigit()
+		authorRaw := faker.Name() + " <" + faker.Email() + ">"
+		authorUUID := faker.UUIDHyphenated()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var serverURL string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			expectedPath := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/commits", workspace, repoSlug, prID)
+			assert.Equal(t, expectedPath, r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `{"values": [{"hash": %q, "message": "second"}]}`, secondHash)
+				return
+			}
+			assert.Equal(t, fmt.Sprint(pageLen), r.URL.Query().Get("pagelen"))
+			fmt.Fprintf(w, `{
+				"values": [{
+					"hash": %q,
+					"message": "first",
+					"author": {"type": "author", "raw": %q, "user": {"type": "user", "uuid": %q}}
+				}],
+				"next": "%s%s?page=2"
+			}`, firstHash, authorRaw, authorUUID, serverURL, expectedPath)
+		}))
+		defer server.Close()
+		serverURL = server.URL
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPRCommits(t.Context(), mockTokenProvider, ListPRCommitsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			PullReqID: prID,
+			PageLen:   pageLen,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Commit{
+			{
+				Hash:    firstHash,
+				Message: "first",
+				Author: &CommitAuthor{
+					Type: "author",
+					Raw:  authorRaw,
+					User: &Account{Type: "user", UUID: authorUUID},
+				},
+			},
+			{Hash: secondHash, Message: "second"},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPRCommits(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListPRCommitsParams{
+				Workspace: faker.Username(),
+				RepoSlug:  faker.Username(),
+				PullReqID: 1 + rand.IntN(100),
+			})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list pull request commits failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListPRCommits(t.Context(), &MockTokenProvider{Err: tokenErr}, ListPRCommitsParams{
+			Workspace: faker.Username(),
+			RepoSlug:  faker.Username(),
+			PullReqID: 1 + rand.IntN(100),
+		})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
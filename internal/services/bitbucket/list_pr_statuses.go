@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListPRStatusesParams contains parameters for listing build statuses of a pull request.
+type ListPRStatusesParams struct {
+	Workspace string
+	RepoSlug  string
+	PullReqID int
+
+	// Optional query parameters
+	Query   string
+	Sort    string
+	PageLen int
+}
+
+// ListPRStatuses returns all build statuses reported for the commits of a pull request.
+// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/statuses.
+func (c *Client) ListPRStatuses(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListPRStatusesParams,
+) ([]CommitStatus, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/statuses",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		params.PullReqID,
+	)
+
+	query := url.Values{}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	statuses, err := fetchAllPages[CommitStatus](ctxWithAuth, c.httpClient, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request statuses failed: %w", err)
+	}
+
+	return statuses, nil
+}
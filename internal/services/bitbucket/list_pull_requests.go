@@ -0,0 +1,75 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListPullRequestsParams contains parameters for listing pull requests of a repository.
+type ListPullRequestsParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Optional query parameters
+	States  []string // OPEN, MERGED, DECLINED or SUPERSEDED, only open pull requests are listed when empty
+	Query   string   // BBQL filter, e.g. title ~ "PAY-123"
+	Sort    string   // e.g. -updated_on
+	Fields  string   // partial response fields, e.g. +values.description
+	PageLen int
+
+	// Limit stops paging once at least this many pull requests are collected.
+	// All pull requests are listed when not positive.
+	Limit int
+}
+
+// ListPullRequests returns pull requests of a repository.
+// GET /repositories/{workspace}/{repo_slug}/pullrequests.
+func (c *Client) ListPullRequests(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListPullRequestsParams,
+) ([]PullRequest, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	for _, state := range params.States {
+		query.Add("state", state)
+	}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.Fields != "" {
+		query.Add("fields", params.Fields)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	pullRequests, err := fetchPages[PullRequest](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list pull requests failed: %w", err)
+	}
+
+	return pullRequests, nil
+}
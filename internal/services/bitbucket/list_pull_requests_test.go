@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPullRequests(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pullrequests", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, []string{"OPEN", "MERGED"}, r.URL.Query()["state"])
+				assert.Equal(t, `title ~ "PAY-1"`, r.URL.Query().Get("q"))
+				assert.Equal(t, "-updated_on", r.URL.Query().Get("sort"))
+				assert.Equal(t, "+values.description", r.URL.Query().Get("fields"))
+				assert.Equal(t, "1", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [{"id": 2, "title": "PAY-1 second", "state": "OPEN"}],
+					"next": "%s%s?page=2"}`, server.URL, r.URL.Path)
+			case "2":
+				fmt.Fprintf(w, `{"values": [{"id": 1, "title": "PAY-1 first", "state": "MERGED"}],
+					"next": "%s%s?page=3"}`, server.URL, r.URL.Path)
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPullRequests(t.Context(), mockTokenProvider, ListPullRequestsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			States:    []string{"OPEN", "MERGED"},
+			Query:     `title ~ "PAY-1"`,
+			Sort:      "-updated_on",
+			Fields:    "+values.description",
+			PageLen:   1,
+			Limit:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []PullRequest{
+			{ID: 2, Title: "PAY-1 second", State: "OPEN"},
+			{ID: 1, Title: "PAY-1 first", State: "MERGED"},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListPullRequests(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListPullRequestsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list pull requests failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListPullRequests(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListPullRequestsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
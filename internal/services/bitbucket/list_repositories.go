@@ -0,0 +1,67 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListRepositoriesParams contains parameters for listing repositories of a workspace.
+type ListRepositoriesParams struct {
+	Workspace string
+
+	// Optional query parameters
+	Role    string // only repositories the user has this role on: member, contributor, admin or owner
+	Query   string // BBQL filter, e.g. name ~ "payments" AND project.key = "PAY"
+	Sort    string // e.g. -updated_on
+	PageLen int
+
+	// Limit stops paging once at least this many repositories are collected.
+	// All repositories are listed when not positive.
+	Limit int
+}
+
+// ListRepositories returns repositories of a workspace visible to the authenticated user.
+// GET /repositories/{workspace}.
+func (c *Client) ListRepositories(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListRepositoriesParams,
+) ([]Repository, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := "/repositories/" + url.PathEscape(params.Workspace)
+
+	query := url.Values{}
+	if params.Role != "" {
+		query.Add("role", params.Role)
+	}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	repositories, err := fetchPages[Repository](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list repositories failed: %w", err)
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,95 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListRepositories(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/repositories/"+workspace, r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, "contributor", r.URL.Query().Get("role"))
+				assert.Equal(t, `name ~ "pay"`, r.URL.Query().Get("q"))
+				assert.Equal(t, "-updated_on", r.URL.Query().Get("sort"))
+				assert.Equal(t, "1", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [{"full_name": "%[1]s/payments", "name": "payments"}],
+					"next": "%[2]s%[3]s?page=2"}`, workspace, server.URL, r.URL.Path)
+			case "2":
+				fmt.Fprintf(w, `{"values": [{"full_name": "%[1]s/pay-ui", "name": "pay-ui"}],
+					"next": "%[2]s%[3]s?page=3"}`, workspace, server.URL, r.URL.Path)
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListRepositories(t.Context(), mockTokenProvider, ListRepositoriesParams{
+			Workspace: workspace,
+			Role:      "contributor",
+			Query:     `name ~ "pay"`,
+			Sort:      "-updated_on",
+			PageLen:   1,
+			Limit:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Repository{
+			{FullName: workspace + "/payments", Name: "payments"},
+			{FullName: workspace + "/pay-ui", Name: "pay-ui"},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListRepositories(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListRepositoriesParams{Workspace: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list repositories failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListRepositories(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListRepositoriesParams{Workspace: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
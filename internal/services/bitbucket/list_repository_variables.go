@@ -0,0 +1,41 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListRepositoryVariablesParams contains parameters for listing pipelines variables of a repository.
+type ListRepositoryVariablesParams struct {
+	Workspace string
+	RepoSlug  string
+}
+
+// ListRepositoryVariables returns all pipelines variables configured for a repository.
+// GET /repositories/{workspace}/{repo_slug}/pipelines_config/variables.
+func (c *Client) ListRepositoryVariables(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListRepositoryVariablesParams,
+) ([]PipelineVariable, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	variables, err := fetchAllPages[PipelineVariable](ctxWithAuth, c.httpClient, c.baseURL+path)
+	if err != nil {
+		return nil, fmt.Errorf("list repository variables failed: %w", err)
+	}
+
+	return variables, nil
+}
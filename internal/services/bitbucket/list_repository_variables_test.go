@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListRepositoryVariables(t *testing.T) {
+	t.Run("success follows all pages", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		plainUUID := "{" + faker.UUIDHyphenated() + "}"
+		securedUUID := "{" + faker.UUIDHyphenated() + "}"
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var serverURL string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables", workspace, repoSlug),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `{"values": [{"uuid": %q, "key": "TOKEN", "secured": true}]}`, securedUUID)
+				return
+			}
+			fmt.Fprintf(w, `{"values": [{"uuid": %q, "key": "REGION", "value": "eu-west-1"}], "next": "%s%s?page=2"}`,
+				plainUUID, serverURL, r.URL.EscapedPath())
+		}))
+		defer server.Close()
+		serverURL = server.URL
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListRepositoryVariables(t.Context(), mockTokenProvider, ListRepositoryVariablesParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []PipelineVariable{
+			{UUID: plainUUID, Key: "REGION", Value: "eu-west-1"},
+			{UUID: securedUUID, Key: "TOKEN", Secured: true},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListRepositoryVariables(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListRepositoryVariablesParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list repository variables failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListRepositoryVariables(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListRepositoryVariablesParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,77 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListTagsParams contains parameters for listing tags of a repository.
+type ListTagsParams struct {
+	Workspace string
+	RepoSlug  string
+
+	// Optional query parameters
+	Query   string // BBQL filter, e.g. name ~ "v1."
+	Sort    string // e.g. name, -target.date
+	Page    int
+	PageLen int
+}
+
+// ListTags returns a single page of tags of a repository.
+// GET /repositories/{workspace}/{repo_slug}/refs/tags.
+func (c *Client) ListTags(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListTagsParams,
+) (*Paginated[Tag], error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/tags",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	query := url.Values{}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.Page > 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var result Paginated[Tag]
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[interface{}, Paginated[Tag]]{
+			Method: "GET",
+			URL:    requestURL,
+			Target: &result,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list tags failed: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,95 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListTags(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		tagName := "v1." + faker.Word()
+		commitHash := faker.UUIDDigit()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/refs/tags", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+			assert.Equal(t, `name ~ "v1."`, r.URL.Query().Get("q"))
+			assert.Equal(t, "-target.date", r.URL.Query().Get("sort"))
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			assert.Equal(t, "10", r.URL.Query().Get("pagelen"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"page": 2, "next": "next-url", "values": [{"type": "tag", "name": %q, `+
+				`"target": {"hash": %q, "date": "2025-01-02T03:04:05+00:00"}, "message": "Release"}]}`,
+				tagName, commitHash)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListTags(t.Context(), mockTokenProvider, ListTagsParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Query:     `name ~ "v1."`,
+			Sort:      "-target.date",
+			Page:      2,
+			PageLen:   10,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Paginated[Tag]{
+			Page: 2,
+			Next: "next-url",
+			Values: []Tag{
+				{
+					Type:    "tag",
+					Name:    tagName,
+					Target:  &Commit{Hash: commitHash, Date: "2025-01-02T03:04:05+00:00"},
+					Message: "Release",
+				},
+			},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListTags(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListTagsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list tags failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListTags(t.Context(), &MockTokenProvider{Err: tokenErr},
+			ListTagsParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// ListWorkspacesParams contains parameters for listing workspaces of the authenticated user.
+type ListWorkspacesParams struct {
+	// Optional query parameters
+	Query   string // BBQL filter, e.g. permission = "owner"
+	Sort    string // e.g. workspace.slug
+	PageLen int
+
+	// Limit stops paging once at least this many workspaces are collected.
+	// All workspaces are listed when not positive.
+	Limit int
+}
+
+// ListWorkspaces returns workspaces the authenticated user is a member of with the role of the user.
+// GET /user/permissions/workspaces.
+func (c *Client) ListWorkspaces(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params ListWorkspacesParams,
+) ([]WorkspaceMembership, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	query := url.Values{}
+	if params.Query != "" {
+		query.Add("q", params.Query)
+	}
+	if params.Sort != "" {
+		query.Add("sort", params.Sort)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	requestURL := c.baseURL + "/user/permissions/workspaces"
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	memberships, err := fetchPages[WorkspaceMembership](ctxWithAuth, c.httpClient, requestURL, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list workspaces failed: %w", err)
+	}
+
+	return memberships, nil
+}
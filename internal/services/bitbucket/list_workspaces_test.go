@@ -0,0 +1,92 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListWorkspaces(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/user/permissions/workspaces", r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, `permission = "owner"`, r.URL.Query().Get("q"))
+				assert.Equal(t, "workspace.slug", r.URL.Query().Get("sort"))
+				assert.Equal(t, "50", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"values": [{"type": "workspace_membership", "permission": "owner",
+					"workspace": {"type": "workspace", "slug": "acme", "name": "Acme"}}],
+					"next": "%s%s?page=2"}`, server.URL, r.URL.Path)
+			case "2":
+				fmt.Fprint(w, `{"values": [{"permission": "member", "workspace": {"slug": "oss"}}]}`)
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListWorkspaces(t.Context(), mockTokenProvider, ListWorkspacesParams{
+			Query:   `permission = "owner"`,
+			Sort:    "workspace.slug",
+			PageLen: 50,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []WorkspaceMembership{
+			{
+				Type:       "workspace_membership",
+				Permission: "owner",
+				Workspace:  &Workspace{Type: "workspace", Slug: "acme", Name: "Acme"},
+			},
+			{Permission: "member", Workspace: &Workspace{Slug: "oss"}},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.ListWorkspaces(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			ListWorkspacesParams{})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "list workspaces failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.ListWorkspaces(t.Context(), &MockTokenProvider{Err: tokenErr}, ListWorkspacesParams{})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,100 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// MergeErrorReason classifies why a pull request could not be merged.
+type MergeErrorReason string
+
+// Merge error reasons.
+const (
+	// MergeErrorReasonConflict means the source and destination branches have conflicting changes.
+	MergeErrorReasonConflict MergeErrorReason = "conflict"
+
+	// MergeErrorReasonRefChanged means one of the refs changed while the merge was attempted.
+	MergeErrorReasonRefChanged MergeErrorReason = "ref_changed"
+
+	// MergeErrorReasonTimeout means the merge did not complete in time and may be retried later.
+	MergeErrorReasonTimeout MergeErrorReason = "timeout"
+
+	// MergeErrorReasonRejected means Bitbucket refused to merge, e.g. because merge checks are not satisfied.
+	MergeErrorReasonRejected MergeErrorReason = "rejected"
+
+	// MergeErrorReasonFailed means the asynchronous merge task failed.
+	MergeErrorReasonFailed MergeErrorReason = "failed"
+)
+
+// statusMergeTimeout is the non standard status code returned by Bitbucket when a merge times out.
+const statusMergeTimeout = 555
+
+// MergeError is returned when Bitbucket refuses or fails to merge a pull request.
+type MergeError struct {
+	Reason  MergeErrorReason
+	Message string
+
+	// BlockedBy explains which branch restrictions prevented the merge, when known.
+	BlockedBy []string
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *MergeError) Error() string {
+	msg := fmt.Sprintf("merge %s: %s", e.Reason, e.Message)
+	if len(e.BlockedBy) > 0 {
+		msg += "; blocked by " + strings.Join(e.BlockedBy, ", ")
+	}
+	return msg
+}
+
+// Unwrap implements error unwrapping for error chain support.
+func (e *MergeError) Unwrap() error {
+	return e.Err
+}
+
+// apiErrorResponse is the error envelope returned by the Bitbucket API.
+type apiErrorResponse struct {
+	Type  string `json:"type"`
+	Error struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail,omitempty"`
+	} `json:"error"`
+}
+
+// newMergeErrorFromHTTPError converts an HTTP error of a merge related endpoint into MergeError.
+// Errors that are not HTTP status errors are returned unchanged.
+func newMergeErrorFromHTTPError(err error, defaultReason MergeErrorReason) error {
+	var httpErr *middleware.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode == 0 {
+		return err
+	}
+
+	message := httpErr.Message
+	var apiErr apiErrorResponse
+	if jsonErr := json.Unmarshal(httpErr.ResponseBody, &apiErr); jsonErr == nil && apiErr.Error.Message != "" {
+		message = apiErr.Error.Message
+		if apiErr.Error.Detail != "" {
+			message += ": " + apiErr.Error.Detail
+		}
+	}
+
+	// Bitbucket reports merge conflicts with various status codes including 409, so the message is checked first.
+	reason := defaultReason
+	switch {
+	case strings.Contains(strings.ToLower(message), "conflict"):
+		reason = MergeErrorReasonConflict
+	case httpErr.StatusCode == http.StatusConflict:
+		reason = MergeErrorReasonRefChanged
+	case httpErr.StatusCode == statusMergeTimeout:
+		reason = MergeErrorReasonTimeout
+	}
+
+	return &MergeError{Reason: reason, Message: message, Err: err}
+}
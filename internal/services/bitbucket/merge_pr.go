@@ -1,10 +1,15 @@
 package bitbucket
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
 
-	httpservices "github.com/gemyago/atlacp/internal/services/http"
 	"github.com/gemyago/atlacp/internal/services/http/middleware"
 )
 
@@ -14,6 +19,18 @@ type MergePRParams struct {
 	RepoSlug        string                      `json:"-"`
 	PullRequestID   int                         `json:"-"`
 	MergeParameters *PullRequestMergeParameters `json:"-"`
+
+	// Async requests the merge to run asynchronously. Bitbucket may still respond
+	// asynchronously to synchronous merges that take too long.
+	Async bool `json:"-"`
+}
+
+// MergePRResult is the outcome of a merge request. PullRequest is set when the merge
+// completed right away, TaskID is set when the merge is queued and should be polled
+// with GetMergeTaskStatus.
+type MergePRResult struct {
+	PullRequest *PullRequest
+	TaskID      string
 }
 
 // MergePR merges a pull request.
@@ -22,33 +39,73 @@ func (c *Client) MergePR(
 	ctx context.Context,
 	tokenProvider TokenProvider,
 	params MergePRParams,
-) (*PullRequest, error) {
+) (*MergePRResult, error) {
 	token, err := tokenProvider.GetToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
 	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
 
-	var pullRequest PullRequest
-	path := fmt.Sprintf(
+	fullURL := c.baseURL + fmt.Sprintf(
 		"/repositories/%s/%s/pullrequests/%d/merge",
 		params.Username,
 		params.RepoSlug,
 		params.PullRequestID,
 	)
-	err = httpservices.SendRequest(
-		ctxWithAuth,
-		c.httpClient,
-		httpservices.SendRequestParams[PullRequestMergeParameters, PullRequest]{
-			Method: "POST",
-			URL:    c.baseURL + path,
-			Body:   params.MergeParameters,
-			Target: &pullRequest,
-		},
-	)
+	if params.Async {
+		fullURL += "?async=true"
+	}
+
+	var reqBody bytes.Buffer
+	if params.MergeParameters != nil {
+		if err = json.NewEncoder(&reqBody).Encode(params.MergeParameters); err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctxWithAuth, http.MethodPost, fullURL, &reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if params.MergeParameters != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("merge pull request failed: %w", err)
+		return nil, fmt.Errorf("merge pull request failed: %w", newMergeErrorFromHTTPError(err, MergeErrorReasonRejected))
 	}
+	defer resp.Body.Close()
 
-	return &pullRequest, nil
+	// Queued merges respond with a link to the task status endpoint in the Location header
+	if resp.StatusCode == http.StatusAccepted {
+		taskID, parseErr := parseMergeTaskID(resp.Header.Get("Location"))
+		if parseErr != nil {
+			return nil, fmt.Errorf("merge pull request failed: %w", parseErr)
+		}
+		return &MergePRResult{TaskID: taskID}, nil
+	}
+
+	var pullRequest PullRequest
+	if err = json.NewDecoder(resp.Body).Decode(&pullRequest); err != nil {
+		return nil, fmt.Errorf("merge pull request failed: failed to unmarshal response: %w", err)
+	}
+
+	return &MergePRResult{PullRequest: &pullRequest}, nil
+}
+
+// parseMergeTaskID extracts the task ID from the task status link,
+// e.g. .../pullrequests/{pull_request_id}/merge/task-status/{task_id}.
+func parseMergeTaskID(location string) (string, error) {
+	if location == "" {
+		return "", errors.New("merge accepted without task status location")
+	}
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse task status location: %w", err)
+	}
+	taskID := path.Base(locationURL.Path)
+	if path.Base(path.Dir(locationURL.Path)) != "task-status" || taskID == "" {
+		return "", fmt.Errorf("unexpected task status location: %s", location)
+	}
+	return taskID, nil
 }
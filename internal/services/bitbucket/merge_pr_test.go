@@ -117,14 +117,16 @@ func TestClient_MergePR(t *testing.T) {
 
 		// Assert
 		require.NoError(t, err)
-		assert.Equal(t, pullRequestID, result.ID)
-		assert.Equal(t, prTitle, result.Title)
-		assert.Equal(t, "MERGED", result.State)
-		assert.Equal(t, sourceBranch, result.Source.Branch.Name)
-		assert.Equal(t, targetBranch, result.Destination.Branch.Name)
-		assert.True(t, result.CloseSourceBranch)
-		assert.Equal(t, mergeCommitHash, result.MergeCommit.Hash)
-		assert.Equal(t, updatedOn, result.UpdatedOn.UTC())
+		require.NotNil(t, result.PullRequest)
+		assert.Empty(t, result.TaskID)
+		assert.Equal(t, pullRequestID, result.PullRequest.ID)
+		assert.Equal(t, prTitle, result.PullRequest.Title)
+		assert.Equal(t, "MERGED", result.PullRequest.State)
+		assert.Equal(t, sourceBranch, result.PullRequest.Source.Branch.Name)
+		assert.Equal(t, targetBranch, result.PullRequest.Destination.Branch.Name)
+		assert.True(t, result.PullRequest.CloseSourceBranch)
+		assert.Equal(t, mergeCommitHash, result.PullRequest.MergeCommit.Hash)
+		assert.Equal(t, updatedOn, result.PullRequest.UpdatedOn.UTC())
 	})
 
 	t.Run("success with required parameters only", func(t *testing.T) {
@@ -181,12 +183,14 @@ func TestClient_MergePR(t *testing.T) {
 
 		// Assert
 		require.NoError(t, err)
-		assert.Equal(t, pullRequestID, result.ID)
-		assert.Equal(t, prTitle, result.Title)
-		assert.Equal(t, "MERGED", result.State)
-		assert.Equal(t, sourceBranch, result.Source.Branch.Name)
-		assert.Equal(t, targetBranch, result.Destination.Branch.Name)
-		assert.Equal(t, mergeCommitHash, result.MergeCommit.Hash)
+		require.NotNil(t, result.PullRequest)
+		assert.Empty(t, result.TaskID)
+		assert.Equal(t, pullRequestID, result.PullRequest.ID)
+		assert.Equal(t, prTitle, result.PullRequest.Title)
+		assert.Equal(t, "MERGED", result.PullRequest.State)
+		assert.Equal(t, sourceBranch, result.PullRequest.Source.Branch.Name)
+		assert.Equal(t, targetBranch, result.PullRequest.Destination.Branch.Name)
+		assert.Equal(t, mergeCommitHash, result.PullRequest.MergeCommit.Hash)
 	})
 
 	t.Run("handles API error", func(t *testing.T) {
@@ -227,6 +231,127 @@ func TestClient_MergePR(t *testing.T) {
 		require.Error(t, err)
 		assert.Nil(t, result)
 		assert.ErrorContains(t, err, "merge pull request failed")
+		var mergeErr *MergeError
+		require.ErrorAs(t, err, &mergeErr)
+		assert.Equal(t, MergeErrorReasonConflict, mergeErr.Reason)
+		assert.Equal(t, "Pull request has conflicts that need to be resolved", mergeErr.Message)
+	})
+
+	t.Run("returns task ID when merge is queued", func(t *testing.T) {
+		username := "test-user-" + faker.Word()
+		repoSlug := "test-repo-" + faker.Word()
+		pullRequestID := rand.Intn(1000) + 1
+		taskID := faker.UUIDHyphenated()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var serverURL string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("async"))
+			w.Header().Set("Location", fmt.Sprintf(
+				"%s/repositories/%s/%s/pullrequests/%d/merge/task-status/%s",
+				serverURL, username, repoSlug, pullRequestID, taskID,
+			))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		serverURL = server.URL
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.MergePR(t.Context(), mockTokenProvider, MergePRParams{
+			Username:      username,
+			RepoSlug:      repoSlug,
+			PullRequestID: pullRequestID,
+			Async:         true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &MergePRResult{TaskID: taskID}, result)
+	})
+
+	t.Run("fails when queued merge has no task status location", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		result, err := client.MergePR(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, MergePRParams{
+			Username:      "test-user-" + faker.Word(),
+			RepoSlug:      "test-repo-" + faker.Word(),
+			PullRequestID: rand.Intn(1000) + 1,
+		})
+
+		require.ErrorContains(t, err, "merge accepted without task status location")
+		assert.Nil(t, result)
+	})
+
+	t.Run("classifies merge errors", func(t *testing.T) {
+		testCases := []struct {
+			name           string
+			statusCode     int
+			body           string
+			expectedReason MergeErrorReason
+		}{
+			{
+				name:           "conflict",
+				statusCode:     http.StatusBadRequest,
+				body:           `{"type": "error", "error": {"message": "You can't merge until you resolve all merge conflicts."}}`,
+				expectedReason: MergeErrorReasonConflict,
+			},
+			{
+				name:           "ref changed",
+				statusCode:     http.StatusConflict,
+				body:           `{"type": "error", "error": {"message": "The source branch has been updated"}}`,
+				expectedReason: MergeErrorReasonRefChanged,
+			},
+			{
+				name:           "rejected",
+				statusCode:     http.StatusBadRequest,
+				body:           `{"type": "error", "error": {"message": "` + faker.Sentence() + `"}}`,
+				expectedReason: MergeErrorReasonRejected,
+			},
+			{
+				name:           "timeout",
+				statusCode:     555,
+				body:           `{"type": "error", "error": {"message": "` + faker.Sentence() + `"}}`,
+				expectedReason: MergeErrorReasonTimeout,
+			},
+			{
+				name:           "non json body",
+				statusCode:     http.StatusForbidden,
+				body:           faker.Sentence(),
+				expectedReason: MergeErrorReasonRejected,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(tc.statusCode)
+					fmt.Fprint(w, tc.body)
+				}))
+				defer server.Close()
+
+				client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+				_, err := client.MergePR(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()}, MergePRParams{
+					Username:      "test-user-" + faker.Word(),
+					RepoSlug:      "test-repo-" + faker.Word(),
+					PullRequestID: rand.Intn(1000) + 1,
+				})
+
+				var mergeErr *MergeError
+				require.ErrorAs(t, err, &mergeErr)
+				assert.Equal(t, tc.expectedReason, mergeErr.Reason)
+				assert.NotEmpty(t, mergeErr.Message)
+			})
+		}
 	})
 
 	t.Run("handles token provider error", func(t *testing.T) {
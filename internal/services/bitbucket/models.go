@@ -94,7 +94,7 @@ type CommitFile struct {
 type Commit struct {
 	Hash         string         `json:"hash,omitempty"`
 	Date         string         `json:"date,omitempty"`
-	Author       interface{}    `json:"author,omitempty"`    // Could be expanded if needed
+	Author       *CommitAuthor  `json:"author,omitempty"`
 	Committer    interface{}    `json:"committer,omitempty"` // Could be expanded if needed
 	Message      string         `json:"message,omitempty"`
 	Summary      *CommitSummary `json:"summary,omitempty"`
@@ -103,6 +103,28 @@ type Commit struct {
 	Participants interface{}    `json:"participants,omitempty"` // Could be expanded if needed
 }
 
+// CommitAuthor matches the Bitbucket OpenAPI "author" definition.
+type CommitAuthor struct {
+	Type string `json:"type,omitempty"`
+
+	// Raw is the author as recorded in the commit, e.g. "Jane Doe <jane@example.com>".
+	Raw string `json:"raw,omitempty"`
+
+	// User is the Bitbucket account the author is mapped to, if any.
+	User *Account `json:"user,omitempty"`
+}
+
+// Name returns the display name of the Bitbucket account of the author or the raw author.
+func (a *CommitAuthor) Name() string {
+	if a == nil {
+		return ""
+	}
+	if a.User != nil && a.User.DisplayName != "" {
+		return a.User.DisplayName
+	}
+	return a.Raw
+}
+
 // CommitSummary matches the summary object in the commit schema.
 type CommitSummary struct {
 	Raw    string `json:"raw,omitempty"`
@@ -128,9 +150,13 @@ type DiffStat struct {
 
 // FileContent represents the content of a file at a specific commit.
 type FileContent struct {
-	Path    string `json:"path"`
-	Commit  string `json:"commit"`
-	Content string `json:"content"`
+	Path        string `json:"path"`
+	Commit      string `json:"commit"`
+	Content     string `json:"content"`
+	ContentType string `json:"content_type,omitempty"`
+
+	// Truncated is true when the file is longer than the requested maximum size.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // FileContentMeta provides metadata about a file's content.
@@ -138,6 +164,20 @@ type FileContentMeta struct {
 	Size     int    `json:"size"`
 	Type     string `json:"type"`
 	Encoding string `json:"encoding"`
+	MimeType string `json:"mimetype,omitempty"`
+
+	// Binary is true for files that are not text. Content of binary files other than images is omitted.
+	Binary bool `json:"binary,omitempty"`
+
+	// TotalLines is the number of lines of a text file, zero when the file was not read completely.
+	TotalLines int `json:"total_lines,omitempty"`
+
+	// FromLine and ToLine are the 1-based range of lines included in the content.
+	FromLine int `json:"from_line,omitempty"`
+	ToLine   int `json:"to_line,omitempty"`
+
+	// Truncated is true when the content was cut at the size limit.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // FileContentResult is the result returned by the service layer for file content.
@@ -217,6 +257,10 @@ type ListPRCommentsParams struct {
 	// Optional pagination parameters
 	Page    int `json:"page,omitempty"`
 	PageLen int `json:"pagelen,omitempty"`
+
+	// Optional filtering and sorting, e.g. created_on >= 2024-01-01 and -created_on
+	Query string `json:"q,omitempty"`
+	Sort  string `json:"sort,omitempty"`
 }
 
 // ListPRCommentsResponse represents the response for listing PR comments.
@@ -229,3 +273,84 @@ type ListPRCommentsResponse struct {
 	Previous string      `json:"previous,omitempty"`
 	Values   []PRComment `json:"values"`
 }
+
+// Commit status (build) states.
+const (
+	// CommitStatusStateSuccessful indicates a build that finished successfully.
+	CommitStatusStateSuccessful = "SUCCESSFUL"
+
+	// CommitStatusStateFailed indicates a build that finished with a failure.
+	CommitStatusStateFailed = "FAILED"
+
+	// CommitStatusStateInProgress indicates a build that is still running.
+	CommitStatusStateInProgress = "INPROGRESS"
+
+	// CommitStatusStateStopped indicates a build that was stopped before completion.
+	CommitStatusStateStopped = "STOPPED"
+)
+
+// CommitStatus represents a build status reported against a commit.
+type CommitStatus struct {
+	Type        string             `json:"type,omitempty"`
+	UUID        string             `json:"uuid,omitempty"`
+	Key         string             `json:"key"`
+	RefName     string             `json:"refname,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	State       string             `json:"state"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Commit      *PullRequestCommit `json:"commit,omitempty"`
+	CreatedOn   *time.Time         `json:"created_on,omitempty"`
+	UpdatedOn   *time.Time         `json:"updated_on,omitempty"`
+}
+
+// Branch restriction kinds.
+const (
+	// BranchRestrictionRequireApprovals requires a minimum number of approvals to merge.
+	BranchRestrictionRequireApprovals = "require_approvals_to_merge"
+
+	// BranchRestrictionRequireDefaultReviewerApprovals requires approvals from default reviewers to merge.
+	BranchRestrictionRequireDefaultReviewerApprovals = "require_default_reviewer_approvals_to_merge"
+
+	// BranchRestrictionRequirePassingBuilds requires a minimum number of successful builds to merge.
+	BranchRestrictionRequirePassingBuilds = "require_passing_builds_to_merge"
+
+	// BranchRestrictionRequireTasksCompleted requires all tasks to be resolved to merge.
+	BranchRestrictionRequireTasksCompleted = "require_tasks_to_be_completed"
+
+	// BranchRestrictionRequireNoChangesRequested requires no reviewer to have requested changes.
+	BranchRestrictionRequireNoChangesRequested = "require_no_changes_requested"
+
+	// BranchRestrictionPush restricts pushes to the listed users and groups.
+	BranchRestrictionPush = "push"
+
+	// BranchRestrictionRestrictMerges restricts merges via pull requests to the listed users and groups.
+	BranchRestrictionRestrictMerges = "restrict_merges"
+
+	// BranchRestrictionForce prevents rewriting history with force pushes.
+	BranchRestrictionForce = "force"
+
+	// BranchRestrictionDelete prevents deleting matching branches.
+	BranchRestrictionDelete = "delete"
+
+	// BranchMatchKindGlob indicates that the restriction pattern is a glob.
+	BranchMatchKindGlob = "glob"
+
+	// BranchMatchKindBranchingModel indicates that the restriction applies to a branching model type.
+	BranchMatchKindBranchingModel = "branching_model"
+)
+
+// BranchRestriction represents a branch permission or merge check configured for a repository.
+type BranchRestriction struct {
+	ID              int       `json:"id"`
+	Kind            string    `json:"kind"`
+	BranchMatchKind string    `json:"branch_match_kind,omitempty"`
+	BranchType      string    `json:"branch_type,omitempty"`
+	Pattern         string    `json:"pattern,omitempty"`
+	Value           *int      `json:"value,omitempty"`
+	Users           []Account `json:"users,omitempty"`
+	Groups          []struct {
+		Name string `json:"name,omitempty"`
+		Slug string `json:"slug,omitempty"`
+	} `json:"groups,omitempty"`
+}
@@ -0,0 +1,53 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+)
+
+// Paginated is the generic Bitbucket paginated response envelope.
+type Paginated[T any] struct {
+	Size     int    `json:"size,omitempty"`
+	Page     int    `json:"page,omitempty"`
+	PageLen  int    `json:"pagelen,omitempty"`
+	Next     string `json:"next,omitempty"`
+	Previous string `json:"previous,omitempty"`
+	Values   []T    `json:"values"`
+}
+
+// fetchAllPages follows the "next" links starting from startURL and collects values of all pages.
+func fetchAllPages[T any](
+	ctx context.Context,
+	httpClient *http.Client,
+	startURL string,
+) ([]T, error) {
+	return fetchPages[T](ctx, httpClient, startURL, 0)
+}
+
+// fetchPages follows the "next" links starting from startURL and collects values
+// until at least limit values are collected. All pages are fetched when limit is not positive.
+func fetchPages[T any](
+	ctx context.Context,
+	httpClient *http.Client,
+	startURL string,
+	limit int,
+) ([]T, error) {
+	var allValues []T
+	nextURL := startURL
+	for nextURL != "" && (limit <= 0 || len(allValues) < limit) {
+		var page Paginated[T]
+		err := httpservices.SendRequest(ctx, httpClient, httpservices.SendRequestParams[interface{}, Paginated[T]]{
+			Method: "GET",
+			URL:    nextURL,
+			Target: &page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		allValues = append(allValues, page.Values...)
+		nextURL = page.Next
+	}
+	return allValues, nil
+}
@@ -0,0 +1,104 @@
+package bitbucket
+
+import "time"
+
+// Pipeline state names.
+const (
+	PipelineStatePending    = "PENDING"
+	PipelineStateInProgress = "IN_PROGRESS"
+	PipelineStateCompleted  = "COMPLETED"
+)
+
+// Pipeline result names of a completed pipeline or step.
+const (
+	PipelineResultSuccessful = "SUCCESSFUL"
+	PipelineResultFailed     = "FAILED"
+	PipelineResultError      = "ERROR"
+	PipelineResultStopped    = "STOPPED"
+)
+
+// Pipeline target and selector types.
+const (
+	PipelineTargetTypeRef    = "pipeline_ref_target"
+	PipelineTargetTypeCommit = "pipeline_commit_target"
+	PipelineRefTypeBranch    = "branch"
+	PipelineSelectorCustom   = "custom"
+	PipelineSelectorDefault  = "default"
+)
+
+// PipelineSelector identifies the pipeline definition in bitbucket-pipelines.yml.
+type PipelineSelector struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// PipelineTarget is the ref or commit a pipeline runs on.
+// Matches both "pipeline_ref_target" and "pipeline_commit_target" definitions.
+type PipelineTarget struct {
+	Type     string            `json:"type"`
+	RefType  string            `json:"ref_type,omitempty"`
+	RefName  string            `json:"ref_name,omitempty"`
+	Commit   *PipelineCommit   `json:"commit,omitempty"`
+	Selector *PipelineSelector `json:"selector,omitempty"`
+}
+
+// PipelineCommit references a commit a pipeline runs on.
+type PipelineCommit struct {
+	Type string `json:"type,omitempty"`
+	Hash string `json:"hash"`
+}
+
+// PipelineStateResult is the result or stage of a pipeline state.
+type PipelineStateResult struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+}
+
+// PipelineState is the progress state of a pipeline or a step.
+// Result is set for COMPLETED state, Stage may be set for IN_PROGRESS state.
+type PipelineState struct {
+	Type   string               `json:"type,omitempty"`
+	Name   string               `json:"name"`
+	Result *PipelineStateResult `json:"result,omitempty"`
+	Stage  *PipelineStateResult `json:"stage,omitempty"`
+}
+
+// PipelineTrigger describes what triggered a pipeline.
+type PipelineTrigger struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// PipelineVariable is a variable passed to a pipeline run or a repository variable
+// configured for pipelines. The value of a secured repository variable is never returned.
+type PipelineVariable struct {
+	UUID    string `json:"uuid,omitempty"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Secured bool   `json:"secured,omitempty"`
+}
+
+// Pipeline matches the Bitbucket OpenAPI "pipeline" definition.
+type Pipeline struct {
+	UUID              string             `json:"uuid"`
+	BuildNumber       int                `json:"build_number"`
+	Creator           *Account           `json:"creator,omitempty"`
+	Target            *PipelineTarget    `json:"target,omitempty"`
+	Trigger           *PipelineTrigger   `json:"trigger,omitempty"`
+	State             *PipelineState     `json:"state,omitempty"`
+	Variables         []PipelineVariable `json:"variables,omitempty"`
+	CreatedOn         *time.Time         `json:"created_on,omitempty"`
+	CompletedOn       *time.Time         `json:"completed_on,omitempty"`
+	BuildSecondsUsed  int                `json:"build_seconds_used,omitempty"`
+	DurationInSeconds int                `json:"duration_in_seconds,omitempty"`
+}
+
+// PipelineStep matches the Bitbucket OpenAPI "pipeline_step" definition.
+type PipelineStep struct {
+	UUID              string         `json:"uuid"`
+	Name              string         `json:"name,omitempty"`
+	State             *PipelineState `json:"state,omitempty"`
+	StartedOn         *time.Time     `json:"started_on,omitempty"`
+	CompletedOn       *time.Time     `json:"completed_on,omitempty"`
+	DurationInSeconds int            `json:"duration_in_seconds,omitempty"`
+}
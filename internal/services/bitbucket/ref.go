@@ -0,0 +1,27 @@
+package bitbucket
+
+// Branch matches the Bitbucket OpenAPI "branch" definition.
+type Branch struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+
+	// Target is the head commit of the branch.
+	Target *Commit `json:"target,omitempty"`
+
+	MergeStrategies      []string `json:"merge_strategies,omitempty"`
+	DefaultMergeStrategy string   `json:"default_merge_strategy,omitempty"`
+}
+
+// Tag matches the Bitbucket OpenAPI "tag" definition.
+type Tag struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+
+	// Target is the tagged commit.
+	Target *Commit `json:"target,omitempty"`
+
+	// Message, Date and Tagger are only available for annotated tags.
+	Message string        `json:"message,omitempty"`
+	Date    string        `json:"date,omitempty"`
+	Tagger  *CommitAuthor `json:"tagger,omitempty"`
+}
@@ -0,0 +1,56 @@
+package bitbucket
+
+// Repository matches the Bitbucket OpenAPI "repository" definition.
+type Repository struct {
+	Type        string `json:"type,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug,omitempty"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description,omitempty"`
+	IsPrivate   bool   `json:"is_private"`
+	Language    string `json:"language,omitempty"`
+
+	// Size of the repository in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// MainBranch is the branch pull requests target by default. Only the name of the branch is populated.
+	MainBranch *Branch     `json:"mainbranch,omitempty"`
+	Project    *Project    `json:"project,omitempty"`
+	Workspace  *Workspace  `json:"workspace,omitempty"`
+	Parent     *Repository `json:"parent,omitempty"`
+	ForkPolicy string      `json:"fork_policy,omitempty"`
+	CreatedOn  string      `json:"created_on,omitempty"`
+	UpdatedOn  string      `json:"updated_on,omitempty"`
+	Links      *Links      `json:"links,omitempty"`
+}
+
+// Project matches the Bitbucket OpenAPI "project" definition.
+type Project struct {
+	Type        string `json:"type,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	IsPrivate   bool   `json:"is_private,omitempty"`
+}
+
+// Workspace matches the Bitbucket OpenAPI "workspace" definition.
+type Workspace struct {
+	Type      string `json:"type,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name,omitempty"`
+	IsPrivate bool   `json:"is_private,omitempty"`
+	Links     *Links `json:"links,omitempty"`
+}
+
+// WorkspaceMembership matches the Bitbucket OpenAPI "workspace_membership" definition.
+type WorkspaceMembership struct {
+	Type string `json:"type,omitempty"`
+
+	// Permission is the highest role of the user in the workspace: owner, collaborator or member.
+	Permission string     `json:"permission,omitempty"`
+	Workspace  *Workspace `json:"workspace"`
+	User       *Account   `json:"user,omitempty"`
+}
@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// CodeSearchResult matches the Bitbucket OpenAPI "search_code_search_result" definition.
+// A file may match by its content, by its path or both.
+type CodeSearchResult struct {
+	Type              string              `json:"type,omitempty"`
+	ContentMatchCount int                 `json:"content_match_count"`
+	ContentMatches    []CodeSearchMatch   `json:"content_matches,omitempty"`
+	PathMatches       []CodeSearchSegment `json:"path_matches,omitempty"`
+	File              *CodeSearchFile     `json:"file,omitempty"`
+}
+
+// CodeSearchMatch is a group of consecutive lines around matches in the content of a file.
+type CodeSearchMatch struct {
+	Lines []CodeSearchLine `json:"lines"`
+}
+
+// CodeSearchLine is a line of a file split into matched and not matched segments.
+type CodeSearchLine struct {
+	Line     int                 `json:"line"`
+	Segments []CodeSearchSegment `json:"segments"`
+}
+
+// CodeSearchSegment is a piece of text that either matched the query or not.
+type CodeSearchSegment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// CodeSearchFile is the file of a search result.
+// Commit is present only when requested with the fields query parameter.
+type CodeSearchFile struct {
+	Type   string            `json:"type,omitempty"`
+	Path   string            `json:"path"`
+	Commit *CodeSearchCommit `json:"commit,omitempty"`
+	Links  *Links            `json:"links,omitempty"`
+}
+
+// CodeSearchCommit is the commit at which a search result file was indexed.
+type CodeSearchCommit struct {
+	Hash       string                 `json:"hash,omitempty"`
+	Repository *PullRequestRepository `json:"repository,omitempty"`
+}
+
+// SearchCodeParams contains parameters for searching code in repositories of a workspace.
+type SearchCodeParams struct {
+	Workspace string
+
+	// Query uses the syntax of the Bitbucket code search, e.g. "foo repo:demo lang:go".
+	Query string
+
+	// Optional query parameters
+	Fields  string // partial response fields, e.g. +values.file.commit.repository
+	PageLen int
+
+	// Limit stops paging once at least this many results are collected. All results are listed when not positive.
+	Limit int
+}
+
+// SearchCode searches code in repositories of a workspace.
+// GET /workspaces/{workspace}/search/code.
+func (c *Client) SearchCode(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params SearchCodeParams,
+) ([]CodeSearchResult, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/workspaces/%s/search/code", url.PathEscape(params.Workspace))
+
+	query := url.Values{"search_query": {params.Query}}
+	if params.Fields != "" {
+		query.Add("fields", params.Fields)
+	}
+	if params.PageLen > 0 {
+		query.Add("pagelen", strconv.Itoa(params.PageLen))
+	}
+
+	results, err := fetchPages[CodeSearchResult](
+		ctxWithAuth, c.httpClient, c.baseURL+path+"?"+query.Encode(), params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("search code failed: %w", err)
+	}
+
+	return results, nil
+}
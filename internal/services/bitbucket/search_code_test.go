@@ -0,0 +1,116 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SearchCode(t *testing.T) {
+	t.Run("success follows pages until limit", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		var server *httptest.Server
+		requests := 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, fmt.Sprintf("/workspaces/%s/search/code", workspace), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "":
+				assert.Equal(t, "parseDiff repo:demo", r.URL.Query().Get("search_query"))
+				assert.Equal(t, "+values.file.commit.repository", r.URL.Query().Get("fields"))
+				assert.Equal(t, "1", r.URL.Query().Get("pagelen"))
+				fmt.Fprintf(w, `{"size": 3, "values": [{
+					"type": "code_search_result",
+					"content_match_count": 1,
+					"content_matches": [{"lines": [{"line": 3, "segments": [
+						{"text": "func "}, {"text": "parseDiff", "match": true}, {"text": "() {"}
+					]}]}],
+					"path_matches": [{"text": "diff.go"}],
+					"file": {"path": "diff.go", "type": "commit_file",
+						"commit": {"hash": "c1", "repository": {"full_name": "ws/demo", "name": "demo"}}}
+				}], "next": "%s%s?page=2"}`, server.URL, r.URL.Path)
+			case "2":
+				fmt.Fprintf(w, `{"size": 3, "values": [{"content_match_count": 0,
+					"path_matches": [{"text": "parseDiff", "match": true}, {"text": ".md"}],
+					"file": {"path": "parseDiff.md"}}], "next": "%s%s?page=3"}`, server.URL, r.URL.Path)
+			default:
+				assert.Fail(t, "unexpected page requested")
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.SearchCode(t.Context(), mockTokenProvider, SearchCodeParams{
+			Workspace: workspace,
+			Query:     "parseDiff repo:demo",
+			Fields:    "+values.file.commit.repository",
+			PageLen:   1,
+			Limit:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []CodeSearchResult{
+			{
+				Type:              "code_search_result",
+				ContentMatchCount: 1,
+				ContentMatches: []CodeSearchMatch{{Lines: []CodeSearchLine{{Line: 3, Segments: []CodeSearchSegment{
+					{Text: "func "}, {Text: "parseDiff", Match: true}, {Text: "() {"},
+				}}}}},
+				PathMatches: []CodeSearchSegment{{Text: "diff.go"}},
+				File: &CodeSearchFile{Type: "commit_file", Path: "diff.go", Commit: &CodeSearchCommit{
+					Hash:       "c1",
+					Repository: &PullRequestRepository{FullName: "ws/demo", Name: "demo"},
+				}},
+			},
+			{
+				PathMatches: []CodeSearchSegment{{Text: "parseDiff", Match: true}, {Text: ".md"}},
+				File:        &CodeSearchFile{Path: "parseDiff.md"},
+			},
+		}, got)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.SearchCode(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			SearchCodeParams{Workspace: faker.Username(), Query: faker.Word()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "search code failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.SearchCode(t.Context(), &MockTokenProvider{Err: tokenErr},
+			SearchCodeParams{Workspace: faker.Username(), Query: faker.Word()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,49 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Types of repository source tree entries.
+const (
+	TreeEntryTypeFile      = "commit_file"
+	TreeEntryTypeDirectory = "commit_directory"
+)
+
+// TreeEntry represents a file or a directory of the repository source at a commit.
+type TreeEntry struct {
+	Type       string   `json:"type"`
+	Path       string   `json:"path"`
+	Size       int64    `json:"size,omitempty"`
+	MimeType   string   `json:"mimetype,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	Commit     *Commit  `json:"commit,omitempty"`
+}
+
+// IsDirectory reports whether the entry is a directory.
+func (e TreeEntry) IsDirectory() bool {
+	return e.Type == TreeEntryTypeDirectory
+}
+
+// sourcePath builds the /src path of a file or a directory at a commit.
+func sourcePath(workspace, repoSlug, commit, filePath string) string {
+	return commitFilePath(workspace, repoSlug, "src", commit, filePath)
+}
+
+// commitFilePath builds the path of a repository resource addressed by a commit and a file path.
+// Each segment of the file path is escaped separately so that nested paths are preserved.
+func commitFilePath(workspace, repoSlug, resource, commit, filePath string) string {
+	segments := strings.Split(strings.Trim(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf("/repositories/%s/%s/%s/%s/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		resource,
+		url.PathEscape(commit),
+		strings.Join(segments, "/"),
+	)
+}
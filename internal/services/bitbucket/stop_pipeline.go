@@ -0,0 +1,47 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// StopPipelineParams contains parameters for stopping a pipeline.
+type StopPipelineParams struct {
+	Workspace    string
+	RepoSlug     string
+	PipelineUUID string
+}
+
+// StopPipeline signals a running pipeline to stop.
+// POST /repositories/{workspace}/{repo_slug}/pipelines/{pipeline_uuid}/stopPipeline.
+func (c *Client) StopPipeline(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params StopPipelineParams,
+) error {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/stopPipeline",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.PipelineUUID),
+	)
+
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[interface{}, interface{}]{
+		Method: "POST",
+		URL:    c.baseURL + path,
+	})
+	if err != nil {
+		return fmt.Errorf("stop pipeline failed: %w", err)
+	}
+
+	return nil
+}
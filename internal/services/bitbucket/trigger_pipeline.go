@@ -0,0 +1,63 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// TriggerPipelineParams contains parameters for triggering a pipeline.
+type TriggerPipelineParams struct {
+	Workspace string
+	RepoSlug  string
+	Target    PipelineTarget
+	Variables []PipelineVariable
+}
+
+type triggerPipelineRequest struct {
+	Target    PipelineTarget     `json:"target"`
+	Variables []PipelineVariable `json:"variables,omitempty"`
+}
+
+// TriggerPipeline starts a new pipeline run for the given target.
+// POST /repositories/{workspace}/{repo_slug}/pipelines.
+func (c *Client) TriggerPipeline(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params TriggerPipelineParams,
+) (*Pipeline, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+	)
+
+	body := triggerPipelineRequest{
+		Target:    params.Target,
+		Variables: params.Variables,
+	}
+	var pipeline Pipeline
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[triggerPipelineRequest, Pipeline]{
+			Method: "POST",
+			URL:    c.baseURL + path,
+			Body:   &body,
+			Target: &pipeline,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("trigger pipeline failed: %w", err)
+	}
+
+	return &pipeline, nil
+}
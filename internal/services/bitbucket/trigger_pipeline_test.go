@@ -0,0 +1,106 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TriggerPipeline(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		branch := "feature/" + faker.Word()
+		pattern := "deploy-" + faker.Word()
+		variableKey := "VAR_" + faker.Word()
+		variableValue := faker.Word()
+		pipelineUUID := "{" + faker.UUIDHyphenated() + "}"
+		buildNumber := 1 + rand.IntN(1000)
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/repositories/%s/%s/pipelines", workspace, repoSlug), r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"target": map[string]any{
+					"type":     PipelineTargetTypeRef,
+					"ref_type": PipelineRefTypeBranch,
+					"ref_name": branch,
+					"selector": map[string]any{"type": PipelineSelectorCustom, "pattern": pattern},
+				},
+				"variables": []any{
+					map[string]any{"key": variableKey, "value": variableValue, "secured": true},
+				},
+			}, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"uuid": %q, "build_number": %d, "state": {"name": "PENDING"}}`, pipelineUUID, buildNumber)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.TriggerPipeline(t.Context(), mockTokenProvider, TriggerPipelineParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Target: PipelineTarget{
+				Type:     PipelineTargetTypeRef,
+				RefType:  PipelineRefTypeBranch,
+				RefName:  branch,
+				Selector: &PipelineSelector{Type: PipelineSelectorCustom, Pattern: pattern},
+			},
+			Variables: []PipelineVariable{{Key: variableKey, Value: variableValue, Secured: true}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &Pipeline{
+			UUID:        pipelineUUID,
+			BuildNumber: buildNumber,
+			State:       &PipelineState{Name: PipelineStatePending},
+		}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.TriggerPipeline(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			TriggerPipelineParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "trigger pipeline failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.TriggerPipeline(t.Context(), &MockTokenProvider{Err: tokenErr},
+			TriggerPipelineParams{Workspace: faker.Username(), RepoSlug: faker.Username()})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
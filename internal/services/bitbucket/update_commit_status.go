@@ -0,0 +1,55 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// UpdateCommitStatusParams contains parameters for updating a build status of a commit.
+type UpdateCommitStatusParams struct {
+	Workspace string
+	RepoSlug  string
+	Commit    string
+	Key       string
+
+	// Only the non empty fields are updated. The key can not be changed.
+	Status CommitStatusPayload
+}
+
+// UpdateCommitStatus updates an existing build status of a commit.
+// PUT /repositories/{workspace}/{repo_slug}/commit/{commit}/statuses/build/{key}.
+func (c *Client) UpdateCommitStatus(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params UpdateCommitStatusParams,
+) (*CommitStatus, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.Commit),
+		url.PathEscape(params.Key),
+	)
+
+	var status CommitStatus
+	err = httpservices.SendRequest(ctxWithAuth, c.httpClient, httpservices.SendRequestParams[CommitStatusPayload, CommitStatus]{
+		Method: "PUT",
+		URL:    c.baseURL + path,
+		Body:   &params.Status,
+		Target: &status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update commit status failed: %w", err)
+	}
+
+	return &status, nil
+}
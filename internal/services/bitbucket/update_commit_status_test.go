@@ -0,0 +1,91 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateCommitStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		commit := faker.UUIDDigit()
+		key := "build-" + faker.Word()
+		description := faker.Sentence()
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build/%s", workspace, repoSlug, commit, key),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"state": CommitStatusStateFailed, "description": description}, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"key": %q, "state": "FAILED", "description": %q}`, key, description)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.UpdateCommitStatus(t.Context(), mockTokenProvider, UpdateCommitStatusParams{
+			Workspace: workspace,
+			RepoSlug:  repoSlug,
+			Commit:    commit,
+			Key:       key,
+			Status: CommitStatusPayload{
+				State:       CommitStatusStateFailed,
+				Description: description,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &CommitStatus{Key: key, State: CommitStatusStateFailed, Description: description}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.UpdateCommitStatus(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			UpdateCommitStatusParams{
+				Workspace: faker.Username(),
+				RepoSlug:  faker.Username(),
+				Commit:    faker.UUIDDigit(),
+				Key:       faker.Word(),
+			})
+
+		require.ErrorContains(t, err, "update commit status failed")
+		assert.Nil(t, got)
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.UpdateCommitStatus(t.Context(), &MockTokenProvider{Err: tokenErr}, UpdateCommitStatusParams{})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
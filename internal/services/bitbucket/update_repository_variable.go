@@ -0,0 +1,55 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	httpservices "github.com/gemyago/atlacp/internal/services/http"
+	"github.com/gemyago/atlacp/internal/services/http/middleware"
+)
+
+// UpdateRepositoryVariableParams contains parameters for updating a pipelines variable of a repository.
+type UpdateRepositoryVariableParams struct {
+	Workspace    string
+	RepoSlug     string
+	VariableUUID string
+	Variable     PipelineVariable
+}
+
+// UpdateRepositoryVariable updates the key, value or secured flag of a pipelines variable of a repository.
+// PUT /repositories/{workspace}/{repo_slug}/pipelines_config/variables/{variable_uuid}.
+func (c *Client) UpdateRepositoryVariable(
+	ctx context.Context,
+	tokenProvider TokenProvider,
+	params UpdateRepositoryVariableParams,
+) (*PipelineVariable, error) {
+	token, err := tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	ctxWithAuth := middleware.WithAuthTokenV2(ctx, token)
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables/%s",
+		url.PathEscape(params.Workspace),
+		url.PathEscape(params.RepoSlug),
+		url.PathEscape(params.VariableUUID),
+	)
+
+	var variable PipelineVariable
+	err = httpservices.SendRequest(
+		ctxWithAuth,
+		c.httpClient,
+		httpservices.SendRequestParams[PipelineVariable, PipelineVariable]{
+			Method: "PUT",
+			URL:    c.baseURL + path,
+			Body:   &params.Variable,
+			Target: &variable,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update repository variable failed: %w", err)
+	}
+
+	return &variable, nil
+}
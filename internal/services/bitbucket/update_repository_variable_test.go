@@ -0,0 +1,91 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateRepositoryVariable(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		workspace := "ws-" + faker.Word()
+		repoSlug := "repo-" + faker.Word()
+		variableUUID := "{" + faker.UUIDHyphenated() + "}"
+		variable := PipelineVariable{Key: "KEY_" + faker.Word(), Value: faker.Word()}
+
+		mockTokenProvider := &MockTokenProvider{
+			TokenType:  "Bearer",
+			TokenValue: faker.UUIDHyphenated(),
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t,
+				fmt.Sprintf("/repositories/%s/%s/pipelines_config/variables/%s", workspace, repoSlug, variableUUID),
+				r.URL.Path)
+			assert.Equal(t, "Bearer "+mockTokenProvider.TokenValue, r.Header.Get("Authorization"))
+
+			var body PipelineVariable
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, variable, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"uuid": %q, "key": %q, "value": %q}`, variableUUID, variable.Key, variable.Value)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.UpdateRepositoryVariable(t.Context(), mockTokenProvider, UpdateRepositoryVariableParams{
+			Workspace:    workspace,
+			RepoSlug:     repoSlug,
+			VariableUUID: variableUUID,
+			Variable:     variable,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &PipelineVariable{UUID: variableUUID, Key: variable.Key, Value: variable.Value}, got)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewClient(makeMockDepsWithTestName(t, server.URL))
+
+		got, err := client.UpdateRepositoryVariable(t.Context(), &MockTokenProvider{TokenValue: faker.UUIDHyphenated()},
+			UpdateRepositoryVariableParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				VariableUUID: faker.UUIDHyphenated(),
+			})
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "update repository variable failed")
+	})
+
+	t.Run("handles token error", func(t *testing.T) {
+		tokenErr := errors.New("token error")
+		client := NewClient(makeMockDepsWithTestName(t, "http://dummy-url"))
+
+		got, err := client.UpdateRepositoryVariable(t.Context(), &MockTokenProvider{Err: tokenErr},
+			UpdateRepositoryVariableParams{
+				Workspace:    faker.Username(),
+				RepoSlug:     faker.Username(),
+				VariableUUID: faker.UUIDHyphenated(),
+			})
+
+		require.ErrorIs(t, err, tokenErr)
+		assert.Nil(t, got)
+	})
+}
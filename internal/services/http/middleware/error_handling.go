@@ -21,6 +21,10 @@ type HTTPError struct {
 	URL        string
 	Message    string
 	Err        error
+
+	// ResponseBody is the body of the error response. It is kept on the error since
+	// http.Client drops the response when the transport returns an error.
+	ResponseBody []byte
 }
 
 // Error implements the error interface.
@@ -104,6 +108,7 @@ func (e *ErrorHandlingMiddleware) RoundTrip(req *http.Request) (*http.Response,
 			// Replace with a new reader containing the same data
 			resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		}
+		httpErr.ResponseBody = bodyBytes
 
 		// Log the error with body content
 		e.logger.WarnContext(req.Context(), "HTTP error response",
@@ -130,6 +130,7 @@ func TestErrorHandlingMiddleware(t *testing.T) {
 		assert.Equal(t, "https://api.example.com/missing", httpErr.URL)
 		assert.Contains(t, httpErr.Message, "client error")
 		assert.Contains(t, httpErr.Message, "404")
+		assert.JSONEq(t, `{"error": "not found"}`, string(httpErr.ResponseBody))
 
 		// Verify body can still be read
 		if resp != nil && resp.Body != nil {